@@ -0,0 +1,34 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/vishenosik/CherryWatch/internal/app"
+	appctx "github.com/vishenosik/CherryWatch/internal/app/context"
+)
+
+// Probe agent: checks endpoints assigned to its location by the central
+// CherryWatch server and streams results back over gRPC.
+func main() {
+	flag.Parse()
+
+	ctx := context.Background()
+
+	application := app.MustInitAgent()
+
+	application.MustRun()
+
+	// Graceful shut down
+	stop := make(chan os.Signal, 1)
+	signal.Notify(stop, syscall.SIGTERM, syscall.SIGINT)
+
+	stopctx, cancel := context.WithTimeout(appctx.WithSignalCtx(ctx, <-stop), time.Second*5)
+	defer cancel()
+
+	application.Stop(stopctx)
+}
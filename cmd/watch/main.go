@@ -3,6 +3,7 @@ package main
 import (
 	"context"
 	"flag"
+	"fmt"
 	"os"
 	"os/signal"
 	"syscall"
@@ -12,6 +13,11 @@ import (
 	appctx "github.com/vishenosik/CherryWatch/internal/app/context"
 )
 
+var (
+	backupPath  = flag.String("backup", "", "Write a snapshot of the sqlite store to the file and exit, a running server keeps monitoring")
+	restorePath = flag.String("restore", "", "Replace the sqlite store with the backup file and exit, the server must be stopped")
+)
+
 // @title           sso
 // @version         0.0.1
 // @description     This is a sample server celler server.
@@ -33,7 +39,22 @@ import (
 // @externalDocs.url          https://swagger.io/resources/open-api/
 func main() {
 	flag.Parse()
-	runServer()
+
+	switch {
+	case *backupPath != "":
+		runCommand(app.Backup, *backupPath)
+	case *restorePath != "":
+		runCommand(app.Restore, *restorePath)
+	default:
+		runServer()
+	}
+}
+
+func runCommand(command func(path string) error, path string) {
+	if err := command(path); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
 }
 
 func runServer() {
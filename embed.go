@@ -5,4 +5,7 @@ import "embed"
 var (
 	//go:embed migrations
 	Migrations embed.FS
+
+	//go:embed templates
+	Templates embed.FS
 )
@@ -0,0 +1,280 @@
+package agent
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/vishenosik/CherryWatch/internal/services/models"
+	"github.com/vishenosik/CherryWatch/internal/services/scheduler"
+	attrs "github.com/vishenosik/web-tools/log"
+)
+
+const (
+	// default period of assignments sync
+	defaultSyncInterval = 30 * time.Second
+	// default delay before a failed registration or report is retried
+	defaultRetryInterval = 5 * time.Second
+	// default number of results kept while the server is unreachable
+	defaultBufferSize = 1000
+)
+
+// Server is the central server the agent reports to.
+type Server interface {
+	Register(ctx context.Context, name, location string) (*models.Agent, error)
+	Assignments(ctx context.Context, agentID string) (models.Endpoints, error)
+	ReportResults(ctx context.Context, agentID string, results <-chan *models.CheckResult) error
+}
+
+type Checker interface {
+	Check(ctx context.Context, endpoint *models.Endpoint) *models.CheckResult
+}
+
+type Config struct {
+	// Name unique within the workspace of the API key
+	Name string
+	// Location the agent checks endpoints from
+	Location string
+	// Period of assignments sync
+	SyncInterval time.Duration
+	// Delay before a failed registration or report is retried
+	RetryInterval time.Duration
+	// Number of results kept while the server is unreachable
+	BufferSize int
+	// Scheduler of local checks
+	Scheduler scheduler.Config
+}
+
+// Agent checks endpoints assigned to its location by the central server
+// and streams results back. The server decides on incidents.
+//
+// Assigned endpoints are synced periodically and checked by the scheduler
+// the server uses itself. The agent registers again whenever the server
+// forgets it, e.g. after a restart.
+type Agent struct {
+	log     *slog.Logger
+	server  Server
+	checker Checker
+	config  Config
+
+	scheduler *scheduler.Scheduler
+	results   chan *models.CheckResult
+
+	mu        sync.Mutex
+	id        string
+	endpoints models.Endpoints
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+	done   chan struct{}
+}
+
+func NewAgent(
+	log *slog.Logger,
+	server Server,
+	checker Checker,
+	config Config,
+) *Agent {
+
+	if config.SyncInterval <= 0 {
+		config.SyncInterval = defaultSyncInterval
+	}
+
+	if config.RetryInterval <= 0 {
+		config.RetryInterval = defaultRetryInterval
+	}
+
+	if config.BufferSize <= 0 {
+		config.BufferSize = defaultBufferSize
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	agent := &Agent{
+		log: log.With(
+			slog.String("component", "agent"),
+			slog.String("location", config.Location),
+		),
+		server:  server,
+		checker: checker,
+		config:  config,
+		results: make(chan *models.CheckResult, config.BufferSize),
+		ctx:     ctx,
+		cancel:  cancel,
+		done:    make(chan struct{}),
+	}
+
+	agent.scheduler = scheduler.NewScheduler(log, agent, config.Scheduler)
+
+	return agent
+}
+
+// MustRun starts the agent and blocks until Stop is called.
+func (agent *Agent) MustRun() {
+	if err := agent.Run(); err != nil {
+		panic(err)
+	}
+}
+
+func (agent *Agent) Run() error {
+
+	defer close(agent.done)
+
+	if !agent.register() {
+		return nil
+	}
+
+	agent.sync()
+
+	agent.wg.Add(2)
+
+	go func() {
+		defer agent.wg.Done()
+		agent.scheduler.MustRun()
+	}()
+
+	go func() {
+		defer agent.wg.Done()
+		agent.report()
+	}()
+
+	ticker := time.NewTicker(agent.config.SyncInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-agent.ctx.Done():
+			agent.scheduler.Stop(context.Background())
+			agent.wg.Wait()
+			return nil
+		case <-ticker.C:
+			agent.sync()
+		}
+	}
+}
+
+// Stop stops checks and reporting, results not sent yet are dropped.
+func (agent *Agent) Stop(ctx context.Context) {
+
+	agent.log.Info("stopping agent")
+
+	agent.cancel()
+
+	select {
+	case <-agent.done:
+	case <-ctx.Done():
+	}
+}
+
+// Endpoints returns endpoints assigned to the agent, it's called by the scheduler.
+func (agent *Agent) Endpoints(_ context.Context) (models.Endpoints, error) {
+	agent.mu.Lock()
+	defer agent.mu.Unlock()
+	return agent.endpoints, nil
+}
+
+// RunCheck checks the endpoint and queues the result for the server.
+func (agent *Agent) RunCheck(ctx context.Context, endpoint *models.Endpoint) (*models.CheckResult, error) {
+
+	result := agent.checker.Check(ctx, endpoint)
+	result.Location = agent.config.Location
+
+	select {
+	case agent.results <- result:
+	default:
+		agent.log.Warn("results buffer is full, result dropped",
+			slog.String("endpoint_id", endpoint.ID),
+		)
+	}
+
+	return result, nil
+}
+
+// register registers the agent retrying until it succeeds or the agent stops.
+func (agent *Agent) register() bool {
+
+	for {
+		registered, err := agent.server.Register(agent.ctx, agent.config.Name, agent.config.Location)
+		if err == nil {
+			agent.mu.Lock()
+			agent.id = registered.ID
+			agent.mu.Unlock()
+
+			agent.log.Info("agent registered", slog.String("agent_id", registered.ID))
+			return true
+		}
+
+		agent.log.Error("failed to register agent", attrs.Error(err))
+
+		if !agent.wait() {
+			return false
+		}
+	}
+}
+
+// sync replaces assigned endpoints with the ones of the server.
+func (agent *Agent) sync() {
+
+	endpoints, err := agent.server.Assignments(agent.ctx, agent.agentID())
+	if errors.Is(err, models.ErrNotFound) && agent.register() {
+		endpoints, err = agent.server.Assignments(agent.ctx, agent.agentID())
+	}
+	if err != nil {
+		if agent.ctx.Err() == nil {
+			agent.log.Error("failed to sync assignments", attrs.Error(err))
+		}
+		return
+	}
+
+	for _, endpoint := range endpoints {
+		// checked right here, the scheduler skips probed endpoints
+		endpoint.Probes = nil
+	}
+
+	agent.mu.Lock()
+	agent.endpoints = endpoints
+	agent.mu.Unlock()
+}
+
+// report streams results reconnecting until the agent stops.
+func (agent *Agent) report() {
+
+	for {
+		err := agent.server.ReportResults(agent.ctx, agent.agentID(), agent.results)
+		if agent.ctx.Err() != nil {
+			return
+		}
+
+		if errors.Is(err, models.ErrNotFound) {
+			if !agent.register() {
+				return
+			}
+			continue
+		}
+
+		agent.log.Error("failed to report results", attrs.Error(err))
+
+		if !agent.wait() {
+			return
+		}
+	}
+}
+
+func (agent *Agent) agentID() string {
+	agent.mu.Lock()
+	defer agent.mu.Unlock()
+	return agent.id
+}
+
+// wait waits for the retry interval, false means the agent stopped.
+func (agent *Agent) wait() bool {
+	select {
+	case <-agent.ctx.Done():
+		return false
+	case <-time.After(agent.config.RetryInterval):
+		return true
+	}
+}
@@ -0,0 +1,264 @@
+package agent
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	grpcAuthentication "github.com/vishenosik/CherryWatch/internal/api/grpc/authentication"
+	cherrywatchGrpc "github.com/vishenosik/CherryWatch/internal/api/grpc/cherrywatch"
+	"github.com/vishenosik/CherryWatch/internal/services/agents"
+	"github.com/vishenosik/CherryWatch/internal/services/models"
+	"github.com/vishenosik/CherryWatch/internal/services/scheduler"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+const apiKey = "agent-key"
+
+type endpointsMock struct {
+	endpoints models.Endpoints
+
+	mu      sync.Mutex
+	applied []*models.CheckResult
+}
+
+func (em *endpointsMock) Endpoint(_ context.Context, id string) (*models.Endpoint, error) {
+	for _, endpoint := range em.endpoints {
+		if endpoint.ID == id {
+			return endpoint, nil
+		}
+	}
+	return nil, models.ErrNotFound
+}
+
+func (em *endpointsMock) Endpoints(_ context.Context) (models.Endpoints, error) {
+	endpoints := make(models.Endpoints, 0, len(em.endpoints))
+	for _, endpoint := range em.endpoints {
+		copied := *endpoint
+		endpoints = append(endpoints, &copied)
+	}
+	return endpoints, nil
+}
+
+func (em *endpointsMock) ApplyResult(_ context.Context, _ *models.Endpoint, result *models.CheckResult) error {
+	em.mu.Lock()
+	defer em.mu.Unlock()
+	em.applied = append(em.applied, result)
+	return nil
+}
+
+func (em *endpointsMock) results() []*models.CheckResult {
+	em.mu.Lock()
+	defer em.mu.Unlock()
+	return append([]*models.CheckResult(nil), em.applied...)
+}
+
+type authenticatorMock struct{}
+
+func (authenticatorMock) Authenticate(_ context.Context, credential string) (*models.Principal, error) {
+	if credential != apiKey {
+		return nil, models.ErrInvalidCredentials
+	}
+	return &models.Principal{
+		WorkspaceID: models.DefaultWorkspace,
+		AppName:     "agents",
+		Role:        models.RoleAdmin,
+	}, nil
+}
+
+// checkerMock fails checks of the listed endpoints.
+type checkerMock struct {
+	failing map[string]bool
+}
+
+func (cm checkerMock) Check(_ context.Context, endpoint *models.Endpoint) *models.CheckResult {
+	result := &models.CheckResult{EndpointID: endpoint.ID, Success: !cm.failing[endpoint.ID], CheckedAt: time.Now()}
+	if !result.Success {
+		result.Message = "connection refused"
+	}
+	return result
+}
+
+func testLogger() *slog.Logger {
+	return slog.New(slog.NewTextHandler(io.Discard, nil))
+}
+
+// startServer serves the agent service of the central server in-process.
+func startServer(t *testing.T, endpoints *endpointsMock) *bufconn.Listener {
+	t.Helper()
+
+	listener := bufconn.Listen(1 << 20)
+
+	server := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(grpcAuthentication.UnaryInterceptor(authenticatorMock{}, cherrywatchGrpc.Roles)),
+		grpc.ChainStreamInterceptor(grpcAuthentication.StreamInterceptor(authenticatorMock{}, cherrywatchGrpc.Roles)),
+	)
+	cherrywatchGrpc.NewAgentsServer(testLogger(), agents.NewAgentsService(testLogger(), endpoints, 0)).Register(server)
+
+	go func() { _ = server.Serve(listener) }()
+	t.Cleanup(server.Stop)
+
+	return listener
+}
+
+func connect(t *testing.T, listener *bufconn.Listener) *cherrywatchGrpc.AgentClient {
+	t.Helper()
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithPerRPCCredentials(grpcAuthentication.APIKey{Key: apiKey, Insecure: true}),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	return cherrywatchGrpc.NewAgentClient(conn)
+}
+
+func startAgent(t *testing.T, server Server, location string, checker Checker) *Agent {
+	t.Helper()
+
+	agent := NewAgent(testLogger(), server, checker, Config{
+		Name:          "probe-" + location,
+		Location:      location,
+		SyncInterval:  20 * time.Millisecond,
+		RetryInterval: 10 * time.Millisecond,
+		Scheduler:     scheduler.Config{Workers: 1, Tick: 10 * time.Millisecond},
+	})
+
+	go agent.MustRun()
+
+	t.Cleanup(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		agent.Stop(ctx)
+	})
+
+	return agent
+}
+
+func Test_Agents(t *testing.T) {
+
+	endpoints := &endpointsMock{
+		endpoints: models.Endpoints{
+			{
+				ID:       "api",
+				Interval: time.Minute,
+				Probes:   &models.Probes{Locations: []string{"eu", "us", "asia"}, Quorum: 2},
+			},
+			{
+				ID:       "eu-only",
+				Interval: time.Minute,
+				Probes:   &models.Probes{Locations: []string{"eu"}},
+			},
+			{
+				ID:       "local",
+				Interval: time.Minute,
+			},
+		},
+	}
+
+	listener := startServer(t, endpoints)
+
+	// the api is unreachable from eu & us
+	failing := checkerMock{failing: map[string]bool{"api": true}}
+	startAgent(t, connect(t, listener), "eu", failing)
+	startAgent(t, connect(t, listener), "us", failing)
+	startAgent(t, connect(t, listener), "asia", checkerMock{})
+
+	// every location checks the api once, eu checks its own endpoint as well
+	require.Eventually(t, func() bool { return len(endpoints.results()) == 4 }, 5*time.Second, 10*time.Millisecond)
+
+	var (
+		api       []*models.CheckResult
+		locations = make(map[string]int)
+	)
+
+	for _, result := range endpoints.results() {
+		assert.NotEqual(t, "local", result.EndpointID, "endpoints checked by the server aren't assigned")
+		if result.EndpointID == "api" {
+			api = append(api, result)
+			locations[result.Location]++
+		}
+	}
+
+	assert.Equal(t, map[string]int{"eu": 1, "us": 1, "asia": 1}, locations)
+
+	// a single location never reaches the quorum of 2, all the three do
+	assert.True(t, api[0].Success)
+
+	last := api[len(api)-1]
+	assert.False(t, last.Success)
+	assert.Contains(t, last.Message, "failing in 2 of 3 locations (quorum 2)")
+}
+
+// serverMock forgets the agent once, like a restarted server does.
+type serverMock struct {
+	mu         sync.Mutex
+	registered int
+	forgot     bool
+	reported   chan *models.CheckResult
+}
+
+func (sm *serverMock) Register(_ context.Context, name, location string) (*models.Agent, error) {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+	sm.registered++
+	return &models.Agent{ID: name, Name: name, Location: location}, nil
+}
+
+func (sm *serverMock) Assignments(_ context.Context, _ string) (models.Endpoints, error) {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+	if !sm.forgot {
+		sm.forgot = true
+		return nil, models.ErrNotFound
+	}
+	return models.Endpoints{
+		{ID: "api", Interval: time.Minute, Probes: &models.Probes{Locations: []string{"eu"}}},
+	}, nil
+}
+
+func (sm *serverMock) ReportResults(ctx context.Context, _ string, results <-chan *models.CheckResult) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case result := <-results:
+			sm.reported <- result
+		}
+	}
+}
+
+func (sm *serverMock) registrations() int {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+	return sm.registered
+}
+
+func Test_Agent_registersAgain(t *testing.T) {
+
+	server := &serverMock{reported: make(chan *models.CheckResult, 1)}
+
+	startAgent(t, server, "eu", checkerMock{})
+
+	select {
+	case result := <-server.reported:
+		assert.Equal(t, "api", result.EndpointID)
+		assert.Equal(t, "eu", result.Location)
+	case <-time.After(5 * time.Second):
+		t.Fatal("no result reported")
+	}
+
+	assert.Equal(t, 2, server.registrations())
+}
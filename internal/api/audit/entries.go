@@ -0,0 +1,37 @@
+package audit
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/vishenosik/CherryWatch/internal/api/models"
+	attrs "github.com/vishenosik/web-tools/log"
+)
+
+// listEntries returns audit log entries, newest first.
+//
+// Query parameters: resource, resource_id, action, actor (user or app id),
+// since & until (RFC 3339), limit (100 by default, 1000 at most).
+func (srv server) listEntries() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		filter, err := models.ToServiceAuditFilter(r.URL.Query())
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		entries, err := srv.service.Entries(r.Context(), filter)
+		if err != nil {
+			srv.log.Error("failed to get audit entries", attrs.Error(err))
+			http.Error(w, "Internal server error", http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+
+		if err := json.NewEncoder(w).Encode(models.FromServiceAuditEntries(entries)); err != nil {
+			http.Error(w, "failed to encode response", http.StatusInternalServerError)
+		}
+	}
+}
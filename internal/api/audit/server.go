@@ -0,0 +1,42 @@
+package audit
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/vishenosik/CherryWatch/internal/api/authentication"
+	"github.com/vishenosik/CherryWatch/internal/services/models"
+	"github.com/vishenosik/web-tools/api"
+)
+
+type Audit interface {
+	Entries(ctx context.Context, filter models.AuditFilter) (models.AuditEntries, error)
+}
+
+type auditAPI struct {
+	log     *slog.Logger
+	service Audit
+}
+
+type server = *auditAPI
+
+func NewAuditServer(
+	log *slog.Logger,
+	service Audit,
+) *auditAPI {
+
+	return &auditAPI{
+		log:     log,
+		service: service,
+	}
+
+}
+
+// Routers registers audit log routes, available to admins only.
+func (srv server) Routers(router chi.Router) {
+	router.Route(api.ApiV1("/audit"), func(r chi.Router) {
+		r.Use(authentication.RequireRole(models.RoleAdmin))
+		r.Get("/", srv.listEntries())
+	})
+}
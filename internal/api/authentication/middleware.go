@@ -0,0 +1,93 @@
+package authentication
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/pkg/errors"
+	serviceModels "github.com/vishenosik/CherryWatch/internal/services/models"
+)
+
+const (
+	// alternative header for API keys
+	apiKeyHeader = "X-API-Key"
+)
+
+// Middleware rejects requests without valid credentials and
+// puts the authenticated caller into the request context.
+//
+// Credentials are read from "Authorization: Bearer <token or API key>"
+// or from the X-API-Key header.
+//
+// Every route requires the viewer role for safe methods and the editor
+// role otherwise, routes needing more use RequireRole.
+func (srv server) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+
+		principal, err := srv.service.Authenticate(r.Context(), Credential(r.Header))
+		if err != nil {
+			switch {
+			case errors.Is(err, serviceModels.ErrUnauthenticated),
+				errors.Is(err, serviceModels.ErrInvalidCredentials):
+				w.Header().Set("WWW-Authenticate", `Bearer realm="cherrywatch"`)
+				http.Error(w, "unauthorized", http.StatusUnauthorized)
+			default:
+				http.Error(w, "Internal server error", http.StatusInternalServerError)
+			}
+			return
+		}
+
+		if !principal.Allows(methodRole(r.Method)) {
+			http.Error(w, "forbidden", http.StatusForbidden)
+			return
+		}
+
+		ctx, err := serviceModels.WithPrincipal(r.Context(), principal)
+		if err != nil {
+			http.Error(w, "forbidden", http.StatusForbidden)
+			return
+		}
+
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// RequireRole rejects callers without the role, it must follow Middleware.
+func RequireRole(role serviceModels.Role) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+
+			principal, _ := serviceModels.PrincipalFrom(r.Context())
+			if !principal.Allows(role) {
+				http.Error(w, "forbidden", http.StatusForbidden)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// Credential extracts a bearer token or an API key from request headers.
+func Credential(header http.Header) string {
+
+	if key := header.Get(apiKeyHeader); key != "" {
+		return key
+	}
+
+	scheme, credential, ok := strings.Cut(header.Get("Authorization"), " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return ""
+	}
+
+	return strings.TrimSpace(credential)
+}
+
+func methodRole(method string) serviceModels.Role {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return serviceModels.RoleViewer
+	default:
+		return serviceModels.RoleEditor
+	}
+}
@@ -0,0 +1,113 @@
+package authentication
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	serviceModels "github.com/vishenosik/CherryWatch/internal/services/models"
+)
+
+func Test_Credential(t *testing.T) {
+
+	testingTable := []struct {
+		name     string
+		header   http.Header
+		expected string
+	}{
+		{
+			name:     "bearer",
+			header:   http.Header{"Authorization": {"Bearer abc"}},
+			expected: "abc",
+		},
+		{
+			name:     "bearer lower case",
+			header:   http.Header{"Authorization": {"bearer abc"}},
+			expected: "abc",
+		},
+		{
+			name:   "basic",
+			header: http.Header{"Authorization": {"Basic abc"}},
+		},
+		{
+			name:     "api key header",
+			header:   http.Header{"X-Api-Key": {"cw_abc"}},
+			expected: "cw_abc",
+		},
+		{
+			name: "none",
+		},
+	}
+
+	for _, tt := range testingTable {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, Credential(tt.header))
+		})
+	}
+}
+
+type authenticationMock struct {
+	principal *serviceModels.Principal
+}
+
+func (am authenticationMock) IssueToken(context.Context, string) (*serviceModels.Token, error) {
+	return nil, serviceModels.ErrInvalidCredentials
+}
+
+func (am authenticationMock) Login(context.Context, string, string) (*serviceModels.Token, error) {
+	return nil, serviceModels.ErrInvalidCredentials
+}
+
+func (am authenticationMock) Authenticate(_ context.Context, credential string) (*serviceModels.Principal, error) {
+	if credential != "valid" {
+		return nil, serviceModels.ErrInvalidCredentials
+	}
+	return am.principal, nil
+}
+
+func Test_Middleware(t *testing.T) {
+
+	viewer := NewAuthenticationServer(
+		slog.New(slog.NewTextHandler(io.Discard, nil)),
+		authenticationMock{principal: &serviceModels.Principal{Role: serviceModels.RoleViewer, WorkspaceID: serviceModels.DefaultWorkspace}},
+	)
+
+	ok := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) { w.WriteHeader(http.StatusOK) })
+
+	router := chi.NewRouter()
+	router.Use(viewer.Middleware)
+	router.Get("/read", ok)
+	router.Post("/write", ok)
+	router.With(RequireRole(serviceModels.RoleAdmin)).Get("/admin", ok)
+
+	testingTable := []struct {
+		name       string
+		method     string
+		path       string
+		credential string
+		status     int
+	}{
+		{name: "no credentials", method: http.MethodGet, path: "/read", status: http.StatusUnauthorized},
+		{name: "invalid credentials", method: http.MethodGet, path: "/read", credential: "wrong", status: http.StatusUnauthorized},
+		{name: "viewer reads", method: http.MethodGet, path: "/read", credential: "valid", status: http.StatusOK},
+		{name: "viewer writes", method: http.MethodPost, path: "/write", credential: "valid", status: http.StatusForbidden},
+		{name: "viewer administrates", method: http.MethodGet, path: "/admin", credential: "valid", status: http.StatusForbidden},
+	}
+
+	for _, tt := range testingTable {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			if tt.credential != "" {
+				req.Header.Set("Authorization", "Bearer "+tt.credential)
+			}
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+			assert.Equal(t, tt.status, rec.Code)
+		})
+	}
+}
@@ -0,0 +1,40 @@
+package authentication
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/vishenosik/CherryWatch/internal/services/models"
+	"github.com/vishenosik/web-tools/api"
+)
+
+type Authentication interface {
+	IssueToken(ctx context.Context, apiKey string) (*models.Token, error)
+	Login(ctx context.Context, email, password string) (*models.Token, error)
+	Authenticate(ctx context.Context, credential string) (*models.Principal, error)
+}
+
+type authenticationAPI struct {
+	log     *slog.Logger
+	service Authentication
+}
+
+type server = *authenticationAPI
+
+func NewAuthenticationServer(
+	log *slog.Logger,
+	service Authentication,
+) *authenticationAPI {
+
+	return &authenticationAPI{
+		log:     log,
+		service: service,
+	}
+
+}
+
+// Routers registers credentials exchange routes, they must stay public.
+func (srv server) Routers(router chi.Router) {
+	router.Post(api.ApiV1("/auth/token"), srv.issueToken())
+}
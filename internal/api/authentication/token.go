@@ -0,0 +1,47 @@
+package authentication
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/pkg/errors"
+	"github.com/vishenosik/CherryWatch/internal/api/models"
+	serviceModels "github.com/vishenosik/CherryWatch/internal/services/models"
+	"github.com/vishenosik/CherryWatch/pkg/httpjson"
+)
+
+func (srv server) issueToken() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		request, err := httpjson.Decode[models.TokenRequest](r)
+		if err != nil {
+			http.Error(w, "failed to decode request body", http.StatusBadRequest)
+			return
+		}
+
+		var token *serviceModels.Token
+
+		if request.APIKey != "" {
+			token, err = srv.service.IssueToken(r.Context(), request.APIKey)
+		} else {
+			token, err = srv.service.Login(r.Context(), request.Email, request.Password)
+		}
+		if err != nil {
+			switch {
+			case errors.Is(err, serviceModels.ErrInvalidCredentials):
+				http.Error(w, "invalid credentials", http.StatusUnauthorized)
+			default:
+				http.Error(w, "Internal server error", http.StatusInternalServerError)
+			}
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", "no-store")
+
+		if err := json.NewEncoder(w).Encode(models.FromServiceToken(token)); err != nil {
+			http.Error(w, "failed to encode response", http.StatusInternalServerError)
+			return
+		}
+	}
+}
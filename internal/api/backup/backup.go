@@ -0,0 +1,47 @@
+package backup
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+	serviceModels "github.com/vishenosik/CherryWatch/internal/services/models"
+	attrs "github.com/vishenosik/web-tools/log"
+)
+
+// backup streams a consistent snapshot of the sqlite store as a file download.
+// Checks keep running while it's made, the file is restored with `watch -restore`.
+func (srv server) backup() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		snapshot, err := srv.service.Snapshot(r.Context())
+		if err != nil {
+			srv.writeError(w, err)
+			return
+		}
+		defer snapshot.Close()
+
+		filename := fmt.Sprintf("CherryWatch-%s.db", time.Now().UTC().Format("20060102T150405Z"))
+
+		w.Header().Set("Content-Type", "application/vnd.sqlite3")
+		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+
+		if _, err := io.Copy(w, snapshot); err != nil {
+			srv.log.Error("failed to send backup", attrs.Error(err))
+		}
+	}
+}
+
+func (srv server) writeError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, serviceModels.ErrForbidden):
+		http.Error(w, "backups are made by the default workspace", http.StatusForbidden)
+	case errors.Is(err, serviceModels.ErrBackupNotSupported):
+		http.Error(w, "backups are supported by the sqlite store only", http.StatusNotImplemented)
+	default:
+		srv.log.Error("failed to back up the store", attrs.Error(err))
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,87 @@
+package backup
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	serviceModels "github.com/vishenosik/CherryWatch/internal/services/models"
+)
+
+type backupMock struct {
+	err error
+}
+
+func (bm *backupMock) Snapshot(context.Context) (io.ReadCloser, error) {
+	if bm.err != nil {
+		return nil, bm.err
+	}
+	return io.NopCloser(strings.NewReader("SQLite format 3")), nil
+}
+
+func Test_backup(t *testing.T) {
+
+	tests := []struct {
+		name   string
+		role   serviceModels.Role
+		err    error
+		status int
+		want   string
+	}{
+		{
+			name:   "admin",
+			role:   serviceModels.RoleAdmin,
+			status: http.StatusOK,
+			want:   "SQLite format 3",
+		},
+		{
+			name:   "editor",
+			role:   serviceModels.RoleEditor,
+			status: http.StatusForbidden,
+		},
+		{
+			name:   "other workspace",
+			role:   serviceModels.RoleAdmin,
+			err:    errors.Wrap(serviceModels.ErrForbidden, "services.backup.Snapshot"),
+			status: http.StatusForbidden,
+		},
+		{
+			name:   "postgres store",
+			role:   serviceModels.RoleAdmin,
+			err:    errors.Wrap(serviceModels.ErrBackupNotSupported, "services.backup.Snapshot"),
+			status: http.StatusNotImplemented,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			router := chi.NewRouter()
+			NewBackupServer(slog.New(slog.NewTextHandler(io.Discard, nil)), &backupMock{err: tt.err}).Routers(router)
+
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/admin/backup", nil)
+			ctx, err := serviceModels.WithPrincipal(req.Context(), &serviceModels.Principal{WorkspaceID: serviceModels.DefaultWorkspace, Role: tt.role})
+			require.NoError(t, err)
+			req = req.WithContext(ctx)
+
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.status, rec.Code)
+
+			if tt.status == http.StatusOK {
+				assert.Equal(t, tt.want, rec.Body.String())
+				assert.Equal(t, "application/vnd.sqlite3", rec.Header().Get("Content-Type"))
+				assert.Regexp(t, `^attachment; filename="CherryWatch-\d{8}T\d{6}Z\.db"$`, rec.Header().Get("Content-Disposition"))
+			}
+		})
+	}
+}
@@ -0,0 +1,43 @@
+package backup
+
+import (
+	"context"
+	"io"
+	"log/slog"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/vishenosik/CherryWatch/internal/api/authentication"
+	"github.com/vishenosik/CherryWatch/internal/services/models"
+	"github.com/vishenosik/web-tools/api"
+)
+
+type Backup interface {
+	Snapshot(ctx context.Context) (io.ReadCloser, error)
+}
+
+type backupAPI struct {
+	log     *slog.Logger
+	service Backup
+}
+
+type server = *backupAPI
+
+func NewBackupServer(
+	log *slog.Logger,
+	service Backup,
+) *backupAPI {
+
+	return &backupAPI{
+		log:     log,
+		service: service,
+	}
+
+}
+
+// Routers registers store administration routes, available to admins only.
+func (srv server) Routers(router chi.Router) {
+	router.Route(api.ApiV1("/admin"), func(r chi.Router) {
+		r.Use(authentication.RequireRole(models.RoleAdmin))
+		r.Post("/backup", srv.backup())
+	})
+}
@@ -0,0 +1,198 @@
+package badges
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/pkg/errors"
+	serviceModels "github.com/vishenosik/CherryWatch/internal/services/models"
+	attrs "github.com/vishenosik/web-tools/log"
+)
+
+const (
+	// default uptime and response time window
+	defaultWindow = 30
+)
+
+// colors are the shields.io palette.
+var colors = map[serviceModels.BadgeColor]string{
+	serviceModels.BadgeBrightGreen: "#4c1",
+	serviceModels.BadgeGreen:       "#97ca00",
+	serviceModels.BadgeYellow:      "#dfb317",
+	serviceModels.BadgeOrange:      "#fe7d37",
+	serviceModels.BadgeRed:         "#e05d44",
+	serviceModels.BadgeBlue:        "#007ec6",
+	serviceModels.BadgeGrey:        "#9f9f9f",
+}
+
+// endpointBadge renders the badge of an endpoint published on a public status page.
+// Query parameters: type (status, uptime, response), window (like 7d) and label.
+func (srv server) endpointBadge() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		srv.badge(w, r, serviceModels.BadgeRequest{EndpointID: chi.URLParam(r, "id")})
+	}
+}
+
+// groupBadge renders the badge of endpoints sharing the component name,
+// the workspace query parameter selects the workspace (default if empty).
+// Only endpoints published on public status pages make up the group.
+func (srv server) groupBadge() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		group, err := url.PathUnescape(chi.URLParam(r, "group"))
+		if err != nil {
+			http.Error(w, "invalid group name", http.StatusBadRequest)
+			return
+		}
+
+		srv.badge(w, r, serviceModels.BadgeRequest{
+			WorkspaceID: r.URL.Query().Get("workspace"),
+			Group:       group,
+		})
+	}
+}
+
+func (srv server) badge(w http.ResponseWriter, r *http.Request, req serviceModels.BadgeRequest) {
+
+	query := r.URL.Query()
+
+	days, err := parseWindow(query.Get("window"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	req.Kind = serviceModels.BadgeKind(query.Get("type"))
+	req.Days = days
+
+	badge, err := srv.service.Badge(r.Context(), req)
+	if err != nil {
+		switch {
+		case errors.Is(err, serviceModels.ErrNotFound):
+			// still an image, so that embedding documents show what's wrong
+			srv.write(w, r, http.StatusNotFound, &serviceModels.Badge{Label: "badge", Message: "not found", Color: serviceModels.BadgeGrey})
+		case errors.Is(err, serviceModels.ErrInvalidBadge):
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		default:
+			srv.log.Error("failed to build badge", attrs.Error(err))
+			http.Error(w, "Internal server error", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	if label := query.Get("label"); label != "" {
+		badge.Label = label
+	}
+
+	// status changes with every check, aggregates are updated slower
+	if req.Kind == serviceModels.BadgeStatus || req.Kind == "" {
+		w.Header().Set("Cache-Control", "public, max-age=60")
+	} else {
+		w.Header().Set("Cache-Control", "public, max-age=300")
+	}
+
+	srv.write(w, r, http.StatusOK, badge)
+}
+
+// write renders the badge, responding not modified if the client has it already.
+func (srv server) write(w http.ResponseWriter, r *http.Request, status int, badge *serviceModels.Badge) {
+
+	var buf bytes.Buffer
+	if err := srv.template.Execute(&buf, newView(badge)); err != nil {
+		srv.log.Error("failed to render badge", attrs.Error(err))
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	sum := sha256.Sum256(buf.Bytes())
+	etag := `"` + hex.EncodeToString(sum[:8]) + `"`
+
+	w.Header().Set("ETag", etag)
+
+	if status == http.StatusOK && r.Header.Get("If-None-Match") == etag {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	w.Header().Set("Content-Type", "image/svg+xml; charset=utf-8")
+	w.WriteHeader(status)
+	_, _ = buf.WriteTo(w)
+}
+
+// parseWindow parses number of days like 30d, default window if empty.
+func parseWindow(window string) (int, error) {
+
+	if window == "" {
+		return defaultWindow, nil
+	}
+
+	days, err := strconv.Atoi(strings.TrimSuffix(window, "d"))
+	if err != nil || !strings.HasSuffix(window, "d") {
+		return 0, errors.New("window must be a number of days like 30d")
+	}
+
+	return days, nil
+}
+
+// view is the badge layout.
+type view struct {
+	Label        string
+	Message      string
+	Color        string
+	Width        int
+	LabelWidth   int
+	MessageWidth int
+	LabelX       float64
+	MessageX     float64
+}
+
+const (
+	// horizontal padding of a badge half
+	padding = 6
+	// approximate width of a Verdana 11px character
+	charWidth = 7
+)
+
+func newView(badge *serviceModels.Badge) view {
+
+	color, ok := colors[badge.Color]
+	if !ok {
+		color = colors[serviceModels.BadgeGrey]
+	}
+
+	labelWidth := textWidth(badge.Label) + 2*padding
+	messageWidth := textWidth(badge.Message) + 2*padding
+
+	return view{
+		Label:        badge.Label,
+		Message:      badge.Message,
+		Color:        color,
+		Width:        labelWidth + messageWidth,
+		LabelWidth:   labelWidth,
+		MessageWidth: messageWidth,
+		LabelX:       float64(labelWidth) / 2,
+		MessageX:     float64(labelWidth) + float64(messageWidth)/2,
+	}
+}
+
+// textWidth estimates the rendered text width, narrow characters take less space.
+func textWidth(text string) int {
+	width := 0
+	for _, char := range text {
+		switch {
+		case strings.ContainsRune("ijlrtf.,:;!|' ()", char):
+			width += 4
+		case strings.ContainsRune("mwMW%", char):
+			width += 10
+		default:
+			width += charWidth
+		}
+	}
+	return width
+}
@@ -0,0 +1,84 @@
+package badges
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	serviceModels "github.com/vishenosik/CherryWatch/internal/services/models"
+)
+
+type badgesMock struct {
+	req serviceModels.BadgeRequest
+}
+
+func (bm *badgesMock) Badge(_ context.Context, req serviceModels.BadgeRequest) (*serviceModels.Badge, error) {
+	bm.req = req
+	if req.EndpointID == "missing" {
+		return nil, serviceModels.ErrNotFound
+	}
+	return &serviceModels.Badge{Label: "api <eu>", Message: "99.95%", Color: serviceModels.BadgeBrightGreen}, nil
+}
+
+func Test_badge(t *testing.T) {
+
+	service := &badgesMock{}
+
+	router := chi.NewRouter()
+	NewBadgesServer(slog.New(slog.NewTextHandler(io.Discard, nil)), service).Routers(router)
+
+	t.Run("endpoint", func(t *testing.T) {
+
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/badges/1.svg?type=uptime&window=7d", nil))
+		require.Equal(t, http.StatusOK, rec.Code)
+
+		assert.Equal(t, serviceModels.BadgeRequest{EndpointID: "1", Kind: serviceModels.BadgeUptime, Days: 7}, service.req)
+		assert.Equal(t, "image/svg+xml; charset=utf-8", rec.Header().Get("Content-Type"))
+		assert.Equal(t, "public, max-age=300", rec.Header().Get("Cache-Control"))
+
+		body := rec.Body.String()
+		assert.Contains(t, body, "api &lt;eu&gt;")
+		assert.Contains(t, body, `fill="#4c1"`)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/badges/1.svg?type=uptime&window=7d", nil)
+		req.Header.Set("If-None-Match", rec.Header().Get("ETag"))
+
+		rec = httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		assert.Equal(t, http.StatusNotModified, rec.Code)
+		assert.Empty(t, rec.Body.String())
+	})
+
+	t.Run("group", func(t *testing.T) {
+
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/badges/groups/Public%20API.svg?workspace=team-a&label=api", nil))
+		require.Equal(t, http.StatusOK, rec.Code)
+
+		assert.Equal(t, serviceModels.BadgeRequest{WorkspaceID: "team-a", Group: "Public API", Days: defaultWindow}, service.req)
+		assert.Equal(t, "public, max-age=60", rec.Header().Get("Cache-Control"))
+		assert.Contains(t, rec.Body.String(), "<title>api: 99.95%</title>")
+	})
+
+	t.Run("not found", func(t *testing.T) {
+
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/badges/missing.svg", nil))
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+		assert.Contains(t, rec.Body.String(), "not found")
+	})
+
+	t.Run("invalid window", func(t *testing.T) {
+
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/badges/1.svg?window=week", nil))
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+}
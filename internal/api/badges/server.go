@@ -0,0 +1,46 @@
+package badges
+
+import (
+	"context"
+	"html/template"
+	"log/slog"
+
+	"github.com/go-chi/chi/v5"
+	embed "github.com/vishenosik/CherryWatch"
+	"github.com/vishenosik/CherryWatch/internal/services/models"
+	"github.com/vishenosik/web-tools/api"
+)
+
+type Badges interface {
+	Badge(ctx context.Context, req models.BadgeRequest) (*models.Badge, error)
+}
+
+type badgesAPI struct {
+	log      *slog.Logger
+	service  Badges
+	template *template.Template
+}
+
+type server = *badgesAPI
+
+func NewBadgesServer(
+	log *slog.Logger,
+	service Badges,
+) *badgesAPI {
+
+	return &badgesAPI{
+		log:      log,
+		service:  service,
+		template: template.Must(template.ParseFS(embed.Templates, "templates/badges/badge.svg")),
+	}
+
+}
+
+// Routers registers badge routes, they are meant to be served publicly
+// so that badges can be embedded into documents.
+func (srv server) Routers(router chi.Router) {
+	router.Route(api.ApiV1("/badges"), func(r chi.Router) {
+		r.Get("/{id}.svg", srv.endpointBadge())
+		r.Get("/groups/{group}.svg", srv.groupBadge())
+	})
+}
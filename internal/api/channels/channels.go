@@ -0,0 +1,83 @@
+package channels
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/pkg/errors"
+	"github.com/vishenosik/CherryWatch/internal/api/models"
+	serviceModels "github.com/vishenosik/CherryWatch/internal/services/models"
+	"github.com/vishenosik/CherryWatch/pkg/httpjson"
+	attrs "github.com/vishenosik/web-tools/log"
+)
+
+// listChannels returns channels of the workspace ordered by name.
+func (srv server) listChannels() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		channels, err := srv.service.Channels(r.Context())
+		if err != nil {
+			srv.writeError(w, err)
+			return
+		}
+
+		writeJSON(w, http.StatusOK, models.FromServiceChannels(channels))
+	}
+}
+
+func (srv server) createChannel() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		request, err := httpjson.Decode[models.Channel](r)
+		if err != nil {
+			http.Error(w, "failed to decode request body", http.StatusBadRequest)
+			return
+		}
+
+		ch, err := srv.service.CreateChannel(r.Context(), models.ToServiceChannel(request))
+		if err != nil {
+			srv.writeError(w, err)
+			return
+		}
+
+		writeJSON(w, http.StatusCreated, models.FromServiceChannel(ch))
+	}
+}
+
+func (srv server) deleteChannel() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		if err := srv.service.DeleteChannel(r.Context(), chi.URLParam(r, "id")); err != nil {
+			srv.writeError(w, err)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func (srv server) writeError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, serviceModels.ErrNotFound):
+		http.Error(w, "channel not found", http.StatusNotFound)
+	case errors.Is(err, serviceModels.ErrInvalidChannel),
+		errors.Is(err, serviceModels.ErrUnsupportedChannel):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, serviceModels.ErrChannelExists):
+		http.Error(w, "channel exists already", http.StatusConflict)
+	default:
+		srv.log.Error("channels request failed", attrs.Error(err))
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, response any) {
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		http.Error(w, "failed to encode response", http.StatusInternalServerError)
+	}
+}
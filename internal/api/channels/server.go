@@ -0,0 +1,47 @@
+package channels
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/vishenosik/CherryWatch/internal/api/authentication"
+	"github.com/vishenosik/CherryWatch/internal/services/models"
+	"github.com/vishenosik/web-tools/api"
+)
+
+type Channels interface {
+	CreateChannel(ctx context.Context, ch *models.Channel) (*models.Channel, error)
+	Channels(ctx context.Context) (models.Channels, error)
+	DeleteChannel(ctx context.Context, id string) error
+}
+
+type channelsAPI struct {
+	log     *slog.Logger
+	service Channels
+}
+
+type server = *channelsAPI
+
+func NewChannelsServer(
+	log *slog.Logger,
+	service Channels,
+) *channelsAPI {
+
+	return &channelsAPI{
+		log:     log,
+		service: service,
+	}
+
+}
+
+// Routers registers notification channels routes,
+// editors only since webhook URLs may hold secrets.
+func (srv server) Routers(router chi.Router) {
+	router.Route(api.ApiV1("/channels"), func(r chi.Router) {
+		r.Use(authentication.RequireRole(models.RoleEditor))
+		r.Get("/", srv.listChannels())
+		r.Post("/", srv.createChannel())
+		r.Delete("/{id}", srv.deleteChannel())
+	})
+}
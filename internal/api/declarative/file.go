@@ -0,0 +1,59 @@
+package declarative
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+	apiModels "github.com/vishenosik/CherryWatch/internal/api/models"
+	"github.com/vishenosik/CherryWatch/internal/services/models"
+)
+
+// FileSource reads the desired endpoints state from a YAML or JSON file,
+// the file is re-read on every load.
+type FileSource struct {
+	path string
+}
+
+func NewFileSource(path string) *FileSource {
+	return &FileSource{
+		path: path,
+	}
+}
+
+func (src *FileSource) Load() (*models.Manifest, error) {
+
+	const op = "api.declarative.Load"
+
+	data, err := os.ReadFile(src.path)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	file, err := apiModels.DecodeEndpointsFile(data, format(src.path))
+	if err != nil {
+		return nil, errors.Wrapf(err, "%s: %s", op, src.path)
+	}
+
+	endpoints, err := apiModels.ToServiceEndpoints(file.Endpoints)
+	if err != nil {
+		return nil, errors.Wrapf(err, "%s: %s", op, src.path)
+	}
+
+	return &models.Manifest{
+		WorkspaceID: file.Workspace,
+		Endpoints:   endpoints,
+	}, nil
+}
+
+// format detects file format by extension.
+func format(path string) string {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		return apiModels.FormatYAML
+	case ".json":
+		return apiModels.FormatJSON
+	}
+	return strings.TrimPrefix(filepath.Ext(path), ".")
+}
@@ -0,0 +1,87 @@
+package endpoints
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/pkg/errors"
+	"github.com/vishenosik/CherryWatch/internal/api/models"
+	serviceModels "github.com/vishenosik/CherryWatch/internal/services/models"
+	attrs "github.com/vishenosik/web-tools/log"
+)
+
+func (srv server) listEndpoints() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		endpoints, err := srv.service.Endpoints(r.Context())
+		if err != nil {
+			srv.writeError(w, err)
+			return
+		}
+
+		writeJSON(w, http.StatusOK, models.FromServiceEndpoints(endpoints))
+	}
+}
+
+func (srv server) getEndpoint() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		endpoint, err := srv.service.Endpoint(r.Context(), chi.URLParam(r, "id"))
+		if err != nil {
+			srv.writeError(w, err)
+			return
+		}
+
+		writeJSON(w, http.StatusOK, models.FromServiceEndpoint(endpoint))
+	}
+}
+
+func (srv server) deleteEndpoint() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		if err := srv.service.DeleteEndpoint(r.Context(), chi.URLParam(r, "id")); err != nil {
+			srv.writeError(w, err)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// checkEndpoint runs the endpoint check out of schedule and returns its result.
+func (srv server) checkEndpoint() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		result, err := srv.service.TriggerCheck(r.Context(), chi.URLParam(r, "id"))
+		if err != nil {
+			srv.writeError(w, err)
+			return
+		}
+
+		writeJSON(w, http.StatusOK, models.FromServiceCheckResult(result))
+	}
+}
+
+func (srv server) writeError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, serviceModels.ErrNotFound):
+		http.Error(w, "endpoint not found", http.StatusNotFound)
+	case errors.Is(err, serviceModels.ErrPassiveCheck),
+		errors.Is(err, serviceModels.ErrProbedCheck):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	default:
+		srv.log.Error("endpoints request failed", attrs.Error(err))
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, response any) {
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		http.Error(w, "failed to encode response", http.StatusInternalServerError)
+	}
+}
@@ -5,7 +5,9 @@ import (
 	"encoding/json"
 	"net/http"
 
+	"github.com/pkg/errors"
 	"github.com/vishenosik/CherryWatch/internal/api/models"
+	serviceModels "github.com/vishenosik/CherryWatch/internal/services/models"
 	"github.com/vishenosik/CherryWatch/pkg/httpjson"
 )
 
@@ -21,9 +23,19 @@ func (srv server) saveEndpoint() http.HandlerFunc {
 		ctx, cancel := context.WithCancel(r.Context())
 		defer cancel()
 
-		added, err := srv.service.SaveEndpoints(ctx, models.ToServiceEndpoints(endpoints))
+		converted, err := models.ToServiceEndpoints(endpoints)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		added, err := srv.service.CreateEndpoints(ctx, converted)
 		if err != nil {
 			switch {
+			case errors.Is(err, serviceModels.ErrInvalidEndpoint):
+				http.Error(w, err.Error(), http.StatusBadRequest)
+			case errors.Is(err, serviceModels.ErrEndpointExists):
+				http.Error(w, "endpoint exists already", http.StatusConflict)
 			default:
 				http.Error(w, "Internal server error", http.StatusInternalServerError)
 			}
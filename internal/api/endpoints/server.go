@@ -5,15 +5,25 @@ import (
 	"log/slog"
 
 	"github.com/go-chi/chi/v5"
+	"github.com/vishenosik/CherryWatch/internal/api/authentication"
 	"github.com/vishenosik/CherryWatch/internal/services/models"
 	"github.com/vishenosik/web-tools/api"
 )
 
 type Endpoints interface {
-	SaveEndpoints(
+	CreateEndpoints(
 		ctx context.Context,
 		endpoints models.Endpoints,
 	) (added models.Endpoints, err error)
+	Endpoint(ctx context.Context, id string) (*models.Endpoint, error)
+	Endpoints(ctx context.Context) (models.Endpoints, error)
+	DeleteEndpoint(ctx context.Context, id string) error
+	TriggerCheck(ctx context.Context, id string) (*models.CheckResult, error)
+	ImportEndpoints(
+		ctx context.Context,
+		endpoints models.Endpoints,
+		mode models.ImportMode,
+	) (*models.ImportResult, error)
 }
 
 type endpointsAPI struct {
@@ -23,7 +33,7 @@ type endpointsAPI struct {
 
 type server = *endpointsAPI
 
-func NewAuthenticationServer(
+func NewEndpointsServer(
 	log *slog.Logger,
 	service Endpoints,
 ) *endpointsAPI {
@@ -35,14 +45,15 @@ func NewAuthenticationServer(
 
 }
 
-func (srv server) Routers() *chi.Mux {
-
-	// Creating a New Router
-	endpointsRouter := chi.NewMux()
-	endpointsRouter.Post("", srv.saveEndpoint())
-
-	router := chi.NewMux()
-	router.Mount(api.ApiV1("/endpoints"), endpointsRouter)
-
-	return router
+func (srv server) Routers(router chi.Router) {
+	router.Route(api.ApiV1("/endpoints"), func(r chi.Router) {
+		r.Get("/", srv.listEndpoints())
+		r.Post("/", srv.saveEndpoint())
+		r.Post("/import", srv.importEndpoints())
+		// exported definitions carry secrets (heartbeat tokens, DSNs)
+		r.With(authentication.RequireRole(models.RoleEditor)).Get("/export", srv.exportEndpoints())
+		r.Get("/{id}", srv.getEndpoint())
+		r.Delete("/{id}", srv.deleteEndpoint())
+		r.Post("/{id}/check", srv.checkEndpoint())
+	})
 }
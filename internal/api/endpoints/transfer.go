@@ -0,0 +1,152 @@
+package endpoints
+
+import (
+	"encoding/json"
+	"io"
+	"mime"
+	"net/http"
+
+	"github.com/pkg/errors"
+	"github.com/vishenosik/CherryWatch/internal/api/importers"
+	"github.com/vishenosik/CherryWatch/internal/api/models"
+	serviceModels "github.com/vishenosik/CherryWatch/internal/services/models"
+	attrs "github.com/vishenosik/web-tools/log"
+)
+
+// maxImportSize limits import request body.
+const maxImportSize = 10 << 20
+
+var contentTypes = map[string]string{
+	models.FormatJSON: "application/json",
+	models.FormatYAML: "application/yaml",
+	models.FormatCSV:  "text/csv",
+}
+
+// exportEndpoints returns all endpoints in the format query parameter: json (default), yaml or csv.
+func (srv server) exportEndpoints() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		format := r.URL.Query().Get("format")
+		if format == "" {
+			format = models.FormatJSON
+		}
+
+		contentType, ok := contentTypes[format]
+		if !ok {
+			http.Error(w, "format must be one of: json, yaml, csv", http.StatusBadRequest)
+			return
+		}
+
+		endpoints, err := srv.service.Endpoints(r.Context())
+		if err != nil {
+			srv.log.Error("failed to get endpoints", attrs.Error(err))
+			http.Error(w, "Internal server error", http.StatusInternalServerError)
+			return
+		}
+
+		data, err := models.EncodeEndpoints(models.FromServiceEndpoints(endpoints), format)
+		if err != nil {
+			srv.log.Error("failed to encode endpoints", attrs.Error(err))
+			http.Error(w, "Internal server error", http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", contentType)
+		w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{
+			"filename": "endpoints." + format,
+		}))
+		_, _ = w.Write(data)
+	}
+}
+
+// importEndpoints stores endpoints of the request body.
+//
+// Body format is taken from the format query parameter or Content-Type (json by default),
+// mode query parameter defines handling of existing endpoints: upsert, skip or fail (default).
+// With source query parameter (gatus, blackbox, uptime-kuma) the body is a config of that tool,
+// parts that can't be translated are reported as warnings.
+func (srv server) importEndpoints() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		query := r.URL.Query()
+
+		format := query.Get("format")
+		if format == "" {
+			format = requestFormat(r)
+		}
+
+		mode := serviceModels.ImportMode(query.Get("mode"))
+		if mode == "" {
+			mode = serviceModels.ImportFail
+		}
+
+		data, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxImportSize))
+		if err != nil {
+			http.Error(w, "failed to read request body", http.StatusBadRequest)
+			return
+		}
+
+		var (
+			endpoints models.Endpoints
+			warnings  []models.ImportWarning
+		)
+
+		if source := query.Get("source"); source != "" {
+			converted, err := importers.Convert(source, data)
+			if err != nil {
+				http.Error(w, "failed to convert request body: "+err.Error(), http.StatusBadRequest)
+				return
+			}
+			endpoints, warnings = converted.Endpoints, converted.Warnings
+		} else {
+			endpoints, err = models.DecodeEndpoints(data, format)
+			if err != nil {
+				http.Error(w, "failed to decode request body: "+err.Error(), http.StatusBadRequest)
+				return
+			}
+		}
+
+		converted, err := models.ToServiceEndpoints(endpoints)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		result, err := srv.service.ImportEndpoints(r.Context(), converted, mode)
+		if err != nil {
+			switch {
+			case errors.Is(err, serviceModels.ErrImportMode),
+				errors.Is(err, serviceModels.ErrInvalidEndpoint),
+				errors.Is(err, serviceModels.ErrDuplicateName):
+				http.Error(w, err.Error(), http.StatusBadRequest)
+			case errors.Is(err, serviceModels.ErrEndpointExists):
+				http.Error(w, err.Error(), http.StatusConflict)
+			default:
+				srv.log.Error("failed to import endpoints", attrs.Error(err))
+				http.Error(w, "Internal server error", http.StatusInternalServerError)
+			}
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+
+		response := models.FromServiceImportResult(result)
+		response.Warnings = warnings
+
+		if err := json.NewEncoder(w).Encode(response); err != nil {
+			http.Error(w, "failed to encode response", http.StatusInternalServerError)
+		}
+	}
+}
+
+// requestFormat detects body format by Content-Type defaulting to json.
+func requestFormat(r *http.Request) string {
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	switch mediaType {
+	case "application/yaml", "application/x-yaml", "text/yaml", "text/x-yaml":
+		return models.FormatYAML
+	case "text/csv":
+		return models.FormatCSV
+	}
+	return models.FormatJSON
+}
@@ -0,0 +1,43 @@
+package events
+
+import (
+	"log/slog"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/vishenosik/CherryWatch/internal/services/events"
+	"github.com/vishenosik/CherryWatch/internal/services/models"
+	"github.com/vishenosik/web-tools/api"
+)
+
+// period of keepalive comments on idle streams, proxies drop quiet connections
+const keepAlive = 15 * time.Second
+
+type Events interface {
+	Subscribe(filter models.EventsFilter) *events.Subscription
+}
+
+type eventsAPI struct {
+	log       *slog.Logger
+	service   Events
+	keepAlive time.Duration
+}
+
+type server = *eventsAPI
+
+func NewEventsServer(
+	log *slog.Logger,
+	service Events,
+) *eventsAPI {
+
+	return &eventsAPI{
+		log:       log,
+		service:   service,
+		keepAlive: keepAlive,
+	}
+
+}
+
+func (srv server) Routers(router chi.Router) {
+	router.Get(api.ApiV1("/events"), srv.watchEvents())
+}
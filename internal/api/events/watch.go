@@ -0,0 +1,96 @@
+package events
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	apiModels "github.com/vishenosik/CherryWatch/internal/api/models"
+	"github.com/vishenosik/CherryWatch/internal/services/models"
+	attrs "github.com/vishenosik/web-tools/log"
+)
+
+// watchEvents streams events as server-sent events.
+//
+// Query parameters (repeatable): endpoint_id, label ("key=value"), severity.
+func (srv server) watchEvents() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		flusher, ok := w.(http.Flusher)
+		if !ok {
+			http.Error(w, "Streaming unsupported", http.StatusInternalServerError)
+			return
+		}
+
+		query := r.URL.Query()
+		filter := apiModels.ToServiceEventsFilter(query["endpoint_id"], query["label"], query["severity"])
+
+		// empty workspace filter passes events of every workspace
+		workspaceID, ok := models.WorkspaceFrom(r.Context())
+		if !ok {
+			http.Error(w, "forbidden", http.StatusForbidden)
+			return
+		}
+		filter.WorkspaceID = workspaceID
+
+		sub := srv.service.Subscribe(filter)
+		defer sub.Close()
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Header().Set("Cache-Control", "no-cache")
+		w.Header().Set("Connection", "keep-alive")
+		w.WriteHeader(http.StatusOK)
+		flusher.Flush()
+
+		ctx := r.Context()
+
+		// Next blocks, events are pumped so that idle streams get keepalives
+		events := make(chan *models.Event)
+		go func() {
+			defer close(events)
+			for {
+				event := sub.Next(ctx)
+				if event == nil {
+					return
+				}
+				select {
+				case events <- event:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+
+		ticker := time.NewTicker(srv.keepAlive)
+		defer ticker.Stop()
+
+		for {
+			var err error
+
+			select {
+			case <-ticker.C:
+				_, err = fmt.Fprint(w, ": ping\n\n")
+			case event, ok := <-events:
+				if !ok {
+					return
+				}
+
+				data, marshalErr := json.Marshal(apiModels.FromServiceEvent(event))
+				if marshalErr != nil {
+					srv.log.Error("failed to marshal event", attrs.Error(marshalErr))
+					continue
+				}
+
+				_, err = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event.Kind, data)
+			}
+
+			if err != nil {
+				srv.log.Debug("events client gone", slog.String("remote", r.RemoteAddr))
+				return
+			}
+			flusher.Flush()
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package events
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vishenosik/CherryWatch/internal/services/events"
+	"github.com/vishenosik/CherryWatch/internal/services/models"
+)
+
+func Test_watchEvents(t *testing.T) {
+
+	bus := events.NewBus(0)
+
+	api := NewEventsServer(slog.New(slog.NewTextHandler(io.Discard, nil)), bus)
+	api.keepAlive = 10 * time.Millisecond
+
+	router := chi.NewRouter()
+	router.Use(func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			next.ServeHTTP(w, r.WithContext(models.WithWorkspace(r.Context(), models.DefaultWorkspace)))
+		})
+	})
+	api.Routers(router)
+
+	server := httptest.NewServer(router)
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"/api/v1/events", nil)
+	require.NoError(t, err)
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+	lines := bufio.NewScanner(resp.Body)
+	next := func() string {
+		for lines.Scan() {
+			if line := lines.Text(); line != "" {
+				return line
+			}
+		}
+		return ""
+	}
+
+	// idle streams are kept alive
+	assert.Equal(t, ": ping", next())
+
+	// events of other workspaces aren't streamed
+	bus.Publish(&models.Event{Kind: models.EventCheckResult, EndpointID: "shop", WorkspaceID: "shop", Time: time.Now()})
+	bus.Publish(&models.Event{Kind: models.EventCheckResult, EndpointID: "api", WorkspaceID: models.DefaultWorkspace, Time: time.Now()})
+
+	line := next()
+	for line == ": ping" {
+		line = next()
+	}
+
+	assert.Equal(t, "event: check_result", line)
+	assert.True(t, strings.HasPrefix(next(), `data: {"kind":"check_result","endpoint_id":"api"`))
+}
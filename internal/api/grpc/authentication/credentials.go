@@ -0,0 +1,20 @@
+package authentication
+
+import (
+	"context"
+)
+
+// APIKey authenticates client calls with the API key sent as "x-api-key" metadata.
+type APIKey struct {
+	Key string
+	// Whether the key may be sent over an insecure connection
+	Insecure bool
+}
+
+func (key APIKey) GetRequestMetadata(_ context.Context, _ ...string) (map[string]string, error) {
+	return map[string]string{"x-api-key": key.Key}, nil
+}
+
+func (key APIKey) RequireTransportSecurity() bool {
+	return !key.Insecure
+}
@@ -0,0 +1,121 @@
+package authentication
+
+import (
+	"context"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/vishenosik/CherryWatch/internal/services/models"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+type Authenticator interface {
+	Authenticate(ctx context.Context, credential string) (*models.Principal, error)
+}
+
+// UnaryInterceptor authenticates & authorizes unary calls, see authenticate.
+func UnaryInterceptor(auth Authenticator, roles map[string]models.Role) grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req any,
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (any, error) {
+
+		ctx, err := authenticate(ctx, auth, roles, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+// StreamInterceptor authenticates & authorizes streaming calls, see authenticate.
+func StreamInterceptor(auth Authenticator, roles map[string]models.Role) grpc.StreamServerInterceptor {
+	return func(
+		srv any,
+		stream grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+
+		ctx, err := authenticate(stream.Context(), auth, roles, info.FullMethod)
+		if err != nil {
+			return err
+		}
+
+		return handler(srv, &serverStream{ServerStream: stream, ctx: ctx})
+	}
+}
+
+// authenticate reads "authorization: Bearer <token or API key>" or "x-api-key"
+// metadata and puts the authenticated caller into the context.
+// Methods missing in roles are denied to everyone.
+func authenticate(
+	ctx context.Context,
+	auth Authenticator,
+	roles map[string]models.Role,
+	method string,
+) (context.Context, error) {
+
+	principal, err := auth.Authenticate(ctx, credential(ctx))
+	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrUnauthenticated),
+			errors.Is(err, models.ErrInvalidCredentials):
+			return nil, status.Error(codes.Unauthenticated, "unauthenticated")
+		default:
+			return nil, status.Error(codes.Internal, "internal error")
+		}
+	}
+
+	role, ok := roles[method]
+	if !ok || !principal.Allows(role) {
+		return nil, status.Error(codes.PermissionDenied, "permission denied")
+	}
+
+	ctx, err = models.WithPrincipal(ctx, principal)
+	if err != nil {
+		return nil, status.Error(codes.PermissionDenied, "permission denied")
+	}
+
+	return ctx, nil
+}
+
+func credential(ctx context.Context) string {
+
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+
+	if keys := md.Get("x-api-key"); len(keys) > 0 {
+		return keys[0]
+	}
+
+	values := md.Get("authorization")
+	if len(values) == 0 {
+		return ""
+	}
+
+	scheme, credential, ok := strings.Cut(values[0], " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return ""
+	}
+
+	return strings.TrimSpace(credential)
+}
+
+// serverStream overrides stream context with the authenticated one.
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (stream *serverStream) Context() context.Context {
+	return stream.ctx
+}
@@ -0,0 +1,123 @@
+package cherrywatch
+
+import (
+	"context"
+	"io"
+	"log/slog"
+
+	"github.com/pkg/errors"
+	"github.com/vishenosik/CherryWatch/internal/services/models"
+	cherrywatchv1 "github.com/vishenosik/CherryWatch/pkg/api/cherrywatch/v1"
+	devCol "github.com/vishenosik/CherryWatch/pkg/collections"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type Agents interface {
+	Register(ctx context.Context, agent *models.Agent) (*models.Agent, error)
+	Agents(ctx context.Context) (models.Agents, error)
+	Assignments(ctx context.Context, agentID string) (models.Endpoints, error)
+	Report(ctx context.Context, agentID string, result *models.CheckResult) error
+}
+
+type agentsServer struct {
+	cherrywatchv1.UnimplementedAgentServiceServer
+	log    *slog.Logger
+	agents Agents
+}
+
+func NewAgentsServer(log *slog.Logger, agents Agents) *agentsServer {
+	return &agentsServer{
+		log:    log,
+		agents: agents,
+	}
+}
+
+// Register registers the service on the gRPC server.
+func (srv *agentsServer) Register(grpcServer *grpc.Server) {
+	cherrywatchv1.RegisterAgentServiceServer(grpcServer, srv)
+}
+
+func (srv *agentsServer) RegisterAgent(
+	ctx context.Context,
+	req *cherrywatchv1.RegisterAgentRequest,
+) (*cherrywatchv1.RegisterAgentResponse, error) {
+
+	agent, err := srv.agents.Register(ctx, &models.Agent{
+		Name:     req.GetName(),
+		Location: req.GetLocation(),
+	})
+	if err != nil {
+		return nil, toStatusError(srv.log, err, "RegisterAgent")
+	}
+
+	return &cherrywatchv1.RegisterAgentResponse{
+		Agent: fromServiceAgent(agent),
+	}, nil
+}
+
+func (srv *agentsServer) ListAssignments(
+	ctx context.Context,
+	req *cherrywatchv1.ListAssignmentsRequest,
+) (*cherrywatchv1.ListAssignmentsResponse, error) {
+
+	endpoints, err := srv.agents.Assignments(ctx, req.GetAgentId())
+	if err != nil {
+		return nil, toStatusError(srv.log, err, "ListAssignments")
+	}
+
+	return &cherrywatchv1.ListAssignmentsResponse{
+		Endpoints: devCol.ConvertSlice(endpoints, fromServiceAssignment),
+	}, nil
+}
+
+// ReportResults applies results until the agent closes the stream.
+// Results of endpoints not assigned to the agent are counted as skipped,
+// other failures abort the stream.
+func (srv *agentsServer) ReportResults(
+	stream grpc.ClientStreamingServer[cherrywatchv1.ReportResultsRequest, cherrywatchv1.ReportResultsResponse],
+) error {
+
+	ctx := stream.Context()
+	response := &cherrywatchv1.ReportResultsResponse{}
+
+	for {
+		req, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return stream.SendAndClose(response)
+		}
+		if err != nil {
+			return err
+		}
+
+		if req.GetResult() == nil {
+			return status.Error(codes.InvalidArgument, "result must be set")
+		}
+
+		err = srv.agents.Report(ctx, req.GetAgentId(), toServiceCheckResult(req.GetResult()))
+		switch {
+		case err == nil:
+			response.Accepted++
+		case errors.Is(err, models.ErrNotAssigned):
+			response.Skipped++
+		default:
+			return toStatusError(srv.log, err, "ReportResults")
+		}
+	}
+}
+
+func (srv *agentsServer) ListAgents(
+	ctx context.Context,
+	_ *cherrywatchv1.ListAgentsRequest,
+) (*cherrywatchv1.ListAgentsResponse, error) {
+
+	agents, err := srv.agents.Agents(ctx)
+	if err != nil {
+		return nil, toStatusError(srv.log, err, "ListAgents")
+	}
+
+	return &cherrywatchv1.ListAgentsResponse{
+		Agents: devCol.ConvertSlice(agents, fromServiceAgent),
+	}, nil
+}
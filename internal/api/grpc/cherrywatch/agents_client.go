@@ -0,0 +1,106 @@
+package cherrywatch
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/vishenosik/CherryWatch/internal/services/models"
+	cherrywatchv1 "github.com/vishenosik/CherryWatch/pkg/api/cherrywatch/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// AgentClient calls the agent service of the central server on behalf of a probe agent.
+type AgentClient struct {
+	client cherrywatchv1.AgentServiceClient
+}
+
+func NewAgentClient(conn grpc.ClientConnInterface) *AgentClient {
+	return &AgentClient{
+		client: cherrywatchv1.NewAgentServiceClient(conn),
+	}
+}
+
+// Register registers the agent, the returned one carries the id assigned by the server.
+func (client *AgentClient) Register(ctx context.Context, name, location string) (*models.Agent, error) {
+
+	const op = "cherrywatch.AgentClient.Register"
+
+	resp, err := client.client.RegisterAgent(ctx, &cherrywatchv1.RegisterAgentRequest{
+		Name:     name,
+		Location: location,
+	})
+	if err != nil {
+		return nil, errors.Wrap(fromStatusError(err), op)
+	}
+
+	return toServiceAgent(resp.GetAgent()), nil
+}
+
+// Assignments returns endpoints assigned to the agent location,
+// models.ErrNotFound means the server doesn't know the agent.
+func (client *AgentClient) Assignments(ctx context.Context, agentID string) (models.Endpoints, error) {
+
+	const op = "cherrywatch.AgentClient.Assignments"
+
+	resp, err := client.client.ListAssignments(ctx, &cherrywatchv1.ListAssignmentsRequest{
+		AgentId: agentID,
+	})
+	if err != nil {
+		return nil, errors.Wrap(fromStatusError(err), op)
+	}
+
+	endpoints := make(models.Endpoints, 0, len(resp.GetEndpoints()))
+	for _, ep := range resp.GetEndpoints() {
+		endpoint, err := toServiceEndpoint(ep)
+		if err != nil {
+			return nil, errors.Wrap(err, op)
+		}
+		endpoints = append(endpoints, endpoint)
+	}
+
+	return endpoints, nil
+}
+
+// ReportResults streams results until the context is done or the stream fails,
+// models.ErrNotFound means the server doesn't know the agent.
+// A result taken from the channel when the stream fails is lost.
+func (client *AgentClient) ReportResults(
+	ctx context.Context,
+	agentID string,
+	results <-chan *models.CheckResult,
+) error {
+
+	const op = "cherrywatch.AgentClient.ReportResults"
+
+	stream, err := client.client.ReportResults(ctx)
+	if err != nil {
+		return errors.Wrap(fromStatusError(err), op)
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case result := <-results:
+			err := stream.Send(&cherrywatchv1.ReportResultsRequest{
+				AgentId: agentID,
+				Result:  fromServiceCheckResult(result),
+			})
+			if err != nil {
+				// the actual error is returned by the receive
+				_, err = stream.CloseAndRecv()
+				return errors.Wrap(fromStatusError(err), op)
+			}
+		}
+	}
+}
+
+// fromStatusError converts gRPC status of a missing entity back to the service error.
+func fromStatusError(err error) error {
+	if status.Code(err) == codes.NotFound {
+		return errors.Wrap(models.ErrNotFound, status.Convert(err).Message())
+	}
+	return err
+}
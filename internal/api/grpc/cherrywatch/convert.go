@@ -0,0 +1,312 @@
+package cherrywatch
+
+import (
+	"time"
+
+	apiModels "github.com/vishenosik/CherryWatch/internal/api/models"
+	"github.com/vishenosik/CherryWatch/internal/services/models"
+	cherrywatchv1 "github.com/vishenosik/CherryWatch/pkg/api/cherrywatch/v1"
+	devCol "github.com/vishenosik/CherryWatch/pkg/collections"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// toServiceEndpoint converts message through the REST model,
+// so that code ranges are parsed the same way.
+func toServiceEndpoint(ep *cherrywatchv1.Endpoint) (*models.Endpoint, error) {
+	return apiModels.ToServiceEndpoint(apiModels.Endpoint{
+		ID:                   ep.GetId(),
+		ServiceName:          ep.GetServiceName(),
+		URL:                  ep.GetUrl(),
+		SuccessCodes:         ep.GetSuccessCodes(),
+		NotificationServices: ep.GetNotificationServices(),
+		Interval:             ep.GetInterval().AsDuration(),
+		Labels:               ep.GetLabels(),
+		Severity:             ep.GetSeverity(),
+		Type:                 ep.GetType(),
+		Transaction:          toApiTransaction(ep.GetTransaction()),
+		Heartbeat:            toApiHeartbeat(ep.GetHeartbeat()),
+		SQL:                  toApiSQLCheck(ep.GetSql()),
+		Exec:                 toApiExecCheck(ep.GetExec()),
+		Probes:               toApiProbes(ep.GetProbes()),
+	})
+}
+
+func toApiTransaction(tr *cherrywatchv1.Transaction) *apiModels.Transaction {
+	if tr == nil {
+		return nil
+	}
+	return &apiModels.Transaction{
+		Steps: devCol.ConvertSlice(tr.GetSteps(), func(step *cherrywatchv1.TransactionStep) apiModels.TransactionStep {
+			return apiModels.TransactionStep{
+				Name:         step.GetName(),
+				Method:       step.GetMethod(),
+				URL:          step.GetUrl(),
+				Headers:      step.GetHeaders(),
+				Body:         step.GetBody(),
+				SuccessCodes: step.GetSuccessCodes(),
+				Extract: devCol.ConvertSlice(step.GetExtract(), func(ex *cherrywatchv1.Extraction) apiModels.Extraction {
+					return apiModels.Extraction{
+						Variable:   ex.GetVar(),
+						Source:     ex.GetFrom(),
+						Expression: ex.GetExpr(),
+					}
+				}),
+			}
+		}),
+	}
+}
+
+func toApiHeartbeat(hb *cherrywatchv1.Heartbeat) *apiModels.Heartbeat {
+	if hb == nil {
+		return nil
+	}
+	return &apiModels.Heartbeat{
+		Grace: hb.GetGrace().AsDuration(),
+		Token: hb.GetToken(),
+	}
+}
+
+func toApiSQLCheck(check *cherrywatchv1.SQLCheck) *apiModels.SQLCheck {
+	if check == nil {
+		return nil
+	}
+	return &apiModels.SQLCheck{
+		Driver:  check.GetDriver(),
+		DSN:     check.GetDsn(),
+		Query:   check.GetQuery(),
+		Timeout: check.GetTimeout().AsDuration(),
+		Expect: apiModels.Expectation{
+			Subject:  check.GetSubject(),
+			Operator: check.GetOp(),
+			Value:    check.GetValue(),
+		},
+	}
+}
+
+func toApiExecCheck(check *cherrywatchv1.ExecCheck) *apiModels.ExecCheck {
+	if check == nil {
+		return nil
+	}
+	return &apiModels.ExecCheck{
+		Command:      check.GetCommand(),
+		Args:         check.GetArgs(),
+		Env:          check.GetEnv(),
+		Timeout:      check.GetTimeout().AsDuration(),
+		SuccessCodes: devCol.ConvertSlice(check.GetSuccessCodes(), func(code int32) int { return int(code) }),
+	}
+}
+
+func toApiProbes(probes *cherrywatchv1.Probes) *apiModels.Probes {
+	if probes == nil {
+		return nil
+	}
+	return &apiModels.Probes{
+		Locations: probes.GetLocations(),
+		Quorum:    int(probes.GetQuorum()),
+	}
+}
+
+func fromServiceEndpoint(endpoint *models.Endpoint) *cherrywatchv1.Endpoint {
+	ep := apiModels.FromServiceEndpoint(endpoint)
+	return &cherrywatchv1.Endpoint{
+		Id:                   ep.ID,
+		ServiceName:          ep.ServiceName,
+		Url:                  ep.URL,
+		SuccessCodes:         ep.SuccessCodes,
+		NotificationServices: ep.NotificationServices,
+		Interval:             durationpb.New(ep.Interval),
+		Labels:               ep.Labels,
+		Severity:             ep.Severity,
+		Type:                 ep.Type,
+		Transaction:          fromApiTransaction(ep.Transaction),
+		Heartbeat:            fromApiHeartbeat(ep.Heartbeat),
+		Sql:                  fromApiSQLCheck(ep.SQL),
+		Exec:                 fromApiExecCheck(ep.Exec),
+		Probes:               fromApiProbes(ep.Probes),
+	}
+}
+
+// fromServiceAssignment converts an endpoint assigned to an agent, which runs
+// the check, so it gets the DSN never returned to API users.
+func fromServiceAssignment(endpoint *models.Endpoint) *cherrywatchv1.Endpoint {
+	ep := fromServiceEndpoint(endpoint)
+	if endpoint.SQL != nil {
+		ep.Sql.Dsn = endpoint.SQL.DSN
+	}
+	return ep
+}
+
+func fromApiTransaction(tr *apiModels.Transaction) *cherrywatchv1.Transaction {
+	if tr == nil {
+		return nil
+	}
+	return &cherrywatchv1.Transaction{
+		Steps: devCol.ConvertSlice(tr.Steps, func(step apiModels.TransactionStep) *cherrywatchv1.TransactionStep {
+			return &cherrywatchv1.TransactionStep{
+				Name:         step.Name,
+				Method:       step.Method,
+				Url:          step.URL,
+				Headers:      step.Headers,
+				Body:         step.Body,
+				SuccessCodes: step.SuccessCodes,
+				Extract: devCol.ConvertSlice(step.Extract, func(ex apiModels.Extraction) *cherrywatchv1.Extraction {
+					return &cherrywatchv1.Extraction{
+						Var:  ex.Variable,
+						From: ex.Source,
+						Expr: ex.Expression,
+					}
+				}),
+			}
+		}),
+	}
+}
+
+func fromApiHeartbeat(hb *apiModels.Heartbeat) *cherrywatchv1.Heartbeat {
+	if hb == nil {
+		return nil
+	}
+	return &cherrywatchv1.Heartbeat{
+		Grace:   durationpb.New(hb.Grace),
+		Token:   hb.Token,
+		PingUrl: hb.PingURL,
+	}
+}
+
+func fromApiSQLCheck(check *apiModels.SQLCheck) *cherrywatchv1.SQLCheck {
+	if check == nil {
+		return nil
+	}
+	return &cherrywatchv1.SQLCheck{
+		Driver:  check.Driver,
+		Dsn:     check.DSN,
+		Query:   check.Query,
+		Timeout: durationpb.New(check.Timeout),
+		Subject: check.Expect.Subject,
+		Op:      check.Expect.Operator,
+		Value:   check.Expect.Value,
+	}
+}
+
+func fromApiExecCheck(check *apiModels.ExecCheck) *cherrywatchv1.ExecCheck {
+	if check == nil {
+		return nil
+	}
+	return &cherrywatchv1.ExecCheck{
+		Command:      check.Command,
+		Args:         check.Args,
+		Env:          check.Env,
+		Timeout:      durationpb.New(check.Timeout),
+		SuccessCodes: devCol.ConvertSlice(check.SuccessCodes, func(code int) int32 { return int32(code) }),
+	}
+}
+
+func fromApiProbes(probes *apiModels.Probes) *cherrywatchv1.Probes {
+	if probes == nil {
+		return nil
+	}
+	return &cherrywatchv1.Probes{
+		Locations: probes.Locations,
+		Quorum:    int32(probes.Quorum),
+	}
+}
+
+func fromServiceIncident(incident *models.Incident) *cherrywatchv1.Incident {
+	return &cherrywatchv1.Incident{
+		Id:         incident.ID,
+		EndpointId: incident.EndpointID,
+		Cause:      incident.Cause,
+		OpenedAt:   timestamppb.New(incident.OpenedAt),
+		ResolvedAt: timestamp(incident.ResolvedAt),
+	}
+}
+
+func fromServiceCheckResult(result *models.CheckResult) *cherrywatchv1.CheckResult {
+	return &cherrywatchv1.CheckResult{
+		EndpointId: result.EndpointID,
+		Type:       string(result.Type),
+		Success:    result.Success,
+		StatusCode: int32(result.StatusCode),
+		ExitCode:   int32(result.ExitCode),
+		Output:     result.Output,
+		Message:    result.Message,
+		FailedStep: result.FailedStep,
+		Latency:    durationpb.New(result.Latency),
+		CheckedAt:  timestamppb.New(result.CheckedAt),
+		Location:   result.Location,
+	}
+}
+
+func toServiceCheckResult(result *cherrywatchv1.CheckResult) *models.CheckResult {
+	return &models.CheckResult{
+		EndpointID: result.GetEndpointId(),
+		Type:       models.CheckType(result.GetType()),
+		Success:    result.GetSuccess(),
+		StatusCode: int(result.GetStatusCode()),
+		ExitCode:   int(result.GetExitCode()),
+		Output:     result.GetOutput(),
+		Message:    result.GetMessage(),
+		FailedStep: result.GetFailedStep(),
+		Latency:    result.GetLatency().AsDuration(),
+		CheckedAt:  result.GetCheckedAt().AsTime(),
+		Location:   result.GetLocation(),
+	}
+}
+
+func fromServiceAgent(agent *models.Agent) *cherrywatchv1.Agent {
+	return &cherrywatchv1.Agent{
+		Id:           agent.ID,
+		Name:         agent.Name,
+		Location:     agent.Location,
+		RegisteredAt: timestamp(agent.RegisteredAt),
+		LastSeenAt:   timestamp(agent.LastSeenAt),
+	}
+}
+
+func toServiceAgent(agent *cherrywatchv1.Agent) *models.Agent {
+	return &models.Agent{
+		ID:           agent.GetId(),
+		Name:         agent.GetName(),
+		Location:     agent.GetLocation(),
+		RegisteredAt: agent.GetRegisteredAt().AsTime(),
+		LastSeenAt:   agent.GetLastSeenAt().AsTime(),
+	}
+}
+
+func fromServiceEvent(event *models.Event) *cherrywatchv1.Event {
+
+	ev := &cherrywatchv1.Event{
+		Kind:       string(event.Kind),
+		EndpointId: event.EndpointID,
+		Labels:     event.Labels,
+		Severity:   string(event.Severity),
+		Dropped:    event.Dropped,
+		Time:       timestamppb.New(event.Time),
+	}
+
+	if event.Result != nil {
+		ev.Result = fromServiceCheckResult(event.Result)
+	}
+
+	if event.Incident != nil {
+		ev.Incident = fromServiceIncident(event.Incident)
+	}
+
+	return ev
+}
+
+func toServiceEventsFilter(req *cherrywatchv1.WatchEventsRequest) models.EventsFilter {
+	return models.EventsFilter{
+		EndpointIDs: req.GetEndpointIds(),
+		Labels:      req.GetLabels(),
+		Severities:  devCol.ConvertSlice(req.GetSeverities(), func(s string) models.Severity { return models.Severity(s) }),
+	}
+}
+
+// timestamp converts time leaving zero time unset.
+func timestamp(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	return timestamppb.New(t)
+}
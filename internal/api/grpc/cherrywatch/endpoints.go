@@ -0,0 +1,126 @@
+package cherrywatch
+
+import (
+	"context"
+
+	"github.com/vishenosik/CherryWatch/internal/services/models"
+	cherrywatchv1 "github.com/vishenosik/CherryWatch/pkg/api/cherrywatch/v1"
+	devCol "github.com/vishenosik/CherryWatch/pkg/collections"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (srv server) CreateEndpoint(
+	ctx context.Context,
+	req *cherrywatchv1.CreateEndpointRequest,
+) (*cherrywatchv1.Endpoint, error) {
+
+	if req.GetEndpoint() == nil {
+		return nil, status.Error(codes.InvalidArgument, "endpoint must be set")
+	}
+
+	endpoint, err := toServiceEndpoint(req.GetEndpoint())
+	if err != nil {
+		return nil, srv.statusError(err, "CreateEndpoint")
+	}
+
+	endpoint, err = srv.endpoints.CreateEndpoint(ctx, endpoint)
+	if err != nil {
+		return nil, srv.statusError(err, "CreateEndpoint")
+	}
+
+	return fromServiceEndpoint(endpoint), nil
+}
+
+func (srv server) GetEndpoint(
+	ctx context.Context,
+	req *cherrywatchv1.GetEndpointRequest,
+) (*cherrywatchv1.Endpoint, error) {
+
+	endpoint, err := srv.endpoints.Endpoint(ctx, req.GetId())
+	if err != nil {
+		return nil, srv.statusError(err, "GetEndpoint")
+	}
+
+	return fromServiceEndpoint(endpoint), nil
+}
+
+func (srv server) ListEndpoints(
+	ctx context.Context,
+	_ *cherrywatchv1.ListEndpointsRequest,
+) (*cherrywatchv1.ListEndpointsResponse, error) {
+
+	endpoints, err := srv.endpoints.Endpoints(ctx)
+	if err != nil {
+		return nil, srv.statusError(err, "ListEndpoints")
+	}
+
+	return &cherrywatchv1.ListEndpointsResponse{
+		Endpoints: devCol.ConvertSlice(endpoints, fromServiceEndpoint),
+	}, nil
+}
+
+func (srv server) UpdateEndpoint(
+	ctx context.Context,
+	req *cherrywatchv1.UpdateEndpointRequest,
+) (*cherrywatchv1.Endpoint, error) {
+
+	if req.GetEndpoint().GetId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "endpoint id must be set")
+	}
+
+	endpoint, err := toServiceEndpoint(req.GetEndpoint())
+	if err != nil {
+		return nil, srv.statusError(err, "UpdateEndpoint")
+	}
+
+	endpoint, err = srv.endpoints.UpdateEndpoint(ctx, endpoint)
+	if err != nil {
+		return nil, srv.statusError(err, "UpdateEndpoint")
+	}
+
+	return fromServiceEndpoint(endpoint), nil
+}
+
+func (srv server) DeleteEndpoint(
+	ctx context.Context,
+	req *cherrywatchv1.DeleteEndpointRequest,
+) (*cherrywatchv1.DeleteEndpointResponse, error) {
+
+	if err := srv.endpoints.DeleteEndpoint(ctx, req.GetId()); err != nil {
+		return nil, srv.statusError(err, "DeleteEndpoint")
+	}
+
+	return &cherrywatchv1.DeleteEndpointResponse{}, nil
+}
+
+func (srv server) ListIncidents(
+	ctx context.Context,
+	req *cherrywatchv1.ListIncidentsRequest,
+) (*cherrywatchv1.ListIncidentsResponse, error) {
+
+	incidents, err := srv.incidents.Incidents(ctx, models.IncidentsFilter{
+		EndpointID: req.GetEndpointId(),
+		ActiveOnly: req.GetActiveOnly(),
+	})
+	if err != nil {
+		return nil, srv.statusError(err, "ListIncidents")
+	}
+
+	return &cherrywatchv1.ListIncidentsResponse{
+		Incidents: devCol.ConvertSlice(incidents, fromServiceIncident),
+	}, nil
+}
+
+func (srv server) TriggerCheck(
+	ctx context.Context,
+	req *cherrywatchv1.TriggerCheckRequest,
+) (*cherrywatchv1.CheckResult, error) {
+
+	result, err := srv.endpoints.TriggerCheck(ctx, req.GetId())
+	if err != nil {
+		return nil, srv.statusError(err, "TriggerCheck")
+	}
+
+	return fromServiceCheckResult(result), nil
+}
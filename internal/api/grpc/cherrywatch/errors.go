@@ -0,0 +1,44 @@
+package cherrywatch
+
+import (
+	"log/slog"
+
+	"github.com/vishenosik/CherryWatch/internal/services/models"
+	webErrors "github.com/vishenosik/web-tools/errors"
+	attrs "github.com/vishenosik/web-tools/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var errorCodes = webErrors.NewErrorsMap(
+	map[error]codes.Code{
+		models.ErrNotFound:        codes.NotFound,
+		models.ErrInvalidEndpoint: codes.InvalidArgument,
+		models.ErrEndpointExists:  codes.AlreadyExists,
+		models.ErrPassiveCheck:    codes.FailedPrecondition,
+		models.ErrProbedCheck:     codes.FailedPrecondition,
+		models.ErrInvalidAgent:    codes.InvalidArgument,
+		models.ErrNotAssigned:     codes.FailedPrecondition,
+	},
+	codes.Internal,
+)
+
+// statusError converts service error to gRPC status hiding internal details.
+func (srv server) statusError(err error, method string) error {
+	return toStatusError(srv.log, err, method)
+}
+
+func toStatusError(log *slog.Logger, err error, method string) error {
+
+	code := errorCodes.Get(err)
+
+	if code == codes.Internal {
+		log.Error("request failed",
+			slog.String("method", method),
+			attrs.Error(err),
+		)
+		return status.Error(code, "internal error")
+	}
+
+	return status.Error(code, err.Error())
+}
@@ -0,0 +1,40 @@
+package cherrywatch
+
+import (
+	"github.com/vishenosik/CherryWatch/internal/services/models"
+	cherrywatchv1 "github.com/vishenosik/CherryWatch/pkg/api/cherrywatch/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// WatchEvents streams events until the client goes away.
+func (srv server) WatchEvents(
+	req *cherrywatchv1.WatchEventsRequest,
+	stream grpc.ServerStreamingServer[cherrywatchv1.Event],
+) error {
+
+	ctx := stream.Context()
+
+	filter := toServiceEventsFilter(req)
+
+	// empty workspace filter passes events of every workspace
+	workspaceID, ok := models.WorkspaceFrom(ctx)
+	if !ok {
+		return status.Error(codes.PermissionDenied, "permission denied")
+	}
+	filter.WorkspaceID = workspaceID
+
+	sub := srv.events.Subscribe(filter)
+	defer sub.Close()
+
+	for {
+		event := sub.Next(ctx)
+		if event == nil {
+			return ctx.Err()
+		}
+		if err := stream.Send(fromServiceEvent(event)); err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,23 @@
+package cherrywatch
+
+import (
+	"github.com/vishenosik/CherryWatch/internal/services/models"
+	cherrywatchv1 "github.com/vishenosik/CherryWatch/pkg/api/cherrywatch/v1"
+)
+
+// Roles maps service methods to the minimal role allowed to call them.
+var Roles = map[string]models.Role{
+	cherrywatchv1.CherryWatchService_GetEndpoint_FullMethodName:    models.RoleViewer,
+	cherrywatchv1.CherryWatchService_ListEndpoints_FullMethodName:  models.RoleViewer,
+	cherrywatchv1.CherryWatchService_ListIncidents_FullMethodName:  models.RoleViewer,
+	cherrywatchv1.CherryWatchService_WatchEvents_FullMethodName:    models.RoleViewer,
+	cherrywatchv1.CherryWatchService_CreateEndpoint_FullMethodName: models.RoleEditor,
+	cherrywatchv1.CherryWatchService_UpdateEndpoint_FullMethodName: models.RoleEditor,
+	cherrywatchv1.CherryWatchService_DeleteEndpoint_FullMethodName: models.RoleEditor,
+	cherrywatchv1.CherryWatchService_TriggerCheck_FullMethodName:   models.RoleEditor,
+	cherrywatchv1.AgentService_ListAgents_FullMethodName:           models.RoleViewer,
+	// agents read check settings, credentials included, and write results
+	cherrywatchv1.AgentService_RegisterAgent_FullMethodName:   models.RoleAdmin,
+	cherrywatchv1.AgentService_ListAssignments_FullMethodName: models.RoleAdmin,
+	cherrywatchv1.AgentService_ReportResults_FullMethodName:   models.RoleAdmin,
+}
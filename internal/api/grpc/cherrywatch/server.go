@@ -0,0 +1,57 @@
+package cherrywatch
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/vishenosik/CherryWatch/internal/services/events"
+	"github.com/vishenosik/CherryWatch/internal/services/models"
+	cherrywatchv1 "github.com/vishenosik/CherryWatch/pkg/api/cherrywatch/v1"
+	"google.golang.org/grpc"
+)
+
+type Endpoints interface {
+	CreateEndpoint(ctx context.Context, endpoint *models.Endpoint) (*models.Endpoint, error)
+	UpdateEndpoint(ctx context.Context, endpoint *models.Endpoint) (*models.Endpoint, error)
+	Endpoint(ctx context.Context, id string) (*models.Endpoint, error)
+	Endpoints(ctx context.Context) (models.Endpoints, error)
+	DeleteEndpoint(ctx context.Context, id string) error
+	TriggerCheck(ctx context.Context, id string) (*models.CheckResult, error)
+}
+
+type Incidents interface {
+	Incidents(ctx context.Context, filter models.IncidentsFilter) (models.Incidents, error)
+}
+
+type Events interface {
+	Subscribe(filter models.EventsFilter) *events.Subscription
+}
+
+type cherryWatchServer struct {
+	cherrywatchv1.UnimplementedCherryWatchServiceServer
+	log       *slog.Logger
+	endpoints Endpoints
+	incidents Incidents
+	events    Events
+}
+
+type server = *cherryWatchServer
+
+func NewCherryWatchServer(
+	log *slog.Logger,
+	endpoints Endpoints,
+	incidents Incidents,
+	events Events,
+) *cherryWatchServer {
+	return &cherryWatchServer{
+		log:       log,
+		endpoints: endpoints,
+		incidents: incidents,
+		events:    events,
+	}
+}
+
+// Register registers the service on the gRPC server.
+func (srv server) Register(grpcServer *grpc.Server) {
+	cherrywatchv1.RegisterCherryWatchServiceServer(grpcServer, srv)
+}
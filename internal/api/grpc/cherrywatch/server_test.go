@@ -0,0 +1,224 @@
+package cherrywatch
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vishenosik/CherryWatch/internal/services/events"
+	"github.com/vishenosik/CherryWatch/internal/services/models"
+	cherrywatchv1 "github.com/vishenosik/CherryWatch/pkg/api/cherrywatch/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+type endpointsMock struct {
+	endpoints map[string]*models.Endpoint
+}
+
+func (em *endpointsMock) CreateEndpoint(_ context.Context, endpoint *models.Endpoint) (*models.Endpoint, error) {
+	if endpoint.ID == "" {
+		endpoint.ID = uuid.NewString()
+	}
+	if _, ok := em.endpoints[endpoint.ID]; ok {
+		return nil, models.ErrEndpointExists
+	}
+	if err := endpoint.Validate(); err != nil {
+		return nil, models.ErrInvalidEndpoint
+	}
+	em.endpoints[endpoint.ID] = endpoint
+	return endpoint, nil
+}
+
+func (em *endpointsMock) UpdateEndpoint(_ context.Context, endpoint *models.Endpoint) (*models.Endpoint, error) {
+	if _, ok := em.endpoints[endpoint.ID]; !ok {
+		return nil, models.ErrNotFound
+	}
+	em.endpoints[endpoint.ID] = endpoint
+	return endpoint, nil
+}
+
+func (em *endpointsMock) Endpoint(_ context.Context, id string) (*models.Endpoint, error) {
+	endpoint, ok := em.endpoints[id]
+	if !ok {
+		return nil, models.ErrNotFound
+	}
+	return endpoint, nil
+}
+
+func (em *endpointsMock) Endpoints(_ context.Context) (models.Endpoints, error) {
+	endpoints := make(models.Endpoints, 0, len(em.endpoints))
+	for _, endpoint := range em.endpoints {
+		endpoints = append(endpoints, endpoint)
+	}
+	return endpoints, nil
+}
+
+func (em *endpointsMock) DeleteEndpoint(_ context.Context, id string) error {
+	if _, ok := em.endpoints[id]; !ok {
+		return models.ErrNotFound
+	}
+	delete(em.endpoints, id)
+	return nil
+}
+
+func (em *endpointsMock) TriggerCheck(_ context.Context, id string) (*models.CheckResult, error) {
+	if _, ok := em.endpoints[id]; !ok {
+		return nil, models.ErrNotFound
+	}
+	return &models.CheckResult{EndpointID: id, Success: true, StatusCode: 200, CheckedAt: time.Now()}, nil
+}
+
+type incidentsMock struct{}
+
+func (incidentsMock) Incidents(_ context.Context, filter models.IncidentsFilter) (models.Incidents, error) {
+	return models.Incidents{
+		{ID: "1", EndpointID: filter.EndpointID, Cause: "down", OpenedAt: time.Now()},
+	}, nil
+}
+
+// scopedStream runs streams in the default workspace, as the
+// authentication interceptor would.
+type scopedStream struct {
+	grpc.ServerStream
+}
+
+func (ss *scopedStream) Context() context.Context {
+	return models.WithWorkspace(ss.ServerStream.Context(), models.DefaultWorkspace)
+}
+
+func newTestClient(t *testing.T, bus *events.Bus) cherrywatchv1.CherryWatchServiceClient {
+	t.Helper()
+
+	listener := bufconn.Listen(1 << 20)
+
+	server := grpc.NewServer(
+		grpc.StreamInterceptor(func(srv any, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+			return handler(srv, &scopedStream{ServerStream: ss})
+		}),
+	)
+	NewCherryWatchServer(
+		slog.New(slog.NewTextHandler(io.Discard, nil)),
+		&endpointsMock{endpoints: make(map[string]*models.Endpoint)},
+		incidentsMock{},
+		bus,
+	).Register(server)
+
+	go func() { _ = server.Serve(listener) }()
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	return cherrywatchv1.NewCherryWatchServiceClient(conn)
+}
+
+func Test_CherryWatchServer(t *testing.T) {
+
+	ctx := context.Background()
+	client := newTestClient(t, events.NewBus(0))
+
+	created, err := client.CreateEndpoint(ctx, &cherrywatchv1.CreateEndpointRequest{
+		Endpoint: &cherrywatchv1.Endpoint{
+			ServiceName:  "api",
+			Url:          "https://example.com/health",
+			SuccessCodes: []string{"200-204", "301"},
+			Interval:     durationpb.New(time.Minute),
+		},
+	})
+	require.NoError(t, err)
+	assert.NotEmpty(t, created.GetId())
+	assert.Equal(t, []string{"200-204", "301"}, created.GetSuccessCodes())
+
+	_, err = client.CreateEndpoint(ctx, &cherrywatchv1.CreateEndpointRequest{
+		Endpoint: &cherrywatchv1.Endpoint{ServiceName: "api", Url: "not-a-url"},
+	})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	got, err := client.GetEndpoint(ctx, &cherrywatchv1.GetEndpointRequest{Id: created.GetId()})
+	require.NoError(t, err)
+	assert.Equal(t, created.GetUrl(), got.GetUrl())
+
+	got.ServiceName = "api_v2"
+	updated, err := client.UpdateEndpoint(ctx, &cherrywatchv1.UpdateEndpointRequest{Endpoint: got})
+	require.NoError(t, err)
+	assert.Equal(t, "api_v2", updated.GetServiceName())
+
+	list, err := client.ListEndpoints(ctx, &cherrywatchv1.ListEndpointsRequest{})
+	require.NoError(t, err)
+	assert.Len(t, list.GetEndpoints(), 1)
+
+	result, err := client.TriggerCheck(ctx, &cherrywatchv1.TriggerCheckRequest{Id: created.GetId()})
+	require.NoError(t, err)
+	assert.True(t, result.GetSuccess())
+
+	incidents, err := client.ListIncidents(ctx, &cherrywatchv1.ListIncidentsRequest{EndpointId: created.GetId()})
+	require.NoError(t, err)
+	require.Len(t, incidents.GetIncidents(), 1)
+	assert.Nil(t, incidents.GetIncidents()[0].GetResolvedAt())
+
+	_, err = client.DeleteEndpoint(ctx, &cherrywatchv1.DeleteEndpointRequest{Id: created.GetId()})
+	require.NoError(t, err)
+
+	_, err = client.GetEndpoint(ctx, &cherrywatchv1.GetEndpointRequest{Id: created.GetId()})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func Test_WatchEvents(t *testing.T) {
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	bus := events.NewBus(0)
+	client := newTestClient(t, bus)
+
+	stream, err := client.WatchEvents(ctx, &cherrywatchv1.WatchEventsRequest{
+		Labels:     map[string]string{"team": "core"},
+		Severities: []string{"critical"},
+	})
+	require.NoError(t, err)
+
+	// subscription is registered asynchronously
+	require.Eventually(t, func() bool {
+		bus.Publish(&models.Event{Kind: models.EventCheckResult, EndpointID: "probe", Severity: models.SeverityInfo})
+		return bus.Subscribers() > 0
+	}, time.Second, 10*time.Millisecond)
+
+	bus.Publish(&models.Event{
+		Kind:       models.EventCheckResult,
+		EndpointID: "other",
+		Labels:     map[string]string{"team": "web"},
+		Severity:   models.SeverityCritical,
+	})
+	bus.Publish(&models.Event{
+		Kind:        models.EventIncidentOpened,
+		EndpointID:  "1",
+		WorkspaceID: models.DefaultWorkspace,
+		Labels:      map[string]string{"team": "core"},
+		Severity:    models.SeverityCritical,
+		Incident:    &models.Incident{ID: "inc", EndpointID: "1", Cause: "down", OpenedAt: time.Now()},
+		Time:        time.Now(),
+	})
+
+	event, err := stream.Recv()
+	require.NoError(t, err)
+	assert.Equal(t, "incident_opened", event.GetKind())
+	assert.Equal(t, "1", event.GetEndpointId())
+	assert.Equal(t, "inc", event.GetIncident().GetId())
+}
@@ -0,0 +1,29 @@
+package heartbeat
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/pkg/errors"
+	"github.com/vishenosik/CherryWatch/internal/services/models"
+)
+
+func (srv server) ping(kind models.PingKind) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		token := chi.URLParam(r, "token")
+
+		if err := srv.service.Ping(r.Context(), token, kind); err != nil {
+			switch {
+			case errors.Is(err, models.ErrNotFound):
+				http.Error(w, "heartbeat not found", http.StatusNotFound)
+			default:
+				http.Error(w, "Internal server error", http.StatusInternalServerError)
+			}
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/plain")
+		_, _ = w.Write([]byte("OK"))
+	}
+}
@@ -0,0 +1,41 @@
+package heartbeat
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/vishenosik/CherryWatch/internal/services/models"
+	"github.com/vishenosik/web-tools/api"
+)
+
+type Heartbeat interface {
+	Ping(ctx context.Context, token string, kind models.PingKind) error
+}
+
+type heartbeatAPI struct {
+	log     *slog.Logger
+	service Heartbeat
+}
+
+type server = *heartbeatAPI
+
+func NewHeartbeatServer(
+	log *slog.Logger,
+	service Heartbeat,
+) *heartbeatAPI {
+
+	return &heartbeatAPI{
+		log:     log,
+		service: service,
+	}
+
+}
+
+// Routers registers unauthenticated ping routes.
+// Jobs may use any method, so that plain "curl <url>" works.
+func (srv server) Routers(router chi.Router) {
+	router.HandleFunc(api.ApiV1("/heartbeat/{token}"), srv.ping(models.PingSuccess))
+	router.HandleFunc(api.ApiV1("/heartbeat/{token}/start"), srv.ping(models.PingStart))
+	router.HandleFunc(api.ApiV1("/heartbeat/{token}/fail"), srv.ping(models.PingFail))
+}
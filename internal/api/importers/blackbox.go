@@ -0,0 +1,119 @@
+package importers
+
+import (
+	"maps"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// prometheus scrapes every minute by default
+	prometheusInterval = time.Minute
+	// blackbox exporter probe handler
+	probePath = "/probe"
+	// blackbox exporter example http module
+	defaultHTTPModule = "http_2xx"
+)
+
+type prometheusConfig struct {
+	Global struct {
+		ScrapeInterval string `yaml:"scrape_interval"`
+	} `yaml:"global"`
+	ScrapeConfigs []scrapeConfig `yaml:"scrape_configs"`
+}
+
+type scrapeConfig struct {
+	JobName        string              `yaml:"job_name"`
+	ScrapeInterval string              `yaml:"scrape_interval"`
+	MetricsPath    string              `yaml:"metrics_path"`
+	Params         map[string][]string `yaml:"params"`
+	StaticConfigs  []struct {
+		Targets []string          `yaml:"targets"`
+		Labels  map[string]string `yaml:"labels"`
+	} `yaml:"static_configs"`
+	// service discovery & relabeling settings
+	Other map[string]any `yaml:",inline"`
+}
+
+// Blackbox converts static targets of blackbox_exporter jobs in a Prometheus scrape config.
+// Jobs not probing through /probe are ignored.
+func Blackbox(data []byte) (*Result, error) {
+
+	var config prometheusConfig
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		return nil, errors.Wrap(err, "prometheus config")
+	}
+
+	res := &Result{}
+
+	globalInterval := res.duration("global", config.Global.ScrapeInterval, prometheusInterval)
+
+	for _, job := range config.ScrapeConfigs {
+
+		if job.MetricsPath != probePath {
+			continue
+		}
+
+		for key := range job.Other {
+			if strings.HasSuffix(key, "_sd_configs") {
+				res.warn(job.JobName, "%s is not supported, discovered targets are not imported", key)
+			}
+		}
+
+		module := defaultHTTPModule
+		if modules := job.Params["module"]; len(modules) > 0 {
+			module = modules[0]
+		}
+
+		if !strings.HasPrefix(module, "http") {
+			res.warn(job.JobName, "module %q is not an http probe, job is skipped", module)
+			continue
+		}
+
+		if module != defaultHTTPModule {
+			res.warn(job.JobName, "module %q settings live in blackbox.yml and are not translated, 2xx codes are expected", module)
+		}
+
+		interval := res.duration(job.JobName, job.ScrapeInterval, globalInterval)
+
+		for _, static := range job.StaticConfigs {
+			for _, target := range static.Targets {
+
+				url := target
+				if !strings.Contains(url, "://") {
+					url = "http://" + url
+				}
+
+				labels := maps.Clone(static.Labels)
+				if labels == nil {
+					labels = make(map[string]string, 1)
+				}
+				labels[groupLabel] = job.JobName
+
+				endpoint := httpEndpoint(target, url, "", nil, "", []string{"200-299"})
+				endpoint.Interval = res.interval(target, interval, prometheusInterval)
+				endpoint.Labels = labels
+
+				res.Endpoints = append(res.Endpoints, endpoint)
+			}
+		}
+	}
+
+	return res, nil
+}
+
+// duration parses a prometheus duration, fallback is used if it's unset or invalid.
+func (res *Result) duration(item, value string, fallback time.Duration) time.Duration {
+	if value == "" {
+		return fallback
+	}
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		res.warn(item, "interval %q is invalid, %s is used", value, fallback)
+		return fallback
+	}
+	return duration
+}
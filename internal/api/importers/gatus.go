@@ -0,0 +1,151 @@
+package importers
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+)
+
+// gatus checks endpoints every minute by default
+const gatusInterval = time.Minute
+
+type gatusConfig struct {
+	Endpoints []gatusEndpoint `yaml:"endpoints"`
+}
+
+type gatusEndpoint struct {
+	Name       string            `yaml:"name"`
+	Group      string            `yaml:"group"`
+	URL        string            `yaml:"url"`
+	Method     string            `yaml:"method"`
+	Headers    map[string]string `yaml:"headers"`
+	Body       string            `yaml:"body"`
+	Interval   string            `yaml:"interval"`
+	Enabled    *bool             `yaml:"enabled"`
+	Conditions []string          `yaml:"conditions"`
+	Alerts     []struct {
+		Type string `yaml:"type"`
+	} `yaml:"alerts"`
+}
+
+var statusCondition = regexp.MustCompile(`^\[STATUS\]\s*(==|<=|>=|<|>)\s*(.+)$`)
+
+// Gatus converts endpoints of a Gatus YAML config.
+func Gatus(data []byte) (*Result, error) {
+
+	var config gatusConfig
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		return nil, errors.Wrap(err, "gatus config")
+	}
+
+	res := &Result{}
+
+	for _, ep := range config.Endpoints {
+
+		item := ep.Name
+		if ep.Group != "" {
+			item = ep.Group + "/" + ep.Name
+		}
+
+		if ep.Enabled != nil && !*ep.Enabled {
+			res.warn(item, "disabled endpoint is skipped")
+			continue
+		}
+
+		if u, err := url.Parse(ep.URL); err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+			res.warn(item, "only http(s) endpoints are supported, %q is skipped", ep.URL)
+			continue
+		}
+
+		var codes []string
+		for _, condition := range ep.Conditions {
+			translated, ok := gatusStatus(condition)
+			switch {
+			case !ok:
+				res.warn(item, "condition %q is not supported", condition)
+			case codes != nil:
+				res.warn(item, "only the first status condition is kept, %q is skipped", condition)
+			default:
+				codes = translated
+			}
+		}
+
+		endpoint := httpEndpoint(ep.Name, ep.URL, strings.ToUpper(ep.Method), ep.Headers, ep.Body, codes)
+
+		var interval time.Duration
+		if ep.Interval != "" {
+			var err error
+			if interval, err = time.ParseDuration(ep.Interval); err != nil {
+				res.warn(item, "interval %q is invalid, default is used", ep.Interval)
+			}
+		}
+		endpoint.Interval = res.interval(item, interval, gatusInterval)
+
+		if ep.Group != "" {
+			endpoint.Labels = map[string]string{groupLabel: ep.Group}
+		}
+
+		for _, alert := range ep.Alerts {
+			endpoint.NotificationServices = append(endpoint.NotificationServices, alert.Type)
+		}
+
+		res.Endpoints = append(res.Endpoints, endpoint)
+	}
+
+	return res, nil
+}
+
+// gatusStatus translates a [STATUS] condition into code ranges.
+func gatusStatus(condition string) ([]string, bool) {
+
+	match := statusCondition.FindStringSubmatch(strings.TrimSpace(condition))
+	if match == nil {
+		return nil, false
+	}
+
+	operator, value := match[1], strings.TrimSpace(match[2])
+
+	if operator == "==" && strings.HasPrefix(value, "any(") && strings.HasSuffix(value, ")") {
+		codes := make([]string, 0)
+		for _, code := range strings.Split(value[len("any("):len(value)-1], ",") {
+			if _, err := strconv.Atoi(strings.TrimSpace(code)); err != nil {
+				return nil, false
+			}
+			codes = append(codes, strings.TrimSpace(code))
+		}
+		return codes, true
+	}
+
+	code, err := strconv.Atoi(value)
+	if err != nil {
+		return nil, false
+	}
+
+	switch operator {
+	case "==":
+		return []string{strconv.Itoa(code)}, true
+	case "<":
+		return codeRange(100, code-1)
+	case "<=":
+		return codeRange(100, code)
+	case ">":
+		return codeRange(code+1, 599)
+	case ">=":
+		return codeRange(code, 599)
+	}
+	return nil, false
+}
+
+func codeRange(from, to int) ([]string, bool) {
+	from, to = max(from, 100), min(to, 599)
+	if from > to {
+		return nil, false
+	}
+	return []string{fmt.Sprintf("%d-%d", from, to)}, true
+}
@@ -0,0 +1,101 @@
+// Package importers converts monitor definitions of other tools into CherryWatch endpoints.
+package importers
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	apiModels "github.com/vishenosik/CherryWatch/internal/api/models"
+)
+
+// Supported sources.
+const (
+	SourceGatus      = "gatus"
+	SourceBlackbox   = "blackbox"
+	SourceUptimeKuma = "uptime-kuma"
+)
+
+const (
+	// shortest interval CherryWatch accepts
+	minInterval = time.Minute
+	// label keeping the group or job of the source monitor
+	groupLabel = "group"
+)
+
+var (
+	// import source isn't supported
+	ErrSource = errors.New("import source must be one of: gatus, blackbox, uptime-kuma")
+)
+
+var converters = map[string]func(data []byte) (*Result, error){
+	SourceGatus:      Gatus,
+	SourceBlackbox:   Blackbox,
+	SourceUptimeKuma: UptimeKuma,
+}
+
+type Result struct {
+	Endpoints apiModels.Endpoints
+	Warnings  []apiModels.ImportWarning
+}
+
+// Convert translates the source document into endpoints.
+func Convert(source string, data []byte) (*Result, error) {
+	convert, ok := converters[source]
+	if !ok {
+		return nil, errors.Wrap(ErrSource, source)
+	}
+	return convert(data)
+}
+
+func (res *Result) warn(item, format string, args ...any) {
+	res.Warnings = append(res.Warnings, apiModels.ImportWarning{
+		Item:   item,
+		Reason: fmt.Sprintf(format, args...),
+	})
+}
+
+// interval clamps the interval to the one CherryWatch accepts, fallback is used if it's unset.
+func (res *Result) interval(item string, interval, fallback time.Duration) time.Duration {
+	if interval <= 0 {
+		interval = fallback
+	}
+	if interval < minInterval {
+		res.warn(item, "interval %s is raised to %s", interval, minInterval)
+		return minInterval
+	}
+	return interval
+}
+
+// httpEndpoint builds a plain http endpoint, or a single step transaction
+// if the request isn't a bare GET.
+func httpEndpoint(
+	name, url, method string,
+	headers map[string]string,
+	body string,
+	codes []string,
+) apiModels.Endpoint {
+
+	if (method == "" || method == "GET") && len(headers) == 0 && body == "" {
+		return apiModels.Endpoint{
+			ServiceName:  name,
+			URL:          url,
+			SuccessCodes: codes,
+		}
+	}
+
+	return apiModels.Endpoint{
+		ServiceName: name,
+		Type:        "transaction",
+		Transaction: &apiModels.Transaction{
+			Steps: []apiModels.TransactionStep{{
+				Name:         name,
+				Method:       method,
+				URL:          url,
+				Headers:      headers,
+				Body:         body,
+				SuccessCodes: codes,
+			}},
+		},
+	}
+}
@@ -0,0 +1,149 @@
+package importers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apiModels "github.com/vishenosik/CherryWatch/internal/api/models"
+)
+
+func items(warnings []apiModels.ImportWarning) []string {
+	result := make([]string, 0, len(warnings))
+	for _, warning := range warnings {
+		result = append(result, warning.Item)
+	}
+	return result
+}
+
+func Test_Gatus(t *testing.T) {
+
+	config := `
+endpoints:
+  - name: website
+    group: core
+    url: https://example.org/health
+    interval: 5m
+    conditions:
+      - "[STATUS] == 200"
+      - "[RESPONSE_TIME] < 300"
+    alerts:
+      - type: slack
+  - name: api
+    url: https://api.example.org/login
+    method: post
+    body: '{"user": "probe"}'
+    interval: 30s
+    conditions:
+      - "[STATUS] < 300"
+  - name: ping
+    url: icmp://example.org
+  - name: old
+    url: https://old.example.org
+    enabled: false
+`
+
+	res, err := Convert(SourceGatus, []byte(config))
+	require.NoError(t, err)
+	require.Len(t, res.Endpoints, 2)
+
+	website := res.Endpoints[0]
+	assert.Equal(t, "website", website.ServiceName)
+	assert.Equal(t, []string{"200"}, website.SuccessCodes)
+	assert.Equal(t, 5*time.Minute, website.Interval)
+	assert.Equal(t, map[string]string{"group": "core"}, website.Labels)
+	assert.Equal(t, []string{"slack"}, website.NotificationServices)
+
+	api := res.Endpoints[1]
+	assert.Equal(t, "transaction", api.Type)
+	require.Len(t, api.Transaction.Steps, 1)
+	assert.Equal(t, "POST", api.Transaction.Steps[0].Method)
+	assert.Equal(t, []string{"100-299"}, api.Transaction.Steps[0].SuccessCodes)
+	assert.Equal(t, time.Minute, api.Interval)
+
+	assert.Equal(t, []string{"core/website", "api", "ping", "old"}, items(res.Warnings))
+}
+
+func Test_Blackbox(t *testing.T) {
+
+	config := `
+global:
+  scrape_interval: 2m
+scrape_configs:
+  - job_name: node
+    static_configs:
+      - targets: [localhost:9100]
+  - job_name: websites
+    metrics_path: /probe
+    params:
+      module: [http_2xx]
+    static_configs:
+      - targets: [https://example.org, example.com]
+        labels:
+          env: prod
+  - job_name: ssh
+    metrics_path: /probe
+    params:
+      module: [ssh_banner]
+    static_configs:
+      - targets: [example.org:22]
+  - job_name: discovered
+    metrics_path: /probe
+    file_sd_configs:
+      - files: [targets.json]
+`
+
+	res, err := Convert(SourceBlackbox, []byte(config))
+	require.NoError(t, err)
+	require.Len(t, res.Endpoints, 2)
+
+	assert.Equal(t, "https://example.org", res.Endpoints[0].URL)
+	assert.Equal(t, "http://example.com", res.Endpoints[1].URL)
+	assert.Equal(t, 2*time.Minute, res.Endpoints[1].Interval)
+	assert.Equal(t, map[string]string{"env": "prod", "group": "websites"}, res.Endpoints[1].Labels)
+	assert.Equal(t, []string{"200-299"}, res.Endpoints[1].SuccessCodes)
+
+	assert.Equal(t, []string{"ssh", "discovered"}, items(res.Warnings))
+}
+
+func Test_UptimeKuma(t *testing.T) {
+
+	backup := `{
+		"version": "1.23.0",
+		"notificationList": [{"id": 1, "name": "ops-telegram"}],
+		"monitorList": [
+			{
+				"id": 1, "name": "shop", "type": "http", "url": "https://shop.example.org",
+				"method": "GET", "interval": 120, "active": 1,
+				"accepted_statuscodes": ["200-299"],
+				"tags": [{"name": "team", "value": "web"}],
+				"notificationIDList": {"1": true}
+			},
+			{"id": 2, "name": "cron", "type": "push", "interval": 3600, "active": true},
+			{"id": 3, "name": "db", "type": "port", "active": true},
+			{"id": 4, "name": "paused", "type": "http", "url": "https://x.example.org", "active": 0},
+			{"id": 5, "name": "folder", "type": "group", "active": true}
+		]
+	}`
+
+	res, err := Convert(SourceUptimeKuma, []byte(backup))
+	require.NoError(t, err)
+	require.Len(t, res.Endpoints, 2)
+
+	shop := res.Endpoints[0]
+	assert.Equal(t, "https://shop.example.org", shop.URL)
+	assert.Equal(t, 2*time.Minute, shop.Interval)
+	assert.Equal(t, []string{"200-299"}, shop.SuccessCodes)
+	assert.Equal(t, map[string]string{"team": "web"}, shop.Labels)
+	assert.Equal(t, []string{"ops-telegram"}, shop.NotificationServices)
+
+	cron := res.Endpoints[1]
+	assert.Equal(t, "heartbeat", cron.Type)
+	assert.Equal(t, time.Hour, cron.Interval)
+
+	assert.Equal(t, []string{"cron", "db", "paused"}, items(res.Warnings))
+
+	_, err = Convert("nagios", nil)
+	assert.ErrorIs(t, err, ErrSource)
+}
@@ -0,0 +1,135 @@
+package importers
+
+import (
+	"encoding/json"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	apiModels "github.com/vishenosik/CherryWatch/internal/api/models"
+)
+
+// uptime kuma checks monitors every minute by default
+const kumaInterval = time.Minute
+
+type kumaBackup struct {
+	NotificationList []struct {
+		ID   int    `json:"id"`
+		Name string `json:"name"`
+	} `json:"notificationList"`
+	MonitorList []kumaMonitor `json:"monitorList"`
+}
+
+type kumaMonitor struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+	Type string `json:"type"`
+	URL  string `json:"url"`
+	// bool or 0/1 depending on the version
+	Active              any      `json:"active"`
+	Method              string   `json:"method"`
+	Body                *string  `json:"body"`
+	Headers             *string  `json:"headers"`
+	Interval            int      `json:"interval"`
+	AcceptedStatusCodes []string `json:"accepted_statuscodes"`
+	Tags                []struct {
+		Name  string `json:"name"`
+		Value string `json:"value"`
+	} `json:"tags"`
+	NotificationIDList map[string]bool `json:"notificationIDList"`
+}
+
+// UptimeKuma converts monitors of an Uptime Kuma JSON backup.
+func UptimeKuma(data []byte) (*Result, error) {
+
+	var backup kumaBackup
+	if err := json.Unmarshal(data, &backup); err != nil {
+		return nil, errors.Wrap(err, "uptime kuma backup")
+	}
+
+	notifications := make(map[string]string, len(backup.NotificationList))
+	for _, notification := range backup.NotificationList {
+		notifications[strconv.Itoa(notification.ID)] = notification.Name
+	}
+
+	res := &Result{}
+
+	for _, monitor := range backup.MonitorList {
+
+		item := monitor.Name
+
+		if !kumaActive(monitor.Active) {
+			res.warn(item, "paused monitor is skipped")
+			continue
+		}
+
+		var endpoint apiModels.Endpoint
+
+		switch monitor.Type {
+		case "group":
+			// folders only group monitors
+			continue
+		case "http", "keyword", "json-query":
+			if monitor.Type != "http" {
+				res.warn(item, "%s assertions are not supported, only status codes are checked", monitor.Type)
+			}
+
+			var headers map[string]string
+			if monitor.Headers != nil && strings.TrimSpace(*monitor.Headers) != "" {
+				if err := json.Unmarshal([]byte(*monitor.Headers), &headers); err != nil {
+					res.warn(item, "headers are not a JSON object and are skipped")
+				}
+			}
+
+			var body string
+			if monitor.Body != nil {
+				body = *monitor.Body
+			}
+
+			endpoint = httpEndpoint(monitor.Name, monitor.URL, strings.ToUpper(monitor.Method), headers, body, monitor.AcceptedStatusCodes)
+		case "push":
+			res.warn(item, "push token is not kept, update the ping URL of the job")
+			endpoint = apiModels.Endpoint{
+				ServiceName: monitor.Name,
+				Type:        "heartbeat",
+				Heartbeat:   &apiModels.Heartbeat{},
+			}
+		default:
+			res.warn(item, "monitor type %q is not supported and is skipped", monitor.Type)
+			continue
+		}
+
+		endpoint.Interval = res.interval(item, time.Duration(monitor.Interval)*time.Second, kumaInterval)
+
+		for _, tag := range monitor.Tags {
+			if endpoint.Labels == nil {
+				endpoint.Labels = make(map[string]string, len(monitor.Tags))
+			}
+			endpoint.Labels[tag.Name] = tag.Value
+		}
+
+		for id, enabled := range monitor.NotificationIDList {
+			if name, ok := notifications[id]; ok && enabled {
+				endpoint.NotificationServices = append(endpoint.NotificationServices, name)
+			}
+		}
+		slices.Sort(endpoint.NotificationServices)
+
+		res.Endpoints = append(res.Endpoints, endpoint)
+	}
+
+	return res, nil
+}
+
+func kumaActive(active any) bool {
+	switch v := active.(type) {
+	case bool:
+		return v
+	case float64:
+		return v != 0
+	}
+	// missing means active
+	return true
+}
@@ -0,0 +1,82 @@
+package incidents
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/pkg/errors"
+	"github.com/vishenosik/CherryWatch/internal/api/models"
+	serviceModels "github.com/vishenosik/CherryWatch/internal/services/models"
+	attrs "github.com/vishenosik/web-tools/log"
+)
+
+// listIncidents returns incidents, newest first.
+//
+// Query parameters: endpoint_id, active (true to skip resolved incidents).
+func (srv server) listIncidents() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		query := r.URL.Query()
+
+		filter := serviceModels.IncidentsFilter{
+			EndpointID: query.Get("endpoint_id"),
+		}
+
+		if active := query.Get("active"); active != "" {
+			var err error
+			if filter.ActiveOnly, err = strconv.ParseBool(active); err != nil {
+				http.Error(w, "active must be a boolean", http.StatusBadRequest)
+				return
+			}
+		}
+
+		incidents, err := srv.service.Incidents(r.Context(), filter)
+		if err != nil {
+			srv.writeError(w, err)
+			return
+		}
+
+		writeJSON(w, http.StatusOK, models.FromServiceIncidents(incidents))
+	}
+}
+
+// acknowledgeIncident marks the active incident as taken by the caller.
+func (srv server) acknowledgeIncident() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		incident, err := srv.service.Acknowledge(r.Context(), chi.URLParam(r, "id"))
+		if err != nil {
+			srv.writeError(w, err)
+			return
+		}
+
+		writeJSON(w, http.StatusOK, models.FromServiceIncident(incident))
+	}
+}
+
+func (srv server) writeError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, serviceModels.ErrNotFound):
+		http.Error(w, "incident not found", http.StatusNotFound)
+	case errors.Is(err, serviceModels.ErrIncidentResolved),
+		errors.Is(err, serviceModels.ErrIncidentActive):
+		http.Error(w, err.Error(), http.StatusConflict)
+	case errors.Is(err, serviceModels.ErrInvalidIncidentUpdate):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	default:
+		srv.log.Error("incidents request failed", attrs.Error(err))
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, response any) {
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		http.Error(w, "failed to encode response", http.StatusInternalServerError)
+	}
+}
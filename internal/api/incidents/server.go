@@ -0,0 +1,52 @@
+package incidents
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/vishenosik/CherryWatch/internal/services/models"
+	"github.com/vishenosik/web-tools/api"
+)
+
+type Incidents interface {
+	Incidents(ctx context.Context, filter models.IncidentsFilter) (models.Incidents, error)
+	Acknowledge(ctx context.Context, id string) (*models.Incident, error)
+	PostUpdate(ctx context.Context, incidentID string, update *models.IncidentUpdate) (*models.IncidentUpdate, error)
+	IncidentUpdates(ctx context.Context, incidentIDs []string) ([]*models.IncidentUpdate, error)
+	SavePostmortem(ctx context.Context, incidentID, body string) (*models.Postmortem, error)
+	Postmortems(ctx context.Context, incidentID string) ([]*models.Postmortem, error)
+	Timeline(ctx context.Context, incidentID string) (*models.Timeline, error)
+}
+
+type incidentsAPI struct {
+	log     *slog.Logger
+	service Incidents
+}
+
+type server = *incidentsAPI
+
+func NewIncidentsServer(
+	log *slog.Logger,
+	service Incidents,
+) *incidentsAPI {
+
+	return &incidentsAPI{
+		log:     log,
+		service: service,
+	}
+
+}
+
+func (srv server) Routers(router chi.Router) {
+	router.Route(api.ApiV1("/incidents"), func(r chi.Router) {
+		r.Get("/", srv.listIncidents())
+		r.Post("/{id}/ack", srv.acknowledgeIncident())
+		r.Get("/{id}/updates", srv.listUpdates())
+		r.Post("/{id}/updates", srv.postUpdate())
+		r.Get("/{id}/postmortem", srv.getPostmortem())
+		r.Put("/{id}/postmortem", srv.savePostmortem())
+		r.Get("/{id}/postmortem/versions", srv.listPostmortems())
+		r.Get("/{id}/timeline", srv.getTimeline())
+	})
+}
@@ -0,0 +1,129 @@
+package incidents
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/vishenosik/CherryWatch/internal/api/models"
+	"github.com/vishenosik/CherryWatch/pkg/httpjson"
+)
+
+// listUpdates returns operator updates of the incident, oldest first.
+func (srv server) listUpdates() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		// an unknown incident is reported instead of an empty list
+		timeline, err := srv.service.Timeline(r.Context(), chi.URLParam(r, "id"))
+		if err != nil {
+			srv.writeError(w, err)
+			return
+		}
+
+		updates, err := srv.service.IncidentUpdates(r.Context(), []string{timeline.Incident.ID})
+		if err != nil {
+			srv.writeError(w, err)
+			return
+		}
+
+		writeJSON(w, http.StatusOK, models.FromServiceIncidentUpdates(updates))
+	}
+}
+
+// postUpdate posts an update onto the incident, it is shown on status pages and sent to subscribers.
+func (srv server) postUpdate() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		request, err := httpjson.Decode[models.IncidentUpdate](r)
+		if err != nil {
+			http.Error(w, "failed to decode request body", http.StatusBadRequest)
+			return
+		}
+
+		update, err := srv.service.PostUpdate(r.Context(), chi.URLParam(r, "id"), models.ToServiceIncidentUpdate(request))
+		if err != nil {
+			srv.writeError(w, err)
+			return
+		}
+
+		writeJSON(w, http.StatusCreated, models.FromServiceIncidentUpdate(update))
+	}
+}
+
+// getPostmortem returns the latest postmortem version, or the one of the version query parameter.
+func (srv server) getPostmortem() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		var version int
+		if value := r.URL.Query().Get("version"); value != "" {
+			var err error
+			if version, err = strconv.Atoi(value); err != nil || version < 1 {
+				http.Error(w, "version must be a positive number", http.StatusBadRequest)
+				return
+			}
+		}
+
+		postmortems, err := srv.service.Postmortems(r.Context(), chi.URLParam(r, "id"))
+		if err != nil {
+			srv.writeError(w, err)
+			return
+		}
+
+		for _, postmortem := range postmortems {
+			if version == 0 || postmortem.Version == version {
+				writeJSON(w, http.StatusOK, models.FromServicePostmortem(postmortem))
+				return
+			}
+		}
+
+		http.Error(w, "postmortem not found", http.StatusNotFound)
+	}
+}
+
+// savePostmortem stores the postmortem as a new version, previous versions are kept.
+func (srv server) savePostmortem() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		request, err := httpjson.Decode[models.Postmortem](r)
+		if err != nil {
+			http.Error(w, "failed to decode request body", http.StatusBadRequest)
+			return
+		}
+
+		postmortem, err := srv.service.SavePostmortem(r.Context(), chi.URLParam(r, "id"), request.Body)
+		if err != nil {
+			srv.writeError(w, err)
+			return
+		}
+
+		writeJSON(w, http.StatusCreated, models.FromServicePostmortem(postmortem))
+	}
+}
+
+// listPostmortems returns every postmortem version, newest first.
+func (srv server) listPostmortems() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		postmortems, err := srv.service.Postmortems(r.Context(), chi.URLParam(r, "id"))
+		if err != nil {
+			srv.writeError(w, err)
+			return
+		}
+
+		writeJSON(w, http.StatusOK, models.FromServicePostmortems(postmortems))
+	}
+}
+
+// getTimeline returns the incident history: transitions, updates and postmortem versions.
+func (srv server) getTimeline() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		timeline, err := srv.service.Timeline(r.Context(), chi.URLParam(r, "id"))
+		if err != nil {
+			srv.writeError(w, err)
+			return
+		}
+
+		writeJSON(w, http.StatusOK, models.FromServiceTimeline(timeline))
+	}
+}
@@ -0,0 +1,167 @@
+package incidents
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vishenosik/CherryWatch/internal/api/models"
+	serviceModels "github.com/vishenosik/CherryWatch/internal/services/models"
+)
+
+// unimplemented lets the mock embed the interface, its field name would clash with the Incidents method.
+type unimplemented = Incidents
+
+type incidentsMock struct {
+	unimplemented
+	postmortems []*serviceModels.Postmortem
+}
+
+func (im *incidentsMock) PostUpdate(_ context.Context, incidentID string, update *serviceModels.IncidentUpdate) (*serviceModels.IncidentUpdate, error) {
+	if incidentID != "1" {
+		return nil, serviceModels.ErrNotFound
+	}
+	if err := update.Validate(); err != nil {
+		return nil, err
+	}
+	update.ID = "u1"
+	update.IncidentID = incidentID
+	return update, nil
+}
+
+func (im *incidentsMock) SavePostmortem(_ context.Context, incidentID, body string) (*serviceModels.Postmortem, error) {
+	if incidentID == "active" {
+		return nil, errors.Wrap(serviceModels.ErrIncidentActive, "incidents.SavePostmortem")
+	}
+	return &serviceModels.Postmortem{IncidentID: incidentID, Version: len(im.postmortems) + 1, Body: body}, nil
+}
+
+func (im *incidentsMock) Postmortems(context.Context, string) ([]*serviceModels.Postmortem, error) {
+	return im.postmortems, nil
+}
+
+func Test_updates(t *testing.T) {
+
+	service := &incidentsMock{postmortems: []*serviceModels.Postmortem{
+		{IncidentID: "1", Version: 2, Body: "second"},
+		{IncidentID: "1", Version: 1, Body: "first"},
+	}}
+
+	router := chi.NewRouter()
+	NewIncidentsServer(slog.New(slog.NewTextHandler(io.Discard, nil)), service).Routers(router)
+
+	tests := []struct {
+		name   string
+		method string
+		target string
+		body   string
+		status int
+		want   string
+	}{
+		{
+			name:   "post update",
+			method: http.MethodPost,
+			target: "/api/v1/incidents/1/updates",
+			body:   `{"state":"identified","body":"The database is overloaded"}`,
+			status: http.StatusCreated,
+			want:   `"id":"u1"`,
+		},
+		{
+			name:   "unknown state",
+			method: http.MethodPost,
+			target: "/api/v1/incidents/1/updates",
+			body:   `{"state":"fixed","body":"done"}`,
+			status: http.StatusBadRequest,
+		},
+		{
+			name:   "unknown incident",
+			method: http.MethodPost,
+			target: "/api/v1/incidents/2/updates",
+			body:   `{"state":"identified","body":"text"}`,
+			status: http.StatusNotFound,
+		},
+		{
+			name:   "postmortem of active incident",
+			method: http.MethodPut,
+			target: "/api/v1/incidents/active/postmortem",
+			body:   `{"body":"text"}`,
+			status: http.StatusConflict,
+		},
+		{
+			name:   "save postmortem",
+			method: http.MethodPut,
+			target: "/api/v1/incidents/1/postmortem",
+			body:   `{"body":"third"}`,
+			status: http.StatusCreated,
+			want:   `"version":3`,
+		},
+		{
+			name:   "latest postmortem",
+			method: http.MethodGet,
+			target: "/api/v1/incidents/1/postmortem",
+			status: http.StatusOK,
+			want:   `"body":"second"`,
+		},
+		{
+			name:   "postmortem version",
+			method: http.MethodGet,
+			target: "/api/v1/incidents/1/postmortem?version=1",
+			status: http.StatusOK,
+			want:   `"body":"first"`,
+		},
+		{
+			name:   "missing postmortem version",
+			method: http.MethodGet,
+			target: "/api/v1/incidents/1/postmortem?version=5",
+			status: http.StatusNotFound,
+		},
+		{
+			name:   "invalid postmortem version",
+			method: http.MethodGet,
+			target: "/api/v1/incidents/1/postmortem?version=first",
+			status: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, httptest.NewRequest(tt.method, tt.target, strings.NewReader(tt.body)))
+
+			require.Equal(t, tt.status, rec.Code, rec.Body.String())
+			if tt.want != "" {
+				assert.Contains(t, rec.Body.String(), tt.want)
+			}
+		})
+	}
+}
+
+func Test_listPostmortems(t *testing.T) {
+
+	service := &incidentsMock{postmortems: []*serviceModels.Postmortem{
+		{IncidentID: "1", Version: 2},
+		{IncidentID: "1", Version: 1},
+	}}
+
+	router := chi.NewRouter()
+	NewIncidentsServer(slog.New(slog.NewTextHandler(io.Discard, nil)), service).Routers(router)
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/incidents/1/postmortem/versions", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var postmortems models.Postmortems
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&postmortems))
+	require.Len(t, postmortems, 2)
+	assert.Equal(t, 2, postmortems[0].Version)
+}
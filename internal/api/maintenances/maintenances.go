@@ -0,0 +1,96 @@
+package maintenances
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/pkg/errors"
+	"github.com/vishenosik/CherryWatch/internal/api/models"
+	serviceModels "github.com/vishenosik/CherryWatch/internal/services/models"
+	"github.com/vishenosik/CherryWatch/pkg/httpjson"
+	attrs "github.com/vishenosik/web-tools/log"
+)
+
+// listMaintenances returns maintenances ordered by start,
+// finished ones are skipped unless the all query parameter is true.
+func (srv server) listMaintenances() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		filter := serviceModels.MaintenancesFilter{EndsAfter: time.Now()}
+
+		if all := r.URL.Query().Get("all"); all != "" {
+			includeFinished, err := strconv.ParseBool(all)
+			if err != nil {
+				http.Error(w, "all must be a boolean", http.StatusBadRequest)
+				return
+			}
+			if includeFinished {
+				filter.EndsAfter = time.Time{}
+			}
+		}
+
+		maintenances, err := srv.service.Maintenances(r.Context(), filter)
+		if err != nil {
+			srv.writeError(w, err)
+			return
+		}
+
+		writeJSON(w, http.StatusOK, models.FromServiceMaintenances(maintenances))
+	}
+}
+
+func (srv server) createMaintenance() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		request, err := httpjson.Decode[models.Maintenance](r)
+		if err != nil {
+			http.Error(w, "failed to decode request body", http.StatusBadRequest)
+			return
+		}
+
+		mnt, err := srv.service.CreateMaintenance(r.Context(), models.ToServiceMaintenance(request))
+		if err != nil {
+			srv.writeError(w, err)
+			return
+		}
+
+		writeJSON(w, http.StatusCreated, models.FromServiceMaintenance(mnt))
+	}
+}
+
+func (srv server) deleteMaintenance() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		if err := srv.service.DeleteMaintenance(r.Context(), chi.URLParam(r, "id")); err != nil {
+			srv.writeError(w, err)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func (srv server) writeError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, serviceModels.ErrNotFound):
+		http.Error(w, "maintenance not found", http.StatusNotFound)
+	case errors.Is(err, serviceModels.ErrInvalidMaintenance):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	default:
+		srv.log.Error("maintenances request failed", attrs.Error(err))
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, response any) {
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		http.Error(w, "failed to encode response", http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,43 @@
+package maintenances
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/vishenosik/CherryWatch/internal/services/models"
+	"github.com/vishenosik/web-tools/api"
+)
+
+type Maintenances interface {
+	CreateMaintenance(ctx context.Context, mnt *models.Maintenance) (*models.Maintenance, error)
+	Maintenances(ctx context.Context, filter models.MaintenancesFilter) (models.Maintenances, error)
+	DeleteMaintenance(ctx context.Context, id string) error
+}
+
+type maintenancesAPI struct {
+	log     *slog.Logger
+	service Maintenances
+}
+
+type server = *maintenancesAPI
+
+func NewMaintenancesServer(
+	log *slog.Logger,
+	service Maintenances,
+) *maintenancesAPI {
+
+	return &maintenancesAPI{
+		log:     log,
+		service: service,
+	}
+
+}
+
+func (srv server) Routers(router chi.Router) {
+	router.Route(api.ApiV1("/maintenances"), func(r chi.Router) {
+		r.Get("/", srv.listMaintenances())
+		r.Post("/", srv.createMaintenance())
+		r.Delete("/{id}", srv.deleteMaintenance())
+	})
+}
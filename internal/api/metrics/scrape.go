@@ -0,0 +1,198 @@
+package metrics
+
+import (
+	"log/slog"
+	"net/http"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/vishenosik/CherryWatch/internal/services/models"
+	attrs "github.com/vishenosik/web-tools/log"
+)
+
+const (
+	namespace = "cherrywatch"
+	// prefix of endpoint labels turned into metric labels
+	labelPrefix = "label_"
+)
+
+// invalidLabelChars are replaced in endpoint label keys to make metric label names.
+var invalidLabelChars = regexp.MustCompile(`[^a-zA-Z0-9_]`)
+
+// endpointLabels are set on every per-endpoint metric before the endpoint labels.
+var endpointLabels = []string{"endpoint_id", "service", "workspace", "type"}
+
+func (srv server) scrape() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		metrics, err := srv.service.Metrics(r.Context())
+		if err != nil {
+			srv.log.Error("failed to collect metrics", attrs.Error(err))
+			http.Error(w, "Internal server error", http.StatusInternalServerError)
+			return
+		}
+
+		registry := prometheus.NewRegistry()
+		if err := registry.Register(newCollector(metrics, time.Now())); err != nil {
+			srv.log.Error("failed to register metrics", attrs.Error(err))
+			http.Error(w, "Internal server error", http.StatusInternalServerError)
+			return
+		}
+
+		promhttp.HandlerFor(
+			prometheus.Gatherers{srv.runtime, registry},
+			promhttp.HandlerOpts{ErrorLog: slog.NewLogLogger(srv.log.Handler(), slog.LevelError)},
+		).ServeHTTP(w, r)
+	}
+}
+
+// collector exposes a metrics snapshot.
+//
+// Endpoint labels become metric labels prefixed with "label_", every endpoint
+// metric carries the union of the label names, empty for endpoints missing a label.
+type collector struct {
+	metrics *models.Metrics
+	now     time.Time
+
+	labelKeys []string
+	// metric label index of every endpoint label key
+	slots      map[string]int
+	labelCount int
+	up         *prometheus.Desc
+	latency    *prometheus.Desc
+	certExpiry *prometheus.Desc
+	duration   *prometheus.Desc
+	checks     *prometheus.Desc
+	failures   *prometheus.Desc
+	opened     *prometheus.Desc
+	open       *prometheus.Desc
+	queueLag   *prometheus.Desc
+}
+
+func newCollector(metrics *models.Metrics, now time.Time) *collector {
+
+	keys := make(map[string]struct{})
+	for _, endpoint := range metrics.Endpoints {
+		for key := range endpoint.Endpoint.Labels {
+			keys[key] = struct{}{}
+		}
+	}
+
+	labelKeys := make([]string, 0, len(keys))
+	for key := range keys {
+		labelKeys = append(labelKeys, key)
+	}
+	slices.Sort(labelKeys)
+
+	labelNames := slices.Clone(endpointLabels)
+	slots := make(map[string]int, len(labelKeys))
+	for _, key := range labelKeys {
+		name := labelName(key)
+		// keys sanitized into the same name share the label
+		if i := slices.Index(labelNames, name); i >= 0 {
+			slots[key] = i
+			continue
+		}
+		slots[key] = len(labelNames)
+		labelNames = append(labelNames, name)
+	}
+
+	desc := func(name, help string) *prometheus.Desc {
+		return prometheus.NewDesc(prometheus.BuildFQName(namespace, "", name), help, labelNames, nil)
+	}
+
+	return &collector{
+		metrics:    metrics,
+		now:        now,
+		labelKeys:  labelKeys,
+		slots:      slots,
+		labelCount: len(labelNames),
+		up:         desc("endpoint_up", "Whether the last check of the endpoint succeeded."),
+		latency:    desc("endpoint_latency_seconds", "Duration of the last check of the endpoint."),
+		certExpiry: desc("endpoint_cert_expiry_seconds", "Seconds until the endpoint certificate expires (https checks only)."),
+		duration:   desc("check_duration_seconds", "Duration of endpoint checks."),
+		checks:     desc("checks_total", "Number of endpoint checks since the server start."),
+		failures:   desc("check_failures_total", "Number of failed endpoint checks since the server start."),
+		opened:     desc("incidents_opened_total", "Number of incidents opened since the server start."),
+		open:       desc("incidents_open", "Number of unresolved incidents of the endpoint."),
+		queueLag: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "scheduler", "queue_lag_seconds"),
+			"Delay between a check being due and taken by a scheduler worker.",
+			nil, nil,
+		),
+	}
+}
+
+// Describe sends nothing: label names depend on endpoints, so the collector is unchecked.
+func (c *collector) Describe(chan<- *prometheus.Desc) {}
+
+func (c *collector) Collect(ch chan<- prometheus.Metric) {
+
+	for _, endpoint := range c.metrics.Endpoints {
+
+		values := c.labelValues(endpoint.Endpoint)
+		stats := endpoint.Stats
+
+		ch <- prometheus.MustNewConstMetric(c.open, prometheus.GaugeValue, float64(endpoint.OpenIncidents), values...)
+		ch <- prometheus.MustNewConstMetric(c.opened, prometheus.CounterValue, float64(stats.IncidentsOpened), values...)
+
+		if stats.CheckedAt.IsZero() {
+			continue
+		}
+
+		ch <- prometheus.MustNewConstMetric(c.up, prometheus.GaugeValue, boolValue(stats.Up), values...)
+		ch <- prometheus.MustNewConstMetric(c.latency, prometheus.GaugeValue, stats.Latency.Seconds(), values...)
+		ch <- prometheus.MustNewConstMetric(c.checks, prometheus.CounterValue, float64(stats.Checks), values...)
+		ch <- prometheus.MustNewConstMetric(c.failures, prometheus.CounterValue, float64(stats.Failures), values...)
+		ch <- constHistogram(c.duration, stats.Durations, values...)
+
+		if !stats.CertExpiresAt.IsZero() {
+			ch <- prometheus.MustNewConstMetric(c.certExpiry, prometheus.GaugeValue, stats.CertExpiresAt.Sub(c.now).Seconds(), values...)
+		}
+	}
+
+	ch <- constHistogram(c.queueLag, c.metrics.QueueLag)
+}
+
+func (c *collector) labelValues(endpoint *models.Endpoint) []string {
+
+	values := make([]string, c.labelCount)
+	copy(values, []string{
+		endpoint.ID,
+		endpoint.ServiceName,
+		endpoint.WorkspaceID,
+		string(endpoint.CheckType()),
+	})
+
+	for _, key := range c.labelKeys {
+		if value := endpoint.Labels[key]; value != "" && values[c.slots[key]] == "" {
+			values[c.slots[key]] = value
+		}
+	}
+
+	return values
+}
+
+func constHistogram(desc *prometheus.Desc, histogram models.Histogram, labelValues ...string) prometheus.Metric {
+	buckets := make(map[float64]uint64, len(histogram.Bounds))
+	for i, bound := range histogram.Bounds {
+		buckets[bound] = histogram.Counts[i]
+	}
+	return prometheus.MustNewConstHistogram(desc, histogram.Count, histogram.Sum, buckets, labelValues...)
+}
+
+// labelName makes a valid metric label name of an endpoint label key.
+func labelName(key string) string {
+	return labelPrefix + strings.ToLower(invalidLabelChars.ReplaceAllString(key, "_"))
+}
+
+func boolValue(value bool) float64 {
+	if value {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,83 @@
+package metrics
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vishenosik/CherryWatch/internal/services/models"
+)
+
+type metricsMock struct {
+	metrics *models.Metrics
+}
+
+func (mm *metricsMock) Metrics(context.Context) (*models.Metrics, error) {
+	return mm.metrics, nil
+}
+
+func Test_Scrape(t *testing.T) {
+
+	durations := models.NewHistogram(0.1, 1)
+	durations.Observe(0.05)
+
+	service := &metricsMock{metrics: &models.Metrics{
+		Endpoints: []models.EndpointMetrics{
+			{
+				Endpoint: &models.Endpoint{
+					ID:          "1",
+					ServiceName: "api",
+					WorkspaceID: "default",
+					Labels:      map[string]string{"team": "core", "app.kubernetes.io/name": "api"},
+				},
+				Stats: models.EndpointStats{
+					Up:            true,
+					Latency:       50 * time.Millisecond,
+					CertExpiresAt: time.Now().Add(time.Hour),
+					CheckedAt:     time.Now(),
+					Checks:        1,
+					Durations:     durations,
+				},
+			},
+			{
+				// never checked yet
+				Endpoint:      &models.Endpoint{ID: "2", ServiceName: "web", WorkspaceID: "default"},
+				OpenIncidents: 1,
+			},
+		},
+		QueueLag: models.NewHistogram(1),
+	}}
+
+	router := chi.NewRouter()
+	NewMetricsServer(slog.New(slog.NewTextHandler(io.Discard, nil)), service).Routers(router)
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	body := rec.Body.String()
+
+	labels := `endpoint_id="1",label_app_kubernetes_io_name="api",label_team="core",service="api",type="http",workspace="default"`
+
+	for _, want := range []string{
+		`cherrywatch_endpoint_up{` + labels + `} 1`,
+		`cherrywatch_endpoint_latency_seconds{` + labels + `} 0.05`,
+		`cherrywatch_checks_total{` + labels + `} 1`,
+		`cherrywatch_check_duration_seconds_bucket{` + labels + `,le="0.1"} 1`,
+		`cherrywatch_endpoint_cert_expiry_seconds{` + labels + `} 3`,
+		`cherrywatch_incidents_open{endpoint_id="2",label_app_kubernetes_io_name="",label_team="",service="web",type="http",workspace="default"} 1`,
+		`cherrywatch_scheduler_queue_lag_seconds_count 0`,
+		`go_goroutines`,
+	} {
+		assert.Contains(t, body, want)
+	}
+
+	assert.NotContains(t, body, `cherrywatch_endpoint_up{endpoint_id="2"`)
+}
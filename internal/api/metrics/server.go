@@ -0,0 +1,49 @@
+package metrics
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/vishenosik/CherryWatch/internal/services/models"
+)
+
+type Metrics interface {
+	Metrics(ctx context.Context) (*models.Metrics, error)
+}
+
+type metricsAPI struct {
+	log     *slog.Logger
+	service Metrics
+	// process & Go runtime collectors, shared by all scrapes
+	runtime *prometheus.Registry
+}
+
+type server = *metricsAPI
+
+func NewMetricsServer(
+	log *slog.Logger,
+	service Metrics,
+) *metricsAPI {
+
+	runtime := prometheus.NewRegistry()
+	runtime.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+
+	return &metricsAPI{
+		log:     log,
+		service: service,
+		runtime: runtime,
+	}
+
+}
+
+// Routers registers Prometheus scrape endpoint.
+// Scrapes are authenticated like any API call and see endpoints of the caller workspace.
+func (srv server) Routers(router chi.Router) {
+	router.Get("/metrics", srv.scrape())
+}
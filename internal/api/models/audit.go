@@ -0,0 +1,100 @@
+package models
+
+import (
+	"encoding/json"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/vishenosik/CherryWatch/internal/services/models"
+	devCol "github.com/vishenosik/CherryWatch/pkg/collections"
+)
+
+const (
+	defaultAuditLimit = 100
+	maxAuditLimit     = 1000
+)
+
+type AuditEntry struct {
+	ID          string `json:"id"`
+	WorkspaceID string `json:"workspace_id"`
+	// Actor user, empty for API keys
+	UserID string `json:"user_id,omitempty"`
+	// Actor app, empty for changes made by CherryWatch itself
+	AppID   string `json:"app_id,omitempty"`
+	AppName string `json:"app_name,omitempty"`
+	// One of: create, update, delete
+	Action string `json:"action"`
+	// One of: endpoint, user, workspace
+	Resource   string `json:"resource"`
+	ResourceID string `json:"resource_id"`
+	// Resource states with secrets redacted
+	Before json.RawMessage      `json:"before,omitempty"`
+	After  json.RawMessage      `json:"after,omitempty"`
+	Diff   []models.FieldChange `json:"diff"`
+	Time   time.Time            `json:"time"`
+}
+
+type AuditEntries = []AuditEntry
+
+func FromServiceAuditEntries(entries models.AuditEntries) AuditEntries {
+	return devCol.ConvertSlice(entries, FromServiceAuditEntry)
+}
+
+func FromServiceAuditEntry(entry *models.AuditEntry) AuditEntry {
+	diff := entry.Diff
+	if diff == nil {
+		diff = []models.FieldChange{}
+	}
+	return AuditEntry{
+		ID:          entry.ID,
+		WorkspaceID: entry.WorkspaceID,
+		UserID:      entry.UserID,
+		AppID:       entry.AppID,
+		AppName:     entry.AppName,
+		Action:      string(entry.Action),
+		Resource:    string(entry.Resource),
+		ResourceID:  entry.ResourceID,
+		Before:      entry.Before,
+		After:       entry.After,
+		Diff:        diff,
+		Time:        entry.Time,
+	}
+}
+
+// ToServiceAuditFilter parses audit log query parameters:
+// resource, resource_id, action, actor, since & until (RFC 3339) and limit.
+func ToServiceAuditFilter(query url.Values) (models.AuditFilter, error) {
+
+	filter := models.AuditFilter{
+		Resource:   models.AuditResource(query.Get("resource")),
+		ResourceID: query.Get("resource_id"),
+		Action:     models.AuditAction(query.Get("action")),
+		Actor:      query.Get("actor"),
+		Limit:      defaultAuditLimit,
+	}
+
+	var err error
+
+	if since := query.Get("since"); since != "" {
+		if filter.Since, err = time.Parse(time.RFC3339, since); err != nil {
+			return models.AuditFilter{}, errors.Wrap(err, "since")
+		}
+	}
+
+	if until := query.Get("until"); until != "" {
+		if filter.Until, err = time.Parse(time.RFC3339, until); err != nil {
+			return models.AuditFilter{}, errors.Wrap(err, "until")
+		}
+	}
+
+	if limit := query.Get("limit"); limit != "" {
+		if filter.Limit, err = strconv.Atoi(limit); err != nil || filter.Limit <= 0 {
+			return models.AuditFilter{}, errors.Errorf("limit: must be a positive number")
+		}
+	}
+	filter.Limit = min(filter.Limit, maxAuditLimit)
+
+	return filter, nil
+}
@@ -0,0 +1,33 @@
+package models
+
+import (
+	"time"
+
+	"github.com/vishenosik/CherryWatch/internal/services/models"
+)
+
+// TokenRequest carries either an API key or user email & password.
+type TokenRequest struct {
+	// API key to exchange
+	APIKey string `json:"api_key,omitempty"`
+	// User email
+	Email string `json:"email,omitempty"`
+	// User password
+	Password string `json:"password,omitempty"`
+}
+
+type Token struct {
+	AccessToken string `json:"access_token"`
+	// Always "Bearer"
+	TokenType string `json:"token_type"`
+	// Token lifetime in seconds
+	ExpiresIn int64 `json:"expires_in"`
+}
+
+func FromServiceToken(token *models.Token) Token {
+	return Token{
+		AccessToken: token.AccessToken,
+		TokenType:   "Bearer",
+		ExpiresIn:   int64(time.Until(token.ExpiresAt).Seconds()),
+	}
+}
@@ -0,0 +1,45 @@
+package models
+
+import (
+	"time"
+
+	"github.com/vishenosik/CherryWatch/internal/services/models"
+	devCol "github.com/vishenosik/CherryWatch/pkg/collections"
+)
+
+type Channel struct {
+	// Channel identifier (generated by the server)
+	ID string `json:"id"`
+	// Name endpoints list in notification_services to be alerted through the channel
+	Name string `json:"name"`
+	// One of: email, webhook
+	Type string `json:"type"`
+	// Email address or webhook URL
+	Target    string    `json:"target"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type Channels = []Channel
+
+func ToServiceChannel(ch Channel) *models.Channel {
+	return &models.Channel{
+		ID:     ch.ID,
+		Name:   ch.Name,
+		Kind:   models.ChannelKind(ch.Type),
+		Target: ch.Target,
+	}
+}
+
+func FromServiceChannels(channels models.Channels) Channels {
+	return devCol.ConvertSlice(channels, FromServiceChannel)
+}
+
+func FromServiceChannel(ch *models.Channel) Channel {
+	return Channel{
+		ID:        ch.ID,
+		Name:      ch.Name,
+		Type:      string(ch.Kind),
+		Target:    ch.Target,
+		CreatedAt: ch.CreatedAt,
+	}
+}
@@ -0,0 +1,237 @@
+package models
+
+import (
+	"bytes"
+	"encoding/json"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/vishenosik/CherryWatch/internal/services/models"
+	"gopkg.in/yaml.v3"
+)
+
+// Document formats of endpoint definitions.
+const (
+	FormatJSON = "json"
+	FormatYAML = "yaml"
+	FormatCSV  = "csv"
+)
+
+var (
+	// document format isn't json, yaml or csv
+	ErrFormat = errors.New("unsupported format")
+)
+
+// durationFields are accepted both as nanoseconds and as strings like "1m30s" in documents.
+var durationFields = map[string]struct{}{
+	"time_interval": {},
+	"grace":         {},
+	"timeout":       {},
+}
+
+// EndpointsFile is a declarative definition of endpoints.
+type EndpointsFile struct {
+	// Workspace endpoints belong to (default if empty)
+	Workspace string    `json:"workspace,omitempty"`
+	Endpoints Endpoints `json:"endpoints"`
+}
+
+// DecodeEndpointsFile decodes a YAML or JSON endpoints file.
+// Unknown fields and invalid success code ranges are rejected.
+func DecodeEndpointsFile(data []byte, format string) (*EndpointsFile, error) {
+
+	var file EndpointsFile
+	if err := decodeDocument(data, format, &file); err != nil {
+		return nil, err
+	}
+
+	if err := validateRanges(file.Endpoints); err != nil {
+		return nil, err
+	}
+
+	return &file, nil
+}
+
+// DecodeEndpoints decodes a JSON, YAML or CSV list of endpoints.
+// Unknown fields and invalid success code ranges are rejected.
+func DecodeEndpoints(data []byte, format string) (Endpoints, error) {
+
+	var (
+		endpoints Endpoints
+		err       error
+	)
+
+	if format == FormatCSV {
+		endpoints, err = decodeEndpointsCSV(data)
+	} else {
+		err = decodeDocument(data, format, &endpoints)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validateRanges(endpoints); err != nil {
+		return nil, err
+	}
+
+	return endpoints, nil
+}
+
+// EncodeEndpoints encodes endpoints as a JSON, YAML or CSV list.
+// YAML & CSV durations are written as strings like "1m30s".
+func EncodeEndpoints(endpoints Endpoints, format string) ([]byte, error) {
+	if format == FormatCSV {
+		return encodeEndpointsCSV(endpoints)
+	}
+	return EncodeDocument(endpoints, format)
+}
+
+// EncodeDocument encodes any API model as indented JSON or YAML.
+// YAML durations are written as strings like "1m30s".
+func EncodeDocument(value any, format string) ([]byte, error) {
+	switch format {
+	case FormatJSON:
+		return json.MarshalIndent(value, "", "  ")
+	case FormatYAML:
+		tree, err := jsonTree(value)
+		if err != nil {
+			return nil, err
+		}
+		return yaml.Marshal(formatDurations(tree))
+	}
+	return nil, errors.Wrap(ErrFormat, format)
+}
+
+func validateRanges(endpoints Endpoints) error {
+	for i, endpoint := range endpoints {
+		if _, err := parseRanges(endpoint.SuccessCodes); err != nil {
+			return errors.Wrapf(err, "endpoints[%d] success_codes", i)
+		}
+	}
+	return nil
+}
+
+// jsonTree returns generic JSON representation of the value.
+func jsonTree(value any) (any, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	var tree any
+	if err := json.Unmarshal(data, &tree); err != nil {
+		return nil, err
+	}
+	return tree, nil
+}
+
+// formatDurations replaces nanoseconds with duration strings.
+func formatDurations(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, field := range v {
+			if ns, ok := field.(float64); ok {
+				if _, isDuration := durationFields[key]; isDuration {
+					v[key] = time.Duration(ns).String()
+					continue
+				}
+			}
+			v[key] = formatDurations(field)
+		}
+	case []any:
+		for i := range v {
+			v[i] = formatDurations(v[i])
+		}
+	}
+	return value
+}
+
+// decodeDocument decodes YAML or JSON into the JSON shape of the target.
+func decodeDocument(data []byte, format string, target any) error {
+
+	var tree any
+
+	switch format {
+	case FormatJSON:
+		if err := json.Unmarshal(data, &tree); err != nil {
+			return err
+		}
+	case FormatYAML:
+		if err := yaml.Unmarshal(data, &tree); err != nil {
+			return err
+		}
+	default:
+		return errors.Wrap(ErrFormat, format)
+	}
+
+	tree, err := parseDurations(tree)
+	if err != nil {
+		return err
+	}
+
+	normalized, err := json.Marshal(tree)
+	if err != nil {
+		return err
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(normalized))
+	decoder.DisallowUnknownFields()
+
+	return decoder.Decode(target)
+}
+
+// parseDurations replaces duration strings with nanoseconds.
+func parseDurations(value any) (any, error) {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, field := range v {
+			if s, ok := field.(string); ok {
+				if _, isDuration := durationFields[key]; isDuration {
+					duration, err := time.ParseDuration(s)
+					if err != nil {
+						return nil, errors.Wrap(err, key)
+					}
+					v[key] = int64(duration)
+					continue
+				}
+			}
+			parsed, err := parseDurations(field)
+			if err != nil {
+				return nil, err
+			}
+			v[key] = parsed
+		}
+	case []any:
+		for i := range v {
+			parsed, err := parseDurations(v[i])
+			if err != nil {
+				return nil, err
+			}
+			v[i] = parsed
+		}
+	}
+	return value, nil
+}
+
+type ImportResult struct {
+	Created Endpoints `json:"created"`
+	Updated Endpoints `json:"updated"`
+	// Existing endpoints left untouched
+	Skipped Endpoints `json:"skipped"`
+	// Parts of a foreign config that were not translated
+	Warnings []ImportWarning `json:"warnings,omitempty"`
+}
+
+// ImportWarning reports a part of a foreign config that was not translated, or translated lossy.
+type ImportWarning struct {
+	// Source monitor, target or job
+	Item   string `json:"item"`
+	Reason string `json:"reason"`
+}
+
+func FromServiceImportResult(result *models.ImportResult) ImportResult {
+	return ImportResult{
+		Created: FromServiceEndpoints(result.Created),
+		Updated: FromServiceEndpoints(result.Updated),
+		Skipped: FromServiceEndpoints(result.Skipped),
+	}
+}
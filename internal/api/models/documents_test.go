@@ -0,0 +1,102 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_DecodeEndpointsFile(t *testing.T) {
+
+	yamlFile := `
+workspace: team-a
+endpoints:
+  - service_name: api
+    url: https://api.example.com/health
+    success_codes: ["200-204", "301"]
+    time_interval: 1m30s
+    labels:
+      env: prod
+  - service_name: nightly-backup
+    type: heartbeat
+    time_interval: 24h
+    heartbeat:
+      grace: 15m
+`
+
+	file, err := DecodeEndpointsFile([]byte(yamlFile), FormatYAML)
+	require.NoError(t, err)
+	assert.Equal(t, "team-a", file.Workspace)
+	require.Len(t, file.Endpoints, 2)
+	assert.Equal(t, 90*time.Second, file.Endpoints[0].Interval)
+	assert.Equal(t, []string{"200-204", "301"}, file.Endpoints[0].SuccessCodes)
+	assert.Equal(t, 15*time.Minute, file.Endpoints[1].Heartbeat.Grace)
+
+	jsonFile := `{"endpoints": [{"service_name": "api", "url": "https://api.example.com", "time_interval": 60000000000}]}`
+
+	file, err = DecodeEndpointsFile([]byte(jsonFile), FormatJSON)
+	require.NoError(t, err)
+	assert.Equal(t, time.Minute, file.Endpoints[0].Interval)
+
+	testingTable := []struct {
+		name   string
+		data   string
+		format string
+	}{
+		{name: "unknown field", data: `{"endpoints": [{"service": "api"}]}`, format: FormatJSON},
+		{name: "bad duration", data: "endpoints:\n  - time_interval: soon\n", format: FormatYAML},
+		{name: "bad range", data: "endpoints:\n  - success_codes: [\"300-200\"]\n", format: FormatYAML},
+		{name: "unknown format", data: `{}`, format: "toml"},
+	}
+
+	for _, tt := range testingTable {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := DecodeEndpointsFile([]byte(tt.data), tt.format)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func Test_EncodeDecodeEndpoints(t *testing.T) {
+
+	endpoints := Endpoints{
+		{
+			ID:                   "1",
+			ServiceName:          "api",
+			URL:                  "https://api.example.com",
+			SuccessCodes:         []string{"200-204", "301"},
+			NotificationServices: []string{"slack", "email"},
+			Interval:             90 * time.Second,
+			Labels:               map[string]string{"env": "prod", "team": "core"},
+			Severity:             "warning",
+		},
+		{
+			ID:          "2",
+			ServiceName: "backup",
+			Type:        "heartbeat",
+			Interval:    24 * time.Hour,
+			Heartbeat:   &Heartbeat{Grace: 15 * time.Minute, Token: "abc"},
+		},
+	}
+
+	for _, format := range []string{FormatJSON, FormatYAML, FormatCSV} {
+		t.Run(format, func(t *testing.T) {
+
+			data, err := EncodeEndpoints(endpoints, format)
+			require.NoError(t, err)
+
+			if format != FormatJSON {
+				assert.Contains(t, string(data), "1m30s")
+			}
+
+			decoded, err := DecodeEndpoints(data, format)
+			require.NoError(t, err)
+			assert.Equal(t, endpoints, decoded)
+		})
+	}
+
+	_, err := DecodeEndpoints([]byte("id,name\n1,api\n"), FormatCSV)
+	assert.Error(t, err)
+}
@@ -7,6 +7,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/pkg/errors"
 	"github.com/vishenosik/CherryWatch/internal/services/models"
 	devCol "github.com/vishenosik/CherryWatch/pkg/collections"
 	"github.com/vishenosik/web-tools/collections"
@@ -26,18 +27,48 @@ type Endpoint struct {
 	NotificationServices []string `json:"notification_services,omitempty"`
 	// Time interval between checks
 	Interval time.Duration `json:"time_interval"`
+	// Arbitrary key-value labels used for grouping & filtering
+	Labels map[string]string `json:"labels,omitempty"`
+	// Failure importance: info, warning or critical (default)
+	Severity string `json:"severity,omitempty"`
+	// Check type: http (default), transaction, heartbeat, sql or exec
+	Type string `json:"type,omitempty"`
+	// Steps of a transaction check
+	Transaction *Transaction `json:"transaction,omitempty"`
+	// Heartbeat monitor settings (time_interval is the expected ping period)
+	Heartbeat *Heartbeat `json:"heartbeat,omitempty"`
+	// Database query check settings
+	SQL *SQLCheck `json:"sql,omitempty"`
+	// Local command check settings (must be enabled on the server)
+	Exec *ExecCheck `json:"exec,omitempty"`
+	// Agents checking the endpoint from their locations instead of the server
+	Probes *Probes `json:"probes,omitempty"`
 }
 
 type Endpoints = []Endpoint
 
-func ToServiceEndpoints(edps Endpoints) models.Endpoints {
-	return devCol.ConvertSlice(edps, ToServiceEndpoint)
+func ToServiceEndpoints(edps Endpoints) (models.Endpoints, error) {
+	endpoints := make(models.Endpoints, 0, len(edps))
+	for i, endpoint := range edps {
+		converted, err := ToServiceEndpoint(endpoint)
+		if err != nil {
+			return nil, errors.Wrapf(err, "endpoints[%d]", i)
+		}
+		endpoints = append(endpoints, converted)
+	}
+	return endpoints, nil
 }
 
-func ToServiceEndpoint(endpoint Endpoint) *models.Endpoint {
+// ToServiceEndpoint converts the endpoint failing with models.ErrInvalidEndpoint
+// on malformed success codes, so that they don't turn into the 2xx default.
+func ToServiceEndpoint(endpoint Endpoint) (*models.Endpoint, error) {
 	ranges, err := parseRanges(endpoint.SuccessCodes)
 	if err != nil {
-		// TODO: Probably want to warn about error
+		return nil, errors.Wrapf(models.ErrInvalidEndpoint, "success_codes: %s", err)
+	}
+	transaction, err := ToServiceTransaction(endpoint.Transaction)
+	if err != nil {
+		return nil, errors.Wrapf(models.ErrInvalidEndpoint, "transaction.%s", err)
 	}
 	return &models.Endpoint{
 		ID:                   endpoint.ID,
@@ -46,7 +77,15 @@ func ToServiceEndpoint(endpoint Endpoint) *models.Endpoint {
 		SuccessCodes:         ranges,
 		NotificationServices: endpoint.NotificationServices,
 		Interval:             endpoint.Interval,
-	}
+		Labels:               endpoint.Labels,
+		Severity:             models.Severity(endpoint.Severity),
+		Type:                 models.CheckType(endpoint.Type),
+		Transaction:          transaction,
+		Heartbeat:            ToServiceHeartbeat(endpoint.Heartbeat),
+		SQL:                  ToServiceSQLCheck(endpoint.SQL),
+		Exec:                 ToServiceExecCheck(endpoint.Exec),
+		Probes:               ToServiceProbes(endpoint.Probes),
+	}, nil
 }
 
 func FromServiceEndpoints(edps models.Endpoints) Endpoints {
@@ -62,6 +101,14 @@ func FromServiceEndpoint(endpoint *models.Endpoint) Endpoint {
 		SuccessCodes:         ranges,
 		NotificationServices: endpoint.NotificationServices,
 		Interval:             endpoint.Interval,
+		Labels:               endpoint.Labels,
+		Severity:             string(endpoint.Severity),
+		Type:                 string(endpoint.Type),
+		Transaction:          FromServiceTransaction(endpoint.Transaction),
+		Heartbeat:            FromServiceHeartbeat(endpoint.Heartbeat),
+		SQL:                  FromServiceSQLCheck(endpoint.SQL),
+		Exec:                 FromServiceExecCheck(endpoint.Exec),
+		Probes:               FromServiceProbes(endpoint.Probes),
 	}
 }
 
@@ -114,7 +161,9 @@ func parseRanges(rangeStrs []string) ([]int, error) {
 		}
 		result = append(result, codes...)
 	}
-	return collections.Unique(result), nil
+	result = collections.Unique(result)
+	sort.Ints(result)
+	return result, nil
 }
 
 // ParseRange converts a string range like "200-345" to []int{200, 201, ..., 345}
@@ -0,0 +1,238 @@
+package models
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// listSeparator joins list & map values within a CSV cell.
+const listSeparator = ";"
+
+// csvHeader lists endpoint CSV columns. Settings of transaction, heartbeat,
+// sql & exec checks are kept in the check column as a JSON object.
+var csvHeader = []string{
+	"id",
+	"service_name",
+	"url",
+	"type",
+	"success_codes",
+	"time_interval",
+	"severity",
+	"labels",
+	"notification_services",
+	"check",
+}
+
+func encodeEndpointsCSV(endpoints Endpoints) ([]byte, error) {
+
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+
+	if err := writer.Write(csvHeader); err != nil {
+		return nil, err
+	}
+
+	for _, endpoint := range endpoints {
+
+		check, err := encodeCheck(endpoint)
+		if err != nil {
+			return nil, errors.Wrap(err, endpoint.ServiceName)
+		}
+
+		record := []string{
+			endpoint.ID,
+			endpoint.ServiceName,
+			endpoint.URL,
+			endpoint.Type,
+			strings.Join(endpoint.SuccessCodes, listSeparator),
+			endpoint.Interval.String(),
+			endpoint.Severity,
+			encodeLabels(endpoint.Labels),
+			strings.Join(endpoint.NotificationServices, listSeparator),
+			check,
+		}
+
+		if err := writer.Write(record); err != nil {
+			return nil, err
+		}
+	}
+
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func decodeEndpointsCSV(data []byte) (Endpoints, error) {
+
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, errors.Wrap(err, "header")
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		name = strings.TrimSpace(strings.ToLower(name))
+		if !slices.Contains(csvHeader, name) {
+			return nil, errors.Errorf("unknown column %q", name)
+		}
+		columns[name] = i
+	}
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+
+	endpoints := make(Endpoints, 0, len(records))
+
+	for i, record := range records {
+		cell := func(column string) string {
+			if idx, ok := columns[column]; ok && idx < len(record) {
+				return strings.TrimSpace(record[idx])
+			}
+			return ""
+		}
+
+		endpoint := Endpoint{
+			ID:                   cell("id"),
+			ServiceName:          cell("service_name"),
+			URL:                  cell("url"),
+			Type:                 cell("type"),
+			SuccessCodes:         splitList(cell("success_codes")),
+			Severity:             cell("severity"),
+			NotificationServices: splitList(cell("notification_services")),
+		}
+
+		// header is line 1
+		line := i + 2
+
+		if interval := cell("time_interval"); interval != "" {
+			if endpoint.Interval, err = time.ParseDuration(interval); err != nil {
+				return nil, errors.Wrapf(err, "line %d: time_interval", line)
+			}
+		}
+
+		if endpoint.Labels, err = decodeLabels(cell("labels")); err != nil {
+			return nil, errors.Wrapf(err, "line %d: labels", line)
+		}
+
+		if err := decodeCheck(cell("check"), &endpoint); err != nil {
+			return nil, errors.Wrapf(err, "line %d: check", line)
+		}
+
+		endpoints = append(endpoints, endpoint)
+	}
+
+	return endpoints, nil
+}
+
+// checkSettings is the check column content.
+type checkSettings struct {
+	Transaction *Transaction `json:"transaction,omitempty"`
+	Heartbeat   *Heartbeat   `json:"heartbeat,omitempty"`
+	SQL         *SQLCheck    `json:"sql,omitempty"`
+	Exec        *ExecCheck   `json:"exec,omitempty"`
+	Probes      *Probes      `json:"probes,omitempty"`
+}
+
+func encodeCheck(endpoint Endpoint) (string, error) {
+
+	settings := checkSettings{
+		Transaction: endpoint.Transaction,
+		Heartbeat:   endpoint.Heartbeat,
+		SQL:         endpoint.SQL,
+		Exec:        endpoint.Exec,
+		Probes:      endpoint.Probes,
+	}
+
+	if settings == (checkSettings{}) {
+		return "", nil
+	}
+
+	tree, err := jsonTree(settings)
+	if err != nil {
+		return "", err
+	}
+
+	data, err := json.Marshal(formatDurations(tree))
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
+
+func decodeCheck(cell string, endpoint *Endpoint) error {
+
+	if cell == "" {
+		return nil
+	}
+
+	var settings checkSettings
+	if err := decodeDocument([]byte(cell), FormatJSON, &settings); err != nil {
+		return err
+	}
+
+	endpoint.Transaction = settings.Transaction
+	endpoint.Heartbeat = settings.Heartbeat
+	endpoint.SQL = settings.SQL
+	endpoint.Exec = settings.Exec
+	endpoint.Probes = settings.Probes
+
+	return nil
+}
+
+func encodeLabels(labels map[string]string) string {
+	pairs := make([]string, 0, len(labels))
+	for key, value := range labels {
+		pairs = append(pairs, key+"="+value)
+	}
+	slices.Sort(pairs)
+	return strings.Join(pairs, listSeparator)
+}
+
+func decodeLabels(cell string) (map[string]string, error) {
+
+	pairs := splitList(cell)
+	if len(pairs) == 0 {
+		return nil, nil
+	}
+
+	labels := make(map[string]string, len(pairs))
+	for _, pair := range pairs {
+		key, value, ok := strings.Cut(pair, "=")
+		if !ok || key == "" {
+			return nil, fmt.Errorf("expected key=value, got %q", pair)
+		}
+		labels[strings.TrimSpace(key)] = strings.TrimSpace(value)
+	}
+
+	return labels, nil
+}
+
+func splitList(cell string) []string {
+	if cell == "" {
+		return nil
+	}
+	items := strings.Split(cell, listSeparator)
+	result := make([]string, 0, len(items))
+	for _, item := range items {
+		if item = strings.TrimSpace(item); item != "" {
+			result = append(result, item)
+		}
+	}
+	return result
+}
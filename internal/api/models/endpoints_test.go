@@ -280,13 +280,8 @@ func TestToServiceEndpoint(t *testing.T) {
 				SuccessCodes: []string{"200-abc"},
 				Interval:     baseEndpoint.Interval,
 			},
-			expected: &models.Endpoint{
-				ID:           baseEndpoint.ID,
-				ServiceName:  baseEndpoint.ServiceName,
-				URL:          baseEndpoint.URL,
-				SuccessCodes: []int{},
-				Interval:     baseEndpoint.Interval,
-			},
+			expectError:   true,
+			errorContains: "success_codes",
 		},
 		{
 			name: "invalid range (start > end)",
@@ -297,13 +292,23 @@ func TestToServiceEndpoint(t *testing.T) {
 				SuccessCodes: []string{"300-200"},
 				Interval:     baseEndpoint.Interval,
 			},
-			expected: &models.Endpoint{
-				ID:           baseEndpoint.ID,
-				ServiceName:  baseEndpoint.ServiceName,
-				URL:          baseEndpoint.URL,
-				SuccessCodes: []int{},
-				Interval:     baseEndpoint.Interval,
+			expectError:   true,
+			errorContains: "success_codes",
+		},
+		{
+			name: "invalid transaction step range",
+			input: Endpoint{
+				ID:          baseEndpoint.ID,
+				ServiceName: baseEndpoint.ServiceName,
+				Type:        string(models.CheckTransaction),
+				Transaction: &Transaction{Steps: []TransactionStep{
+					{URL: "https://example.com/login"},
+					{URL: "https://example.com/me", SuccessCodes: []string{"2xx"}},
+				}},
+				Interval: baseEndpoint.Interval,
 			},
+			expectError:   true,
+			errorContains: "transaction.steps[1]: success_codes",
 		},
 		{
 			name: "with notification services",
@@ -328,17 +333,15 @@ func TestToServiceEndpoint(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := ToServiceEndpoint(tt.input)
+			result, err := ToServiceEndpoint(tt.input)
 
 			if tt.expectError {
-				// If your function doesn't currently return errors, you might want to:
-				// 1. Change the function signature to return error
-				// 2. Add logging for the error case
-				// 3. Add assertions based on whatever error handling you implement
-				t.Log("Note: Currently the function doesn't return errors, but invalid ranges should be handled")
+				assert.ErrorIs(t, err, models.ErrInvalidEndpoint)
+				assert.ErrorContains(t, err, tt.errorContains)
 				return
 			}
 
+			require.NoError(t, err)
 			require.NotNil(t, result, "result should not be nil")
 			assert.Equal(t, tt.expected.ID, result.ID)
 			assert.Equal(t, tt.expected.ServiceName, result.ServiceName)
@@ -553,3 +556,18 @@ func generateSequence(start, end int) []int {
 	}
 	return result
 }
+
+func Test_FromServiceEndpoint_redactsDSN(t *testing.T) {
+
+	endpoint := FromServiceEndpoint(&models.Endpoint{
+		Type: models.CheckSQL,
+		SQL:  &models.SQLCheck{Driver: "postgres", DSN: "postgres://user:pass@db", Query: "SELECT 1"},
+	})
+
+	require.NotNil(t, endpoint.SQL)
+	assert.Empty(t, endpoint.SQL.DSN)
+
+	data, err := json.Marshal(endpoint)
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "dsn")
+}
@@ -0,0 +1,80 @@
+package models
+
+import (
+	"strings"
+	"time"
+
+	"github.com/vishenosik/CherryWatch/internal/services/models"
+)
+
+type Event struct {
+	// One of: check_result, incident_opened, incident_updated, incident_resolved, lagged
+	Kind       string            `json:"kind"`
+	EndpointID string            `json:"endpoint_id,omitempty"`
+	Labels     map[string]string `json:"labels,omitempty"`
+	Severity   string            `json:"severity,omitempty"`
+	// Set for check_result events
+	Result *CheckResult `json:"result,omitempty"`
+	// Set for incident events
+	Incident *Incident `json:"incident,omitempty"`
+	// Set for incident_updated events
+	Update *IncidentUpdate `json:"update,omitempty"`
+	// Number of skipped events, set for lagged events
+	Dropped uint64    `json:"dropped,omitempty"`
+	Time    time.Time `json:"time"`
+}
+
+func FromServiceEvent(event *models.Event) Event {
+
+	ev := Event{
+		Kind:       string(event.Kind),
+		EndpointID: event.EndpointID,
+		Labels:     event.Labels,
+		Severity:   string(event.Severity),
+		Dropped:    event.Dropped,
+		Time:       event.Time,
+	}
+
+	if event.Result != nil {
+		result := FromServiceCheckResult(event.Result)
+		ev.Result = &result
+	}
+
+	if event.Incident != nil {
+		incident := FromServiceIncident(event.Incident)
+		ev.Incident = &incident
+	}
+
+	if event.Update != nil {
+		update := FromServiceIncidentUpdate(event.Update)
+		ev.Update = &update
+	}
+
+	return ev
+}
+
+// ToServiceEventsFilter builds events filter from raw request values.
+// Labels are "key=value" pairs, pairs without "=" are ignored.
+func ToServiceEventsFilter(endpointIDs, labels, severities []string) models.EventsFilter {
+
+	filter := models.EventsFilter{
+		EndpointIDs: endpointIDs,
+	}
+
+	for _, label := range labels {
+		key, value, ok := strings.Cut(label, "=")
+		if !ok {
+			continue
+		}
+		if filter.Labels == nil {
+			filter.Labels = make(map[string]string)
+		}
+		filter.Labels[key] = value
+	}
+
+	for _, severity := range severities {
+		filter.Severities = append(filter.Severities, models.Severity(severity))
+	}
+
+	return filter
+}
@@ -0,0 +1,46 @@
+package models
+
+import (
+	"time"
+
+	"github.com/vishenosik/CherryWatch/internal/services/models"
+)
+
+type ExecCheck struct {
+	// Executable name or path
+	Command string `json:"command"`
+	// Command arguments
+	Args []string `json:"args,omitempty"`
+	// Extra environment variables
+	Env map[string]string `json:"env,omitempty"`
+	// Command timeout (30s by default)
+	Timeout time.Duration `json:"timeout,omitempty"`
+	// Exit codes which are considered successful (0 by default)
+	SuccessCodes []int `json:"success_codes,omitempty"`
+}
+
+func ToServiceExecCheck(check *ExecCheck) *models.ExecCheck {
+	if check == nil {
+		return nil
+	}
+	return &models.ExecCheck{
+		Command:      check.Command,
+		Args:         check.Args,
+		Env:          check.Env,
+		Timeout:      check.Timeout,
+		SuccessCodes: check.SuccessCodes,
+	}
+}
+
+func FromServiceExecCheck(check *models.ExecCheck) *ExecCheck {
+	if check == nil {
+		return nil
+	}
+	return &ExecCheck{
+		Command:      check.Command,
+		Args:         check.Args,
+		Env:          check.Env,
+		Timeout:      check.Timeout,
+		SuccessCodes: check.SuccessCodes,
+	}
+}
@@ -0,0 +1,47 @@
+package models
+
+import (
+	"time"
+
+	"github.com/vishenosik/CherryWatch/internal/services/models"
+	"github.com/vishenosik/web-tools/api"
+)
+
+type Heartbeat struct {
+	// Extra time to wait for a ping before opening an incident,
+	// also the time a started job has to finish (time_interval if zero)
+	Grace time.Duration `json:"grace,omitempty"`
+	// Ping token (generated by the server)
+	Token string `json:"token,omitempty"`
+	// Ping URL path, "/start" & "/fail" suffixes are accepted too
+	PingURL string `json:"ping_url,omitempty"`
+}
+
+func ToServiceHeartbeat(hb *Heartbeat) *models.Heartbeat {
+	if hb == nil {
+		return nil
+	}
+	return &models.Heartbeat{
+		Token: hb.Token,
+		Grace: hb.Grace,
+	}
+}
+
+func FromServiceHeartbeat(hb *models.Heartbeat) *Heartbeat {
+	if hb == nil {
+		return nil
+	}
+	return &Heartbeat{
+		Grace:   hb.Grace,
+		Token:   hb.Token,
+		PingURL: HeartbeatPingURL(hb.Token),
+	}
+}
+
+// HeartbeatPingURL returns ping URL path of the token.
+func HeartbeatPingURL(token string) string {
+	if token == "" {
+		return ""
+	}
+	return api.ApiV1("/heartbeat", token)
+}
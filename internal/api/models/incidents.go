@@ -0,0 +1,85 @@
+package models
+
+import (
+	"time"
+
+	"github.com/vishenosik/CherryWatch/internal/services/models"
+	devCol "github.com/vishenosik/CherryWatch/pkg/collections"
+)
+
+type Incident struct {
+	ID         string `json:"id"`
+	EndpointID string `json:"endpoint_id"`
+	// Failure description of the check opened the incident
+	Cause    string    `json:"cause"`
+	OpenedAt time.Time `json:"opened_at"`
+	// Not set while the incident is active
+	ResolvedAt *time.Time `json:"resolved_at,omitempty"`
+	// Not set until an operator takes the incident
+	AcknowledgedAt *time.Time `json:"acknowledged_at,omitempty"`
+	// user:<id> or app:<name>
+	AcknowledgedBy string `json:"acknowledged_by,omitempty"`
+}
+
+type Incidents = []Incident
+
+func FromServiceIncidents(incidents models.Incidents) Incidents {
+	return devCol.ConvertSlice(incidents, FromServiceIncident)
+}
+
+func FromServiceIncident(incident *models.Incident) Incident {
+	return Incident{
+		ID:         incident.ID,
+		EndpointID: incident.EndpointID,
+		Cause:      incident.Cause,
+		OpenedAt:   incident.OpenedAt,
+		ResolvedAt: optionalTime(incident.ResolvedAt),
+
+		AcknowledgedAt: optionalTime(incident.AcknowledgedAt),
+		AcknowledgedBy: incident.AcknowledgedBy,
+	}
+}
+
+type CheckResult struct {
+	EndpointID string `json:"endpoint_id"`
+	Type       string `json:"type"`
+	Success    bool   `json:"success"`
+	StatusCode int    `json:"status_code,omitempty"`
+	ExitCode   int    `json:"exit_code,omitempty"`
+	Output     string `json:"output,omitempty"`
+	Message    string `json:"message,omitempty"`
+	FailedStep string `json:"failed_step,omitempty"`
+	// Check duration
+	Latency   time.Duration `json:"latency"`
+	CheckedAt time.Time     `json:"checked_at"`
+	// Expiry of the server certificate (https checks only)
+	CertExpiresAt *time.Time `json:"cert_expires_at,omitempty"`
+	// Location of the agent reporting the result, empty for checks of the server
+	Location string `json:"location,omitempty"`
+}
+
+func FromServiceCheckResult(result *models.CheckResult) CheckResult {
+	return CheckResult{
+		EndpointID: result.EndpointID,
+		Type:       string(result.Type),
+		Success:    result.Success,
+		StatusCode: result.StatusCode,
+		ExitCode:   result.ExitCode,
+		Output:     result.Output,
+		Message:    result.Message,
+		FailedStep: result.FailedStep,
+		Latency:    result.Latency,
+		CheckedAt:  result.CheckedAt,
+
+		CertExpiresAt: optionalTime(result.CertExpiresAt),
+		Location:      result.Location,
+	}
+}
+
+// optionalTime leaves zero time unset.
+func optionalTime(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
@@ -0,0 +1,108 @@
+package models
+
+import (
+	"time"
+
+	"github.com/vishenosik/CherryWatch/internal/services/models"
+	devCol "github.com/vishenosik/CherryWatch/pkg/collections"
+)
+
+type IncidentUpdate struct {
+	// Update identifier (generated by the server)
+	ID         string `json:"id"`
+	IncidentID string `json:"incident_id"`
+	// One of: investigating, identified, monitoring, resolved
+	State string `json:"state"`
+	// Message (markdown)
+	Body string `json:"body"`
+	// user:<id> or app:<name> (read-only)
+	Author    string    `json:"author,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type IncidentUpdates = []IncidentUpdate
+
+func ToServiceIncidentUpdate(update IncidentUpdate) *models.IncidentUpdate {
+	return &models.IncidentUpdate{
+		State: models.IncidentState(update.State),
+		Body:  update.Body,
+	}
+}
+
+func FromServiceIncidentUpdates(updates []*models.IncidentUpdate) IncidentUpdates {
+	return devCol.ConvertSlice(updates, FromServiceIncidentUpdate)
+}
+
+func FromServiceIncidentUpdate(update *models.IncidentUpdate) IncidentUpdate {
+	return IncidentUpdate{
+		ID:         update.ID,
+		IncidentID: update.IncidentID,
+		State:      string(update.State),
+		Body:       update.Body,
+		Author:     update.Author,
+		CreatedAt:  update.CreatedAt,
+	}
+}
+
+type Postmortem struct {
+	IncidentID string `json:"incident_id"`
+	// Version number, every save adds one (read-only)
+	Version int `json:"version"`
+	// Document (markdown)
+	Body string `json:"body"`
+	// user:<id> or app:<name> (read-only)
+	Author    string    `json:"author,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type Postmortems = []Postmortem
+
+func FromServicePostmortems(postmortems []*models.Postmortem) Postmortems {
+	return devCol.ConvertSlice(postmortems, FromServicePostmortem)
+}
+
+func FromServicePostmortem(postmortem *models.Postmortem) Postmortem {
+	return Postmortem{
+		IncidentID: postmortem.IncidentID,
+		Version:    postmortem.Version,
+		Body:       postmortem.Body,
+		Author:     postmortem.Author,
+		CreatedAt:  postmortem.CreatedAt,
+	}
+}
+
+type TimelineEntry struct {
+	// One of: opened, acknowledged, update, resolved, postmortem
+	Kind   string    `json:"kind"`
+	Time   time.Time `json:"time"`
+	Author string    `json:"author,omitempty"`
+	// Announced state (updates only)
+	State string `json:"state,omitempty"`
+	// Failure cause, update message or postmortem document
+	Body string `json:"body,omitempty"`
+	// Postmortem version (postmortems only)
+	Version int `json:"version,omitempty"`
+}
+
+type Timeline struct {
+	Incident Incident        `json:"incident"`
+	Entries  []TimelineEntry `json:"entries"`
+}
+
+func FromServiceTimeline(timeline *models.Timeline) Timeline {
+	return Timeline{
+		Incident: FromServiceIncident(timeline.Incident),
+		Entries:  devCol.ConvertSlice(timeline.Entries, FromServiceTimelineEntry),
+	}
+}
+
+func FromServiceTimelineEntry(entry *models.TimelineEntry) TimelineEntry {
+	return TimelineEntry{
+		Kind:    string(entry.Kind),
+		Time:    entry.Time,
+		Author:  entry.Author,
+		State:   string(entry.State),
+		Body:    entry.Body,
+		Version: entry.Version,
+	}
+}
@@ -0,0 +1,48 @@
+package models
+
+import (
+	"time"
+
+	"github.com/vishenosik/CherryWatch/internal/services/models"
+	devCol "github.com/vishenosik/CherryWatch/pkg/collections"
+)
+
+type Maintenance struct {
+	// Maintenance identifier (generated by the server)
+	ID      string `json:"id"`
+	Title   string `json:"title"`
+	Message string `json:"message,omitempty"`
+	// Planned period (RFC 3339)
+	StartsAt time.Time `json:"starts_at"`
+	EndsAt   time.Time `json:"ends_at"`
+	// Affected endpoints, every endpoint of the workspace if empty
+	EndpointIDs []string `json:"endpoint_ids,omitempty"`
+}
+
+type Maintenances = []Maintenance
+
+func ToServiceMaintenance(mnt Maintenance) *models.Maintenance {
+	return &models.Maintenance{
+		ID:          mnt.ID,
+		Title:       mnt.Title,
+		Message:     mnt.Message,
+		StartsAt:    mnt.StartsAt,
+		EndsAt:      mnt.EndsAt,
+		EndpointIDs: mnt.EndpointIDs,
+	}
+}
+
+func FromServiceMaintenances(maintenances models.Maintenances) Maintenances {
+	return devCol.ConvertSlice(maintenances, FromServiceMaintenance)
+}
+
+func FromServiceMaintenance(mnt *models.Maintenance) Maintenance {
+	return Maintenance{
+		ID:          mnt.ID,
+		Title:       mnt.Title,
+		Message:     mnt.Message,
+		StartsAt:    mnt.StartsAt,
+		EndsAt:      mnt.EndsAt,
+		EndpointIDs: mnt.EndpointIDs,
+	}
+}
@@ -0,0 +1,32 @@
+package models
+
+import (
+	"github.com/vishenosik/CherryWatch/internal/services/models"
+)
+
+type Probes struct {
+	// Locations of agents checking the endpoint
+	Locations []string `json:"locations"`
+	// Number of failing locations making the endpoint fail, a majority if zero
+	Quorum int `json:"quorum,omitempty"`
+}
+
+func ToServiceProbes(probes *Probes) *models.Probes {
+	if probes == nil {
+		return nil
+	}
+	return &models.Probes{
+		Locations: probes.Locations,
+		Quorum:    probes.Quorum,
+	}
+}
+
+func FromServiceProbes(probes *models.Probes) *Probes {
+	if probes == nil {
+		return nil
+	}
+	return &Probes{
+		Locations: probes.Locations,
+		Quorum:    probes.Quorum,
+	}
+}
@@ -0,0 +1,48 @@
+package models
+
+import (
+	"time"
+
+	"github.com/vishenosik/CherryWatch/internal/services/models"
+	devCol "github.com/vishenosik/CherryWatch/pkg/collections"
+)
+
+type Silence struct {
+	// Silence identifier (generated by the server)
+	ID      string `json:"id"`
+	Comment string `json:"comment,omitempty"`
+	// Principal who created the silence (set by the server)
+	CreatedBy string `json:"created_by,omitempty"`
+	// Muted period (RFC 3339), starting now if starts_at is empty
+	StartsAt time.Time `json:"starts_at"`
+	EndsAt   time.Time `json:"ends_at"`
+	// Muted endpoints, every endpoint of the workspace if empty
+	EndpointIDs []string `json:"endpoint_ids,omitempty"`
+}
+
+type Silences = []Silence
+
+func ToServiceSilence(sil Silence) *models.Silence {
+	return &models.Silence{
+		ID:          sil.ID,
+		Comment:     sil.Comment,
+		StartsAt:    sil.StartsAt,
+		EndsAt:      sil.EndsAt,
+		EndpointIDs: sil.EndpointIDs,
+	}
+}
+
+func FromServiceSilences(silences models.Silences) Silences {
+	return devCol.ConvertSlice(silences, FromServiceSilence)
+}
+
+func FromServiceSilence(sil *models.Silence) Silence {
+	return Silence{
+		ID:          sil.ID,
+		Comment:     sil.Comment,
+		CreatedBy:   sil.CreatedBy,
+		StartsAt:    sil.StartsAt,
+		EndsAt:      sil.EndsAt,
+		EndpointIDs: sil.EndpointIDs,
+	}
+}
@@ -0,0 +1,63 @@
+package models
+
+import (
+	"time"
+
+	"github.com/vishenosik/CherryWatch/internal/services/models"
+)
+
+type SQLCheck struct {
+	// database/sql driver name ("sqlite3" is built in)
+	Driver string `json:"driver"`
+	// Data source name passed to the driver, it often holds credentials
+	// so it's never returned (omit it on updates to keep the current one)
+	DSN string `json:"dsn,omitempty"`
+	// Single read-only statement
+	Query string `json:"query"`
+	// Query timeout (10s by default)
+	Timeout time.Duration `json:"timeout,omitempty"`
+	// Assertion on the query result
+	Expect Expectation `json:"expect"`
+}
+
+type Expectation struct {
+	// "rows" (row count, default) or "value" (first column of the first row)
+	Subject string `json:"subject,omitempty"`
+	// One of: ==, !=, <, <=, >, >=
+	Operator string `json:"op"`
+	// Expected value, compared numerically if both sides are numbers
+	Value string `json:"value"`
+}
+
+func ToServiceSQLCheck(check *SQLCheck) *models.SQLCheck {
+	if check == nil {
+		return nil
+	}
+	return &models.SQLCheck{
+		Driver:  check.Driver,
+		DSN:     check.DSN,
+		Query:   check.Query,
+		Timeout: check.Timeout,
+		Expect: models.Expectation{
+			Subject:  models.SQLSubject(check.Expect.Subject),
+			Operator: models.Operator(check.Expect.Operator),
+			Value:    check.Expect.Value,
+		},
+	}
+}
+
+func FromServiceSQLCheck(check *models.SQLCheck) *SQLCheck {
+	if check == nil {
+		return nil
+	}
+	return &SQLCheck{
+		Driver:  check.Driver,
+		Query:   check.Query,
+		Timeout: check.Timeout,
+		Expect: Expectation{
+			Subject:  string(check.Expect.Subject),
+			Operator: string(check.Expect.Operator),
+			Value:    check.Expect.Value,
+		},
+	}
+}
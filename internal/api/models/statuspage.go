@@ -0,0 +1,128 @@
+package models
+
+import (
+	"time"
+
+	"github.com/vishenosik/CherryWatch/internal/services/models"
+	devCol "github.com/vishenosik/CherryWatch/pkg/collections"
+)
+
+// StatusPage is the public state of endpoints, URLs & failure causes are never exposed.
+type StatusPage struct {
+	Slug    string `json:"slug,omitempty"`
+	Title   string `json:"title"`
+	LogoURL string `json:"logo_url,omitempty"`
+	// One of: operational, maintenance, degraded, outage
+	Status       string            `json:"status"`
+	Components   []StatusComponent `json:"components"`
+	Incidents    []StatusIncident  `json:"incidents"`
+	Maintenances Maintenances      `json:"maintenances"`
+	UpdatedAt    time.Time         `json:"updated_at"`
+}
+
+type StatusComponent struct {
+	Name      string           `json:"name"`
+	Status    string           `json:"status"`
+	Endpoints []StatusEndpoint `json:"endpoints"`
+	// Share of successful checks over the history in percents, not set without checks
+	Uptime  *float64    `json:"uptime,omitempty"`
+	History []UptimeDay `json:"history"`
+}
+
+type StatusEndpoint struct {
+	ID          string `json:"id"`
+	ServiceName string `json:"service_name"`
+	Status      string `json:"status"`
+}
+
+type UptimeDay struct {
+	// Day (UTC) formatted as 2006-01-02
+	Day      string `json:"day"`
+	Checks   uint64 `json:"checks"`
+	Failures uint64 `json:"failures"`
+	// Share of successful checks in percents, not set without checks
+	Uptime *float64 `json:"uptime,omitempty"`
+}
+
+type StatusIncident struct {
+	ID          string    `json:"id"`
+	Component   string    `json:"component"`
+	ServiceName string    `json:"service_name"`
+	OpenedAt    time.Time `json:"opened_at"`
+	// Set once an operator took the incident
+	AcknowledgedAt *time.Time `json:"acknowledged_at,omitempty"`
+	// Operator updates, newest first
+	Updates []StatusIncidentUpdate `json:"updates"`
+}
+
+type StatusIncidentUpdate struct {
+	// One of: investigating, identified, monitoring, resolved
+	State string `json:"state"`
+	// Message (markdown)
+	Body      string    `json:"body"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func FromServiceStatusPage(page *models.StatusPage) StatusPage {
+	return StatusPage{
+		Slug:         page.Slug,
+		Title:        page.Title,
+		LogoURL:      page.LogoURL,
+		Status:       string(page.Status),
+		Components:   devCol.ConvertSlice(page.Components, FromServiceComponent),
+		Incidents:    devCol.ConvertSlice(page.Incidents, FromServiceStatusIncident),
+		Maintenances: FromServiceMaintenances(page.Maintenances),
+		UpdatedAt:    page.UpdatedAt,
+	}
+}
+
+func FromServiceComponent(component *models.Component) StatusComponent {
+	return StatusComponent{
+		Name:   component.Name,
+		Status: string(component.Status),
+		Endpoints: devCol.ConvertSlice(component.Endpoints, func(endpoint *models.ComponentEndpoint) StatusEndpoint {
+			return StatusEndpoint{
+				ID:          endpoint.ID,
+				ServiceName: endpoint.ServiceName,
+				Status:      string(endpoint.Status),
+			}
+		}),
+		Uptime:  UptimePercent(&component.Total),
+		History: devCol.ConvertSlice(component.History, FromServiceDailyChecks),
+	}
+}
+
+func FromServiceDailyChecks(checks *models.DailyChecks) UptimeDay {
+	return UptimeDay{
+		Day:      checks.Day.Format(time.DateOnly),
+		Checks:   checks.Checks,
+		Failures: checks.Failures,
+		Uptime:   UptimePercent(checks),
+	}
+}
+
+func FromServiceStatusIncident(incident *models.StatusIncident) StatusIncident {
+	return StatusIncident{
+		ID:             incident.ID,
+		Component:      incident.Component,
+		ServiceName:    incident.ServiceName,
+		OpenedAt:       incident.OpenedAt,
+		AcknowledgedAt: optionalTime(incident.AcknowledgedAt),
+		Updates: devCol.ConvertSlice(incident.Updates, func(update *models.IncidentUpdate) StatusIncidentUpdate {
+			return StatusIncidentUpdate{
+				State:     string(update.State),
+				Body:      update.Body,
+				CreatedAt: update.CreatedAt,
+			}
+		}),
+	}
+}
+
+// UptimePercent returns share of successful checks in percents, nil without checks.
+func UptimePercent(checks *models.DailyChecks) *float64 {
+	if checks.Checks == 0 {
+		return nil
+	}
+	uptime := checks.Uptime() * 100
+	return &uptime
+}
@@ -0,0 +1,61 @@
+package models
+
+import (
+	"github.com/vishenosik/CherryWatch/internal/services/models"
+	devCol "github.com/vishenosik/CherryWatch/pkg/collections"
+)
+
+type StatusPageConfig struct {
+	// Status page identifier (generated by the server)
+	ID string `json:"id"`
+	// Workspace the page shows endpoints of (read-only)
+	WorkspaceID string `json:"workspace_id,omitempty"`
+	// Page is served at /status/{slug}
+	Slug    string `json:"slug"`
+	Title   string `json:"title"`
+	LogoURL string `json:"logo_url,omitempty"`
+	// Endpoints shown on the page, selector is used if empty
+	EndpointIDs []string `json:"endpoint_ids,omitempty"`
+	// Labels endpoints shown on the page must have, all workspace endpoints if empty
+	Selector map[string]string `json:"selector,omitempty"`
+	// One of: public, private
+	Visibility string `json:"visibility"`
+	// Access token of a private page (read-only)
+	Token string `json:"token,omitempty"`
+	// Host name the page is served at the root of
+	Host string `json:"host,omitempty"`
+}
+
+type StatusPageConfigs = []StatusPageConfig
+
+func ToServiceStatusPageConfig(page StatusPageConfig) *models.StatusPageConfig {
+	return &models.StatusPageConfig{
+		ID:          page.ID,
+		Slug:        page.Slug,
+		Title:       page.Title,
+		LogoURL:     page.LogoURL,
+		EndpointIDs: page.EndpointIDs,
+		Selector:    page.Selector,
+		Visibility:  models.Visibility(page.Visibility),
+		Host:        page.Host,
+	}
+}
+
+func FromServiceStatusPageConfigs(pages models.StatusPageConfigs) StatusPageConfigs {
+	return devCol.ConvertSlice(pages, FromServiceStatusPageConfig)
+}
+
+func FromServiceStatusPageConfig(page *models.StatusPageConfig) StatusPageConfig {
+	return StatusPageConfig{
+		ID:          page.ID,
+		WorkspaceID: page.WorkspaceID,
+		Slug:        page.Slug,
+		Title:       page.Title,
+		LogoURL:     page.LogoURL,
+		EndpointIDs: page.EndpointIDs,
+		Selector:    page.Selector,
+		Visibility:  string(page.Visibility),
+		Token:       page.Token,
+		Host:        page.Host,
+	}
+}
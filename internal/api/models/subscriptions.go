@@ -0,0 +1,36 @@
+package models
+
+import (
+	"github.com/vishenosik/CherryWatch/internal/services/models"
+)
+
+type Subscription struct {
+	// Subscription identifier (generated by the server)
+	ID string `json:"id"`
+	// One of: email, webhook
+	Type string `json:"type"`
+	// Email address or webhook URL
+	Target string `json:"target"`
+	// Components updates are sent about, every page component if empty
+	Components []string `json:"components,omitempty"`
+	// Updates are sent once the subscription is confirmed through the link sent to the target
+	Confirmed bool `json:"confirmed"`
+}
+
+func ToServiceSubscription(sub Subscription) *models.Subscription {
+	return &models.Subscription{
+		Kind:       models.ChannelKind(sub.Type),
+		Target:     sub.Target,
+		Components: sub.Components,
+	}
+}
+
+func FromServiceSubscription(sub *models.Subscription) Subscription {
+	return Subscription{
+		ID:         sub.ID,
+		Type:       string(sub.Kind),
+		Target:     sub.Target,
+		Components: sub.Components,
+		Confirmed:  sub.Confirmed(),
+	}
+}
@@ -0,0 +1,108 @@
+package models
+
+import (
+	"github.com/pkg/errors"
+	"github.com/vishenosik/CherryWatch/internal/services/models"
+	devCol "github.com/vishenosik/CherryWatch/pkg/collections"
+)
+
+type Transaction struct {
+	// Steps executed in order, the check fails on the first broken one
+	Steps []TransactionStep `json:"steps"`
+}
+
+type TransactionStep struct {
+	// Step name used in failure reports
+	Name string `json:"name,omitempty"`
+	// HTTP method (GET by default)
+	Method string `json:"method,omitempty"`
+	// URL template, e.g. "https://api.example.com/users/{{.user_id}}"
+	URL string `json:"url"`
+	// Header templates, e.g. {"Authorization": "Bearer {{.token}}"}
+	Headers map[string]string `json:"headers,omitempty"`
+	// Body template
+	Body string `json:"body,omitempty"`
+	// HTTP codes & code ranges which are considered successful (2xx by default)
+	SuccessCodes []string `json:"success_codes,omitempty"`
+	// Variables extracted from the response
+	Extract []Extraction `json:"extract,omitempty"`
+}
+
+type Extraction struct {
+	// Variable name available to the following steps
+	Variable string `json:"var"`
+	// One of: json, header, regex
+	Source string `json:"from"`
+	// JSONPath ("$.data.token"), header name or regular expression
+	Expression string `json:"expr"`
+}
+
+func ToServiceTransaction(tr *Transaction) (*models.Transaction, error) {
+	if tr == nil {
+		return nil, nil
+	}
+
+	steps := make([]models.TransactionStep, 0, len(tr.Steps))
+	for i, step := range tr.Steps {
+		converted, err := toServiceStep(step)
+		if err != nil {
+			return nil, errors.Wrapf(err, "steps[%d]", i)
+		}
+		steps = append(steps, converted)
+	}
+
+	return &models.Transaction{Steps: steps}, nil
+}
+
+func toServiceStep(step TransactionStep) (models.TransactionStep, error) {
+	ranges, err := parseRanges(step.SuccessCodes)
+	if err != nil {
+		return models.TransactionStep{}, errors.Wrap(err, "success_codes")
+	}
+	return models.TransactionStep{
+		Name:         step.Name,
+		Method:       step.Method,
+		URL:          step.URL,
+		Headers:      step.Headers,
+		Body:         step.Body,
+		SuccessCodes: ranges,
+		Extract:      devCol.ConvertSlice(step.Extract, toServiceExtraction),
+	}, nil
+}
+
+func toServiceExtraction(ex Extraction) models.Extraction {
+	return models.Extraction{
+		Variable:   ex.Variable,
+		Source:     models.ExtractSource(ex.Source),
+		Expression: ex.Expression,
+	}
+}
+
+func FromServiceTransaction(tr *models.Transaction) *Transaction {
+	if tr == nil {
+		return nil
+	}
+	return &Transaction{
+		Steps: devCol.ConvertSlice(tr.Steps, fromServiceStep),
+	}
+}
+
+func fromServiceStep(step models.TransactionStep) TransactionStep {
+	return TransactionStep{
+		Name:         step.Name,
+		Method:       step.Method,
+		URL:          step.URL,
+		Headers:      step.Headers,
+		Body:         step.Body,
+		SuccessCodes: codesRanges(step.SuccessCodes),
+		Extract:      devCol.ConvertSlice(step.Extract, fromServiceExtraction),
+	}
+}
+
+func fromServiceExtraction(ex models.Extraction) Extraction {
+	return Extraction{
+		Variable:   ex.Variable,
+		Source:     string(ex.Source),
+		Expression: ex.Expression,
+	}
+}
@@ -0,0 +1,44 @@
+package models
+
+import (
+	"github.com/vishenosik/CherryWatch/internal/services/models"
+	devCol "github.com/vishenosik/CherryWatch/pkg/collections"
+)
+
+type User struct {
+	// User identifier (generated by the server)
+	ID string `json:"id"`
+	// Workspace the user belongs to (read-only)
+	WorkspaceID string `json:"workspace_id,omitempty"`
+	Nickname    string `json:"nickname"`
+	Email       string `json:"email"`
+	// One of: viewer, editor, admin
+	Role string `json:"role"`
+	// Write-only, kept unchanged on update if empty
+	Password string `json:"password,omitempty"`
+}
+
+type Users = []User
+
+func ToServiceUser(user User) *models.User {
+	return &models.User{
+		ID:       user.ID,
+		Nickname: user.Nickname,
+		Email:    user.Email,
+		Role:     models.Role(user.Role),
+	}
+}
+
+func FromServiceUsers(users models.Users) Users {
+	return devCol.ConvertSlice(users, FromServiceUser)
+}
+
+func FromServiceUser(user *models.User) User {
+	return User{
+		ID:          user.ID,
+		WorkspaceID: user.WorkspaceID,
+		Nickname:    user.Nickname,
+		Email:       user.Email,
+		Role:        string(user.Role),
+	}
+}
@@ -0,0 +1,34 @@
+package models
+
+import (
+	"github.com/vishenosik/CherryWatch/internal/services/models"
+	devCol "github.com/vishenosik/CherryWatch/pkg/collections"
+)
+
+type Workspace struct {
+	// Workspace identifier (generated by the server)
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	// API key of the workspace admin app, returned once on creation
+	APIKey string `json:"api_key,omitempty"`
+}
+
+type Workspaces = []Workspace
+
+func ToServiceWorkspace(ws Workspace) *models.Workspace {
+	return &models.Workspace{
+		ID:   ws.ID,
+		Name: ws.Name,
+	}
+}
+
+func FromServiceWorkspaces(workspaces models.Workspaces) Workspaces {
+	return devCol.ConvertSlice(workspaces, FromServiceWorkspace)
+}
+
+func FromServiceWorkspace(ws *models.Workspace) Workspace {
+	return Workspace{
+		ID:   ws.ID,
+		Name: ws.Name,
+	}
+}
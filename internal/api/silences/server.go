@@ -0,0 +1,43 @@
+package silences
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/vishenosik/CherryWatch/internal/services/models"
+	"github.com/vishenosik/web-tools/api"
+)
+
+type Silences interface {
+	CreateSilence(ctx context.Context, sil *models.Silence) (*models.Silence, error)
+	Silences(ctx context.Context, filter models.SilencesFilter) (models.Silences, error)
+	DeleteSilence(ctx context.Context, id string) error
+}
+
+type silencesAPI struct {
+	log     *slog.Logger
+	service Silences
+}
+
+type server = *silencesAPI
+
+func NewSilencesServer(
+	log *slog.Logger,
+	service Silences,
+) *silencesAPI {
+
+	return &silencesAPI{
+		log:     log,
+		service: service,
+	}
+
+}
+
+func (srv server) Routers(router chi.Router) {
+	router.Route(api.ApiV1("/silences"), func(r chi.Router) {
+		r.Get("/", srv.listSilences())
+		r.Post("/", srv.createSilence())
+		r.Delete("/{id}", srv.deleteSilence())
+	})
+}
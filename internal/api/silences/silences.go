@@ -0,0 +1,96 @@
+package silences
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/pkg/errors"
+	"github.com/vishenosik/CherryWatch/internal/api/models"
+	serviceModels "github.com/vishenosik/CherryWatch/internal/services/models"
+	"github.com/vishenosik/CherryWatch/pkg/httpjson"
+	attrs "github.com/vishenosik/web-tools/log"
+)
+
+// listSilences returns silences ordered by start,
+// expired ones are skipped unless the all query parameter is true.
+func (srv server) listSilences() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		filter := serviceModels.SilencesFilter{EndsAfter: time.Now()}
+
+		if all := r.URL.Query().Get("all"); all != "" {
+			includeExpired, err := strconv.ParseBool(all)
+			if err != nil {
+				http.Error(w, "all must be a boolean", http.StatusBadRequest)
+				return
+			}
+			if includeExpired {
+				filter.EndsAfter = time.Time{}
+			}
+		}
+
+		silences, err := srv.service.Silences(r.Context(), filter)
+		if err != nil {
+			srv.writeError(w, err)
+			return
+		}
+
+		writeJSON(w, http.StatusOK, models.FromServiceSilences(silences))
+	}
+}
+
+func (srv server) createSilence() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		request, err := httpjson.Decode[models.Silence](r)
+		if err != nil {
+			http.Error(w, "failed to decode request body", http.StatusBadRequest)
+			return
+		}
+
+		sil, err := srv.service.CreateSilence(r.Context(), models.ToServiceSilence(request))
+		if err != nil {
+			srv.writeError(w, err)
+			return
+		}
+
+		writeJSON(w, http.StatusCreated, models.FromServiceSilence(sil))
+	}
+}
+
+func (srv server) deleteSilence() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		if err := srv.service.DeleteSilence(r.Context(), chi.URLParam(r, "id")); err != nil {
+			srv.writeError(w, err)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func (srv server) writeError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, serviceModels.ErrNotFound):
+		http.Error(w, "silence not found", http.StatusNotFound)
+	case errors.Is(err, serviceModels.ErrInvalidSilence):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	default:
+		srv.log.Error("silences request failed", attrs.Error(err))
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, response any) {
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		http.Error(w, "failed to encode response", http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,212 @@
+package statuspage
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"mime"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/pkg/errors"
+	"github.com/vishenosik/CherryWatch/internal/api/models"
+	serviceModels "github.com/vishenosik/CherryWatch/internal/services/models"
+	attrs "github.com/vishenosik/web-tools/log"
+	"github.com/yuin/goldmark"
+)
+
+var funcs = template.FuncMap{
+	"headline":   headline,
+	"statusText": statusText,
+	"percent":    percent,
+	"barClass":   barClass,
+	"datetime":   datetime,
+	"markdown":   markdown,
+	"stateText":  stateText,
+}
+
+// statusPage renders the page as HTML,
+// or as JSON for the json format query parameter or Accept header.
+func (srv server) statusPage() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		lookup := serviceModels.StatusPageLookup{
+			Slug:  chi.URLParam(r, "slug"),
+			Host:  host(r),
+			Token: token(r),
+		}
+
+		page, err := srv.service.StatusPage(r.Context(), lookup)
+		if err != nil {
+			srv.writeError(w, err)
+			return
+		}
+
+		response := models.FromServiceStatusPage(page)
+
+		// the page state changes with every check, private pages are not shared by proxies
+		if lookup.Token != "" {
+			w.Header().Set("Cache-Control", "private, max-age=30")
+		} else {
+			w.Header().Set("Cache-Control", "public, max-age=30")
+		}
+
+		if wantsJSON(r) {
+			w.Header().Set("Content-Type", "application/json")
+			if err := json.NewEncoder(w).Encode(response); err != nil {
+				http.Error(w, "failed to encode response", http.StatusInternalServerError)
+			}
+			return
+		}
+
+		var buf bytes.Buffer
+		if err := srv.template.Execute(&buf, pageView{StatusPage: response, JSONLink: jsonLink(lookup.Token)}); err != nil {
+			srv.log.Error("failed to render status page", attrs.Error(err))
+			http.Error(w, "Internal server error", http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		_, _ = buf.WriteTo(w)
+	}
+}
+
+// pageView is the rendered page with links keeping the access token.
+type pageView struct {
+	models.StatusPage
+	JSONLink string
+}
+
+func (srv server) writeError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, serviceModels.ErrNotFound):
+		http.Error(w, "status page not found", http.StatusNotFound)
+	case errors.Is(err, serviceModels.ErrUnauthenticated):
+		http.Error(w, "status page token required", http.StatusUnauthorized)
+	default:
+		srv.log.Error("failed to build status page", attrs.Error(err))
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+	}
+}
+
+// host returns the lowercased request host without port.
+func host(r *http.Request) string {
+	name := r.Host
+	if h, _, err := net.SplitHostPort(name); err == nil {
+		name = h
+	}
+	return strings.ToLower(name)
+}
+
+// token returns the private page token from the token query parameter or bearer authorization.
+func token(r *http.Request) string {
+	if token := r.URL.Query().Get("token"); token != "" {
+		return token
+	}
+	if token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer "); ok {
+		return token
+	}
+	return ""
+}
+
+func jsonLink(token string) string {
+	query := url.Values{"format": {models.FormatJSON}}
+	if token != "" {
+		query.Set("token", token)
+	}
+	return "?" + query.Encode()
+}
+
+func wantsJSON(r *http.Request) bool {
+	if r.URL.Query().Get("format") == models.FormatJSON {
+		return true
+	}
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Accept"))
+	return mediaType == "application/json"
+}
+
+func headline(status string) string {
+	switch serviceModels.Status(status) {
+	case serviceModels.StatusMaintenance:
+		return "Scheduled maintenance in progress"
+	case serviceModels.StatusDegraded:
+		return "Some systems are degraded"
+	case serviceModels.StatusOutage:
+		return "Some systems are down"
+	}
+	return "All systems operational"
+}
+
+func statusText(status string) string {
+	switch serviceModels.Status(status) {
+	case serviceModels.StatusMaintenance:
+		return "Under maintenance"
+	case serviceModels.StatusDegraded:
+		return "Degraded performance"
+	case serviceModels.StatusOutage:
+		return "Outage"
+	}
+	return "Operational"
+}
+
+func percent(uptime *float64) string {
+	if uptime == nil {
+		return "no data"
+	}
+	return fmt.Sprintf("%.2f%%", *uptime)
+}
+
+// barClass colors the uptime bar of a day.
+func barClass(day models.UptimeDay) string {
+	switch {
+	case day.Uptime == nil:
+		return ""
+	case *day.Uptime >= 99.9:
+		return "bg-operational"
+	case *day.Uptime >= 95:
+		return "bg-degraded"
+	}
+	return "bg-outage"
+}
+
+func datetime(t any) string {
+	const layout = "Jan 2, 15:04 MST"
+	switch t := t.(type) {
+	case time.Time:
+		return t.UTC().Format(layout)
+	case *time.Time:
+		if t != nil {
+			return t.UTC().Format(layout)
+		}
+	}
+	return ""
+}
+
+// markdown renders operator text as HTML.
+// Raw HTML and dangerous links are dropped by the renderer defaults.
+func markdown(text string) template.HTML {
+	var buf bytes.Buffer
+	if err := goldmark.Convert([]byte(text), &buf); err != nil {
+		return template.HTML(template.HTMLEscapeString(text))
+	}
+	return template.HTML(buf.String())
+}
+
+func stateText(state string) string {
+	switch serviceModels.IncidentState(state) {
+	case serviceModels.IncidentInvestigating:
+		return "Investigating"
+	case serviceModels.IncidentIdentified:
+		return "Identified"
+	case serviceModels.IncidentMonitoring:
+		return "Monitoring"
+	case serviceModels.IncidentResolvedState:
+		return "Resolved"
+	}
+	return state
+}
@@ -0,0 +1,156 @@
+package statuspage
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vishenosik/CherryWatch/internal/api/models"
+	serviceModels "github.com/vishenosik/CherryWatch/internal/services/models"
+)
+
+type statusPageMock struct {
+	page   *serviceModels.StatusPage
+	lookup serviceModels.StatusPageLookup
+}
+
+func (spm *statusPageMock) StatusPage(_ context.Context, lookup serviceModels.StatusPageLookup) (*serviceModels.StatusPage, error) {
+	spm.lookup = lookup
+	if lookup.Token == "wrong" {
+		return nil, serviceModels.ErrUnauthenticated
+	}
+	return spm.page, nil
+}
+
+func Test_statusPage(t *testing.T) {
+
+	now := time.Now()
+
+	service := &statusPageMock{page: &serviceModels.StatusPage{
+		Title:  "Acme <status>",
+		Status: serviceModels.StatusOutage,
+		Components: []*serviceModels.Component{{
+			Name:   "API",
+			Status: serviceModels.StatusOutage,
+			Endpoints: []*serviceModels.ComponentEndpoint{
+				{ID: "1", ServiceName: "api", Status: serviceModels.StatusOutage},
+			},
+			History: []*serviceModels.DailyChecks{
+				{Day: serviceModels.Day(now).AddDate(0, 0, -1)},
+				{Day: serviceModels.Day(now), Checks: 4, Failures: 1},
+			},
+			Total: serviceModels.DailyChecks{Checks: 4, Failures: 1},
+		}},
+		Incidents: []*serviceModels.StatusIncident{{
+			Incident:    &serviceModels.Incident{ID: "1", EndpointID: "1", Cause: "dial tcp 10.0.0.1:443", OpenedAt: now},
+			ServiceName: "api",
+			Component:   "API",
+			Updates: []*serviceModels.IncidentUpdate{{
+				ID:        "1",
+				State:     serviceModels.IncidentIdentified,
+				Body:      "The **database** is overloaded <script>alert(1)</script>",
+				Author:    "user:1",
+				CreatedAt: now,
+			}},
+		}},
+		Maintenances: serviceModels.Maintenances{
+			{ID: "1", Title: "Database upgrade", StartsAt: now, EndsAt: now.Add(time.Hour)},
+		},
+		Days:      2,
+		UpdatedAt: now,
+	}}
+
+	router := chi.NewRouter()
+	NewStatusPageServer(slog.New(slog.NewTextHandler(io.Discard, nil)), service).Routers(router)
+
+	t.Run("html", func(t *testing.T) {
+
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/status", nil))
+		require.Equal(t, http.StatusOK, rec.Code)
+
+		body := rec.Body.String()
+		assert.Equal(t, "text/html; charset=utf-8", rec.Header().Get("Content-Type"))
+		assert.Contains(t, body, "Acme &lt;status&gt;")
+		assert.Contains(t, body, "Some systems are down")
+		assert.Contains(t, body, "Database upgrade")
+		assert.Contains(t, body, "75.00% uptime")
+		assert.Contains(t, body, "bar bg-outage")
+		assert.Contains(t, body, "Identified")
+		assert.Contains(t, body, "<strong>database</strong>")
+		assert.NotContains(t, body, "<script>")
+		// failure causes may reveal internals
+		assert.NotContains(t, body, "10.0.0.1")
+	})
+
+	t.Run("json", func(t *testing.T) {
+
+		req := httptest.NewRequest(http.MethodGet, "/status", nil)
+		req.Header.Set("Accept", "application/json")
+
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		require.Equal(t, http.StatusOK, rec.Code)
+
+		var page models.StatusPage
+		require.NoError(t, json.NewDecoder(rec.Body).Decode(&page))
+
+		assert.Equal(t, "outage", page.Status)
+		require.Len(t, page.Components, 1)
+		require.Len(t, page.Components[0].History, 2)
+		assert.Nil(t, page.Components[0].History[0].Uptime)
+		assert.InDelta(t, 75, *page.Components[0].Uptime, 0.001)
+		require.Len(t, page.Incidents, 1)
+		require.Len(t, page.Incidents[0].Updates, 1)
+		assert.Equal(t, "identified", page.Incidents[0].Updates[0].State)
+		assert.NotContains(t, rec.Body.String(), "10.0.0.1")
+		// authors are internal names
+		assert.NotContains(t, rec.Body.String(), "user:1")
+	})
+}
+
+func Test_statusPage_lookup(t *testing.T) {
+
+	service := &statusPageMock{page: &serviceModels.StatusPage{Title: "Team A", Status: serviceModels.StatusOperational}}
+
+	router := chi.NewRouter()
+	NewStatusPageServer(slog.New(slog.NewTextHandler(io.Discard, nil)), service).Routers(router)
+
+	t.Run("slug and token", func(t *testing.T) {
+
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "http://Status.Example:8080/status/team-a?token=secret", nil))
+		require.Equal(t, http.StatusOK, rec.Code)
+
+		assert.Equal(t, serviceModels.StatusPageLookup{Slug: "team-a", Host: "status.example", Token: "secret"}, service.lookup)
+		assert.Equal(t, "private, max-age=30", rec.Header().Get("Cache-Control"))
+		assert.Contains(t, rec.Body.String(), `href="?format=json&amp;token=secret"`)
+	})
+
+	t.Run("host bound root", func(t *testing.T) {
+
+		req := httptest.NewRequest(http.MethodGet, "http://status.example/", nil)
+		req.Header.Set("Authorization", "Bearer secret")
+
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		require.Equal(t, http.StatusOK, rec.Code)
+
+		assert.Equal(t, serviceModels.StatusPageLookup{Host: "status.example", Token: "secret"}, service.lookup)
+	})
+
+	t.Run("wrong token", func(t *testing.T) {
+
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/status/team-a?token=wrong", nil))
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	})
+}
@@ -0,0 +1,44 @@
+package statuspage
+
+import (
+	"context"
+	"html/template"
+	"log/slog"
+
+	"github.com/go-chi/chi/v5"
+	embed "github.com/vishenosik/CherryWatch"
+	"github.com/vishenosik/CherryWatch/internal/services/models"
+)
+
+type StatusPage interface {
+	StatusPage(ctx context.Context, lookup models.StatusPageLookup) (*models.StatusPage, error)
+}
+
+type statusPageAPI struct {
+	log      *slog.Logger
+	service  StatusPage
+	template *template.Template
+}
+
+type server = *statusPageAPI
+
+func NewStatusPageServer(
+	log *slog.Logger,
+	service StatusPage,
+) *statusPageAPI {
+
+	return &statusPageAPI{
+		log:      log,
+		service:  service,
+		template: template.Must(template.New("page.html").Funcs(funcs).ParseFS(embed.Templates, "templates/statuspage/*.html")),
+	}
+
+}
+
+// Routers registers status pages, they are meant to be served publicly.
+// The root serves the page bound to the request host.
+func (srv server) Routers(router chi.Router) {
+	router.Get("/", srv.statusPage())
+	router.Get("/status", srv.statusPage())
+	router.Get("/status/{slug}", srv.statusPage())
+}
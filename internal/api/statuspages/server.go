@@ -0,0 +1,51 @@
+package statuspages
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/vishenosik/CherryWatch/internal/api/authentication"
+	"github.com/vishenosik/CherryWatch/internal/services/models"
+	"github.com/vishenosik/web-tools/api"
+)
+
+type StatusPages interface {
+	CreateStatusPage(ctx context.Context, page *models.StatusPageConfig) (*models.StatusPageConfig, error)
+	UpdateStatusPage(ctx context.Context, page *models.StatusPageConfig, rotateToken bool) (*models.StatusPageConfig, error)
+	StatusPageConfig(ctx context.Context, id string) (*models.StatusPageConfig, error)
+	StatusPageConfigs(ctx context.Context) (models.StatusPageConfigs, error)
+	DeleteStatusPage(ctx context.Context, id string) error
+}
+
+type statusPagesAPI struct {
+	log     *slog.Logger
+	service StatusPages
+}
+
+type server = *statusPagesAPI
+
+func NewStatusPagesServer(
+	log *slog.Logger,
+	service StatusPages,
+) *statusPagesAPI {
+
+	return &statusPagesAPI{
+		log:     log,
+		service: service,
+	}
+
+}
+
+// Routers registers status pages management routes,
+// editors only since private page tokens are returned.
+func (srv server) Routers(router chi.Router) {
+	router.Route(api.ApiV1("/status-pages"), func(r chi.Router) {
+		r.Use(authentication.RequireRole(models.RoleEditor))
+		r.Get("/", srv.listStatusPages())
+		r.Post("/", srv.createStatusPage())
+		r.Get("/{id}", srv.getStatusPage())
+		r.Put("/{id}", srv.updateStatusPage())
+		r.Delete("/{id}", srv.deleteStatusPage())
+	})
+}
@@ -0,0 +1,127 @@
+package statuspages
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/pkg/errors"
+	"github.com/vishenosik/CherryWatch/internal/api/models"
+	serviceModels "github.com/vishenosik/CherryWatch/internal/services/models"
+	"github.com/vishenosik/CherryWatch/pkg/httpjson"
+	attrs "github.com/vishenosik/web-tools/log"
+)
+
+func (srv server) listStatusPages() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		pages, err := srv.service.StatusPageConfigs(r.Context())
+		if err != nil {
+			srv.writeError(w, err)
+			return
+		}
+
+		writeJSON(w, http.StatusOK, models.FromServiceStatusPageConfigs(pages))
+	}
+}
+
+func (srv server) createStatusPage() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		request, err := httpjson.Decode[models.StatusPageConfig](r)
+		if err != nil {
+			http.Error(w, "failed to decode request body", http.StatusBadRequest)
+			return
+		}
+
+		page, err := srv.service.CreateStatusPage(r.Context(), models.ToServiceStatusPageConfig(request))
+		if err != nil {
+			srv.writeError(w, err)
+			return
+		}
+
+		writeJSON(w, http.StatusCreated, models.FromServiceStatusPageConfig(page))
+	}
+}
+
+func (srv server) getStatusPage() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		page, err := srv.service.StatusPageConfig(r.Context(), chi.URLParam(r, "id"))
+		if err != nil {
+			srv.writeError(w, err)
+			return
+		}
+
+		writeJSON(w, http.StatusOK, models.FromServiceStatusPageConfig(page))
+	}
+}
+
+// updateStatusPage replaces a status page,
+// private page token is regenerated if the rotate_token query parameter is true.
+func (srv server) updateStatusPage() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		var rotateToken bool
+		if rotate := r.URL.Query().Get("rotate_token"); rotate != "" {
+			var err error
+			if rotateToken, err = strconv.ParseBool(rotate); err != nil {
+				http.Error(w, "rotate_token must be a boolean", http.StatusBadRequest)
+				return
+			}
+		}
+
+		request, err := httpjson.Decode[models.StatusPageConfig](r)
+		if err != nil {
+			http.Error(w, "failed to decode request body", http.StatusBadRequest)
+			return
+		}
+
+		request.ID = chi.URLParam(r, "id")
+
+		page, err := srv.service.UpdateStatusPage(r.Context(), models.ToServiceStatusPageConfig(request), rotateToken)
+		if err != nil {
+			srv.writeError(w, err)
+			return
+		}
+
+		writeJSON(w, http.StatusOK, models.FromServiceStatusPageConfig(page))
+	}
+}
+
+func (srv server) deleteStatusPage() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		if err := srv.service.DeleteStatusPage(r.Context(), chi.URLParam(r, "id")); err != nil {
+			srv.writeError(w, err)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func (srv server) writeError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, serviceModels.ErrNotFound):
+		http.Error(w, "status page not found", http.StatusNotFound)
+	case errors.Is(err, serviceModels.ErrInvalidStatusPage):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, serviceModels.ErrStatusPageExists):
+		http.Error(w, "status page slug or host is already taken", http.StatusConflict)
+	default:
+		srv.log.Error("status pages request failed", attrs.Error(err))
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, response any) {
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		http.Error(w, "failed to encode response", http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,46 @@
+package subscriptions
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/vishenosik/CherryWatch/internal/services/models"
+)
+
+type Subscriptions interface {
+	Subscribe(ctx context.Context, lookup models.StatusPageLookup, sub *models.Subscription, client string) (*models.Subscription, error)
+	Confirm(ctx context.Context, token string) (*models.Subscription, error)
+	Unsubscribe(ctx context.Context, token string) error
+}
+
+type subscriptionsAPI struct {
+	log     *slog.Logger
+	service Subscriptions
+}
+
+type server = *subscriptionsAPI
+
+func NewSubscriptionsServer(
+	log *slog.Logger,
+	service Subscriptions,
+) *subscriptionsAPI {
+
+	return &subscriptionsAPI{
+		log:     log,
+		service: service,
+	}
+
+}
+
+// Routers registers status page subscription routes, they are meant to be served publicly.
+// Confirmation and unsubscribe links are opened from emails, so GET is accepted as well.
+func (srv server) Routers(router chi.Router) {
+	router.Route("/status/{slug}/subscriptions", func(r chi.Router) {
+		r.Post("/", srv.subscribe())
+		r.Get("/confirm", srv.confirm())
+		r.Post("/confirm", srv.confirm())
+		r.Get("/unsubscribe", srv.unsubscribe())
+		r.Post("/unsubscribe", srv.unsubscribe())
+	})
+}
@@ -0,0 +1,123 @@
+package subscriptions
+
+import (
+	"encoding/json"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/pkg/errors"
+	"github.com/vishenosik/CherryWatch/internal/api/models"
+	serviceModels "github.com/vishenosik/CherryWatch/internal/services/models"
+	"github.com/vishenosik/CherryWatch/pkg/httpjson"
+	attrs "github.com/vishenosik/web-tools/log"
+)
+
+// subscribe creates a subscription to the page and sends its confirmation link to the target.
+// Private pages need their token in the token query parameter or bearer authorization.
+func (srv server) subscribe() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		request, err := httpjson.Decode[models.Subscription](r)
+		if err != nil {
+			http.Error(w, "failed to decode request body", http.StatusBadRequest)
+			return
+		}
+
+		lookup := serviceModels.StatusPageLookup{
+			Slug:  chi.URLParam(r, "slug"),
+			Token: pageToken(r),
+		}
+
+		sub, err := srv.service.Subscribe(r.Context(), lookup, models.ToServiceSubscription(request), clientAddr(r))
+		if err != nil {
+			srv.writeError(w, err)
+			return
+		}
+
+		writeJSON(w, http.StatusAccepted, models.FromServiceSubscription(sub))
+	}
+}
+
+func (srv server) confirm() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		if _, err := srv.service.Confirm(r.Context(), r.URL.Query().Get("token")); err != nil {
+			srv.writeError(w, err)
+			return
+		}
+
+		writeText(w, "Your subscription is confirmed.")
+	}
+}
+
+func (srv server) unsubscribe() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		if err := srv.service.Unsubscribe(r.Context(), r.URL.Query().Get("token")); err != nil {
+			srv.writeError(w, err)
+			return
+		}
+
+		writeText(w, "You are unsubscribed.")
+	}
+}
+
+func (srv server) writeError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, serviceModels.ErrNotFound):
+		http.Error(w, "not found", http.StatusNotFound)
+	case errors.Is(err, serviceModels.ErrUnauthenticated):
+		http.Error(w, "status page token required", http.StatusUnauthorized)
+	case errors.Is(err, serviceModels.ErrInvalidSubscription):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, serviceModels.ErrUnsupportedChannel):
+		http.Error(w, "subscription type is not available", http.StatusBadRequest)
+	case errors.Is(err, serviceModels.ErrSubscriptionExists):
+		http.Error(w, "already subscribed", http.StatusConflict)
+	case errors.Is(err, serviceModels.ErrTooManySubscriptions):
+		http.Error(w, "too many requests, try again later", http.StatusTooManyRequests)
+	case errors.Is(err, serviceModels.ErrNotDelivered):
+		http.Error(w, "failed to deliver the confirmation", http.StatusBadGateway)
+	default:
+		srv.log.Error("subscriptions request failed", attrs.Error(err))
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+	}
+}
+
+// pageToken returns the private page token from the token query parameter or bearer authorization.
+func pageToken(r *http.Request) string {
+	if token := r.URL.Query().Get("token"); token != "" {
+		return token
+	}
+	if token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer "); ok {
+		return token
+	}
+	return ""
+}
+
+// clientAddr returns the IP address of the client, subscribe requests are limited by it.
+func clientAddr(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+func writeText(w http.ResponseWriter, text string) {
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-store")
+	_, _ = w.Write([]byte(text + "\n"))
+}
+
+func writeJSON(w http.ResponseWriter, status int, response any) {
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		http.Error(w, "failed to encode response", http.StatusInternalServerError)
+	}
+}
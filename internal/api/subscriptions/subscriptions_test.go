@@ -0,0 +1,106 @@
+package subscriptions
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vishenosik/CherryWatch/internal/api/models"
+	serviceModels "github.com/vishenosik/CherryWatch/internal/services/models"
+)
+
+type subscriptionsMock struct {
+	lookup serviceModels.StatusPageLookup
+	client string
+	sub    *serviceModels.Subscription
+}
+
+func (sm *subscriptionsMock) Subscribe(
+	_ context.Context,
+	lookup serviceModels.StatusPageLookup,
+	sub *serviceModels.Subscription,
+	client string,
+) (*serviceModels.Subscription, error) {
+	sm.lookup = lookup
+	sm.client = client
+	if lookup.Token != "secret" {
+		return nil, serviceModels.ErrUnauthenticated
+	}
+	sub.ID = "1"
+	sub.Token = "link-token"
+	sm.sub = sub
+	return sub, nil
+}
+
+func (sm *subscriptionsMock) Confirm(_ context.Context, token string) (*serviceModels.Subscription, error) {
+	if token != "link-token" {
+		return nil, serviceModels.ErrNotFound
+	}
+	return sm.sub, nil
+}
+
+func (sm *subscriptionsMock) Unsubscribe(_ context.Context, token string) error {
+	if token != "link-token" {
+		return serviceModels.ErrNotFound
+	}
+	return nil
+}
+
+func Test_subscriptions(t *testing.T) {
+
+	service := &subscriptionsMock{}
+
+	router := chi.NewRouter()
+	NewSubscriptionsServer(slog.New(slog.NewTextHandler(io.Discard, nil)), service).Routers(router)
+
+	body := `{"type": "email", "target": "ops@example.com", "components": ["API"]}`
+
+	t.Run("subscribe", func(t *testing.T) {
+
+		req := httptest.NewRequest(http.MethodPost, "/status/team-a/subscriptions", strings.NewReader(body))
+		req.Header.Set("Authorization", "Bearer secret")
+
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		require.Equal(t, http.StatusAccepted, rec.Code)
+
+		assert.Equal(t, "team-a", service.lookup.Slug)
+		assert.Equal(t, "192.0.2.1", service.client)
+
+		var sub models.Subscription
+		require.NoError(t, json.NewDecoder(rec.Body).Decode(&sub))
+		assert.Equal(t, models.Subscription{ID: "1", Type: "email", Target: "ops@example.com", Components: []string{"API"}}, sub)
+		// the link token proves control over the target, it's never returned
+		assert.NotContains(t, rec.Body.String(), "link-token")
+	})
+
+	t.Run("private page without token", func(t *testing.T) {
+
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/status/team-a/subscriptions", strings.NewReader(body)))
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	})
+
+	t.Run("confirm and unsubscribe", func(t *testing.T) {
+
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/status/team-a/subscriptions/confirm?token=link-token", nil))
+		assert.Equal(t, http.StatusOK, rec.Code)
+
+		rec = httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/status/team-a/subscriptions/unsubscribe?token=link-token", nil))
+		assert.Equal(t, http.StatusOK, rec.Code)
+
+		rec = httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/status/team-a/subscriptions/unsubscribe?token=guess", nil))
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+}
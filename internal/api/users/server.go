@@ -0,0 +1,50 @@
+package users
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/vishenosik/CherryWatch/internal/api/authentication"
+	"github.com/vishenosik/CherryWatch/internal/services/models"
+	"github.com/vishenosik/web-tools/api"
+)
+
+type Users interface {
+	CreateUser(ctx context.Context, user *models.User, password string) (*models.User, error)
+	UpdateUser(ctx context.Context, user *models.User, password string) (*models.User, error)
+	User(ctx context.Context, id string) (*models.User, error)
+	Users(ctx context.Context) (models.Users, error)
+	DeleteUser(ctx context.Context, id string) error
+}
+
+type usersAPI struct {
+	log     *slog.Logger
+	service Users
+}
+
+type server = *usersAPI
+
+func NewUsersServer(
+	log *slog.Logger,
+	service Users,
+) *usersAPI {
+
+	return &usersAPI{
+		log:     log,
+		service: service,
+	}
+
+}
+
+// Routers registers user management routes, available to admins only.
+func (srv server) Routers(router chi.Router) {
+	router.Route(api.ApiV1("/users"), func(r chi.Router) {
+		r.Use(authentication.RequireRole(models.RoleAdmin))
+		r.Get("/", srv.listUsers())
+		r.Post("/", srv.createUser())
+		r.Get("/{id}", srv.getUser())
+		r.Put("/{id}", srv.updateUser())
+		r.Delete("/{id}", srv.deleteUser())
+	})
+}
@@ -0,0 +1,113 @@
+package users
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/pkg/errors"
+	"github.com/vishenosik/CherryWatch/internal/api/models"
+	serviceModels "github.com/vishenosik/CherryWatch/internal/services/models"
+	"github.com/vishenosik/CherryWatch/pkg/httpjson"
+)
+
+func (srv server) listUsers() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		users, err := srv.service.Users(r.Context())
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+
+		writeJSON(w, http.StatusOK, models.FromServiceUsers(users))
+	}
+}
+
+func (srv server) getUser() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		user, err := srv.service.User(r.Context(), chi.URLParam(r, "id"))
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+
+		writeJSON(w, http.StatusOK, models.FromServiceUser(user))
+	}
+}
+
+func (srv server) createUser() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		request, err := httpjson.Decode[models.User](r)
+		if err != nil {
+			http.Error(w, "failed to decode request body", http.StatusBadRequest)
+			return
+		}
+
+		user, err := srv.service.CreateUser(r.Context(), models.ToServiceUser(request), request.Password)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+
+		writeJSON(w, http.StatusCreated, models.FromServiceUser(user))
+	}
+}
+
+func (srv server) updateUser() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		request, err := httpjson.Decode[models.User](r)
+		if err != nil {
+			http.Error(w, "failed to decode request body", http.StatusBadRequest)
+			return
+		}
+
+		request.ID = chi.URLParam(r, "id")
+
+		user, err := srv.service.UpdateUser(r.Context(), models.ToServiceUser(request), request.Password)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+
+		writeJSON(w, http.StatusOK, models.FromServiceUser(user))
+	}
+}
+
+func (srv server) deleteUser() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		if err := srv.service.DeleteUser(r.Context(), chi.URLParam(r, "id")); err != nil {
+			writeError(w, err)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, serviceModels.ErrNotFound):
+		http.Error(w, "user not found", http.StatusNotFound)
+	case errors.Is(err, serviceModels.ErrInvalidUser):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, serviceModels.ErrUserExists):
+		http.Error(w, "user exists already", http.StatusConflict)
+	default:
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, response any) {
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		http.Error(w, "failed to encode response", http.StatusInternalServerError)
+	}
+}
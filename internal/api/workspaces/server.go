@@ -0,0 +1,47 @@
+package workspaces
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/vishenosik/CherryWatch/internal/api/authentication"
+	"github.com/vishenosik/CherryWatch/internal/services/models"
+	"github.com/vishenosik/web-tools/api"
+)
+
+type Workspaces interface {
+	CreateWorkspace(ctx context.Context, ws *models.Workspace) (*models.Workspace, string, error)
+	Workspace(ctx context.Context, id string) (*models.Workspace, error)
+	Workspaces(ctx context.Context) (models.Workspaces, error)
+}
+
+type workspacesAPI struct {
+	log     *slog.Logger
+	service Workspaces
+}
+
+type server = *workspacesAPI
+
+func NewWorkspacesServer(
+	log *slog.Logger,
+	service Workspaces,
+) *workspacesAPI {
+
+	return &workspacesAPI{
+		log:     log,
+		service: service,
+	}
+
+}
+
+// Routers registers workspace management routes, available to admins only.
+// Workspaces are created by admins of the default workspace.
+func (srv server) Routers(router chi.Router) {
+	router.Route(api.ApiV1("/workspaces"), func(r chi.Router) {
+		r.Use(authentication.RequireRole(models.RoleAdmin))
+		r.Get("/", srv.listWorkspaces())
+		r.Post("/", srv.createWorkspace())
+		r.Get("/{id}", srv.getWorkspace())
+	})
+}
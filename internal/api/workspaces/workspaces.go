@@ -0,0 +1,85 @@
+package workspaces
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/pkg/errors"
+	"github.com/vishenosik/CherryWatch/internal/api/models"
+	serviceModels "github.com/vishenosik/CherryWatch/internal/services/models"
+	"github.com/vishenosik/CherryWatch/pkg/httpjson"
+)
+
+func (srv server) listWorkspaces() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		workspaces, err := srv.service.Workspaces(r.Context())
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+
+		writeJSON(w, http.StatusOK, models.FromServiceWorkspaces(workspaces))
+	}
+}
+
+func (srv server) getWorkspace() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		ws, err := srv.service.Workspace(r.Context(), chi.URLParam(r, "id"))
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+
+		writeJSON(w, http.StatusOK, models.FromServiceWorkspace(ws))
+	}
+}
+
+func (srv server) createWorkspace() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		request, err := httpjson.Decode[models.Workspace](r)
+		if err != nil {
+			http.Error(w, "failed to decode request body", http.StatusBadRequest)
+			return
+		}
+
+		ws, apiKey, err := srv.service.CreateWorkspace(r.Context(), models.ToServiceWorkspace(request))
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+
+		response := models.FromServiceWorkspace(ws)
+		response.APIKey = apiKey
+
+		writeJSON(w, http.StatusCreated, response)
+	}
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, serviceModels.ErrNotFound):
+		http.Error(w, "workspace not found", http.StatusNotFound)
+	case errors.Is(err, serviceModels.ErrWorkspaceName):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, serviceModels.ErrWorkspaceExists):
+		http.Error(w, "workspace exists already", http.StatusConflict)
+	case errors.Is(err, serviceModels.ErrForbidden):
+		http.Error(w, "forbidden", http.StatusForbidden)
+	default:
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, response any) {
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		http.Error(w, "failed to encode response", http.StatusInternalServerError)
+	}
+}
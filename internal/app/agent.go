@@ -0,0 +1,84 @@
+package app
+
+import (
+	"crypto/tls"
+	"fmt"
+	"os"
+
+	"github.com/pkg/errors"
+	"github.com/vishenosik/CherryWatch/internal/agent"
+	grpcAuthentication "github.com/vishenosik/CherryWatch/internal/api/grpc/authentication"
+	cherrywatchGrpc "github.com/vishenosik/CherryWatch/internal/api/grpc/cherrywatch"
+	appctx "github.com/vishenosik/CherryWatch/internal/app/context"
+	"github.com/vishenosik/CherryWatch/internal/services/checks"
+	"github.com/vishenosik/CherryWatch/internal/services/scheduler"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+func MustInitAgent() *App {
+	app, err := NewAgentApp()
+	if err != nil {
+		panic(fmt.Sprintf("failed to create agent %s", err))
+	}
+	return app
+}
+
+// NewAgentApp creates a probe agent checking endpoints assigned to its location
+// by the central server.
+func NewAgentApp() (*App, error) {
+
+	const op = "app.NewAgentApp"
+
+	conf, err := appctx.LoadAgentConfig()
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	log := appctx.NewLogger(conf.Env)
+
+	if conf.Name == "" {
+		if conf.Name, err = os.Hostname(); err != nil {
+			return nil, errors.Wrap(err, op)
+		}
+	}
+
+	transport := insecure.NewCredentials()
+	if conf.TLS {
+		transport = credentials.NewTLS(&tls.Config{MinVersion: tls.VersionTLS12})
+	}
+
+	conn, err := grpc.NewClient(conf.Server,
+		grpc.WithTransportCredentials(transport),
+		grpc.WithPerRPCCredentials(grpcAuthentication.APIKey{Key: conf.APIKey, Insecure: !conf.TLS}),
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	checker := checks.NewChecks(
+		nil,
+		checks.WithExecChecks(conf.Checks.ExecEnabled),
+		checks.WithSQLChecks(conf.Checks.SQLEnabled),
+	)
+
+	probe := agent.NewAgent(
+		log,
+		cherrywatchGrpc.NewAgentClient(conn),
+		checker,
+		agent.Config{
+			Name:          conf.Name,
+			Location:      conf.Location,
+			SyncInterval:  conf.SyncInterval,
+			RetryInterval: conf.RetryInterval,
+			BufferSize:    conf.BufferSize,
+			Scheduler: scheduler.Config{
+				Workers: conf.Scheduler.Workers,
+				Tick:    conf.Scheduler.Tick,
+			},
+		},
+	)
+
+	return newApp(log, probe), nil
+}
@@ -4,12 +4,36 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
-
+	"net/http"
+	"os"
+
+	auditApi "github.com/vishenosik/CherryWatch/internal/api/audit"
+	authenticationApi "github.com/vishenosik/CherryWatch/internal/api/authentication"
+	backupApi "github.com/vishenosik/CherryWatch/internal/api/backup"
+	badgesApi "github.com/vishenosik/CherryWatch/internal/api/badges"
+	channelsApi "github.com/vishenosik/CherryWatch/internal/api/channels"
+	declarativeApi "github.com/vishenosik/CherryWatch/internal/api/declarative"
+	endpointsApi "github.com/vishenosik/CherryWatch/internal/api/endpoints"
+	eventsApi "github.com/vishenosik/CherryWatch/internal/api/events"
+	grpcAuthentication "github.com/vishenosik/CherryWatch/internal/api/grpc/authentication"
+	cherrywatchGrpc "github.com/vishenosik/CherryWatch/internal/api/grpc/cherrywatch"
+	heartbeatApi "github.com/vishenosik/CherryWatch/internal/api/heartbeat"
+	incidentsApi "github.com/vishenosik/CherryWatch/internal/api/incidents"
+	maintenancesApi "github.com/vishenosik/CherryWatch/internal/api/maintenances"
+	metricsApi "github.com/vishenosik/CherryWatch/internal/api/metrics"
+	silencesApi "github.com/vishenosik/CherryWatch/internal/api/silences"
+	statusPageApi "github.com/vishenosik/CherryWatch/internal/api/statuspage"
+	statusPagesApi "github.com/vishenosik/CherryWatch/internal/api/statuspages"
+	subscriptionsApi "github.com/vishenosik/CherryWatch/internal/api/subscriptions"
+	usersApi "github.com/vishenosik/CherryWatch/internal/api/users"
+	workspacesApi "github.com/vishenosik/CherryWatch/internal/api/workspaces"
 	grpcApp "github.com/vishenosik/CherryWatch/internal/app/grpc"
 	restApp "github.com/vishenosik/CherryWatch/internal/app/rest"
 
 	appctx "github.com/vishenosik/CherryWatch/internal/app/context"
+	"github.com/vishenosik/CherryWatch/internal/services/declarative"
 	"github.com/vishenosik/web-tools/config"
+	"google.golang.org/grpc"
 )
 
 type App struct {
@@ -39,32 +63,103 @@ func NewApp() (*App, error) {
 	conf := appContext.Config
 
 	// Stores init
-	_, err := loadSqlStore(ctx)
+	store, err := loadSqlStore(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	cache, err := loadCache(conf.Cache)
 	if err != nil {
 		return nil, err
 	}
 
+	// Services init
+	services := loadServices(ctx, store, cache)
+
+	// the key is printed outside of the logger, so that it isn't shipped with logs
+	if err := services.authentication.Bootstrap(ctx, os.Stderr); err != nil {
+		return nil, err
+	}
+
+	authenticationServer := authenticationApi.NewAuthenticationServer(log, services.authentication)
+
 	grpcServer := grpcApp.NewGrpcApp(
 		log,
 		grpcApp.Config{
 			Server: config.Server{
 				Port: conf.GrpcConfig.Port,
 			},
+			UnaryInterceptors: []grpc.UnaryServerInterceptor{
+				grpcAuthentication.UnaryInterceptor(services.authentication, cherrywatchGrpc.Roles),
+			},
+			StreamInterceptors: []grpc.StreamServerInterceptor{
+				grpcAuthentication.StreamInterceptor(services.authentication, cherrywatchGrpc.Roles),
+			},
 		},
-		// authenticationService,
+		cherrywatchGrpc.NewCherryWatchServer(log, services.endpoints, services.incidents, services.events),
+		cherrywatchGrpc.NewAgentsServer(log, services.agents),
 	)
 
+	restServices := []restApp.Service{
+		restApp.Public(authenticationServer),
+		restApp.Public(heartbeatApi.NewHeartbeatServer(log, services.heartbeat)),
+		endpointsApi.NewEndpointsServer(log, services.endpoints),
+		incidentsApi.NewIncidentsServer(log, services.incidents),
+		maintenancesApi.NewMaintenancesServer(log, services.maintenances),
+		channelsApi.NewChannelsServer(log, services.channels),
+		silencesApi.NewSilencesServer(log, services.silences),
+		usersApi.NewUsersServer(log, services.users),
+		workspacesApi.NewWorkspacesServer(log, services.workspaces),
+		auditApi.NewAuditServer(log, services.audit),
+		backupApi.NewBackupServer(log, services.backup),
+		eventsApi.NewEventsServer(log, services.events),
+		metricsApi.NewMetricsServer(log, services.metrics),
+		statusPagesApi.NewStatusPagesServer(log, services.statusPage),
+		// pages defined through the API are served even if the default one is disabled
+		restApp.Public(statusPageApi.NewStatusPageServer(log, services.statusPage)),
+		restApp.Public(subscriptionsApi.NewSubscriptionsServer(log, services.subscriptions)),
+	}
+
+	if conf.Badges.Enabled {
+		restServices = append(restServices, restApp.Public(badgesApi.NewBadgesServer(log, services.badges)))
+	}
+
 	restServer := restApp.NewRestApp(
 		ctx,
 		restApp.Config{
 			Server: config.Server{
 				Port: conf.RestConfig.Port,
 			},
+			Middlewares: []func(http.Handler) http.Handler{
+				authenticationServer.Middleware,
+			},
 		},
-		// authenticationService,
+		restServices...,
 	)
 
-	return newApp(log, grpcServer, restServer), nil
+	servers := []Server{
+		grpcServer,
+		restServer,
+		services.heartbeat,
+		services.agents,
+		services.scheduler,
+		services.subscriptions,
+		services.channels,
+	}
+
+	if conf.Declarative.Path != "" {
+		servers = append(servers, declarative.NewReconciler(
+			log,
+			declarativeApi.NewFileSource(conf.Declarative.Path),
+			services.endpoints,
+			declarative.Config{
+				Prune:  conf.Declarative.Prune,
+				DryRun: conf.Declarative.DryRun,
+			},
+		))
+	}
+
+	return newApp(log, servers...), nil
 }
 
 func newApp(
@@ -0,0 +1,46 @@
+package app
+
+import (
+	"context"
+
+	appctx "github.com/vishenosik/CherryWatch/internal/app/context"
+	"github.com/vishenosik/CherryWatch/internal/services/backup"
+	"github.com/vishenosik/CherryWatch/internal/services/models"
+	sqlstore "github.com/vishenosik/CherryWatch/internal/store/sql"
+)
+
+// Backup writes a snapshot of the configured store to dst.
+// It's safe to run next to a serving instance, monitoring isn't interrupted.
+func Backup(dst string) error {
+	return withBackupService(func(ctx context.Context, service *backup.Service) error {
+		return service.Backup(ctx, dst)
+	})
+}
+
+// Restore replaces the configured store with the backup at src once its schema is validated.
+// The server must be stopped, pending migrations are applied on its next start.
+func Restore(src string) error {
+	return withBackupService(func(ctx context.Context, service *backup.Service) error {
+		return service.Restore(ctx, src)
+	})
+}
+
+func withBackupService(run func(ctx context.Context, service *backup.Service) error) error {
+
+	ctx := appctx.SetupAppCtx()
+	appContext := appctx.AppCtx(ctx)
+
+	provider, err := loadSqlProvider(appContext.Config)
+	if err != nil {
+		return err
+	}
+
+	store := sqlstore.NewStore(provider)
+	defer store.Stop()
+
+	// the command is run by the operator of the whole instance
+	return run(
+		models.WithAllWorkspaces(ctx),
+		backup.NewBackupService(appContext.Logger, store),
+	)
+}
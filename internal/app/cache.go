@@ -0,0 +1,57 @@
+package app
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+	appctx "github.com/vishenosik/CherryWatch/internal/app/context"
+	"github.com/vishenosik/CherryWatch/internal/store/cache/providers/memory"
+	"github.com/vishenosik/CherryWatch/internal/store/cache/providers/redis"
+	storeModels "github.com/vishenosik/CherryWatch/internal/store/models"
+)
+
+const (
+	defaultCacheTTL       = time.Minute
+	defaultStatusCacheTTL = 10 * time.Second
+)
+
+var ErrCacheAddrRequired = errors.New("CACHE_REDIS_ADDR is required by the redis cache driver")
+
+// loadCache creates the cache of the configured driver, in-memory by default.
+// Nothing is cached with the none driver.
+func loadCache(config appctx.Cache) (storeModels.CacheProvider, error) {
+
+	switch config.Driver {
+	case "", "memory":
+		return memory.NewMemoryCache(config.Size), nil
+	case "redis":
+		if config.RedisAddr == "" {
+			return nil, ErrCacheAddrRequired
+		}
+		return redis.NewRedisCache(redis.Config{
+			Addr:     config.RedisAddr,
+			Password: config.RedisPassword,
+			DB:       config.RedisDB,
+		}), nil
+	case "none":
+		return nil, nil
+	}
+
+	return nil, errors.Errorf("unknown cache driver %q", config.Driver)
+}
+
+// cacheTTLs returns lifetimes of cached records and of cached status, defaulting zero ones.
+func cacheTTLs(config appctx.Cache) (records, status time.Duration) {
+
+	records, status = config.TTL, config.StatusTTL
+
+	if records <= 0 {
+		records = defaultCacheTTL
+	}
+
+	if status <= 0 {
+		status = defaultStatusCacheTTL
+	}
+
+	return records, status
+}
@@ -0,0 +1,50 @@
+package context
+
+import (
+	"log/slog"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/vishenosik/web-tools/env"
+)
+
+var (
+	ErrAgentServerRequired   = errors.New("AGENT_SERVER is required")
+	ErrAgentLocationRequired = errors.New("AGENT_LOCATION is required")
+)
+
+// AgentConfig configures a probe agent (cmd/agent) reporting to the central server.
+type AgentConfig struct {
+	Env           string        `env:"ENV" default:"dev" validate:"oneof=dev prod test" desc:"The environment in which the agent is running"`
+	Server        string        `env:"AGENT_SERVER" desc:"gRPC address (host:port) of the central server"`
+	TLS           bool          `env:"AGENT_TLS" default:"false" desc:"Connect to the central server over TLS"`
+	APIKey        string        `env:"AGENT_API_KEY" desc:"API key of an admin app, agents check endpoints of its workspace"`
+	Name          string        `env:"AGENT_NAME" desc:"Agent name unique within the workspace, the host name if empty"`
+	Location      string        `env:"AGENT_LOCATION" desc:"Location the agent checks endpoints from, endpoints list locations of their probes"`
+	SyncInterval  time.Duration `env:"AGENT_SYNC_INTERVAL" default:"30s" desc:"Period of assigned endpoints sync"`
+	RetryInterval time.Duration `env:"AGENT_RETRY_INTERVAL" default:"5s" desc:"Delay before a failed registration or report is retried"`
+	BufferSize    int           `env:"AGENT_BUFFER_SIZE" default:"1000" desc:"Number of results kept while the central server is unreachable"`
+	Checks        Checks
+	Scheduler     Scheduler
+}
+
+// LoadAgentConfig reads agent config from env.
+func LoadAgentConfig() (AgentConfig, error) {
+
+	conf := env.ReadEnv[AgentConfig]()
+
+	if conf.Server == "" {
+		return AgentConfig{}, ErrAgentServerRequired
+	}
+
+	if conf.Location == "" {
+		return AgentConfig{}, ErrAgentLocationRequired
+	}
+
+	return conf, nil
+}
+
+// NewLogger creates a logger of the environment.
+func NewLogger(env string) *slog.Logger {
+	return setupLogger(env)
+}
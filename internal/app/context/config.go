@@ -24,10 +24,22 @@ var (
 
 type Config struct {
 	Env                   string `env:"ENV" default:"dev" validate:"oneof=dev prod test" desc:"The environment in which the application is running"`
-	StorePath             string `env:"STORE_PATH" default:"./storage/CherryWatch.db" validate:"required" desc:"Path to sqlite store"`
+	StoreDriver           string `env:"STORE_DRIVER" default:"sqlite" validate:"oneof=sqlite postgres" desc:"SQL store driver: sqlite or postgres"`
+	StorePath             string `env:"STORE_PATH" default:"./storage/CherryWatch.db" desc:"Path to sqlite store"`
+	StoreDSN              string `env:"STORE_DSN" desc:"PostgreSQL connection string, URL or key=value pairs, required by the postgres driver"`
 	AuthenticationService AuthenticationService
 	GrpcConfig            GrpcServer
 	RestConfig            RestServer
+	Heartbeat             Heartbeat
+	Agents                Agents
+	Checks                Checks
+	Scheduler             Scheduler
+	Events                Events
+	Declarative           Declarative
+	StatusPage            StatusPage
+	Badges                Badges
+	Notifications         Notifications
+	Cache                 Cache
 }
 
 type RestServer struct {
@@ -38,6 +50,67 @@ type GrpcServer struct {
 	Port uint16 `env:"GRPC_PORT" default:"44844" desc:"gRPC server port"`
 }
 
+type Heartbeat struct {
+	CheckInterval time.Duration `env:"HEARTBEAT_CHECK_INTERVAL" default:"30s" desc:"Period of overdue heartbeat monitors lookup"`
+}
+
+type Agents struct {
+	TTL time.Duration `env:"AGENTS_TTL" default:"5m" desc:"Time probe agents are kept without syncing, should exceed AGENT_SYNC_INTERVAL of the agents"`
+}
+
+type Checks struct {
+	ExecEnabled bool `env:"CHECKS_EXEC_ENABLED" default:"false" desc:"Allow exec checks running local commands (security-sensitive)"`
+	SQLEnabled  bool `env:"CHECKS_SQL_ENABLED" default:"false" desc:"Allow sql checks querying databases reachable by the server (security-sensitive)"`
+}
+
+type Scheduler struct {
+	Workers int           `env:"SCHEDULER_WORKERS" default:"4" desc:"Number of concurrent checks"`
+	Tick    time.Duration `env:"SCHEDULER_TICK" default:"5s" desc:"Period of due endpoints lookup"`
+}
+
+type Events struct {
+	BufferSize int `env:"EVENTS_BUFFER_SIZE" default:"256" desc:"Number of events buffered per live subscriber"`
+}
+
+type Declarative struct {
+	Path   string `env:"DECLARATIVE_CONFIG_PATH" desc:"Path to YAML or JSON file declaring endpoints, reconciled on start and SIGHUP"`
+	Prune  bool   `env:"DECLARATIVE_PRUNE" default:"false" desc:"Delete endpoints created from the file once removed from it"`
+	DryRun bool   `env:"DECLARATIVE_DRY_RUN" default:"false" desc:"Only log changes the file would make"`
+}
+
+type StatusPage struct {
+	Enabled   bool   `env:"STATUS_PAGE_ENABLED" default:"false" desc:"Serve default public status page at /status, pages bound to the request host take precedence"`
+	Title     string `env:"STATUS_PAGE_TITLE" default:"Status" desc:"Default status page heading"`
+	Workspace string `env:"STATUS_PAGE_WORKSPACE" default:"default" desc:"Workspace the endpoints of which are shown on the default status page"`
+	Days      int    `env:"STATUS_PAGE_DAYS" default:"90" desc:"Number of days of uptime history shown on status pages"`
+}
+
+type Badges struct {
+	Enabled bool `env:"BADGES_ENABLED" default:"false" desc:"Serve public SVG badges of endpoints and endpoint groups at /api/v1/badges"`
+}
+
+type Notifications struct {
+	PublicURL           string        `env:"PUBLIC_URL" desc:"External address of the server used in links sent to subscribers, http://localhost:<REST_PORT> if empty"`
+	SMTPAddr            string        `env:"SMTP_ADDR" desc:"SMTP server host:port, email notifications are disabled if empty"`
+	SMTPUsername        string        `env:"SMTP_USERNAME" desc:"SMTP PLAIN authentication username, no authentication if empty"`
+	SMTPPassword        string        `env:"SMTP_PASSWORD" desc:"SMTP PLAIN authentication password"`
+	SMTPFrom            string        `env:"SMTP_FROM" desc:"Sender address of emails"`
+	WebhookTimeout      time.Duration `env:"WEBHOOK_TIMEOUT" default:"10s" desc:"Webhook request timeout"`
+	WebhookAllowPrivate bool          `env:"WEBHOOK_ALLOW_PRIVATE" default:"false" desc:"Allow webhooks to loopback, private and link-local addresses"`
+	SubscribePageLimit  int           `env:"SUBSCRIBE_PAGE_LIMIT" default:"100" desc:"Subscribe requests accepted per status page an hour, unlimited if 0"`
+	SubscribeIPLimit    int           `env:"SUBSCRIBE_IP_LIMIT" default:"10" desc:"Subscribe requests accepted per client IP an hour, unlimited if 0"`
+}
+
+type Cache struct {
+	Driver        string        `env:"CACHE_DRIVER" default:"memory" validate:"oneof=memory redis none" desc:"Cache of authentication lookups, status pages and badges: memory, redis (shared by instances, authentication lookups stay in memory) or none"`
+	Size          int           `env:"CACHE_SIZE" default:"10000" desc:"Number of values kept by the memory cache, authentication lookups included"`
+	TTL           time.Duration `env:"CACHE_TTL" default:"1m" desc:"Lifetime of cached apps and users"`
+	StatusTTL     time.Duration `env:"CACHE_STATUS_TTL" default:"10s" desc:"Lifetime of cached status pages and badges, endpoint status they show may be behind by it"`
+	RedisAddr     string        `env:"CACHE_REDIS_ADDR" desc:"Redis host:port, required by the redis driver"`
+	RedisPassword string        `env:"CACHE_REDIS_PASSWORD" desc:"Redis AUTH password, no authentication if empty"`
+	RedisDB       int           `env:"CACHE_REDIS_DB" default:"0" desc:"Redis database index"`
+}
+
 type AuthenticationService struct {
 	TokenTTL time.Duration `env:"AUTHENTICATION_TOKEN_TTL" default:"1h" desc:"Authentication service standart TTL"`
 }
@@ -24,38 +24,46 @@ type App struct {
 
 type Config struct {
 	Server config.Server
+	// Interceptors applied to every unary call, in order
+	UnaryInterceptors []grpc.UnaryServerInterceptor
+	// Interceptors applied to every streaming call, in order
+	StreamInterceptors []grpc.StreamServerInterceptor
+}
+
+// Service registers its gRPC service implementation on the server.
+type Service interface {
+	Register(server *grpc.Server)
 }
 
 // NewGrpcApp creates and initializes a new gRPC application.
 //
-// It sets up a gRPC server with authentication services and configures logging.
+// It sets up a gRPC server with the provided services registered and configures logging.
 //
 // Parameters:
 //   - log: A pointer to a slog.Logger for application logging.
 //   - conf: A GRPCConfig struct containing the gRPC server configuration.
-//   - authService: An Authentication interface for handling authentication operations.
+//   - services: Services to register on the server.
 //
 // Returns:
 //   - *App: A pointer to the newly created App struct, ready to be run.
 func NewGrpcApp(
 	log *slog.Logger,
 	config Config,
-	// authService authentication.Authentication,
+	services ...Service,
 ) *App {
 
 	Log := log.WithGroup(
 		"gRPC",
 	)
 
-	server := grpc.NewServer()
+	server := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(config.UnaryInterceptors...),
+		grpc.ChainStreamInterceptor(config.StreamInterceptors...),
+	)
 
-	// authentication_v1.RegisterAuthenticationServer(
-	// 	server,
-	// 	authentication.NewAuthenticationServer(
-	// 		Log,
-	// 		authService,
-	// 	),
-	// )
+	for _, service := range services {
+		service.Register(server)
+	}
 
 	return &App{
 		log:    Log,
@@ -26,12 +26,14 @@ type App struct {
 
 type Config struct {
 	Server config.Server
+	// Middlewares applied to routes of non-public services, e.g. authentication
+	Middlewares []func(http.Handler) http.Handler
 }
 
 func NewRestApp(
 	ctx context.Context,
 	config Config,
-	// authenticationService authentication.Authentication,
+	services ...Service,
 ) *App {
 
 	err := config.Server.Validate()
@@ -39,7 +41,7 @@ func NewRestApp(
 		panic(errors.Wrap(err, "failed to validate REST config"))
 	}
 
-	app, err := newRestApp(ctx, config)
+	app, err := newRestApp(ctx, config, services...)
 	if err != nil {
 		panic(err)
 	}
@@ -49,15 +51,13 @@ func NewRestApp(
 func newRestApp(
 	ctx context.Context,
 	config Config,
-	// authenticationService authentication.Authentication,
+	services ...Service,
 ) (*App, error) {
 
 	appContext := appctx.AppCtx(ctx)
 
 	log := appContext.Logger
 
-	// authentication := authentication.NewAuthenticationServer(log, authenticationService)
-
 	router := chi.NewRouter()
 	router.Use(
 		middleW.RequestLogger(log),
@@ -67,7 +67,8 @@ func newRestApp(
 
 	setRouters(
 		router,
-		// authentication,
+		config.Middlewares,
+		services...,
 	)
 
 	return &App{
@@ -118,12 +119,39 @@ func (a *App) Stop(ctx context.Context) {
 	}
 }
 
+// Service registers its API routes on the router.
 type Service interface {
-	InitRouters() *chi.Mux
+	Routers(router chi.Router)
+}
+
+type publicService struct {
+	Service
+}
+
+// Public marks service routes to be served without config middlewares
+// (credentials exchange, heartbeat pings).
+func Public(service Service) Service {
+	return publicService{Service: service}
 }
 
-func setRouters(router *chi.Mux, services ...Service) {
+func setRouters(
+	router *chi.Mux,
+	middlewares []func(http.Handler) http.Handler,
+	services ...Service,
+) {
+
 	for i := range services {
-		router.Mount("/api", services[i].InitRouters())
+		if _, ok := services[i].(publicService); ok {
+			services[i].Routers(router)
+		}
 	}
+
+	router.Group(func(r chi.Router) {
+		r.Use(middlewares...)
+		for i := range services {
+			if _, ok := services[i].(publicService); !ok {
+				services[i].Routers(r)
+			}
+		}
+	})
 }
@@ -0,0 +1,275 @@
+package app
+
+import (
+	"context"
+	"fmt"
+
+	appctx "github.com/vishenosik/CherryWatch/internal/app/context"
+	"github.com/vishenosik/CherryWatch/internal/services/agents"
+	"github.com/vishenosik/CherryWatch/internal/services/audit"
+	"github.com/vishenosik/CherryWatch/internal/services/authentication"
+	"github.com/vishenosik/CherryWatch/internal/services/backup"
+	"github.com/vishenosik/CherryWatch/internal/services/badges"
+	"github.com/vishenosik/CherryWatch/internal/services/channels"
+	"github.com/vishenosik/CherryWatch/internal/services/checks"
+	"github.com/vishenosik/CherryWatch/internal/services/endpoints"
+	"github.com/vishenosik/CherryWatch/internal/services/events"
+	"github.com/vishenosik/CherryWatch/internal/services/heartbeat"
+	"github.com/vishenosik/CherryWatch/internal/services/incidents"
+	"github.com/vishenosik/CherryWatch/internal/services/maintenances"
+	"github.com/vishenosik/CherryWatch/internal/services/metrics"
+	"github.com/vishenosik/CherryWatch/internal/services/models"
+	"github.com/vishenosik/CherryWatch/internal/services/notifier"
+	"github.com/vishenosik/CherryWatch/internal/services/scheduler"
+	"github.com/vishenosik/CherryWatch/internal/services/silences"
+	"github.com/vishenosik/CherryWatch/internal/services/statuspage"
+	"github.com/vishenosik/CherryWatch/internal/services/subscriptions"
+	"github.com/vishenosik/CherryWatch/internal/services/users"
+	"github.com/vishenosik/CherryWatch/internal/services/workspaces"
+	appsCache "github.com/vishenosik/CherryWatch/internal/store/cache/components/apps"
+	usersCache "github.com/vishenosik/CherryWatch/internal/store/cache/components/users"
+	"github.com/vishenosik/CherryWatch/internal/store/cache/providers/memory"
+	storeModels "github.com/vishenosik/CherryWatch/internal/store/models"
+	sqlstore "github.com/vishenosik/CherryWatch/internal/store/sql"
+	appsStore "github.com/vishenosik/CherryWatch/internal/store/sql/components/apps"
+	auditStore "github.com/vishenosik/CherryWatch/internal/store/sql/components/audit"
+	channelsStore "github.com/vishenosik/CherryWatch/internal/store/sql/components/channels"
+	endpointsStore "github.com/vishenosik/CherryWatch/internal/store/sql/components/endpoints"
+	historyStore "github.com/vishenosik/CherryWatch/internal/store/sql/components/history"
+	incidentsStore "github.com/vishenosik/CherryWatch/internal/store/sql/components/incidents"
+	maintenancesStore "github.com/vishenosik/CherryWatch/internal/store/sql/components/maintenances"
+	silencesStore "github.com/vishenosik/CherryWatch/internal/store/sql/components/silences"
+	statusPagesStore "github.com/vishenosik/CherryWatch/internal/store/sql/components/statuspages"
+	subscriptionsStore "github.com/vishenosik/CherryWatch/internal/store/sql/components/subscriptions"
+	usersStore "github.com/vishenosik/CherryWatch/internal/store/sql/components/users"
+	workspacesStore "github.com/vishenosik/CherryWatch/internal/store/sql/components/workspaces"
+)
+
+type services struct {
+	agents         *agents.Service
+	audit          *audit.Service
+	authentication *authentication.Service
+	backup         *backup.Service
+	badges         *badges.Service
+	channels       *channels.Service
+	events         *events.Bus
+	endpoints      *endpoints.Service
+	incidents      *incidents.Service
+	heartbeat      *heartbeat.Service
+	maintenances   *maintenances.Service
+	metrics        *metrics.Service
+	notifier       *notifier.Notifier
+	scheduler      *scheduler.Scheduler
+	silences       *silences.Service
+	statusPage     *statuspage.Service
+	subscriptions  *subscriptions.Service
+	users          *users.Service
+	workspaces     *workspaces.Service
+}
+
+// loadServices wires services to the store, cache is optional.
+func loadServices(ctx context.Context, store *sqlstore.Store, cache storeModels.CacheProvider) *services {
+
+	appContext := appctx.AppCtx(ctx)
+
+	log := appContext.Logger
+	conf := appContext.Config
+
+	endpointsStore := endpointsStore.NewEndpointsStore(store.DB())
+	incidentsStore := incidentsStore.NewIncidentsStore(store.DB())
+	historyStore := historyStore.NewHistoryStore(store.DB())
+
+	var (
+		appsStore  authentication.Store = appsStore.NewAppsStore(store.DB())
+		usersStore users.Store          = usersStore.NewUsersStore(store.DB())
+
+		statusPageOpts []statuspage.Option
+		badgesOpts     []badges.Option
+	)
+
+	if cache != nil {
+		recordsTTL, statusTTL := cacheTTLs(conf.Cache)
+
+		// apps and users hold secrets, they never leave the process
+		records, ok := cache.(storeModels.LocalCacheProvider)
+		if !ok {
+			records = memory.NewMemoryCache(conf.Cache.Size)
+		}
+
+		appsStore = appsCache.NewAppsStore(appsStore, records, recordsTTL)
+		usersStore = usersCache.NewUsersStore(usersStore, records, recordsTTL)
+
+		statusPageOpts = append(statusPageOpts, statuspage.WithCache(cache, statusTTL))
+		badgesOpts = append(badgesOpts, badges.WithCache(cache, statusTTL))
+	}
+
+	auditService := audit.NewAuditService(log, auditStore.NewAuditStore(store.DB()))
+
+	usersService := users.NewUsersService(log, usersStore, users.WithAudit(auditService))
+
+	authenticationService := authentication.NewAuthenticationService(
+		log,
+		appsStore,
+		usersService,
+		conf.AuthenticationService.TokenTTL,
+	)
+
+	bus := events.NewBus(conf.Events.BufferSize)
+
+	recorder := metrics.NewRecorder()
+
+	incidentsService := incidents.NewIncidentsService(
+		log,
+		incidentsStore,
+		bus,
+		incidents.WithIncidentObserver(recorder),
+	)
+
+	checker := checks.NewChecks(
+		nil,
+		checks.WithExecChecks(conf.Checks.ExecEnabled),
+		checks.WithSQLChecks(conf.Checks.SQLEnabled),
+	)
+
+	endpointsService := endpoints.NewEndpointsService(
+		log,
+		endpointsStore,
+		checker,
+		incidentsService,
+		bus,
+		endpoints.WithExecChecks(conf.Checks.ExecEnabled),
+		endpoints.WithSQLChecks(conf.Checks.SQLEnabled),
+		endpoints.WithAudit(auditService),
+		endpoints.WithHistory(historyStore),
+		endpoints.WithResultObserver(recorder),
+	)
+
+	maintenancesService := maintenances.NewMaintenancesService(
+		log,
+		maintenancesStore.NewMaintenancesStore(store.DB()),
+	)
+
+	metricsService := metrics.NewMetricsService(log, endpointsService, incidentsService, recorder)
+
+	statusPageConfig := statuspage.Config{
+		Days: conf.StatusPage.Days,
+	}
+
+	if conf.StatusPage.Enabled {
+		statusPageConfig.Default = &models.StatusPageConfig{
+			WorkspaceID: conf.StatusPage.Workspace,
+			Title:       conf.StatusPage.Title,
+		}
+	}
+
+	statusPageService := statuspage.NewStatusPageService(
+		log,
+		statusPagesStore.NewStatusPagesStore(store.DB()),
+		endpointsService,
+		incidentsService,
+		historyStore,
+		maintenancesService,
+		statusPageConfig,
+		statusPageOpts...,
+	)
+
+	badgesService := badges.NewBadgesService(
+		log,
+		endpointsService,
+		incidentsService,
+		historyStore,
+		maintenancesService,
+		statusPageService,
+		badgesOpts...,
+	)
+
+	notifierOpts := []notifier.Option{
+		notifier.WithSender(models.ChannelWebhook, notifier.NewWebhookSender(
+			conf.Notifications.WebhookTimeout,
+			conf.Notifications.WebhookAllowPrivate,
+		)),
+	}
+
+	if conf.Notifications.SMTPAddr != "" {
+		notifierOpts = append(notifierOpts, notifier.WithSender(models.ChannelEmail, notifier.NewEmailSender(notifier.EmailConfig{
+			Addr:     conf.Notifications.SMTPAddr,
+			Username: conf.Notifications.SMTPUsername,
+			Password: conf.Notifications.SMTPPassword,
+			From:     conf.Notifications.SMTPFrom,
+		})))
+	}
+
+	notifierService := notifier.NewNotifier(log, notifierOpts...)
+
+	silencesService := silences.NewSilencesService(
+		log,
+		silencesStore.NewSilencesStore(store.DB()),
+		endpointsService,
+	)
+
+	channelsService := channels.NewChannelsService(
+		log,
+		channelsStore.NewChannelsStore(store.DB()),
+		endpointsService,
+		silencesService,
+		notifierService,
+		bus,
+	)
+
+	publicURL := conf.Notifications.PublicURL
+	if publicURL == "" {
+		publicURL = fmt.Sprintf("http://localhost:%d", conf.RestConfig.Port)
+	}
+
+	return &services{
+		agents:         agents.NewAgentsService(log, endpointsService, conf.Agents.TTL),
+		audit:          auditService,
+		authentication: authenticationService,
+		backup:         backup.NewBackupService(log, store),
+		badges:         badgesService,
+		channels:       channelsService,
+		events:         bus,
+		endpoints:      endpointsService,
+		incidents:      incidentsService,
+		heartbeat: heartbeat.NewHeartbeatService(
+			log,
+			endpointsStore,
+			incidentsService,
+			conf.Heartbeat.CheckInterval,
+		),
+		maintenances: maintenancesService,
+		metrics:      metricsService,
+		notifier:     notifierService,
+		silences:     silencesService,
+		statusPage:   statusPageService,
+		subscriptions: subscriptions.NewSubscriptionsService(
+			log,
+			subscriptionsStore.NewSubscriptionsStore(store.DB()),
+			statusPageService,
+			endpointsService,
+			notifierService,
+			bus,
+			subscriptions.Config{
+				PublicURL:   publicURL,
+				PageLimit:   conf.Notifications.SubscribePageLimit,
+				ClientLimit: conf.Notifications.SubscribeIPLimit,
+			},
+		),
+		users: usersService,
+		workspaces: workspaces.NewWorkspacesService(
+			log,
+			workspacesStore.NewWorkspacesStore(store.DB()),
+			authenticationService,
+			workspaces.WithAudit(auditService),
+		),
+		scheduler: scheduler.NewScheduler(
+			log,
+			endpointsService,
+			scheduler.Config{
+				Workers: conf.Scheduler.Workers,
+				Tick:    conf.Scheduler.Tick,
+			},
+			scheduler.WithLagObserver(recorder),
+		),
+	}
+}
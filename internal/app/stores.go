@@ -3,27 +3,56 @@ package app
 import (
 	"context"
 
+	"github.com/pkg/errors"
 	embed "github.com/vishenosik/CherryWatch"
 	appctx "github.com/vishenosik/CherryWatch/internal/app/context"
 	sqlstore "github.com/vishenosik/CherryWatch/internal/store/sql"
+	"github.com/vishenosik/CherryWatch/internal/store/sql/providers/postgres"
 	"github.com/vishenosik/CherryWatch/internal/store/sql/providers/sqlite"
 	std "github.com/vishenosik/web-tools/log"
 	"github.com/vishenosik/web-tools/migrate"
 )
 
+const defaultStorePath = "./storage/CherryWatch.db"
+
+var ErrStoreDSNRequired = errors.New("STORE_DSN is required by the postgres store driver")
+
 func loadSqlStore(ctx context.Context) (*sqlstore.Store, error) {
 
 	appContext := appctx.AppCtx(ctx)
 
 	// Stores init
-	sqliteStore := sqlite.MustInitSqlite(appContext.Config.StorePath)
-	store := sqlstore.NewStore(sqliteStore)
+	provider, err := loadSqlProvider(appContext.Config)
+	if err != nil {
+		return nil, err
+	}
+	store := sqlstore.NewStore(provider)
 
 	// Stores migration
 	migrate.NewMigrator(
 		std.NewStdLogger(appContext.Logger),
 		embed.Migrations,
-	).MustMigrate(sqliteStore)
+	).MustMigrate(provider)
 
 	return store, nil
 }
+
+// loadSqlProvider opens the store of the configured driver, sqlite by default.
+func loadSqlProvider(config appctx.Config) (sqlstore.StoreProvider, error) {
+
+	switch sqlstore.Dialect(config.StoreDriver) {
+	case "", sqlstore.SQLite:
+		path := config.StorePath
+		if path == "" {
+			path = defaultStorePath
+		}
+		return sqlite.NewSqliteStore(path)
+	case sqlstore.Postgres:
+		if config.StoreDSN == "" {
+			return nil, ErrStoreDSNRequired
+		}
+		return postgres.NewPostgresStore(config.StoreDSN)
+	}
+
+	return nil, errors.Errorf("unknown store driver %q", config.StoreDriver)
+}
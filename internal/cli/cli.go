@@ -0,0 +1,169 @@
+// Package cli implements cherrywatch-cli, the command line client of CherryWatch REST API.
+package cli
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	envServer = "CHERRYWATCH_SERVER"
+	envAPIKey = "CHERRYWATCH_API_KEY"
+
+	defaultServer = "http://localhost:8080"
+)
+
+// ErrUsage is returned for malformed command lines, usage is printed along.
+var ErrUsage = errors.New("invalid usage")
+
+type command struct {
+	usage string
+	run   func(ctx context.Context, cli *cli, args []string) error
+}
+
+type cli struct {
+	client *Client
+	out    *printer
+	stdin  io.Reader
+	stdout io.Writer
+}
+
+var commands = map[string]map[string]command{
+	"endpoints": {
+		"list":  {usage: "endpoints list", run: listEndpoints},
+		"get":   {usage: "endpoints get ID", run: getEndpoint},
+		"add":   {usage: "endpoints add -name NAME -url URL [-type TYPE] [-interval 1m] [-severity LEVEL] [-label k=v]... | -f FILE", run: addEndpoints},
+		"rm":    {usage: "endpoints rm ID...", run: removeEndpoints},
+		"check": {usage: "endpoints check ID", run: checkEndpoint},
+	},
+	"incidents": {
+		"list": {usage: "incidents list [-endpoint ID] [-active]", run: listIncidents},
+		"ack":  {usage: "incidents ack ID", run: acknowledgeIncident},
+	},
+	"silence": {
+		"add": {usage: "silence add -for DURATION [-comment TEXT] ID...|-all", run: addSilence},
+	},
+	"import": {
+		"": {usage: "import [-source gatus|blackbox|uptime-kuma] [-format json|yaml|csv] [-mode fail|skip|upsert] FILE|-", run: importEndpoints},
+	},
+	"export": {
+		"": {usage: "export [-format json|yaml|csv] [-out FILE]", run: exportEndpoints},
+	},
+}
+
+// Run executes the command line and returns process exit code:
+// 1 on failed requests, 2 on usage errors.
+func Run(ctx context.Context, args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+
+	flags := flag.NewFlagSet("cherrywatch-cli", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.Usage = func() { printUsage(stderr, flags) }
+
+	var (
+		server = flags.String("server", envOr(envServer, defaultServer), "REST API address (env "+envServer+")")
+		apiKey = flags.String("api-key", os.Getenv(envAPIKey), "API key (env "+envAPIKey+")")
+		output = flags.String("o", formatTable, "Output format: table, json or yaml")
+	)
+
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	out, err := newPrinter(stdout, *output)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 2
+	}
+
+	cmd, cmdArgs, ok := lookup(flags.Args())
+	if !ok {
+		flags.Usage()
+		return 2
+	}
+
+	cli := &cli{
+		client: NewClient(*server, *apiKey),
+		out:    out,
+		stdin:  stdin,
+		stdout: stdout,
+	}
+
+	if err := cmd.run(ctx, cli, cmdArgs); err != nil {
+		if errors.Is(err, ErrUsage) {
+			fmt.Fprintf(stderr, "%s\nusage: cherrywatch-cli %s\n", err, cmd.usage)
+			return 2
+		}
+		fmt.Fprintln(stderr, "error:", err)
+		return 1
+	}
+
+	return 0
+}
+
+func lookup(args []string) (command, []string, bool) {
+
+	if len(args) == 0 {
+		return command{}, nil, false
+	}
+
+	group, ok := commands[args[0]]
+	if !ok {
+		return command{}, nil, false
+	}
+
+	if cmd, ok := group[""]; ok {
+		return cmd, args[1:], true
+	}
+
+	if len(args) < 2 {
+		return command{}, nil, false
+	}
+
+	cmd, ok := group[args[1]]
+	return cmd, args[2:], ok
+}
+
+func printUsage(w io.Writer, flags *flag.FlagSet) {
+
+	usages := make([]string, 0)
+	for _, group := range commands {
+		for _, cmd := range group {
+			usages = append(usages, cmd.usage)
+		}
+	}
+	sort.Strings(usages)
+
+	fmt.Fprintf(w, "usage: cherrywatch-cli [flags] <command>\n\ncommands:\n  %s\n\nflags:\n", strings.Join(usages, "\n  "))
+	flags.PrintDefaults()
+}
+
+// parseFlags parses command flags, wrong flags are reported as ErrUsage.
+func parseFlags(flags *flag.FlagSet, args []string) error {
+	flags.SetOutput(io.Discard)
+	if err := flags.Parse(args); err != nil {
+		return errors.Wrap(ErrUsage, err.Error())
+	}
+	return nil
+}
+
+// exactArgs checks number of positional arguments.
+func exactArgs(args []string, n int) error {
+	if len(args) != n {
+		return errors.Wrapf(ErrUsage, "expected %d argument(s), got %d", n, len(args))
+	}
+	return nil
+}
+
+func envOr(key, fallback string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return fallback
+}
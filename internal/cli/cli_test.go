@@ -0,0 +1,180 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vishenosik/CherryWatch/internal/api/models"
+)
+
+const testAPIKey = "secret"
+
+func testServer(t *testing.T) *httptest.Server {
+
+	endpoints := models.Endpoints{
+		{ID: "1", ServiceName: "api", URL: "https://api.example.com", Type: "http", Interval: time.Minute},
+	}
+
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("GET /api/v1/endpoints", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(endpoints)
+	})
+	mux.HandleFunc("POST /api/v1/endpoints", func(w http.ResponseWriter, r *http.Request) {
+		var added models.Endpoints
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&added))
+		for i := range added {
+			added[i].ID = "new"
+		}
+		_ = json.NewEncoder(w).Encode(added)
+	})
+	mux.HandleFunc("POST /api/v1/incidents/{id}/ack", func(w http.ResponseWriter, r *http.Request) {
+		if r.PathValue("id") != "42" {
+			http.Error(w, "incident not found", http.StatusNotFound)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(models.Incident{ID: "42", EndpointID: "1", AcknowledgedBy: "app:cli"})
+	})
+
+	mux.HandleFunc("POST /api/v1/silences", func(w http.ResponseWriter, r *http.Request) {
+		var silence models.Silence
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&silence))
+		silence.ID = "s1"
+		silence.CreatedBy = "app:cli"
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(silence)
+	})
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-API-Key") != testAPIKey {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		mux.ServeHTTP(w, r)
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func Test_Run(t *testing.T) {
+
+	server := testServer(t)
+
+	tests := []struct {
+		name     string
+		args     []string
+		wantCode int
+		// substrings of stdout or stderr
+		wantOut []string
+		wantErr []string
+	}{
+		{
+			name:    "endpoints list table",
+			args:    []string{"endpoints", "list"},
+			wantOut: []string{"ID  NAME  TYPE", "1   api   http  https://api.example.com  1m0s"},
+		},
+		{
+			name:    "endpoints list yaml",
+			args:    []string{"-o", "yaml", "endpoints", "list"},
+			wantOut: []string{"service_name: api", "time_interval: 1m0s"},
+		},
+		{
+			name:    "endpoints add",
+			args:    []string{"-o", "json", "endpoints", "add", "-name", "web", "-url", "https://web.example.com", "-label", "team=core"},
+			wantOut: []string{`"id": "new"`, `"team": "core"`},
+		},
+		{
+			name:    "incidents ack",
+			args:    []string{"-o", "json", "incidents", "ack", "42"},
+			wantOut: []string{`"acknowledged_by": "app:cli"`},
+		},
+		{
+			name:     "api error",
+			args:     []string{"incidents", "ack", "7"},
+			wantCode: 1,
+			wantErr:  []string{"404", "incident not found"},
+		},
+		{
+			name:     "unauthorized",
+			args:     []string{"-api-key", "wrong", "endpoints", "list"},
+			wantCode: 1,
+			wantErr:  []string{"401"},
+		},
+		{
+			name:     "missing argument",
+			args:     []string{"endpoints", "get"},
+			wantCode: 2,
+			wantErr:  []string{"usage: cherrywatch-cli endpoints get ID"},
+		},
+		{
+			name:    "silence add",
+			args:    []string{"silence", "add", "-for", "2h", "-comment", "deploy", "1"},
+			wantOut: []string{"ID  ENDPOINTS", "s1  1", "app:cli", "deploy"},
+		},
+		{
+			name:    "silence add all",
+			args:    []string{"-o", "json", "silence", "add", "-for", "30m", "-all"},
+			wantOut: []string{`"id": "s1"`},
+		},
+		{
+			name:     "silence add without endpoints",
+			args:     []string{"silence", "add", "-for", "2h"},
+			wantCode: 2,
+			wantErr:  []string{"either endpoint IDs or -all are required"},
+		},
+		{
+			name:     "unknown command",
+			args:     []string{"silences", "rm"},
+			wantCode: 2,
+			wantErr:  []string{"usage: cherrywatch-cli"},
+		},
+		{
+			name:     "unknown output",
+			args:     []string{"-o", "xml", "endpoints", "list"},
+			wantCode: 2,
+			wantErr:  []string{"output must be one of"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			var stdout, stderr bytes.Buffer
+
+			args := append([]string{"-server", server.URL, "-api-key", testAPIKey}, tt.args...)
+			code := Run(context.Background(), args, strings.NewReader(""), &stdout, &stderr)
+
+			assert.Equal(t, tt.wantCode, code, stderr.String())
+			for _, want := range tt.wantOut {
+				assert.Contains(t, stdout.String(), want)
+			}
+			for _, want := range tt.wantErr {
+				assert.Contains(t, stderr.String(), want)
+			}
+		})
+	}
+}
+
+func Test_Client_JSON(t *testing.T) {
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		assert.JSONEq(t, `{"a":1}`, string(body))
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	err := NewClient(server.URL+"/", "").JSON(context.Background(), http.MethodPost, "/x", nil, map[string]int{"a": 1}, nil)
+	require.NoError(t, err)
+}
@@ -0,0 +1,132 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// maxErrorSize limits error response body read into the message.
+const maxErrorSize = 4 << 10
+
+// APIError is a non-2xx server response.
+type APIError struct {
+	Status  int
+	Message string
+}
+
+func (err *APIError) Error() string {
+	return fmt.Sprintf("server responded %d %s: %s", err.Status, http.StatusText(err.Status), err.Message)
+}
+
+// Client calls CherryWatch REST API authenticated with an API key.
+type Client struct {
+	server string
+	apiKey string
+	http   *http.Client
+}
+
+func NewClient(server, apiKey string) *Client {
+	return &Client{
+		server: strings.TrimRight(server, "/"),
+		apiKey: apiKey,
+		http:   &http.Client{Timeout: time.Minute},
+	}
+}
+
+// Do sends the request and returns response body, non-2xx responses are returned as *APIError.
+func (client *Client) Do(
+	ctx context.Context,
+	method, path string,
+	query url.Values,
+	body io.Reader,
+	contentType string,
+) ([]byte, error) {
+
+	const op = "cli.Client.Do"
+
+	target := client.server + path
+	if len(query) > 0 {
+		target += "?" + query.Encode()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, target, body)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	if client.apiKey != "" {
+		req.Header.Set("X-API-Key", client.apiKey)
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+
+	resp, err := client.http.Do(req)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorSize))
+		return nil, &APIError{
+			Status:  resp.StatusCode,
+			Message: strings.TrimSpace(string(message)),
+		}
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	return data, nil
+}
+
+// JSON sends request with JSON encoded body (if not nil) and decodes response into out (if not nil).
+func (client *Client) JSON(
+	ctx context.Context,
+	method, path string,
+	query url.Values,
+	in, out any,
+) error {
+
+	const op = "cli.Client.JSON"
+
+	var (
+		body        io.Reader
+		contentType string
+	)
+
+	if in != nil {
+		data, err := json.Marshal(in)
+		if err != nil {
+			return errors.Wrap(err, op)
+		}
+		body, contentType = bytes.NewReader(data), "application/json"
+	}
+
+	data, err := client.Do(ctx, method, path, query, body, contentType)
+	if err != nil {
+		return err
+	}
+
+	if out == nil || len(data) == 0 {
+		return nil
+	}
+
+	if err := json.Unmarshal(data, out); err != nil {
+		return errors.Wrap(err, op)
+	}
+
+	return nil
+}
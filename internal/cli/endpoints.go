@@ -0,0 +1,168 @@
+package cli
+
+import (
+	"context"
+	"flag"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/vishenosik/CherryWatch/internal/api/models"
+)
+
+const endpointsPath = "/api/v1/endpoints"
+
+func listEndpoints(ctx context.Context, cli *cli, args []string) error {
+
+	if err := exactArgs(args, 0); err != nil {
+		return err
+	}
+
+	var endpoints models.Endpoints
+	if err := cli.client.JSON(ctx, http.MethodGet, endpointsPath, nil, nil, &endpoints); err != nil {
+		return err
+	}
+
+	return cli.out.print(endpoints, func() table { return endpointsTable(endpoints) })
+}
+
+func getEndpoint(ctx context.Context, cli *cli, args []string) error {
+
+	if err := exactArgs(args, 1); err != nil {
+		return err
+	}
+
+	var endpoint models.Endpoint
+	if err := cli.client.JSON(ctx, http.MethodGet, endpointPath(args[0]), nil, nil, &endpoint); err != nil {
+		return err
+	}
+
+	return cli.out.print(endpoint, func() table { return endpointsTable(models.Endpoints{endpoint}) })
+}
+
+// addEndpoints creates an endpoint described by flags, or all endpoints of a definitions file.
+func addEndpoints(ctx context.Context, cli *cli, args []string) error {
+
+	var (
+		flags    = flag.NewFlagSet("endpoints add", flag.ContinueOnError)
+		file     = flags.String("f", "", "")
+		name     = flags.String("name", "", "")
+		target   = flags.String("url", "", "")
+		kind     = flags.String("type", "", "")
+		severity = flags.String("severity", "", "")
+		interval = flags.Duration("interval", time.Minute, "")
+		codes    = flags.String("codes", "", "")
+		labels   = labelsFlag{}
+	)
+	flags.Var(labels, "label", "")
+
+	if err := parseFlags(flags, args); err != nil {
+		return err
+	}
+	if err := exactArgs(flags.Args(), 0); err != nil {
+		return err
+	}
+
+	var endpoints models.Endpoints
+
+	switch {
+	case *file != "":
+		data, err := os.ReadFile(*file)
+		if err != nil {
+			return err
+		}
+		if endpoints, err = models.DecodeEndpoints(data, fileFormat(*file)); err != nil {
+			return errors.Wrap(err, *file)
+		}
+	case *name != "":
+		endpoint := models.Endpoint{
+			ServiceName: *name,
+			URL:         *target,
+			Type:        *kind,
+			Severity:    *severity,
+			Interval:    *interval,
+			Labels:      labels,
+		}
+		if *codes != "" {
+			endpoint.SuccessCodes = strings.Split(*codes, ",")
+		}
+		endpoints = models.Endpoints{endpoint}
+	default:
+		return errors.Wrap(ErrUsage, "either -name or -f is required")
+	}
+
+	var added models.Endpoints
+	if err := cli.client.JSON(ctx, http.MethodPost, endpointsPath, nil, endpoints, &added); err != nil {
+		return err
+	}
+
+	return cli.out.print(added, func() table { return endpointsTable(added) })
+}
+
+func removeEndpoints(ctx context.Context, cli *cli, args []string) error {
+
+	if len(args) == 0 {
+		return errors.Wrap(ErrUsage, "endpoint id is required")
+	}
+
+	for _, id := range args {
+		if _, err := cli.client.Do(ctx, http.MethodDelete, endpointPath(id), nil, nil, ""); err != nil {
+			return errors.Wrap(err, id)
+		}
+	}
+
+	return nil
+}
+
+func checkEndpoint(ctx context.Context, cli *cli, args []string) error {
+
+	if err := exactArgs(args, 1); err != nil {
+		return err
+	}
+
+	var result models.CheckResult
+	if err := cli.client.JSON(ctx, http.MethodPost, endpointPath(args[0])+"/check", nil, nil, &result); err != nil {
+		return err
+	}
+
+	return cli.out.print(result, func() table { return checkResultTable(result) })
+}
+
+func endpointPath(id string) string {
+	return endpointsPath + "/" + url.PathEscape(id)
+}
+
+// fileFormat detects definitions format by file extension, json by default.
+func fileFormat(path string) string {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		return models.FormatYAML
+	case ".csv":
+		return models.FormatCSV
+	}
+	return models.FormatJSON
+}
+
+// labelsFlag collects repeated -label key=value flags.
+type labelsFlag map[string]string
+
+func (labels labelsFlag) String() string {
+	pairs := make([]string, 0, len(labels))
+	for key, value := range labels {
+		pairs = append(pairs, key+"="+value)
+	}
+	return strings.Join(pairs, ",")
+}
+
+func (labels labelsFlag) Set(value string) error {
+	key, val, ok := strings.Cut(value, "=")
+	if !ok || key == "" {
+		return errors.New("label must look like key=value")
+	}
+	labels[key] = val
+	return nil
+}
@@ -0,0 +1,58 @@
+package cli
+
+import (
+	"context"
+	"flag"
+	"net/http"
+	"net/url"
+
+	"github.com/vishenosik/CherryWatch/internal/api/models"
+)
+
+const incidentsPath = "/api/v1/incidents"
+
+func listIncidents(ctx context.Context, cli *cli, args []string) error {
+
+	var (
+		flags    = flag.NewFlagSet("incidents list", flag.ContinueOnError)
+		endpoint = flags.String("endpoint", "", "")
+		active   = flags.Bool("active", false, "")
+	)
+
+	if err := parseFlags(flags, args); err != nil {
+		return err
+	}
+	if err := exactArgs(flags.Args(), 0); err != nil {
+		return err
+	}
+
+	query := url.Values{}
+	if *endpoint != "" {
+		query.Set("endpoint_id", *endpoint)
+	}
+	if *active {
+		query.Set("active", "true")
+	}
+
+	var incidents models.Incidents
+	if err := cli.client.JSON(ctx, http.MethodGet, incidentsPath, query, nil, &incidents); err != nil {
+		return err
+	}
+
+	return cli.out.print(incidents, func() table { return incidentsTable(incidents) })
+}
+
+func acknowledgeIncident(ctx context.Context, cli *cli, args []string) error {
+
+	if err := exactArgs(args, 1); err != nil {
+		return err
+	}
+
+	var incident models.Incident
+	path := incidentsPath + "/" + url.PathEscape(args[0]) + "/ack"
+	if err := cli.client.JSON(ctx, http.MethodPost, path, nil, nil, &incident); err != nil {
+		return err
+	}
+
+	return cli.out.print(incident, func() table { return incidentsTable(models.Incidents{incident}) })
+}
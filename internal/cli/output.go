@@ -0,0 +1,161 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/vishenosik/CherryWatch/internal/api/models"
+)
+
+const formatTable = "table"
+
+var ErrOutput = errors.New("output must be one of: table, json, yaml")
+
+type table struct {
+	header []string
+	rows   [][]string
+}
+
+type printer struct {
+	w      io.Writer
+	format string
+}
+
+func newPrinter(w io.Writer, format string) (*printer, error) {
+	switch format {
+	case formatTable, models.FormatJSON, models.FormatYAML:
+		return &printer{w: w, format: format}, nil
+	}
+	return nil, errors.Wrap(ErrOutput, format)
+}
+
+// print writes the value as JSON or YAML document, or as the table built by toTable.
+func (p *printer) print(value any, toTable func() table) error {
+
+	if p.format != formatTable {
+		data, err := models.EncodeDocument(value, p.format)
+		if err != nil {
+			return err
+		}
+		if _, err := p.w.Write(data); err != nil {
+			return err
+		}
+		if p.format == models.FormatJSON {
+			_, err = fmt.Fprintln(p.w)
+		}
+		return err
+	}
+
+	tbl := toTable()
+
+	tw := tabwriter.NewWriter(p.w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, strings.Join(tbl.header, "\t"))
+	for _, row := range tbl.rows {
+		fmt.Fprintln(tw, strings.Join(row, "\t"))
+	}
+
+	return tw.Flush()
+}
+
+func endpointsTable(endpoints models.Endpoints) table {
+	tbl := table{header: []string{"ID", "NAME", "TYPE", "URL", "INTERVAL", "SEVERITY"}}
+	for _, endpoint := range endpoints {
+		tbl.rows = append(tbl.rows, []string{
+			endpoint.ID,
+			endpoint.ServiceName,
+			orDash(endpoint.Type),
+			orDash(endpoint.URL),
+			endpoint.Interval.String(),
+			orDash(endpoint.Severity),
+		})
+	}
+	return tbl
+}
+
+func incidentsTable(incidents models.Incidents) table {
+	tbl := table{header: []string{"ID", "ENDPOINT", "OPENED", "RESOLVED", "ACKNOWLEDGED BY", "CAUSE"}}
+	for _, incident := range incidents {
+		tbl.rows = append(tbl.rows, []string{
+			incident.ID,
+			incident.EndpointID,
+			formatTime(&incident.OpenedAt),
+			formatTime(incident.ResolvedAt),
+			orDash(incident.AcknowledgedBy),
+			orDash(incident.Cause),
+		})
+	}
+	return tbl
+}
+
+func silencesTable(silences models.Silences) table {
+	tbl := table{header: []string{"ID", "ENDPOINTS", "STARTS", "ENDS", "CREATED BY", "COMMENT"}}
+	for _, silence := range silences {
+		endpoints := strings.Join(silence.EndpointIDs, ",")
+		if endpoints == "" {
+			endpoints = "all"
+		}
+		tbl.rows = append(tbl.rows, []string{
+			silence.ID,
+			endpoints,
+			formatTime(&silence.StartsAt),
+			formatTime(&silence.EndsAt),
+			orDash(silence.CreatedBy),
+			orDash(silence.Comment),
+		})
+	}
+	return tbl
+}
+
+func checkResultTable(result models.CheckResult) table {
+	status := "down"
+	if result.Success {
+		status = "up"
+	}
+	return table{
+		header: []string{"ENDPOINT", "STATUS", "LATENCY", "MESSAGE"},
+		rows: [][]string{{
+			result.EndpointID,
+			status,
+			result.Latency.Round(time.Millisecond).String(),
+			orDash(result.Message),
+		}},
+	}
+}
+
+func importResultTable(result models.ImportResult) table {
+	tbl := table{header: []string{"RESULT", "ID", "NAME"}}
+	for _, group := range []struct {
+		name      string
+		endpoints models.Endpoints
+	}{
+		{"created", result.Created},
+		{"updated", result.Updated},
+		{"skipped", result.Skipped},
+	} {
+		for _, endpoint := range group.endpoints {
+			tbl.rows = append(tbl.rows, []string{group.name, endpoint.ID, endpoint.ServiceName})
+		}
+	}
+	for _, warning := range result.Warnings {
+		tbl.rows = append(tbl.rows, []string{"warning", warning.Item, warning.Reason})
+	}
+	return tbl
+}
+
+func formatTime(t *time.Time) string {
+	if t == nil || t.IsZero() {
+		return "-"
+	}
+	return t.Local().Format(time.DateTime)
+}
+
+func orDash(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
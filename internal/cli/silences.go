@@ -0,0 +1,52 @@
+package cli
+
+import (
+	"context"
+	"flag"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/vishenosik/CherryWatch/internal/api/models"
+)
+
+const silencesPath = "/api/v1/silences"
+
+func addSilence(ctx context.Context, cli *cli, args []string) error {
+
+	var (
+		flags    = flag.NewFlagSet("silence add", flag.ContinueOnError)
+		duration = flags.Duration("for", 0, "")
+		comment  = flags.String("comment", "", "")
+		all      = flags.Bool("all", false, "")
+	)
+
+	if err := parseFlags(flags, args); err != nil {
+		return err
+	}
+
+	ids := flags.Args()
+
+	switch {
+	case *duration <= 0:
+		return errors.Wrap(ErrUsage, "-for must be a positive duration")
+	// muting the whole workspace by a missed argument would hide every incident
+	case *all == (len(ids) > 0):
+		return errors.Wrap(ErrUsage, "either endpoint IDs or -all are required")
+	}
+
+	now := time.Now()
+	request := models.Silence{
+		Comment:     *comment,
+		StartsAt:    now,
+		EndsAt:      now.Add(*duration),
+		EndpointIDs: ids,
+	}
+
+	var silence models.Silence
+	if err := cli.client.JSON(ctx, http.MethodPost, silencesPath, nil, request, &silence); err != nil {
+		return err
+	}
+
+	return cli.out.print(silence, func() table { return silencesTable(models.Silences{silence}) })
+}
@@ -0,0 +1,102 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"flag"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+
+	"github.com/vishenosik/CherryWatch/internal/api/models"
+)
+
+// importEndpoints uploads definitions file, or a config of another monitoring tool with -source.
+func importEndpoints(ctx context.Context, cli *cli, args []string) error {
+
+	var (
+		flags  = flag.NewFlagSet("import", flag.ContinueOnError)
+		source = flags.String("source", "", "")
+		format = flags.String("format", "", "")
+		mode   = flags.String("mode", "", "")
+	)
+
+	if err := parseFlags(flags, args); err != nil {
+		return err
+	}
+	if err := exactArgs(flags.Args(), 1); err != nil {
+		return err
+	}
+
+	path := flags.Arg(0)
+
+	var (
+		data []byte
+		err  error
+	)
+	if path == "-" {
+		data, err = io.ReadAll(cli.stdin)
+	} else {
+		data, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return err
+	}
+
+	query := url.Values{}
+	if *source != "" {
+		query.Set("source", *source)
+	}
+	if *mode != "" {
+		query.Set("mode", *mode)
+	}
+	switch {
+	case *format != "":
+		query.Set("format", *format)
+	case path != "-" && *source == "":
+		query.Set("format", fileFormat(path))
+	}
+
+	response, err := cli.client.Do(ctx, http.MethodPost, endpointsPath+"/import", query, bytes.NewReader(data), "")
+	if err != nil {
+		return err
+	}
+
+	var result models.ImportResult
+	if err := json.Unmarshal(response, &result); err != nil {
+		return err
+	}
+
+	return cli.out.print(result, func() table { return importResultTable(result) })
+}
+
+// exportEndpoints writes all endpoints definitions to stdout or the -out file as is.
+func exportEndpoints(ctx context.Context, cli *cli, args []string) error {
+
+	var (
+		flags  = flag.NewFlagSet("export", flag.ContinueOnError)
+		format = flags.String("format", models.FormatYAML, "")
+		out    = flags.String("out", "", "")
+	)
+
+	if err := parseFlags(flags, args); err != nil {
+		return err
+	}
+	if err := exactArgs(flags.Args(), 0); err != nil {
+		return err
+	}
+
+	data, err := cli.client.Do(ctx, http.MethodGet, endpointsPath+"/export", url.Values{"format": {*format}}, nil, "")
+	if err != nil {
+		return err
+	}
+
+	if *out != "" {
+		return os.WriteFile(*out, data, 0o600)
+	}
+
+	_, err = cli.stdout.Write(data)
+	return err
+}
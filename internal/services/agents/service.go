@@ -0,0 +1,414 @@
+package agents
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"github.com/vishenosik/CherryWatch/internal/services/models"
+	attrs "github.com/vishenosik/web-tools/log"
+	"github.com/vishenosik/web-tools/operation"
+)
+
+const (
+	// number of endpoint intervals a location result is counted for
+	staleIntervals = 3
+	// default time agents are kept without being heard of
+	defaultAgentTTL = 5 * time.Minute
+	// period of agents expiry & stale results lookup
+	sweepInterval = 30 * time.Second
+)
+
+type Endpoints interface {
+	Endpoint(ctx context.Context, id string) (*models.Endpoint, error)
+	Endpoints(ctx context.Context) (models.Endpoints, error)
+	ApplyResult(ctx context.Context, endpoint *models.Endpoint, result *models.CheckResult) error
+}
+
+// Service keeps probe agents registered with the server and combines results
+// they report from their locations by quorum policies of the endpoints.
+//
+// Agents & their latest results are kept in memory: agents register again
+// once the server restarts and endpoints are judged by new results.
+// Agents not heard of for the TTL are forgotten, probed endpoints no location
+// reported fresh results of fail.
+type Service struct {
+	log       *slog.Logger
+	endpoints Endpoints
+	agentTTL  time.Duration
+	now       func() time.Time
+	stop      chan struct{}
+	done      chan struct{}
+
+	mu     sync.Mutex
+	agents map[string]*models.Agent
+
+	// serializes verdicts, so that incidents follow the order of results
+	verdicts sync.Mutex
+	// latest results by endpoint id
+	results map[string]*reports
+}
+
+type reports struct {
+	// latest results by location
+	locations map[string]*report
+	// time a verdict was last applied to the endpoint
+	judgedAt time.Time
+}
+
+type report struct {
+	result *models.CheckResult
+	// time the server received the result, agent clocks may differ
+	receivedAt time.Time
+}
+
+func NewAgentsService(log *slog.Logger, endpoints Endpoints, agentTTL time.Duration) *Service {
+
+	if agentTTL <= 0 {
+		agentTTL = defaultAgentTTL
+	}
+
+	return &Service{
+		log:       log.With(slog.String("component", "agents")),
+		endpoints: endpoints,
+		agentTTL:  agentTTL,
+		now:       time.Now,
+		stop:      make(chan struct{}),
+		done:      make(chan struct{}),
+		agents:    make(map[string]*models.Agent),
+		results:   make(map[string]*reports),
+	}
+}
+
+// Register registers the agent in the caller's workspace.
+// An agent of the same name is updated and keeps its id.
+func (srv *Service) Register(ctx context.Context, agent *models.Agent) (*models.Agent, error) {
+
+	op := operation.ServicesOperation("agents", "Register")
+
+	if err := agent.Validate(); err != nil {
+		return nil, errors.Wrapf(models.ErrInvalidAgent, "%s: %s", op, err)
+	}
+
+	workspaceID := models.OwnerWorkspace(ctx, agent.WorkspaceID)
+	now := srv.now()
+
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+
+	for _, registered := range srv.agents {
+		if registered.WorkspaceID == workspaceID && registered.Name == agent.Name {
+			registered.Location = agent.Location
+			registered.LastSeenAt = now
+			return copyAgent(registered), nil
+		}
+	}
+
+	registered := &models.Agent{
+		ID:           uuid.NewString(),
+		WorkspaceID:  workspaceID,
+		Name:         agent.Name,
+		Location:     agent.Location,
+		RegisteredAt: now,
+		LastSeenAt:   now,
+	}
+
+	srv.agents[registered.ID] = registered
+
+	srv.log.Info("agent registered",
+		slog.String("agent_id", registered.ID),
+		slog.String("name", registered.Name),
+		slog.String("location", registered.Location),
+	)
+
+	return copyAgent(registered), nil
+}
+
+// Agents returns agents of the caller's workspace ordered by name.
+func (srv *Service) Agents(ctx context.Context) (models.Agents, error) {
+
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+
+	agents := make(models.Agents, 0, len(srv.agents))
+	for _, agent := range srv.agents {
+		if visible(ctx, agent) {
+			agents = append(agents, copyAgent(agent))
+		}
+	}
+
+	slices.SortFunc(agents, func(a, b *models.Agent) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	return agents, nil
+}
+
+// Assignments returns endpoints the agent checks from its location.
+func (srv *Service) Assignments(ctx context.Context, agentID string) (models.Endpoints, error) {
+
+	op := operation.ServicesOperation("agents", "Assignments")
+
+	agent, err := srv.agent(ctx, agentID)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	endpoints, err := srv.endpoints.Endpoints(scope(ctx, agent))
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	assigned := make(models.Endpoints, 0)
+	for _, endpoint := range endpoints {
+		if endpoint.Probes.Assigned(agent.Location) {
+			assigned = append(assigned, endpoint)
+		}
+	}
+
+	return assigned, nil
+}
+
+// Report applies the result of the agent to the endpoint: the endpoint fails
+// once its quorum of locations report failures. Results of locations not heard
+// of for a few endpoint intervals aren't counted.
+func (srv *Service) Report(ctx context.Context, agentID string, result *models.CheckResult) error {
+
+	op := operation.ServicesOperation("agents", "Report")
+
+	agent, err := srv.agent(ctx, agentID)
+	if err != nil {
+		return errors.Wrap(err, op)
+	}
+
+	ctx = scope(ctx, agent)
+
+	endpoint, err := srv.endpoints.Endpoint(ctx, result.EndpointID)
+	if err != nil {
+		if errors.Is(err, models.ErrNotFound) {
+			return errors.Wrap(models.ErrNotAssigned, op)
+		}
+		return errors.Wrap(err, op)
+	}
+
+	if !endpoint.Probes.Assigned(agent.Location) {
+		return errors.Wrap(models.ErrNotAssigned, op)
+	}
+
+	reported := *result
+	reported.EndpointID = endpoint.ID
+	reported.Location = agent.Location
+
+	srv.verdicts.Lock()
+	defer srv.verdicts.Unlock()
+
+	verdict := endpoint.Probes.Verdict(&reported, srv.record(endpoint, &reported))
+
+	if err := srv.endpoints.ApplyResult(ctx, endpoint, verdict); err != nil {
+		return errors.Wrap(err, op)
+	}
+
+	return nil
+}
+
+// record keeps the result and returns fresh results of the endpoint locations.
+func (srv *Service) record(endpoint *models.Endpoint, result *models.CheckResult) map[string]*models.CheckResult {
+
+	now := srv.now()
+
+	reports := srv.reports(endpoint.ID, now)
+	reports.locations[result.Location] = &report{result: result, receivedAt: now}
+	reports.judgedAt = now
+
+	return reports.fresh(endpoint, now)
+}
+
+// reports returns results of the endpoint, new ones are counted from now.
+func (srv *Service) reports(endpointID string, now time.Time) *reports {
+
+	endpointReports, ok := srv.results[endpointID]
+	if !ok {
+		endpointReports = &reports{
+			locations: make(map[string]*report),
+			judgedAt:  now,
+		}
+		srv.results[endpointID] = endpointReports
+	}
+
+	return endpointReports
+}
+
+// fresh drops stale results and returns the rest by location.
+func (reports *reports) fresh(endpoint *models.Endpoint, now time.Time) map[string]*models.CheckResult {
+
+	stale := now.Add(-staleIntervals * endpoint.Interval)
+
+	fresh := make(map[string]*models.CheckResult, len(reports.locations))
+	for location, report := range reports.locations {
+		// locations may be unassigned since
+		if !endpoint.Probes.Assigned(location) || report.receivedAt.Before(stale) {
+			delete(reports.locations, location)
+			continue
+		}
+		fresh[location] = report.result
+	}
+
+	return fresh
+}
+
+// MustRun starts agents expiry & stale results lookup and blocks until Stop is called.
+func (srv *Service) MustRun() {
+	if err := srv.Run(); err != nil {
+		panic(err)
+	}
+}
+
+func (srv *Service) Run() error {
+
+	defer close(srv.done)
+
+	ticker := time.NewTicker(sweepInterval)
+	defer ticker.Stop()
+
+	srv.log.Info("agents sweeper is running", slog.Duration("agent_ttl", srv.agentTTL))
+
+	for {
+		select {
+		case <-srv.stop:
+			return nil
+		case <-ticker.C:
+			srv.sweep(models.WithAllWorkspaces(context.Background()))
+		}
+	}
+}
+
+// Stop stops the lookup waiting for the current one to finish.
+func (srv *Service) Stop(ctx context.Context) {
+
+	srv.log.Info("stopping agents sweeper")
+
+	close(srv.stop)
+
+	select {
+	case <-srv.done:
+	case <-ctx.Done():
+	}
+}
+
+// sweep forgets agents not heard of for the TTL and results of endpoints
+// which are gone or no longer probed. Probed endpoints without fresh results
+// of any location fail once in a stale period, as agents may be down.
+func (srv *Service) sweep(ctx context.Context) {
+
+	op := operation.ServicesOperation("agents", "sweep")
+	log := srv.log.With(attrs.Operation(op))
+
+	now := srv.now()
+
+	srv.expire(now)
+
+	endpoints, err := srv.endpoints.Endpoints(ctx)
+	if err != nil {
+		log.Error("failed to load endpoints", attrs.Error(err))
+		return
+	}
+
+	srv.verdicts.Lock()
+	defer srv.verdicts.Unlock()
+
+	probed := make(map[string]struct{}, len(srv.results))
+
+	for _, endpoint := range endpoints {
+
+		if endpoint.Probes == nil || len(endpoint.Probes.Locations) == 0 {
+			continue
+		}
+
+		probed[endpoint.ID] = struct{}{}
+
+		reports := srv.reports(endpoint.ID, now)
+		if len(reports.fresh(endpoint, now)) > 0 ||
+			now.Sub(reports.judgedAt) < staleIntervals*endpoint.Interval {
+			continue
+		}
+
+		reports.judgedAt = now
+
+		result := &models.CheckResult{
+			EndpointID: endpoint.ID,
+			Message:    "no fresh results from locations " + strings.Join(endpoint.Probes.Locations, ", "),
+			CheckedAt:  now,
+		}
+
+		if err := srv.endpoints.ApplyResult(ctx, endpoint, result); err != nil {
+			log.Error("failed to apply result",
+				slog.String("endpoint_id", endpoint.ID),
+				attrs.Error(err),
+			)
+		}
+	}
+
+	for id := range srv.results {
+		if _, ok := probed[id]; !ok {
+			delete(srv.results, id)
+		}
+	}
+}
+
+// expire forgets agents not heard of for the TTL, they register again once they are back.
+func (srv *Service) expire(now time.Time) {
+
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+
+	for id, agent := range srv.agents {
+		if now.Sub(agent.LastSeenAt) < srv.agentTTL {
+			continue
+		}
+
+		delete(srv.agents, id)
+
+		srv.log.Info("agent expired",
+			slog.String("agent_id", id),
+			slog.String("name", agent.Name),
+			slog.String("location", agent.Location),
+		)
+	}
+}
+
+// agent returns the agent visible in the context marking it seen.
+func (srv *Service) agent(ctx context.Context, id string) (*models.Agent, error) {
+
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+
+	agent, ok := srv.agents[id]
+	if !ok || !visible(ctx, agent) {
+		return nil, models.ErrNotFound
+	}
+
+	agent.LastSeenAt = srv.now()
+
+	return copyAgent(agent), nil
+}
+
+// visible reports if the agent belongs to the workspace scope of the context.
+func visible(ctx context.Context, agent *models.Agent) bool {
+	id, ok := models.WorkspaceFrom(ctx)
+	return ok && (id == "" || id == agent.WorkspaceID)
+}
+
+// scope limits the context to endpoints the agent checks, the ones of its workspace.
+func scope(ctx context.Context, agent *models.Agent) context.Context {
+	return models.WithWorkspace(ctx, agent.WorkspaceID)
+}
+
+func copyAgent(agent *models.Agent) *models.Agent {
+	copied := *agent
+	return &copied
+}
@@ -0,0 +1,321 @@
+package agents
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vishenosik/CherryWatch/internal/services/models"
+)
+
+type endpointsMock struct {
+	endpoints map[string]*models.Endpoint
+	applied   []*models.CheckResult
+}
+
+func (em *endpointsMock) Endpoint(ctx context.Context, id string) (*models.Endpoint, error) {
+	endpoint, ok := em.endpoints[id]
+	if !ok || !inScope(ctx, endpoint) {
+		return nil, models.ErrNotFound
+	}
+	return endpoint, nil
+}
+
+func (em *endpointsMock) Endpoints(ctx context.Context) (models.Endpoints, error) {
+	endpoints := make(models.Endpoints, 0, len(em.endpoints))
+	for _, endpoint := range em.endpoints {
+		if inScope(ctx, endpoint) {
+			endpoints = append(endpoints, endpoint)
+		}
+	}
+	return endpoints, nil
+}
+
+func (em *endpointsMock) ApplyResult(_ context.Context, _ *models.Endpoint, result *models.CheckResult) error {
+	em.applied = append(em.applied, result)
+	return nil
+}
+
+func (em *endpointsMock) last() *models.CheckResult {
+	return em.applied[len(em.applied)-1]
+}
+
+func inScope(ctx context.Context, endpoint *models.Endpoint) bool {
+	id, _ := models.WorkspaceFrom(ctx)
+	return id == "" || id == endpoint.WorkspaceID
+}
+
+func newTestService() (*Service, *endpointsMock) {
+	endpoints := &endpointsMock{
+		endpoints: map[string]*models.Endpoint{
+			"api": {
+				ID:          "api",
+				WorkspaceID: models.DefaultWorkspace,
+				Interval:    time.Minute,
+				Probes:      &models.Probes{Locations: []string{"eu", "us", "asia"}, Quorum: 2},
+			},
+			"shop": {
+				ID:          "shop",
+				WorkspaceID: "shop",
+				Interval:    time.Minute,
+				Probes:      &models.Probes{Locations: []string{"eu"}},
+			},
+			"local": {
+				ID:          "local",
+				WorkspaceID: models.DefaultWorkspace,
+				Interval:    time.Minute,
+			},
+		},
+	}
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+	return NewAgentsService(log, endpoints, time.Hour), endpoints
+}
+
+func Test_Register(t *testing.T) {
+
+	srv, _ := newTestService()
+	ctx := models.WithWorkspace(context.Background(), models.DefaultWorkspace)
+	shop := models.WithWorkspace(context.Background(), "shop")
+
+	agent, err := srv.Register(ctx, &models.Agent{Name: "probe-1", Location: "eu"})
+	require.NoError(t, err)
+	assert.NotEmpty(t, agent.ID)
+	assert.Equal(t, models.DefaultWorkspace, agent.WorkspaceID)
+
+	// registering again moves the agent keeping its id
+	moved, err := srv.Register(ctx, &models.Agent{Name: "probe-1", Location: "us"})
+	require.NoError(t, err)
+	assert.Equal(t, agent.ID, moved.ID)
+	assert.Equal(t, "us", moved.Location)
+
+	// names are unique within a workspace only
+	other, err := srv.Register(shop, &models.Agent{Name: "probe-1", Location: "eu"})
+	require.NoError(t, err)
+	assert.NotEqual(t, agent.ID, other.ID)
+
+	_, err = srv.Register(ctx, &models.Agent{Name: "probe-2"})
+	assert.ErrorIs(t, err, models.ErrInvalidAgent)
+
+	agents, err := srv.Agents(shop)
+	require.NoError(t, err)
+	require.Len(t, agents, 1)
+	assert.Equal(t, other.ID, agents[0].ID)
+
+	agents, err = srv.Agents(models.WithAllWorkspaces(context.Background()))
+	require.NoError(t, err)
+	assert.Len(t, agents, 2)
+}
+
+func Test_Assignments(t *testing.T) {
+
+	srv, _ := newTestService()
+	ctx := models.WithWorkspace(context.Background(), models.DefaultWorkspace)
+	shop := models.WithWorkspace(context.Background(), "shop")
+
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		location string
+		want     []string
+	}{
+		{
+			name:     "default workspace agents check their workspace",
+			ctx:      ctx,
+			location: "eu",
+			want:     []string{"api"},
+		},
+		{
+			name:     "workspace agents check their workspace",
+			ctx:      shop,
+			location: "eu",
+			want:     []string{"shop"},
+		},
+		{
+			name:     "only endpoints of the location",
+			ctx:      ctx,
+			location: "us",
+			want:     []string{"api"},
+		},
+		{
+			name:     "unknown location",
+			ctx:      ctx,
+			location: "mars",
+			want:     []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			agent, err := srv.Register(tt.ctx, &models.Agent{Name: tt.name, Location: tt.location})
+			require.NoError(t, err)
+
+			endpoints, err := srv.Assignments(tt.ctx, agent.ID)
+			require.NoError(t, err)
+
+			ids := make([]string, 0, len(endpoints))
+			for _, endpoint := range endpoints {
+				ids = append(ids, endpoint.ID)
+			}
+			assert.ElementsMatch(t, tt.want, ids)
+		})
+	}
+
+	agent, err := srv.Register(ctx, &models.Agent{Name: "hidden", Location: "eu"})
+	require.NoError(t, err)
+
+	// agents of other workspaces are unknown
+	_, err = srv.Assignments(shop, agent.ID)
+	assert.ErrorIs(t, err, models.ErrNotFound)
+
+	_, err = srv.Assignments(ctx, "missing")
+	assert.ErrorIs(t, err, models.ErrNotFound)
+}
+
+func Test_Report_quorum(t *testing.T) {
+
+	srv, endpoints := newTestService()
+	ctx := models.WithWorkspace(context.Background(), models.DefaultWorkspace)
+
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	srv.now = func() time.Time { return now }
+
+	agents := make(map[string]string)
+	for _, location := range []string{"eu", "us", "asia"} {
+		agent, err := srv.Register(ctx, &models.Agent{Name: location, Location: location})
+		require.NoError(t, err)
+		agents[location] = agent.ID
+	}
+
+	report := func(location string, success bool) *models.CheckResult {
+		result := &models.CheckResult{EndpointID: "api", Success: success, CheckedAt: now}
+		if !success {
+			result.Message = "timeout"
+		}
+		require.NoError(t, srv.Report(ctx, agents[location], result))
+		return endpoints.last()
+	}
+
+	// a single failing location doesn't reach the quorum of 2
+	verdict := report("eu", false)
+	assert.True(t, verdict.Success)
+	assert.Equal(t, "eu", verdict.Location)
+	assert.Equal(t, "failing in 1 of 3 locations (quorum 2): eu: timeout", verdict.Message)
+
+	verdict = report("us", false)
+	assert.False(t, verdict.Success)
+	assert.Equal(t, "failing in 2 of 3 locations (quorum 2): eu: timeout; us: timeout", verdict.Message)
+
+	// results are judged together whichever location reports
+	verdict = report("asia", true)
+	assert.False(t, verdict.Success)
+	assert.Equal(t, "asia", verdict.Location)
+
+	verdict = report("eu", true)
+	assert.True(t, verdict.Success)
+
+	// locations not heard of for a few intervals aren't counted
+	now = now.Add(staleIntervals*time.Minute + time.Second)
+	report("eu", false)
+	verdict = report("asia", false)
+	assert.False(t, verdict.Success)
+	assert.Equal(t, "failing in 2 of 3 locations (quorum 2): eu: timeout; asia: timeout", verdict.Message)
+
+	now = now.Add(staleIntervals*time.Minute + time.Second)
+	verdict = report("us", false)
+	assert.True(t, verdict.Success)
+}
+
+func Test_Report_notAssigned(t *testing.T) {
+
+	srv, endpoints := newTestService()
+	ctx := models.WithWorkspace(context.Background(), models.DefaultWorkspace)
+	shop := models.WithWorkspace(context.Background(), "shop")
+
+	agent, err := srv.Register(shop, &models.Agent{Name: "probe", Location: "eu"})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name       string
+		ctx        context.Context
+		agentID    string
+		endpointID string
+		err        error
+	}{
+		{name: "assigned", ctx: shop, agentID: agent.ID, endpointID: "shop"},
+		{name: "checked by the server", ctx: shop, agentID: agent.ID, endpointID: "local", err: models.ErrNotAssigned},
+		{name: "other workspace", ctx: shop, agentID: agent.ID, endpointID: "api", err: models.ErrNotAssigned},
+		{name: "deleted", ctx: shop, agentID: agent.ID, endpointID: "deleted", err: models.ErrNotAssigned},
+		{name: "unknown agent", ctx: ctx, agentID: agent.ID, endpointID: "shop", err: models.ErrNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := srv.Report(tt.ctx, tt.agentID, &models.CheckResult{EndpointID: tt.endpointID})
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+
+	assert.Len(t, endpoints.applied, 1)
+}
+
+func Test_sweep(t *testing.T) {
+
+	srv, endpoints := newTestService()
+	ctx := models.WithWorkspace(context.Background(), models.DefaultWorkspace)
+	all := models.WithAllWorkspaces(context.Background())
+
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	srv.now = func() time.Time { return now }
+
+	eu, err := srv.Register(ctx, &models.Agent{Name: "eu", Location: "eu"})
+	require.NoError(t, err)
+
+	require.NoError(t, srv.Report(ctx, eu.ID, &models.CheckResult{EndpointID: "api", Success: true}))
+	require.Len(t, endpoints.applied, 1)
+
+	// fresh results & endpoints only just probed are left alone
+	srv.sweep(all)
+	assert.Len(t, endpoints.applied, 1)
+	assert.Contains(t, srv.results, "shop")
+
+	// agents not heard of for the TTL are forgotten
+	now = now.Add(time.Hour)
+	srv.sweep(all)
+
+	agents, err := srv.Agents(all)
+	require.NoError(t, err)
+	assert.Empty(t, agents)
+
+	_, err = srv.Assignments(ctx, eu.ID)
+	assert.ErrorIs(t, err, models.ErrNotFound)
+
+	// probed endpoints without fresh results fail once in a stale period
+	failed := make(map[string]string)
+	for _, result := range endpoints.applied[1:] {
+		assert.False(t, result.Success)
+		failed[result.EndpointID] = result.Message
+	}
+	assert.Equal(t, map[string]string{
+		"api":  "no fresh results from locations eu, us, asia",
+		"shop": "no fresh results from locations eu",
+	}, failed)
+
+	srv.sweep(all)
+	assert.Len(t, endpoints.applied, 3)
+
+	// results of deleted endpoints are dropped
+	delete(endpoints.endpoints, "shop")
+	srv.sweep(all)
+	assert.NotContains(t, srv.results, "shop")
+	assert.Contains(t, srv.results, "api")
+	assert.NotContains(t, srv.results, "local")
+}
@@ -0,0 +1,156 @@
+package audit
+
+import (
+	"bytes"
+	"encoding/json"
+	"slices"
+	"strings"
+
+	"github.com/vishenosik/CherryWatch/internal/services/models"
+)
+
+const redacted = "[redacted]"
+
+// sensitive fields are never written to the audit log, matched case-insensitively.
+var sensitive = []string{"token", "dsn", "password", "secret", "env", "headers"}
+
+// Snapshot encodes a resource state as JSON with sensitive fields redacted.
+func Snapshot(state any) (json.RawMessage, error) {
+
+	if state == nil {
+		return nil, nil
+	}
+
+	data, err := json.Marshal(state)
+	if err != nil {
+		return nil, err
+	}
+
+	var value any
+	if err := json.Unmarshal(data, &value); err != nil {
+		return nil, err
+	}
+
+	if value == nil {
+		return nil, nil
+	}
+
+	return json.Marshal(redact(value))
+}
+
+func redact(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, field := range v {
+			if slices.Contains(sensitive, strings.ToLower(key)) && !isEmpty(field) {
+				v[key] = redacted
+				continue
+			}
+			v[key] = redact(field)
+		}
+	case []any:
+		for i := range v {
+			v[i] = redact(v[i])
+		}
+	}
+	return value
+}
+
+func isEmpty(value any) bool {
+	switch v := value.(type) {
+	case nil:
+		return true
+	case string:
+		return v == ""
+	case map[string]any:
+		return len(v) == 0
+	case []any:
+		return len(v) == 0
+	}
+	return false
+}
+
+// Diff returns changed fields of two JSON documents ordered by path.
+// Objects are compared field by field, arrays as a whole,
+// empty values are considered unset.
+func Diff(before, after json.RawMessage) ([]models.FieldChange, error) {
+
+	beforeFields, err := flatten(before)
+	if err != nil {
+		return nil, err
+	}
+
+	afterFields, err := flatten(after)
+	if err != nil {
+		return nil, err
+	}
+
+	paths := make([]string, 0, len(beforeFields)+len(afterFields))
+	for path := range beforeFields {
+		paths = append(paths, path)
+	}
+	for path := range afterFields {
+		if _, ok := beforeFields[path]; !ok {
+			paths = append(paths, path)
+		}
+	}
+	slices.Sort(paths)
+
+	changes := make([]models.FieldChange, 0)
+	for _, path := range paths {
+		was, is := beforeFields[path], afterFields[path]
+		if bytes.Equal(was, is) {
+			continue
+		}
+		changes = append(changes, models.FieldChange{Path: path, Before: was, After: is})
+	}
+
+	return changes, nil
+}
+
+func flatten(data json.RawMessage) (map[string]json.RawMessage, error) {
+
+	fields := make(map[string]json.RawMessage)
+	if len(data) == 0 {
+		return fields, nil
+	}
+
+	var value any
+	if err := json.Unmarshal(data, &value); err != nil {
+		return nil, err
+	}
+
+	return fields, walk(fields, "", value)
+}
+
+func walk(fields map[string]json.RawMessage, path string, value any) error {
+
+	if object, ok := value.(map[string]any); ok && len(object) > 0 {
+		for key, field := range object {
+			if err := walk(fields, join(path, key), field); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	// unset and empty fields are the same
+	if isEmpty(value) {
+		return nil
+	}
+
+	// json.Marshal sorts map keys, so equal values encode equally
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	fields[path] = data
+	return nil
+}
+
+func join(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
@@ -0,0 +1,80 @@
+package audit
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vishenosik/CherryWatch/internal/services/models"
+)
+
+func Test_Diff(t *testing.T) {
+
+	testingTable := []struct {
+		name     string
+		before   string
+		after    string
+		expected []models.FieldChange
+	}{
+		{
+			name:     "equal",
+			before:   `{"a":1,"b":{"c":"x"}}`,
+			after:    `{"b":{"c":"x"},"a":1}`,
+			expected: []models.FieldChange{},
+		},
+		{
+			name:   "nested field changed",
+			before: `{"a":1,"b":{"c":"x","d":[1,2]}}`,
+			after:  `{"a":1,"b":{"c":"y","d":[1,2,3]}}`,
+			expected: []models.FieldChange{
+				{Path: "b.c", Before: json.RawMessage(`"x"`), After: json.RawMessage(`"y"`)},
+				{Path: "b.d", Before: json.RawMessage(`[1,2]`), After: json.RawMessage(`[1,2,3]`)},
+			},
+		},
+		{
+			name:  "created",
+			after: `{"a":1}`,
+			expected: []models.FieldChange{
+				{Path: "a", After: json.RawMessage(`1`)},
+			},
+		},
+		{
+			name:   "deleted",
+			before: `{"a":1,"b":null}`,
+			expected: []models.FieldChange{
+				{Path: "a", Before: json.RawMessage(`1`)},
+			},
+		},
+	}
+
+	for _, tt := range testingTable {
+		t.Run(tt.name, func(t *testing.T) {
+			changes, err := Diff(json.RawMessage(tt.before), json.RawMessage(tt.after))
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, changes)
+		})
+	}
+}
+
+func Test_SnapshotRedacts(t *testing.T) {
+
+	endpoint := &models.Endpoint{
+		ID:        "1",
+		Heartbeat: &models.Heartbeat{Token: "secret-token"},
+		SQL:       &models.SQLCheck{Driver: "postgres", DSN: "postgres://user:pass@db"},
+		Exec:      &models.ExecCheck{Command: "check", Env: map[string]string{"API_KEY": "key"}},
+	}
+
+	data, err := Snapshot(endpoint)
+	require.NoError(t, err)
+
+	assert.NotContains(t, string(data), "secret-token")
+	assert.NotContains(t, string(data), "user:pass")
+	assert.NotContains(t, string(data), "API_KEY")
+	assert.Contains(t, string(data), `"Driver":"postgres"`)
+
+	data, err = Snapshot(nil)
+	require.NoError(t, err)
+	assert.Nil(t, data)
+}
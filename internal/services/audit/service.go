@@ -0,0 +1,109 @@
+package audit
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"github.com/vishenosik/CherryWatch/internal/services/models"
+	attrs "github.com/vishenosik/web-tools/log"
+	"github.com/vishenosik/web-tools/operation"
+)
+
+type Store interface {
+	SaveEntry(ctx context.Context, entry *models.AuditEntry) error
+	Entries(ctx context.Context, filter models.AuditFilter) (models.AuditEntries, error)
+}
+
+type Service struct {
+	log   *slog.Logger
+	store Store
+}
+
+func NewAuditService(
+	log *slog.Logger,
+	store Store,
+) *Service {
+	return &Service{
+		log:   log,
+		store: store,
+	}
+}
+
+// Record appends the change made by the context principal to the audit log.
+// The change is applied already, so failures are logged rather than returned.
+func (srv *Service) Record(ctx context.Context, change models.Change) {
+	if err := srv.record(ctx, change); err != nil {
+		srv.log.Error("failed to record audit entry",
+			slog.String("action", string(change.Action)),
+			slog.String("resource", string(change.Resource)),
+			slog.String("resource_id", change.ResourceID),
+			attrs.Error(err),
+		)
+	}
+}
+
+func (srv *Service) record(ctx context.Context, change models.Change) error {
+
+	op := operation.ServicesOperation("audit", "Record")
+
+	before, err := Snapshot(change.Before)
+	if err != nil {
+		return errors.Wrap(err, op)
+	}
+
+	after, err := Snapshot(change.After)
+	if err != nil {
+		return errors.Wrap(err, op)
+	}
+
+	diff, err := Diff(before, after)
+	if err != nil {
+		return errors.Wrap(err, op)
+	}
+
+	entry := &models.AuditEntry{
+		ID:          uuid.NewString(),
+		WorkspaceID: models.OwnerWorkspace(ctx, change.WorkspaceID),
+		Action:      change.Action,
+		Resource:    change.Resource,
+		ResourceID:  change.ResourceID,
+		Before:      before,
+		After:       after,
+		Diff:        diff,
+		Time:        time.Now().UTC(),
+	}
+
+	if principal, ok := models.PrincipalFrom(ctx); ok {
+		entry.UserID = principal.UserID
+		entry.AppID = principal.AppID
+		entry.AppName = principal.AppName
+	}
+
+	// request may be gone already, the entry must be stored anyway
+	ctx = models.WithWorkspace(context.WithoutCancel(ctx), entry.WorkspaceID)
+
+	if err := srv.store.SaveEntry(ctx, entry); err != nil {
+		return errors.Wrap(err, op)
+	}
+
+	return nil
+}
+
+// Entries returns audit entries of the caller workspace matching the filter.
+func (srv *Service) Entries(ctx context.Context, filter models.AuditFilter) (models.AuditEntries, error) {
+
+	op := operation.ServicesOperation("audit", "Entries")
+
+	filter.Since = filter.Since.UTC()
+	filter.Until = filter.Until.UTC()
+
+	entries, err := srv.store.Entries(ctx, filter)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	return entries, nil
+}
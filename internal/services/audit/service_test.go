@@ -0,0 +1,80 @@
+package audit
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vishenosik/CherryWatch/internal/services/models"
+	storeModels "github.com/vishenosik/CherryWatch/internal/store/models"
+)
+
+type storeMock struct {
+	entries models.AuditEntries
+}
+
+func (sm *storeMock) SaveEntry(ctx context.Context, entry *models.AuditEntry) error {
+	if _, err := storeModels.WorkspaceScope(ctx); err != nil {
+		return err
+	}
+	sm.entries = append(sm.entries, entry)
+	return nil
+}
+
+func (sm *storeMock) Entries(_ context.Context, _ models.AuditFilter) (models.AuditEntries, error) {
+	return sm.entries, nil
+}
+
+func Test_Record(t *testing.T) {
+
+	store := &storeMock{}
+	srv := NewAuditService(slog.New(slog.NewTextHandler(io.Discard, nil)), store)
+
+	ctx, err := models.WithPrincipal(context.Background(), &models.Principal{
+		WorkspaceID: "team-a",
+		AppID:       "app-1",
+		AppName:     "ci",
+		UserID:      "user-1",
+		Role:        models.RoleEditor,
+	})
+	require.NoError(t, err)
+
+	// canceled request must not lose the entry
+	ctx, cancel := context.WithCancel(ctx)
+	cancel()
+
+	srv.Record(ctx, models.Change{
+		WorkspaceID: "team-b",
+		Action:      models.AuditDelete,
+		Resource:    models.AuditEndpoint,
+		ResourceID:  "ep-1",
+		Before:      &models.Endpoint{ID: "ep-1", ServiceName: "api"},
+	})
+
+	require.Len(t, store.entries, 1)
+	entry := store.entries[0]
+
+	assert.Equal(t, "team-a", entry.WorkspaceID)
+	assert.Equal(t, "user-1", entry.UserID)
+	assert.Equal(t, "app-1", entry.AppID)
+	assert.Equal(t, models.AuditDelete, entry.Action)
+	assert.Nil(t, entry.After)
+	assert.Contains(t, string(entry.Before), `"ServiceName":"api"`)
+	assert.NotEmpty(t, entry.Diff)
+
+	// changes made outside of requests belong to the resource workspace
+	srv.Record(models.WithAllWorkspaces(context.Background()), models.Change{
+		WorkspaceID: "team-b",
+		Action:      models.AuditCreate,
+		Resource:    models.AuditEndpoint,
+		ResourceID:  "ep-2",
+		After:       &models.Endpoint{ID: "ep-2"},
+	})
+
+	require.Len(t, store.entries, 2)
+	assert.Equal(t, "team-b", store.entries[1].WorkspaceID)
+	assert.Empty(t, store.entries[1].AppID)
+}
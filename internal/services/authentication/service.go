@@ -0,0 +1,358 @@
+package authentication
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"github.com/vishenosik/CherryWatch/internal/services/models"
+	storeModels "github.com/vishenosik/CherryWatch/internal/store/models"
+	"github.com/vishenosik/web-tools/operation"
+)
+
+const (
+	// prefix telling API keys apart from JWTs
+	apiKeyPrefix = "cw_"
+	// issuer of access tokens
+	issuer = "cherrywatch"
+	// name of the app created on the first start, it signs user tokens
+	defaultAppName = "default"
+	// default access token lifetime
+	defaultTokenTTL = time.Hour
+)
+
+type Store interface {
+	App(ctx context.Context, id string) (*storeModels.App, error)
+	AppByName(ctx context.Context, name string) (*storeModels.App, error)
+	AppByAPIKey(ctx context.Context, hash string) (*storeModels.App, error)
+	Apps(ctx context.Context) ([]*storeModels.App, error)
+	SaveApp(ctx context.Context, app *storeModels.App) error
+	SaveAPIKey(ctx context.Context, appID, hash string) error
+}
+
+type Users interface {
+	User(ctx context.Context, id string) (*models.User, error)
+	Verify(ctx context.Context, email, password string) (*models.User, error)
+}
+
+// Service authenticates callers by long-lived API keys
+// and short-lived JWTs signed with the app secret.
+type Service struct {
+	log      *slog.Logger
+	store    Store
+	users    Users
+	tokenTTL time.Duration
+}
+
+type claims struct {
+	jwt.RegisteredClaims
+	// App signed the token
+	App string `json:"app"`
+}
+
+func NewAuthenticationService(
+	log *slog.Logger,
+	store Store,
+	users Users,
+	tokenTTL time.Duration,
+) *Service {
+
+	if tokenTTL <= 0 {
+		tokenTTL = defaultTokenTTL
+	}
+
+	return &Service{
+		log:      log,
+		store:    store,
+		users:    users,
+		tokenTTL: tokenTTL,
+	}
+}
+
+// Bootstrap creates the default admin app if there are no apps yet.
+// Its API key is written to out once, it can't be recovered later.
+// The key is kept out of logs, which are usually shipped & retained.
+func (srv *Service) Bootstrap(ctx context.Context, out io.Writer) error {
+
+	op := operation.ServicesOperation("authentication", "Bootstrap")
+
+	apps, err := srv.store.Apps(ctx)
+	if err != nil {
+		return errors.Wrap(err, op)
+	}
+
+	if len(apps) > 0 {
+		return nil
+	}
+
+	app, apiKey, err := srv.CreateApp(ctx, models.DefaultWorkspace, defaultAppName, models.RoleAdmin)
+	if err != nil {
+		return errors.Wrap(err, op)
+	}
+
+	if _, err := fmt.Fprintf(out, "CherryWatch default admin API key, store it safely, it won't be shown again:\n%s\n", apiKey); err != nil {
+		return errors.Wrap(err, op)
+	}
+
+	srv.log.Warn("default app created, its API key was printed once",
+		slog.String("app_id", app.ID),
+	)
+
+	return nil
+}
+
+// CreateApp creates an app of the workspace with a random secret and returns its API key.
+func (srv *Service) CreateApp(
+	ctx context.Context,
+	workspaceID, name string,
+	role models.Role,
+) (*storeModels.App, string, error) {
+
+	op := operation.ServicesOperation("authentication", "CreateApp")
+
+	if err := role.Validate(); err != nil {
+		return nil, "", errors.Wrap(err, op)
+	}
+
+	secret, err := randomHex(32)
+	if err != nil {
+		return nil, "", errors.Wrap(err, op)
+	}
+
+	app := &storeModels.App{
+		ID:          uuid.NewString(),
+		WorkspaceID: workspaceID,
+		Name:        name,
+		Secret:      secret,
+		Role:        string(role),
+	}
+
+	if err := srv.store.SaveApp(ctx, app); err != nil {
+		return nil, "", errors.Wrap(err, op)
+	}
+
+	apiKey, err := srv.CreateAPIKey(ctx, app.ID)
+	if err != nil {
+		return nil, "", errors.Wrap(err, op)
+	}
+
+	return app, apiKey, nil
+}
+
+// CreateAPIKey generates a new API key of the app, only its hash is stored.
+func (srv *Service) CreateAPIKey(ctx context.Context, appID string) (string, error) {
+
+	op := operation.ServicesOperation("authentication", "CreateAPIKey")
+
+	key, err := randomHex(32)
+	if err != nil {
+		return "", errors.Wrap(err, op)
+	}
+
+	apiKey := apiKeyPrefix + key
+
+	if err := srv.store.SaveAPIKey(ctx, appID, hashAPIKey(apiKey)); err != nil {
+		return "", errors.Wrap(err, op)
+	}
+
+	return apiKey, nil
+}
+
+// IssueToken exchanges an API key for a short-lived access token.
+func (srv *Service) IssueToken(ctx context.Context, apiKey string) (*models.Token, error) {
+
+	op := operation.ServicesOperation("authentication", "IssueToken")
+
+	app, err := srv.appByAPIKey(ctx, apiKey)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	token, err := srv.sign(app, app.ID)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	return token, nil
+}
+
+// Login exchanges user email & password for a short-lived access token
+// signed by the default app.
+func (srv *Service) Login(ctx context.Context, email, password string) (*models.Token, error) {
+
+	op := operation.ServicesOperation("authentication", "Login")
+
+	// user workspace isn't known before login
+	ctx = models.WithAllWorkspaces(ctx)
+
+	user, err := srv.users.Verify(ctx, email, password)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	app, err := srv.store.AppByName(ctx, defaultAppName)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	token, err := srv.sign(app, user.ID)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	return token, nil
+}
+
+// Authenticate resolves the caller by an API key or an access token.
+func (srv *Service) Authenticate(ctx context.Context, credential string) (*models.Principal, error) {
+
+	op := operation.ServicesOperation("authentication", "Authenticate")
+
+	// caller workspace isn't known before authentication
+	ctx = models.WithAllWorkspaces(ctx)
+
+	if credential == "" {
+		return nil, errors.Wrap(models.ErrUnauthenticated, op)
+	}
+
+	if strings.HasPrefix(credential, apiKeyPrefix) {
+		app, err := srv.appByAPIKey(ctx, credential)
+		if err != nil {
+			return nil, errors.Wrap(err, op)
+		}
+		return appPrincipal(app), nil
+	}
+
+	principal, err := srv.principalByToken(ctx, credential)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	return principal, nil
+}
+
+func (srv *Service) sign(app *storeModels.App, subject string) (*models.Token, error) {
+
+	now := time.Now()
+	expiresAt := now.Add(srv.tokenTTL)
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    issuer,
+			Subject:   subject,
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+		},
+		App: app.ID,
+	})
+
+	signed, err := token.SignedString(app.GetSecret())
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.Token{
+		AccessToken: signed,
+		ExpiresAt:   expiresAt,
+	}, nil
+}
+
+func (srv *Service) appByAPIKey(ctx context.Context, apiKey string) (*storeModels.App, error) {
+
+	app, err := srv.store.AppByAPIKey(ctx, hashAPIKey(apiKey))
+	if err != nil {
+		if errors.Is(err, storeModels.ErrNotFound) {
+			return nil, models.ErrInvalidCredentials
+		}
+		return nil, err
+	}
+
+	return app, nil
+}
+
+// principalByToken verifies token signature with the secret of the signing app.
+// Subject is either the app itself or a user, whose current role is applied.
+func (srv *Service) principalByToken(ctx context.Context, accessToken string) (*models.Principal, error) {
+
+	var (
+		app      *storeModels.App
+		storeErr error
+	)
+
+	token, err := jwt.ParseWithClaims(accessToken, &claims{},
+		func(token *jwt.Token) (any, error) {
+			var err error
+			app, err = srv.store.App(ctx, token.Claims.(*claims).App)
+			if err != nil {
+				if !errors.Is(err, storeModels.ErrNotFound) {
+					storeErr = err
+				}
+				return nil, err
+			}
+			return app.GetSecret(), nil
+		},
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}),
+		jwt.WithIssuer(issuer),
+		jwt.WithExpirationRequired(),
+	)
+
+	switch {
+	case storeErr != nil:
+		return nil, storeErr
+	case err != nil:
+		return nil, models.ErrInvalidCredentials
+	}
+
+	subject := token.Claims.(*claims).Subject
+
+	if subject == app.ID {
+		return appPrincipal(app), nil
+	}
+
+	user, err := srv.users.User(ctx, subject)
+	if err != nil {
+		if errors.Is(err, models.ErrNotFound) {
+			return nil, models.ErrInvalidCredentials
+		}
+		return nil, err
+	}
+
+	return &models.Principal{
+		WorkspaceID: user.WorkspaceID,
+		AppID:       app.ID,
+		AppName:     app.Name,
+		UserID:      user.ID,
+		Role:        user.Role,
+	}, nil
+}
+
+func appPrincipal(app *storeModels.App) *models.Principal {
+	return &models.Principal{
+		WorkspaceID: app.WorkspaceID,
+		AppID:       app.ID,
+		AppName:     app.Name,
+		Role:        models.Role(app.Role),
+	}
+}
+
+func hashAPIKey(apiKey string) string {
+	sum := sha256.Sum256([]byte(apiKey))
+	return hex.EncodeToString(sum[:])
+}
+
+func randomHex(size int) (string, error) {
+	buf := make([]byte, size)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
@@ -0,0 +1,223 @@
+package authentication
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vishenosik/CherryWatch/internal/services/models"
+	storeModels "github.com/vishenosik/CherryWatch/internal/store/models"
+)
+
+type storeMock struct {
+	apps map[string]*storeModels.App
+	keys map[string]string
+}
+
+func newStoreMock() *storeMock {
+	return &storeMock{
+		apps: make(map[string]*storeModels.App),
+		keys: make(map[string]string),
+	}
+}
+
+func (sm *storeMock) App(_ context.Context, id string) (*storeModels.App, error) {
+	app, ok := sm.apps[id]
+	if !ok {
+		return nil, storeModels.ErrNotFound
+	}
+	return app, nil
+}
+
+func (sm *storeMock) AppByName(_ context.Context, name string) (*storeModels.App, error) {
+	for _, app := range sm.apps {
+		if app.Name == name {
+			return app, nil
+		}
+	}
+	return nil, storeModels.ErrNotFound
+}
+
+func (sm *storeMock) AppByAPIKey(ctx context.Context, hash string) (*storeModels.App, error) {
+	id, ok := sm.keys[hash]
+	if !ok {
+		return nil, storeModels.ErrNotFound
+	}
+	return sm.App(ctx, id)
+}
+
+func (sm *storeMock) Apps(_ context.Context) ([]*storeModels.App, error) {
+	apps := make([]*storeModels.App, 0, len(sm.apps))
+	for _, app := range sm.apps {
+		apps = append(apps, app)
+	}
+	return apps, nil
+}
+
+func (sm *storeMock) SaveApp(_ context.Context, app *storeModels.App) error {
+	sm.apps[app.ID] = app
+	return nil
+}
+
+func (sm *storeMock) SaveAPIKey(_ context.Context, appID, hash string) error {
+	sm.keys[hash] = appID
+	return nil
+}
+
+type usersMock struct {
+	users map[string]*models.User
+}
+
+func (um usersMock) User(_ context.Context, id string) (*models.User, error) {
+	user, ok := um.users[id]
+	if !ok {
+		return nil, models.ErrNotFound
+	}
+	return user, nil
+}
+
+func (um usersMock) Verify(_ context.Context, email, password string) (*models.User, error) {
+	for _, user := range um.users {
+		if user.Email == email && password == "secret-password" {
+			return user, nil
+		}
+	}
+	return nil, models.ErrInvalidCredentials
+}
+
+func newTestService(ttl time.Duration) *Service {
+	return NewAuthenticationService(
+		slog.New(slog.NewTextHandler(io.Discard, nil)),
+		newStoreMock(),
+		usersMock{users: map[string]*models.User{
+			"u1": {ID: "u1", Email: "viewer@example.com", Role: models.RoleViewer},
+		}},
+		ttl,
+	)
+}
+
+func Test_Authenticate(t *testing.T) {
+
+	ctx := context.Background()
+	srv := newTestService(time.Hour)
+
+	app, apiKey, err := srv.CreateApp(ctx, models.DefaultWorkspace, "ci", models.RoleEditor)
+	require.NoError(t, err)
+
+	principal, err := srv.Authenticate(ctx, apiKey)
+	require.NoError(t, err)
+	assert.Equal(t, app.ID, principal.AppID)
+
+	token, err := srv.IssueToken(ctx, apiKey)
+	require.NoError(t, err)
+	assert.WithinDuration(t, time.Now().Add(time.Hour), token.ExpiresAt, time.Minute)
+
+	principal, err = srv.Authenticate(ctx, token.AccessToken)
+	require.NoError(t, err)
+	assert.Equal(t, "ci", principal.AppName)
+	assert.Equal(t, models.RoleEditor, principal.Role)
+	assert.Empty(t, principal.UserID)
+
+	forged, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.RegisteredClaims{
+		Issuer:    issuer,
+		Subject:   app.ID,
+		ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+	}).SignedString([]byte("wrong secret"))
+	require.NoError(t, err)
+
+	testingTable := []struct {
+		name       string
+		credential string
+		err        error
+	}{
+		{name: "empty", err: models.ErrUnauthenticated},
+		{name: "unknown API key", credential: apiKeyPrefix + "deadbeef", err: models.ErrInvalidCredentials},
+		{name: "malformed token", credential: "not.a.jwt", err: models.ErrInvalidCredentials},
+		{name: "foreign signature", credential: forged, err: models.ErrInvalidCredentials},
+	}
+
+	for _, tt := range testingTable {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := srv.Authenticate(ctx, tt.credential)
+			assert.ErrorIs(t, err, tt.err)
+		})
+	}
+}
+
+func Test_Authenticate_Expired(t *testing.T) {
+
+	ctx := context.Background()
+	srv := newTestService(time.Hour)
+
+	_, apiKey, err := srv.CreateApp(ctx, models.DefaultWorkspace, "ci", models.RoleEditor)
+	require.NoError(t, err)
+
+	srv.tokenTTL = -time.Minute
+
+	token, err := srv.IssueToken(ctx, apiKey)
+	require.NoError(t, err)
+
+	_, err = srv.Authenticate(ctx, token.AccessToken)
+	assert.ErrorIs(t, err, models.ErrInvalidCredentials)
+}
+
+func Test_Bootstrap(t *testing.T) {
+
+	ctx := context.Background()
+	srv := newTestService(0)
+
+	var logs, out bytes.Buffer
+	srv.log = slog.New(slog.NewTextHandler(&logs, nil))
+
+	require.NoError(t, srv.Bootstrap(ctx, &out))
+	require.NoError(t, srv.Bootstrap(ctx, &out))
+
+	apps, err := srv.store.Apps(ctx)
+	require.NoError(t, err)
+	assert.Len(t, apps, 1)
+
+	// the key is printed once and never logged
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	require.Len(t, lines, 2)
+	apiKey := lines[1]
+
+	principal, err := srv.Authenticate(ctx, apiKey)
+	require.NoError(t, err)
+	assert.Equal(t, models.RoleAdmin, principal.Role)
+
+	assert.Contains(t, logs.String(), apps[0].ID)
+	assert.NotContains(t, logs.String(), apiKey)
+}
+
+func Test_Login(t *testing.T) {
+
+	ctx := context.Background()
+	srv := newTestService(time.Hour)
+
+	require.NoError(t, srv.Bootstrap(ctx, io.Discard))
+
+	_, err := srv.Login(ctx, "viewer@example.com", "wrong")
+	assert.ErrorIs(t, err, models.ErrInvalidCredentials)
+
+	token, err := srv.Login(ctx, "viewer@example.com", "secret-password")
+	require.NoError(t, err)
+
+	principal, err := srv.Authenticate(ctx, token.AccessToken)
+	require.NoError(t, err)
+	assert.Equal(t, "u1", principal.UserID)
+	assert.Equal(t, models.RoleViewer, principal.Role)
+	assert.False(t, principal.Allows(models.RoleEditor))
+
+	// deleted users lose access before their tokens expire
+	delete(srv.users.(usersMock).users, "u1")
+
+	_, err = srv.Authenticate(ctx, token.AccessToken)
+	assert.ErrorIs(t, err, models.ErrInvalidCredentials)
+}
@@ -0,0 +1,139 @@
+package backup
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/vishenosik/CherryWatch/internal/services/models"
+	storeModels "github.com/vishenosik/CherryWatch/internal/store/models"
+	"github.com/vishenosik/web-tools/operation"
+)
+
+type Store interface {
+	Backup(ctx context.Context, dst string) error
+	Restore(ctx context.Context, src string) error
+}
+
+type Service struct {
+	log   *slog.Logger
+	store Store
+}
+
+func NewBackupService(
+	log *slog.Logger,
+	store Store,
+) *Service {
+	return &Service{
+		log:   log,
+		store: store,
+	}
+}
+
+// Backup writes a consistent snapshot of the store to dst while the server keeps running.
+// Backups hold data of every workspace, so the default workspace only is allowed to make them.
+func (srv *Service) Backup(ctx context.Context, dst string) error {
+
+	op := operation.ServicesOperation("backup", "Backup")
+
+	if !manager(ctx) {
+		return errors.Wrap(models.ErrForbidden, op)
+	}
+
+	start := time.Now()
+
+	if err := srv.store.Backup(ctx, dst); err != nil {
+		return errors.Wrap(storeError(err), op)
+	}
+
+	srv.log.Info("store backed up",
+		slog.String("path", dst),
+		slog.Duration("duration", time.Since(start)),
+	)
+
+	return nil
+}
+
+// Snapshot backs the store up to a temporary file and opens it,
+// the file is removed once the snapshot is closed.
+func (srv *Service) Snapshot(ctx context.Context) (io.ReadCloser, error) {
+
+	op := operation.ServicesOperation("backup", "Snapshot")
+
+	dir, err := os.MkdirTemp("", "cherrywatch-backup-*")
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	path := filepath.Join(dir, "CherryWatch.db")
+
+	if err := srv.Backup(ctx, path); err != nil {
+		os.RemoveAll(dir)
+		return nil, errors.Wrap(err, op)
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		os.RemoveAll(dir)
+		return nil, errors.Wrap(err, op)
+	}
+
+	return &snapshot{File: file, dir: dir}, nil
+}
+
+// Restore replaces the store with the backup at src.
+// The backup schema is validated first, the server is expected to be stopped.
+func (srv *Service) Restore(ctx context.Context, src string) error {
+
+	op := operation.ServicesOperation("backup", "Restore")
+
+	if !manager(ctx) {
+		return errors.Wrap(models.ErrForbidden, op)
+	}
+
+	if err := srv.store.Restore(ctx, src); err != nil {
+		return errors.Wrap(storeError(err), op)
+	}
+
+	srv.log.Info("store restored", slog.String("path", src))
+
+	return nil
+}
+
+// snapshot is a temporary backup file removed on close.
+type snapshot struct {
+	*os.File
+	dir string
+}
+
+func (snap *snapshot) Close() error {
+	err := snap.File.Close()
+	if rmErr := os.RemoveAll(snap.dir); err == nil {
+		err = rmErr
+	}
+	return err
+}
+
+// storeError translates store errors to the service ones.
+func storeError(err error) error {
+	switch {
+	case errors.Is(err, storeModels.ErrNotSupported):
+		return models.ErrBackupNotSupported
+	case errors.Is(err, storeModels.ErrInvalidBackup):
+		// the reason is kept, operators restore from the CLI and read it there
+		reason := strings.TrimSuffix(err.Error(), ": "+storeModels.ErrInvalidBackup.Error())
+		return errors.Wrap(models.ErrInvalidBackup, reason)
+	}
+	return err
+}
+
+// manager reports whether the context belongs to the default workspace.
+func manager(ctx context.Context) bool {
+	id, ok := models.WorkspaceFrom(ctx)
+	return ok && (id == "" || id == models.DefaultWorkspace)
+}
@@ -0,0 +1,105 @@
+package backup
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vishenosik/CherryWatch/internal/services/models"
+	storeModels "github.com/vishenosik/CherryWatch/internal/store/models"
+)
+
+type storeMock struct {
+	err error
+}
+
+func (sm *storeMock) Backup(_ context.Context, dst string) error {
+	if sm.err != nil {
+		return sm.err
+	}
+	return os.WriteFile(dst, []byte("snapshot"), 0o600)
+}
+
+func (sm *storeMock) Restore(context.Context, string) error {
+	return sm.err
+}
+
+func newService(store Store) *Service {
+	return NewBackupService(slog.New(slog.NewTextHandler(io.Discard, nil)), store)
+}
+
+func Test_Snapshot(t *testing.T) {
+
+	srv := newService(&storeMock{})
+
+	snap, err := srv.Snapshot(models.WithWorkspace(context.Background(), models.DefaultWorkspace))
+	require.NoError(t, err)
+
+	data, err := io.ReadAll(snap)
+	require.NoError(t, err)
+	assert.Equal(t, "snapshot", string(data))
+
+	// the temporary file is removed along with its directory
+	dir := filepath.Dir(snap.(*snapshot).Name())
+	require.NoError(t, snap.Close())
+	assert.NoDirExists(t, dir)
+}
+
+func Test_errors(t *testing.T) {
+
+	invalid := errors.Wrap(storeModels.ErrInvalidBackup, "Store.sqlite.Restore: schema version 99 is unknown, latest is 11")
+
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		err     error
+		want    error
+		message string
+	}{
+		{
+			name: "other workspace",
+			ctx:  models.WithWorkspace(context.Background(), "team-a"),
+			want: models.ErrForbidden,
+		},
+		{
+			name: "no workspace scope",
+			ctx:  context.Background(),
+			want: models.ErrForbidden,
+		},
+		{
+			name: "not supported",
+			ctx:  models.WithAllWorkspaces(context.Background()),
+			err:  errors.Wrap(storeModels.ErrNotSupported, "Store.sql.Backup"),
+			want: models.ErrBackupNotSupported,
+		},
+		{
+			name:    "invalid backup",
+			ctx:     models.WithAllWorkspaces(context.Background()),
+			err:     invalid,
+			want:    models.ErrInvalidBackup,
+			message: "schema version 99 is unknown, latest is 11: invalid backup",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			srv := newService(&storeMock{err: tt.err})
+
+			err := srv.Restore(tt.ctx, "backup.db")
+			assert.ErrorIs(t, err, tt.want)
+			assert.Contains(t, err.Error(), tt.message)
+
+			if !errors.Is(tt.want, models.ErrInvalidBackup) {
+				_, err = srv.Snapshot(tt.ctx)
+				assert.ErrorIs(t, err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,299 @@
+package badges
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/vishenosik/CherryWatch/internal/services/models"
+	storeModels "github.com/vishenosik/CherryWatch/internal/store/models"
+	attrs "github.com/vishenosik/web-tools/log"
+	"github.com/vishenosik/web-tools/operation"
+)
+
+type Endpoints interface {
+	Endpoint(ctx context.Context, id string) (*models.Endpoint, error)
+	Endpoints(ctx context.Context) (models.Endpoints, error)
+}
+
+type Incidents interface {
+	Incidents(ctx context.Context, filter models.IncidentsFilter) (models.Incidents, error)
+}
+
+type History interface {
+	DailyChecks(ctx context.Context, endpointIDs []string, since time.Time) ([]*models.DailyChecks, error)
+}
+
+type Maintenances interface {
+	Maintenances(ctx context.Context, filter models.MaintenancesFilter) (models.Maintenances, error)
+}
+
+type Pages interface {
+	Published(ctx context.Context, workspaceID string, endpoints models.Endpoints) (models.Endpoints, error)
+}
+
+// Service builds badges of endpoints and endpoint groups.
+type Service struct {
+	log          *slog.Logger
+	endpoints    Endpoints
+	incidents    Incidents
+	history      History
+	maintenances Maintenances
+	pages        Pages
+	cache        storeModels.CacheProvider
+	cacheTTL     time.Duration
+}
+
+type Option func(*Service)
+
+// WithCache keeps badges for the ttl, badges are embedded into READMEs and dashboards
+// and requested far more often than endpoints are checked.
+func WithCache(cache storeModels.CacheProvider, ttl time.Duration) Option {
+	return func(srv *Service) {
+		srv.cache = cache
+		srv.cacheTTL = ttl
+	}
+}
+
+func NewBadgesService(
+	log *slog.Logger,
+	endpoints Endpoints,
+	incidents Incidents,
+	history History,
+	maintenances Maintenances,
+	pages Pages,
+	opts ...Option,
+) *Service {
+
+	srv := &Service{
+		log:          log,
+		endpoints:    endpoints,
+		incidents:    incidents,
+		history:      history,
+		maintenances: maintenances,
+		pages:        pages,
+	}
+
+	for _, opt := range opts {
+		opt(srv)
+	}
+
+	return srv
+}
+
+// Badge returns the badge of an endpoint, or of a workspace endpoints group.
+// Badges are public, so they show endpoints published on a public status page
+// of their workspace only, others are reported not found.
+func (srv *Service) Badge(ctx context.Context, req models.BadgeRequest) (*models.Badge, error) {
+
+	op := operation.ServicesOperation("badges", "Badge")
+
+	if req.Kind == "" {
+		req.Kind = models.BadgeStatus
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	if badge, ok := srv.cachedBadge(ctx, req); ok {
+		return badge, nil
+	}
+
+	badge, err := srv.badge(ctx, req)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	srv.cacheBadge(ctx, req, badge)
+
+	return badge, nil
+}
+
+// badge computes the requested value of the badge endpoints.
+func (srv *Service) badge(ctx context.Context, req models.BadgeRequest) (*models.Badge, error) {
+
+	label, endpoints, err := srv.subject(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(endpoints) == 0 {
+		return nil, models.ErrNotFound
+	}
+
+	ctx = models.WithWorkspace(ctx, endpoints[0].WorkspaceID)
+
+	switch req.Kind {
+	case models.BadgeUptime, models.BadgeResponse:
+
+		checks, err := srv.checks(ctx, endpoints, req.Days)
+		if err != nil {
+			return nil, err
+		}
+
+		if req.Kind == models.BadgeUptime {
+			return models.UptimeBadge(fmt.Sprintf("uptime %dd", req.Days), checks), nil
+		}
+		return models.ResponseBadge(fmt.Sprintf("response %dd", req.Days), checks), nil
+	}
+
+	status, err := srv.status(ctx, endpoints)
+	if err != nil {
+		return nil, err
+	}
+
+	return models.StatusBadge(label, status), nil
+}
+
+// cachedBadge returns the badge computed recently.
+func (srv *Service) cachedBadge(ctx context.Context, req models.BadgeRequest) (*models.Badge, bool) {
+
+	if srv.cache == nil {
+		return nil, false
+	}
+
+	cached, err := srv.cache.Get(ctx, badgeCacheKey(req))
+	if err != nil {
+		return nil, false
+	}
+
+	badge := &models.Badge{}
+	if err := storeModels.DecodeCacheValue(cached, badge); err != nil {
+		return nil, false
+	}
+
+	return badge, true
+}
+
+// cacheBadge keeps the computed badge, failures are logged only: badges are computed again.
+func (srv *Service) cacheBadge(ctx context.Context, req models.BadgeRequest, badge *models.Badge) {
+
+	if srv.cache == nil {
+		return
+	}
+
+	data, err := storeModels.EncodeCacheValue(badge)
+	if err == nil {
+		err = srv.cache.Set(ctx, badgeCacheKey(req), data, srv.cacheTTL)
+	}
+
+	if err != nil {
+		srv.log.Warn("failed to cache badge", attrs.Error(err))
+	}
+}
+
+// badgeCacheKey keys badges by every request field, names are quoted as they may contain separators.
+func badgeCacheKey(req models.BadgeRequest) string {
+	return fmt.Sprintf("badge:%s:%q:%q:%q:%d", req.Kind, req.EndpointID, req.WorkspaceID, req.Group, req.Days)
+}
+
+// subject returns the published badge endpoints and their name.
+func (srv *Service) subject(ctx context.Context, req models.BadgeRequest) (string, models.Endpoints, error) {
+
+	if req.EndpointID != "" {
+		endpoint, err := srv.endpoints.Endpoint(models.WithAllWorkspaces(ctx), req.EndpointID)
+		if err != nil {
+			return "", nil, err
+		}
+
+		published, err := srv.pages.Published(ctx, endpoint.WorkspaceID, models.Endpoints{endpoint})
+		if err != nil {
+			return "", nil, err
+		}
+
+		return endpoint.ServiceName, published, nil
+	}
+
+	workspaceID := req.WorkspaceID
+	if workspaceID == "" {
+		workspaceID = models.DefaultWorkspace
+	}
+
+	endpoints, err := srv.endpoints.Endpoints(models.WithWorkspace(ctx, workspaceID))
+	if err != nil {
+		return "", nil, err
+	}
+
+	group := make(models.Endpoints, 0)
+	for _, endpoint := range endpoints {
+		if models.ComponentName(endpoint) == req.Group {
+			group = append(group, endpoint)
+		}
+	}
+
+	published, err := srv.pages.Published(ctx, workspaceID, group)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return req.Group, published, nil
+}
+
+// status returns the worst status of the endpoints.
+func (srv *Service) status(ctx context.Context, endpoints models.Endpoints) (models.Status, error) {
+
+	now := time.Now()
+
+	filter := models.IncidentsFilter{ActiveOnly: true}
+	if len(endpoints) == 1 {
+		filter.EndpointID = endpoints[0].ID
+	}
+
+	incidents, err := srv.incidents.Incidents(ctx, filter)
+	if err != nil {
+		return "", err
+	}
+
+	maintenances, err := srv.maintenances.Maintenances(ctx, models.MaintenancesFilter{EndsAfter: now})
+	if err != nil {
+		return "", err
+	}
+
+	status := models.StatusOperational
+
+	for _, endpoint := range endpoints {
+
+		var active *models.Incident
+		for _, incident := range incidents {
+			if incident.EndpointID == endpoint.ID {
+				active = incident
+				break
+			}
+		}
+
+		inMaintenance := slices.ContainsFunc(maintenances, func(mnt *models.Maintenance) bool {
+			return mnt.Active(now) && mnt.Covers(endpoint.ID)
+		})
+
+		status = status.Worst(models.EndpointStatus(endpoint, active, inMaintenance))
+	}
+
+	return status, nil
+}
+
+// checks sums up checks of the endpoints over the last days.
+func (srv *Service) checks(ctx context.Context, endpoints models.Endpoints, days int) (*models.DailyChecks, error) {
+
+	since := models.Day(time.Now()).AddDate(0, 0, 1-days)
+
+	ids := make([]string, 0, len(endpoints))
+	for _, endpoint := range endpoints {
+		ids = append(ids, endpoint.ID)
+	}
+
+	history, err := srv.history.DailyChecks(ctx, ids, since)
+	if err != nil {
+		return nil, err
+	}
+
+	total := &models.DailyChecks{}
+	for _, checks := range history {
+		total.Add(checks)
+	}
+
+	return total, nil
+}
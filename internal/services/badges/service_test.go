@@ -0,0 +1,231 @@
+package badges
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vishenosik/CherryWatch/internal/services/models"
+	"github.com/vishenosik/CherryWatch/internal/store/cache/providers/memory"
+)
+
+type sourcesMock struct {
+	endpoints    models.Endpoints
+	incidents    models.Incidents
+	maintenances models.Maintenances
+	history      []*models.DailyChecks
+	// endpoints not shown on public status pages
+	unpublished []string
+	// number of incidents listings
+	reads int
+}
+
+func (sm *sourcesMock) Endpoint(_ context.Context, id string) (*models.Endpoint, error) {
+	for _, endpoint := range sm.endpoints {
+		if endpoint.ID == id {
+			return endpoint, nil
+		}
+	}
+	return nil, models.ErrNotFound
+}
+
+func (sm *sourcesMock) Endpoints(ctx context.Context) (models.Endpoints, error) {
+	workspaceID, _ := models.WorkspaceFrom(ctx)
+	endpoints := make(models.Endpoints, 0)
+	for _, endpoint := range sm.endpoints {
+		if endpoint.WorkspaceID == workspaceID {
+			endpoints = append(endpoints, endpoint)
+		}
+	}
+	return endpoints, nil
+}
+
+func (sm *sourcesMock) Incidents(context.Context, models.IncidentsFilter) (models.Incidents, error) {
+	sm.reads++
+	return sm.incidents, nil
+}
+
+func (sm *sourcesMock) Maintenances(context.Context, models.MaintenancesFilter) (models.Maintenances, error) {
+	return sm.maintenances, nil
+}
+
+func (sm *sourcesMock) DailyChecks(_ context.Context, ids []string, _ time.Time) ([]*models.DailyChecks, error) {
+	history := make([]*models.DailyChecks, 0)
+	for _, checks := range sm.history {
+		for _, id := range ids {
+			if checks.EndpointID == id {
+				history = append(history, checks)
+			}
+		}
+	}
+	return history, nil
+}
+
+func (sm *sourcesMock) Published(_ context.Context, workspaceID string, endpoints models.Endpoints) (models.Endpoints, error) {
+	published := make(models.Endpoints, 0)
+	for _, endpoint := range endpoints {
+		if endpoint.WorkspaceID == workspaceID && !slices.Contains(sm.unpublished, endpoint.ID) {
+			published = append(published, endpoint)
+		}
+	}
+	return published, nil
+}
+
+func Test_Badge(t *testing.T) {
+
+	now := time.Now()
+
+	sources := &sourcesMock{
+		endpoints: models.Endpoints{
+			{ID: "api-eu", WorkspaceID: models.DefaultWorkspace, ServiceName: "api-eu", Labels: map[string]string{models.ComponentLabel: "API"}},
+			{ID: "api-us", WorkspaceID: models.DefaultWorkspace, ServiceName: "api-us", Labels: map[string]string{models.ComponentLabel: "API"}},
+			{ID: "db", WorkspaceID: models.DefaultWorkspace, ServiceName: "db"},
+			{ID: "web", WorkspaceID: "team-a", ServiceName: "web"},
+			{ID: "billing", WorkspaceID: "team-a", ServiceName: "billing"},
+			{ID: "admin-eu", WorkspaceID: models.DefaultWorkspace, ServiceName: "admin-eu", Labels: map[string]string{models.ComponentLabel: "Admin"}},
+		},
+		unpublished: []string{"billing", "admin-eu"},
+		incidents: models.Incidents{
+			{ID: "1", EndpointID: "api-us", OpenedAt: now},
+		},
+		maintenances: models.Maintenances{
+			{ID: "1", StartsAt: now.Add(-time.Hour), EndsAt: now.Add(time.Hour), EndpointIDs: []string{"db"}},
+		},
+		history: []*models.DailyChecks{
+			{EndpointID: "api-eu", Checks: 100, Failures: 1, Latency: 100 * 150 * time.Millisecond},
+			{EndpointID: "api-us", Checks: 100, Failures: 3, Latency: 100 * 450 * time.Millisecond},
+		},
+	}
+
+	srv := NewBadgesService(slog.New(slog.NewTextHandler(io.Discard, nil)), sources, sources, sources, sources, sources)
+
+	tests := []struct {
+		name    string
+		req     models.BadgeRequest
+		want    *models.Badge
+		wantErr error
+	}{
+		{
+			name: "endpoint up",
+			req:  models.BadgeRequest{EndpointID: "api-eu", Days: 30},
+			want: &models.Badge{Label: "api-eu", Message: "up", Color: models.BadgeBrightGreen},
+		},
+		{
+			name: "endpoint in maintenance",
+			req:  models.BadgeRequest{EndpointID: "db", Days: 30},
+			want: &models.Badge{Label: "db", Message: "maintenance", Color: models.BadgeBlue},
+		},
+		{
+			name: "endpoint uptime",
+			req:  models.BadgeRequest{EndpointID: "api-eu", Kind: models.BadgeUptime, Days: 7},
+			want: &models.Badge{Label: "uptime 7d", Message: "99.00%", Color: models.BadgeGreen},
+		},
+		{
+			name: "endpoint without checks",
+			req:  models.BadgeRequest{EndpointID: "db", Kind: models.BadgeResponse, Days: 30},
+			want: &models.Badge{Label: "response 30d", Message: "no data", Color: models.BadgeGrey},
+		},
+		{
+			name: "group status",
+			req:  models.BadgeRequest{Group: "API", Days: 30},
+			want: &models.Badge{Label: "API", Message: "down", Color: models.BadgeRed},
+		},
+		{
+			name: "group response",
+			req:  models.BadgeRequest{Group: "API", Kind: models.BadgeResponse, Days: 30},
+			want: &models.Badge{Label: "response 30d", Message: "300ms", Color: models.BadgeGreen},
+		},
+		{
+			name:    "group of another workspace",
+			req:     models.BadgeRequest{Group: "web", Days: 30},
+			wantErr: models.ErrNotFound,
+		},
+		{
+			name: "group in workspace",
+			req:  models.BadgeRequest{Group: "web", WorkspaceID: "team-a", Days: 30},
+			want: &models.Badge{Label: "web", Message: "up", Color: models.BadgeBrightGreen},
+		},
+		{
+			name:    "unpublished endpoint",
+			req:     models.BadgeRequest{EndpointID: "billing", Days: 30},
+			wantErr: models.ErrNotFound,
+		},
+		{
+			name:    "unpublished group",
+			req:     models.BadgeRequest{Group: "Admin", Days: 30},
+			wantErr: models.ErrNotFound,
+		},
+		{
+			name:    "unknown endpoint",
+			req:     models.BadgeRequest{EndpointID: "missing", Days: 30},
+			wantErr: models.ErrNotFound,
+		},
+		{
+			name:    "window too long",
+			req:     models.BadgeRequest{EndpointID: "api-eu", Kind: models.BadgeUptime, Days: 1000},
+			wantErr: models.ErrInvalidBadge,
+		},
+		{
+			name:    "unknown kind",
+			req:     models.BadgeRequest{EndpointID: "api-eu", Kind: "coverage", Days: 30},
+			wantErr: models.ErrInvalidBadge,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			badge, err := srv.Badge(context.Background(), tt.req)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.want, badge)
+		})
+	}
+}
+
+func Test_Badge_cache(t *testing.T) {
+
+	ctx := context.Background()
+
+	sources := &sourcesMock{
+		endpoints: models.Endpoints{
+			{ID: "api", WorkspaceID: models.DefaultWorkspace, ServiceName: "api"},
+			{ID: "web", WorkspaceID: "team-a", ServiceName: "web"},
+		},
+	}
+
+	srv := NewBadgesService(
+		slog.New(slog.NewTextHandler(io.Discard, nil)),
+		sources, sources, sources, sources, sources,
+		WithCache(memory.NewMemoryCache(0), time.Minute),
+	)
+
+	for range 3 {
+		badge, err := srv.Badge(ctx, models.BadgeRequest{EndpointID: "api", Days: 30})
+		require.NoError(t, err)
+		assert.Equal(t, &models.Badge{Label: "api", Message: "up", Color: models.BadgeBrightGreen}, badge)
+	}
+	assert.Equal(t, 1, sources.reads)
+
+	// requests differing in any field are cached apart
+	_, err := srv.Badge(ctx, models.BadgeRequest{Group: "web", WorkspaceID: "team-a", Days: 30})
+	require.NoError(t, err)
+	assert.Equal(t, 2, sources.reads)
+
+	_, err = srv.Badge(ctx, models.BadgeRequest{Group: "web", Days: 30})
+	assert.ErrorIs(t, err, models.ErrNotFound)
+
+	assert.NotEqual(t,
+		badgeCacheKey(models.BadgeRequest{WorkspaceID: "a:b", Group: "c"}),
+		badgeCacheKey(models.BadgeRequest{WorkspaceID: "a", Group: "b:c"}),
+	)
+}
@@ -0,0 +1,65 @@
+package channels
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/vishenosik/CherryWatch/internal/services/models"
+)
+
+// payload is the data posted to webhook channels.
+type payload struct {
+	// One of: incident_opened, incident_resolved
+	Type       string `json:"type"`
+	Workspace  string `json:"workspace"`
+	EndpointID string `json:"endpoint_id"`
+	Endpoint   string `json:"endpoint"`
+	Severity   string `json:"severity"`
+	// Failure of the check which opened the incident
+	Cause    string    `json:"cause,omitempty"`
+	OpenedAt time.Time `json:"opened_at"`
+	// Resolution time (incident_resolved only)
+	ResolvedAt *time.Time `json:"resolved_at,omitempty"`
+}
+
+// alertMessage tells the team about an incident transition, the failure cause included.
+func alertMessage(ch *models.Channel, endpoint *models.Endpoint, event *models.Event) *models.Message {
+
+	incident := event.Incident
+	if incident == nil {
+		incident = &models.Incident{EndpointID: endpoint.ID, OpenedAt: event.Time}
+	}
+
+	severity := endpoint.SeverityOrDefault()
+
+	subject := fmt.Sprintf("[%s] %s: incident opened", severity, endpoint.ServiceName)
+	text := fmt.Sprintf("%s is failing since %s: %s",
+		endpoint.ServiceName, incident.OpenedAt.UTC().Format(time.RFC1123), incident.Cause)
+
+	var resolvedAt *time.Time
+
+	if event.Kind == models.EventIncidentResolved {
+		resolved := incident.ResolvedAt.UTC()
+		resolvedAt = &resolved
+		subject = fmt.Sprintf("[%s] %s: incident resolved", severity, endpoint.ServiceName)
+		text = fmt.Sprintf("%s is operational again, the incident lasted %s.",
+			endpoint.ServiceName, incident.ResolvedAt.Sub(incident.OpenedAt).Round(time.Second))
+	}
+
+	return &models.Message{
+		Kind:    ch.Kind,
+		To:      ch.Target,
+		Subject: subject,
+		Text:    text,
+		Data: payload{
+			Type:       string(event.Kind),
+			Workspace:  endpoint.WorkspaceID,
+			EndpointID: endpoint.ID,
+			Endpoint:   endpoint.ServiceName,
+			Severity:   string(severity),
+			Cause:      incident.Cause,
+			OpenedAt:   incident.OpenedAt.UTC(),
+			ResolvedAt: resolvedAt,
+		},
+	}
+}
@@ -0,0 +1,290 @@
+package channels
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"github.com/vishenosik/CherryWatch/internal/services/events"
+	"github.com/vishenosik/CherryWatch/internal/services/models"
+	storeModels "github.com/vishenosik/CherryWatch/internal/store/models"
+	attrs "github.com/vishenosik/web-tools/log"
+	"github.com/vishenosik/web-tools/operation"
+)
+
+type Store interface {
+	SaveChannel(ctx context.Context, ch *models.Channel) error
+	Channels(ctx context.Context) (models.Channels, error)
+	DeleteChannel(ctx context.Context, id string) error
+}
+
+type Endpoints interface {
+	Endpoint(ctx context.Context, id string) (*models.Endpoint, error)
+}
+
+type Silences interface {
+	Silenced(ctx context.Context, endpoint *models.Endpoint) (bool, error)
+}
+
+type Notifier interface {
+	Supports(kind models.ChannelKind) bool
+	Verify(ctx context.Context, kind models.ChannelKind, to string) error
+	Notify(ctx context.Context, msg *models.Message) error
+}
+
+type Events interface {
+	Subscribe(filter models.EventsFilter) *events.Subscription
+}
+
+const (
+	// messages waiting to be sent, more are dropped
+	queueSize = 1024
+	// messages sent at once
+	senders = 4
+	// time a message is given to be sent
+	sendTimeout = 30 * time.Second
+)
+
+// incident events alerted about
+var incidentEvents = []models.EventKind{
+	models.EventIncidentOpened,
+	models.EventIncidentResolved,
+}
+
+// Service manages notification channels of workspaces and alerts them
+// about incidents of endpoints naming them, unless the endpoints are silenced.
+type Service struct {
+	log       *slog.Logger
+	store     Store
+	endpoints Endpoints
+	silences  Silences
+	notifier  Notifier
+	sub       *events.Subscription
+	queue     chan *models.Message
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+func NewChannelsService(
+	log *slog.Logger,
+	store Store,
+	endpoints Endpoints,
+	silences Silences,
+	notifier Notifier,
+	events Events,
+) *Service {
+	return &Service{
+		log:       log.With(slog.String("component", "channels")),
+		store:     store,
+		endpoints: endpoints,
+		silences:  silences,
+		notifier:  notifier,
+		// subscribed right away not to miss events published before Run
+		sub:   events.Subscribe(models.EventsFilter{Kinds: incidentEvents}),
+		queue: make(chan *models.Message, queueSize),
+		stop:  make(chan struct{}),
+		done:  make(chan struct{}),
+	}
+}
+
+// CreateChannel validates and stores a channel of the context workspace.
+func (srv *Service) CreateChannel(ctx context.Context, ch *models.Channel) (*models.Channel, error) {
+
+	op := operation.ServicesOperation("channels", "CreateChannel")
+
+	ch.ID = uuid.NewString()
+	ch.WorkspaceID = models.OwnerWorkspace(ctx, ch.WorkspaceID)
+	ch.CreatedAt = time.Now()
+
+	if err := ch.Validate(); err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	if !srv.notifier.Supports(ch.Kind) {
+		return nil, errors.Wrap(errors.Wrap(models.ErrUnsupportedChannel, string(ch.Kind)), op)
+	}
+
+	if err := srv.notifier.Verify(ctx, ch.Kind, ch.Target); err != nil {
+		if errors.Is(err, models.ErrForbiddenRecipient) {
+			return nil, errors.Wrap(errors.Wrap(models.ErrInvalidChannel, "target address is not allowed"), op)
+		}
+		return nil, errors.Wrap(err, op)
+	}
+
+	if err := srv.store.SaveChannel(ctx, ch); err != nil {
+		if errors.Is(err, storeModels.ErrAlreadyExists) {
+			return nil, errors.Wrap(models.ErrChannelExists, op)
+		}
+		return nil, errors.Wrap(err, op)
+	}
+
+	srv.log.Info("channel created",
+		slog.String("channel_id", ch.ID),
+		slog.String("type", string(ch.Kind)),
+	)
+
+	return ch, nil
+}
+
+// Channels returns channels of the context workspace ordered by name.
+func (srv *Service) Channels(ctx context.Context) (models.Channels, error) {
+
+	op := operation.ServicesOperation("channels", "Channels")
+
+	channels, err := srv.store.Channels(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	return channels, nil
+}
+
+// DeleteChannel deletes a channel by id.
+func (srv *Service) DeleteChannel(ctx context.Context, id string) error {
+
+	op := operation.ServicesOperation("channels", "DeleteChannel")
+
+	if err := srv.store.DeleteChannel(ctx, id); err != nil {
+		if errors.Is(err, storeModels.ErrNotFound) {
+			return errors.Wrap(models.ErrNotFound, op)
+		}
+		return errors.Wrap(err, op)
+	}
+
+	return nil
+}
+
+// MustRun sends incident alerts to channels and blocks until Stop is called.
+func (srv *Service) MustRun() {
+	if err := srv.Run(); err != nil {
+		panic(err)
+	}
+}
+
+func (srv *Service) Run() error {
+
+	defer close(srv.done)
+	defer srv.sub.Close()
+
+	// slow recipients hold up senders, not the events subscription
+	var wg sync.WaitGroup
+	for range senders {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for msg := range srv.queue {
+				srv.send(msg)
+			}
+		}()
+	}
+	defer wg.Wait()
+	// queued messages are sent before Run returns
+	defer close(srv.queue)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go func() {
+		select {
+		case <-srv.stop:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	for {
+		event := srv.sub.Next(ctx)
+		if event == nil {
+			return nil
+		}
+		srv.handle(ctx, event)
+	}
+}
+
+// Stop stops sending alerts.
+func (srv *Service) Stop(ctx context.Context) {
+
+	srv.log.Info("stopping channels")
+
+	close(srv.stop)
+
+	select {
+	case <-srv.done:
+	case <-ctx.Done():
+	}
+}
+
+// handle alerts channels the endpoint names in its workspace about the incident transition.
+func (srv *Service) handle(ctx context.Context, event *models.Event) {
+
+	switch event.Kind {
+	case models.EventLagged:
+		srv.log.Warn("channels missed incident events, events dropped", slog.Uint64("dropped", event.Dropped))
+		return
+	case models.EventIncidentOpened, models.EventIncidentResolved:
+	default:
+		return
+	}
+
+	endpoint, err := srv.endpoints.Endpoint(models.WithAllWorkspaces(ctx), event.EndpointID)
+	if err != nil {
+		if !errors.Is(err, models.ErrNotFound) {
+			srv.log.Error("failed to get incident endpoint", attrs.Error(err))
+		}
+		return
+	}
+
+	if len(endpoint.NotificationServices) == 0 {
+		return
+	}
+
+	silenced, err := srv.silences.Silenced(ctx, endpoint)
+	if err != nil {
+		// alerting while silenced beats missing an incident
+		srv.log.Error("failed to check silences", attrs.Error(err))
+	}
+	if silenced {
+		srv.log.Debug("incident alert silenced", slog.String("endpoint_id", endpoint.ID))
+		return
+	}
+
+	channels, err := srv.store.Channels(models.WithWorkspace(ctx, endpoint.WorkspaceID))
+	if err != nil {
+		srv.log.Error("failed to get channels", attrs.Error(err))
+		return
+	}
+
+	for _, ch := range channels {
+		if slices.Contains(endpoint.NotificationServices, ch.Name) {
+			srv.enqueue(alertMessage(ch, endpoint, event))
+		}
+	}
+}
+
+// enqueue queues the message to be sent, dropping it if the queue is full.
+func (srv *Service) enqueue(msg *models.Message) {
+	select {
+	case srv.queue <- msg:
+	default:
+		srv.log.Warn("alerts queue is full, message dropped", slog.String("type", string(msg.Kind)))
+	}
+}
+
+// send sends the message within sendTimeout.
+func (srv *Service) send(msg *models.Message) {
+
+	ctx, cancel := context.WithTimeout(context.Background(), sendTimeout)
+	defer cancel()
+
+	if err := srv.notifier.Notify(ctx, msg); err != nil {
+		srv.log.Warn("failed to alert channel",
+			slog.String("type", string(msg.Kind)),
+			attrs.Error(err),
+		)
+	}
+}
@@ -0,0 +1,257 @@
+package channels
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vishenosik/CherryWatch/internal/services/events"
+	"github.com/vishenosik/CherryWatch/internal/services/models"
+	storeModels "github.com/vishenosik/CherryWatch/internal/store/models"
+)
+
+type storeMock struct {
+	channels models.Channels
+}
+
+func (sm *storeMock) SaveChannel(_ context.Context, ch *models.Channel) error {
+	for _, saved := range sm.channels {
+		if saved.WorkspaceID == ch.WorkspaceID && saved.Name == ch.Name {
+			return storeModels.ErrAlreadyExists
+		}
+	}
+	sm.channels = append(sm.channels, ch)
+	return nil
+}
+
+func (sm *storeMock) Channels(ctx context.Context) (models.Channels, error) {
+	scope, _ := models.WorkspaceFrom(ctx)
+	channels := make(models.Channels, 0, len(sm.channels))
+	for _, ch := range sm.channels {
+		if scope == "" || ch.WorkspaceID == scope {
+			channels = append(channels, ch)
+		}
+	}
+	return channels, nil
+}
+
+func (sm *storeMock) DeleteChannel(_ context.Context, _ string) error {
+	return nil
+}
+
+type endpointsMock struct {
+	endpoints models.Endpoints
+}
+
+func (em *endpointsMock) Endpoint(_ context.Context, id string) (*models.Endpoint, error) {
+	for _, endpoint := range em.endpoints {
+		if endpoint.ID == id {
+			return endpoint, nil
+		}
+	}
+	return nil, models.ErrNotFound
+}
+
+type silencesMock struct {
+	silenced map[string]bool
+}
+
+func (sm *silencesMock) Silenced(_ context.Context, endpoint *models.Endpoint) (bool, error) {
+	return sm.silenced[endpoint.ID], nil
+}
+
+type notifierMock struct{}
+
+func (nm *notifierMock) Supports(kind models.ChannelKind) bool {
+	return kind == models.ChannelWebhook
+}
+
+func (nm *notifierMock) Verify(_ context.Context, _ models.ChannelKind, to string) error {
+	if to == "http://127.0.0.1/hook" {
+		return models.ErrForbiddenRecipient
+	}
+	return nil
+}
+
+func (nm *notifierMock) Notify(context.Context, *models.Message) error {
+	return nil
+}
+
+func newTestService(silenced map[string]bool) (*Service, *storeMock) {
+
+	store := &storeMock{}
+
+	endpoints := &endpointsMock{endpoints: models.Endpoints{
+		{ID: "api", WorkspaceID: "team-a", ServiceName: "api", NotificationServices: []string{"ops", "unknown"}},
+		{ID: "db", WorkspaceID: "team-a", ServiceName: "db"},
+		{ID: "shop", WorkspaceID: "team-b", ServiceName: "shop", NotificationServices: []string{"ops"}},
+	}}
+
+	srv := NewChannelsService(
+		slog.New(slog.NewTextHandler(io.Discard, nil)),
+		store,
+		endpoints,
+		&silencesMock{silenced: silenced},
+		&notifierMock{},
+		events.NewBus(1),
+	)
+
+	return srv, store
+}
+
+func Test_CreateChannel(t *testing.T) {
+
+	teamA := models.WithWorkspace(context.Background(), "team-a")
+	teamB := models.WithWorkspace(context.Background(), "team-b")
+
+	srv, _ := newTestService(nil)
+
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		channel *models.Channel
+		err     error
+	}{
+		{
+			name:    "created",
+			ctx:     teamA,
+			channel: &models.Channel{Name: "ops", Kind: models.ChannelWebhook, Target: "https://hooks.example.com/a"},
+		},
+		{
+			name:    "name taken in the workspace",
+			ctx:     teamA,
+			channel: &models.Channel{Name: "ops", Kind: models.ChannelWebhook, Target: "https://hooks.example.com/b"},
+			err:     models.ErrChannelExists,
+		},
+		{
+			name:    "name taken in another workspace",
+			ctx:     teamB,
+			channel: &models.Channel{Name: "ops", Kind: models.ChannelWebhook, Target: "https://hooks.example.com/b"},
+		},
+		{
+			name:    "internal target",
+			ctx:     teamA,
+			channel: &models.Channel{Name: "local", Kind: models.ChannelWebhook, Target: "http://127.0.0.1/hook"},
+			err:     models.ErrInvalidChannel,
+		},
+		{
+			name:    "unsupported",
+			ctx:     teamA,
+			channel: &models.Channel{Name: "mail", Kind: models.ChannelEmail, Target: "ops@example.com"},
+			err:     models.ErrUnsupportedChannel,
+		},
+		{
+			name:    "no name",
+			ctx:     teamA,
+			channel: &models.Channel{Kind: models.ChannelWebhook, Target: "https://hooks.example.com/a"},
+			err:     models.ErrInvalidChannel,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := srv.CreateChannel(tt.ctx, tt.channel)
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func Test_handle(t *testing.T) {
+
+	ctx := context.Background()
+	now := time.Now()
+
+	channels := models.Channels{
+		{ID: "1", WorkspaceID: "team-a", Name: "ops", Kind: models.ChannelWebhook, Target: "https://hooks.example.com/a"},
+		{ID: "2", WorkspaceID: "team-a", Name: "dev", Kind: models.ChannelWebhook, Target: "https://hooks.example.com/dev"},
+		// same name in another workspace
+		{ID: "3", WorkspaceID: "team-b", Name: "ops", Kind: models.ChannelWebhook, Target: "https://hooks.example.com/b"},
+	}
+
+	opened := func(endpointID string) *models.Event {
+		return &models.Event{
+			Kind:       models.EventIncidentOpened,
+			EndpointID: endpointID,
+			Incident:   &models.Incident{ID: "1", EndpointID: endpointID, Cause: "connection refused", OpenedAt: now},
+			Time:       now,
+		}
+	}
+
+	tests := []struct {
+		name     string
+		event    *models.Event
+		silenced map[string]bool
+		want     []string
+	}{
+		{
+			name:  "named channels of the workspace",
+			event: opened("api"),
+			want:  []string{"https://hooks.example.com/a"},
+		},
+		{
+			name:  "other workspace",
+			event: opened("shop"),
+			want:  []string{"https://hooks.example.com/b"},
+		},
+		{
+			name:  "no channels named",
+			event: opened("db"),
+		},
+		{
+			name:     "silenced",
+			event:    opened("api"),
+			silenced: map[string]bool{"api": true},
+		},
+		{
+			name:  "check results are not alerted",
+			event: &models.Event{Kind: models.EventCheckResult, EndpointID: "api", Time: now},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			srv, store := newTestService(tt.silenced)
+			store.channels = channels
+
+			srv.handle(ctx, tt.event)
+
+			recipients := make([]string, 0)
+			for len(srv.queue) > 0 {
+				msg := <-srv.queue
+				recipients = append(recipients, msg.To)
+			}
+			assert.ElementsMatch(t, tt.want, recipients)
+		})
+	}
+}
+
+func Test_alertMessage(t *testing.T) {
+
+	openedAt := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	ch := &models.Channel{Kind: models.ChannelWebhook, Target: "https://hooks.example.com/a"}
+	endpoint := &models.Endpoint{ID: "api", WorkspaceID: "team-a", ServiceName: "api", Severity: models.SeverityWarning}
+	incident := &models.Incident{ID: "1", EndpointID: "api", Cause: "connection refused", OpenedAt: openedAt}
+
+	msg := alertMessage(ch, endpoint, &models.Event{Kind: models.EventIncidentOpened, Incident: incident})
+	assert.Equal(t, "[warning] api: incident opened", msg.Subject)
+	assert.Contains(t, msg.Text, "connection refused")
+	require.IsType(t, payload{}, msg.Data)
+	assert.Nil(t, msg.Data.(payload).ResolvedAt)
+
+	incident.ResolvedAt = openedAt.Add(90 * time.Second)
+
+	msg = alertMessage(ch, endpoint, &models.Event{Kind: models.EventIncidentResolved, Incident: incident})
+	assert.Equal(t, "[warning] api: incident resolved", msg.Subject)
+	assert.Contains(t, msg.Text, "1m30s")
+	require.NotNil(t, msg.Data.(payload).ResolvedAt)
+}
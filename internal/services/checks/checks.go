@@ -0,0 +1,118 @@
+package checks
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/vishenosik/CherryWatch/internal/services/models"
+	"github.com/vishenosik/web-tools/api"
+)
+
+const (
+	// default timeout of a single HTTP request
+	defaultTimeout = 30 * time.Second
+	// max response body size read by checkers
+	maxBodySize = 1 << 20
+)
+
+// Checker performs checks of a particular type.
+type Checker interface {
+	Check(ctx context.Context, endpoint *models.Endpoint) *models.CheckResult
+}
+
+// Checks dispatches endpoint checks to a checker registered for the endpoint type.
+type Checks struct {
+	checkers map[models.CheckType]Checker
+}
+
+type Option func(*Checks)
+
+// WithChecker registers checker for the check type replacing the default one.
+func WithChecker(checkType models.CheckType, checker Checker) Option {
+	return func(c *Checks) {
+		c.checkers[checkType] = checker
+	}
+}
+
+// NewChecks creates checks dispatcher with http & transaction checkers registered.
+// Exec and sql checks are disabled unless WithExecChecks(true) and WithSQLChecks(true) options are passed.
+// If client is nil, a client with default timeout is used.
+func NewChecks(client *http.Client, opts ...Option) *Checks {
+
+	if client == nil {
+		client = &http.Client{Timeout: defaultTimeout}
+	}
+
+	checks := &Checks{
+		checkers: map[models.CheckType]Checker{
+			models.CheckHTTP:        NewHttpChecker(client),
+			models.CheckTransaction: NewTransactionChecker(client),
+			models.CheckSQL:         NewSqlChecker(false),
+			models.CheckExec:        NewExecChecker(false),
+		},
+	}
+
+	for _, opt := range opts {
+		opt(checks)
+	}
+
+	return checks
+}
+
+// Check runs the check matching endpoint type.
+// It never returns nil, failures are reported through the result.
+func (c *Checks) Check(ctx context.Context, endpoint *models.Endpoint) *models.CheckResult {
+
+	checker, ok := c.checkers[endpoint.CheckType()]
+	if !ok {
+		result := newResult(endpoint)
+		return result.fail(errors.Wrapf(models.ErrCheckType, "type %q", endpoint.Type))
+	}
+
+	result := checker.Check(ctx, endpoint)
+	result.EndpointID = endpoint.ID
+	result.Type = endpoint.CheckType()
+	return result
+}
+
+type result struct {
+	*models.CheckResult
+	start time.Time
+}
+
+func newResult(endpoint *models.Endpoint) *result {
+	start := time.Now()
+	return &result{
+		CheckResult: &models.CheckResult{
+			EndpointID: endpoint.ID,
+			Type:       endpoint.CheckType(),
+			CheckedAt:  start,
+		},
+		start: start,
+	}
+}
+
+func (res *result) fail(err error) *models.CheckResult {
+	res.Success = false
+	res.Message = err.Error()
+	res.Latency = time.Since(res.start)
+	return res.CheckResult
+}
+
+func (res *result) succeed() *models.CheckResult {
+	res.Success = true
+	res.Message = ""
+	res.Latency = time.Since(res.start)
+	return res.CheckResult
+}
+
+// codeAccepted reports if code is in codes or is 2xx when codes are not set.
+func codeAccepted(codes []int, code int) bool {
+	if len(codes) == 0 {
+		return api.IsSuccess(code)
+	}
+	return slices.Contains(codes, code)
+}
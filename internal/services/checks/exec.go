@@ -0,0 +1,159 @@
+package checks
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/vishenosik/CherryWatch/internal/services/models"
+)
+
+const (
+	// max command output kept in a check result
+	maxOutputSize = 4 << 10
+	// time to wait for command output after it has been killed
+	execWaitDelay = time.Second
+)
+
+var (
+	// unexpected command exit code
+	ErrExitCode = errors.New("unexpected exit code")
+	// command did not finish in time
+	ErrExecTimeout = errors.New("command timed out")
+)
+
+type execChecker struct {
+	enabled bool
+}
+
+// NewExecChecker creates checker running a local command.
+// Running commands is security-sensitive, so a disabled checker fails every check.
+func NewExecChecker(enabled bool) *execChecker {
+	return &execChecker{
+		enabled: enabled,
+	}
+}
+
+// WithExecChecks registers exec checker enabled or disabled.
+func WithExecChecks(enabled bool) Option {
+	return WithChecker(models.CheckExec, NewExecChecker(enabled))
+}
+
+func (ec *execChecker) Check(ctx context.Context, endpoint *models.Endpoint) *models.CheckResult {
+
+	result := newResult(endpoint)
+
+	if !ec.enabled {
+		return result.fail(models.ErrExecDisabled)
+	}
+
+	check := endpoint.Exec
+	if err := check.Validate(); err != nil {
+		return result.fail(err)
+	}
+
+	timeout := check.Timeout
+	if timeout == 0 {
+		timeout = defaultTimeout
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	output := &limitedBuffer{limit: maxOutputSize}
+
+	cmd := exec.CommandContext(ctx, check.Command, check.Args...)
+	cmd.Env = commandEnv(check.Env)
+	cmd.Stdout = output
+	cmd.Stderr = output
+	cmd.WaitDelay = execWaitDelay
+
+	err := cmd.Run()
+
+	result.Output = output.String()
+	result.ExitCode = cmd.ProcessState.ExitCode()
+
+	if ctx.Err() != nil {
+		return result.fail(errors.Wrapf(ErrExecTimeout, "timeout %s", timeout))
+	}
+
+	var exitErr *exec.ExitError
+	if err != nil && !errors.As(err, &exitErr) {
+		return result.fail(err)
+	}
+
+	successCodes := check.SuccessCodes
+	if len(successCodes) == 0 {
+		successCodes = []int{0}
+	}
+
+	if !slices.Contains(successCodes, result.ExitCode) {
+		return result.fail(errors.Wrapf(ErrExitCode, "code %d", result.ExitCode))
+	}
+
+	return result.succeed()
+}
+
+// commandEnv passes PATH only from the server environment
+// so that secrets of the server are not leaked to commands.
+func commandEnv(extra map[string]string) []string {
+
+	env := make([]string, 0, len(extra)+1)
+
+	if path, ok := os.LookupEnv("PATH"); ok {
+		env = append(env, "PATH="+path)
+	}
+
+	keys := make([]string, 0, len(extra))
+	for key := range extra {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+
+	for _, key := range keys {
+		env = append(env, key+"="+extra[key])
+	}
+
+	return env
+}
+
+// limitedBuffer keeps the first limit bytes written and discards the rest.
+type limitedBuffer struct {
+	mu        sync.Mutex
+	buf       strings.Builder
+	limit     int
+	truncated int
+}
+
+func (lb *limitedBuffer) Write(p []byte) (int, error) {
+	lb.mu.Lock()
+	defer lb.mu.Unlock()
+
+	// report full write, so the command is not broken by the limit
+	written := len(p)
+
+	free := lb.limit - lb.buf.Len()
+	if free < len(p) {
+		lb.truncated += len(p) - max(free, 0)
+		p = p[:max(free, 0)]
+	}
+	lb.buf.Write(p)
+
+	return written, nil
+}
+
+func (lb *limitedBuffer) String() string {
+	lb.mu.Lock()
+	defer lb.mu.Unlock()
+
+	if lb.truncated == 0 {
+		return lb.buf.String()
+	}
+	return lb.buf.String() + "\n... truncated " + strconv.Itoa(lb.truncated) + " bytes"
+}
@@ -0,0 +1,114 @@
+package checks
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/vishenosik/CherryWatch/internal/services/models"
+)
+
+func execEndpoint(check models.ExecCheck) *models.Endpoint {
+	return &models.Endpoint{
+		ID:          uuid.NewString(),
+		ServiceName: "local_tool",
+		Interval:    time.Minute,
+		Type:        models.CheckExec,
+		Exec:        &check,
+	}
+}
+
+func Test_ExecCheck(t *testing.T) {
+
+	testingTable := []struct {
+		name     string
+		check    models.ExecCheck
+		success  bool
+		exitCode int
+		output   string
+	}{
+		{
+			name:    "exit code 0",
+			check:   models.ExecCheck{Command: "sh", Args: []string{"-c", "echo ok"}},
+			success: true,
+			output:  "ok\n",
+		},
+		{
+			name:     "failing command",
+			check:    models.ExecCheck{Command: "sh", Args: []string{"-c", "echo broken >&2; exit 3"}},
+			exitCode: 3,
+			output:   "broken\n",
+		},
+		{
+			name: "custom success codes",
+			check: models.ExecCheck{
+				Command:      "sh",
+				Args:         []string{"-c", "exit 1"},
+				SuccessCodes: []int{0, 1},
+			},
+			success:  true,
+			exitCode: 1,
+		},
+		{
+			name: "environment",
+			check: models.ExecCheck{
+				Command: "sh",
+				Args:    []string{"-c", `test "$TARGET" = db01`},
+				Env:     map[string]string{"TARGET": "db01"},
+			},
+			success: true,
+		},
+		{
+			name: "timeout",
+			check: models.ExecCheck{
+				Command: "sleep",
+				Args:    []string{"5"},
+				Timeout: 100 * time.Millisecond,
+			},
+			exitCode: -1,
+		},
+		{
+			name:     "missing command",
+			check:    models.ExecCheck{Command: "cherrywatch-missing-command"},
+			exitCode: -1,
+		},
+	}
+
+	checks := NewChecks(nil, WithExecChecks(true))
+
+	for _, tt := range testingTable {
+		t.Run(tt.name, func(t *testing.T) {
+			result := checks.Check(context.Background(), execEndpoint(tt.check))
+			assert.Equal(t, tt.success, result.Success, result.Message)
+			assert.Equal(t, tt.exitCode, result.ExitCode)
+			assert.Equal(t, tt.output, result.Output)
+		})
+	}
+}
+
+func Test_ExecCheckDisabled(t *testing.T) {
+	result := NewChecks(nil).Check(
+		context.Background(),
+		execEndpoint(models.ExecCheck{Command: "true"}),
+	)
+	assert.False(t, result.Success)
+	assert.Equal(t, models.ErrExecDisabled.Error(), result.Message)
+}
+
+func Test_limitedBuffer(t *testing.T) {
+	buf := &limitedBuffer{limit: 4}
+
+	n, err := buf.Write([]byte("abc"))
+	assert.NoError(t, err)
+	assert.Equal(t, 3, n)
+
+	n, err = buf.Write([]byte("defgh"))
+	assert.NoError(t, err)
+	assert.Equal(t, 5, n)
+
+	assert.True(t, strings.HasPrefix(buf.String(), "abcd"))
+	assert.Contains(t, buf.String(), "truncated 4 bytes")
+}
@@ -0,0 +1,148 @@
+package checks
+
+import (
+	"encoding/json"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/vishenosik/CherryWatch/internal/services/models"
+)
+
+var (
+	// value to extract is missing in the response
+	ErrExtractNotFound = errors.New("value not found")
+	// malformed JSONPath expression
+	ErrJSONPath = errors.New("invalid JSONPath expression")
+)
+
+// extract takes a variable value out of response headers or body.
+func extract(ex models.Extraction, header http.Header, body []byte) (string, error) {
+	switch ex.Source {
+
+	case models.ExtractHeader:
+		value := header.Get(ex.Expression)
+		if value == "" {
+			return "", errors.Wrapf(ErrExtractNotFound, "header %s", ex.Expression)
+		}
+		return value, nil
+
+	case models.ExtractRegex:
+		re, err := regexp.Compile(ex.Expression)
+		if err != nil {
+			return "", err
+		}
+		match := re.FindSubmatch(body)
+		switch {
+		case match == nil:
+			return "", errors.Wrapf(ErrExtractNotFound, "regex %s", ex.Expression)
+		case len(match) > 1:
+			return string(match[1]), nil
+		default:
+			return string(match[0]), nil
+		}
+
+	case models.ExtractJSON:
+		var data any
+		if err := json.Unmarshal(body, &data); err != nil {
+			return "", errors.Wrap(err, "response body is not JSON")
+		}
+		value, err := jsonPath(data, ex.Expression)
+		if err != nil {
+			return "", err
+		}
+		return stringify(value)
+
+	default:
+		return "", errors.Wrapf(models.ErrExtractSource, "source %q", ex.Source)
+	}
+}
+
+// jsonPath resolves a subset of JSONPath: "$.a.b", "$.items[0].id", "$['a-b']".
+func jsonPath(data any, expr string) (any, error) {
+
+	path := strings.TrimPrefix(strings.TrimSpace(expr), "$")
+
+	current := data
+
+	for path != "" {
+		var (
+			key   string
+			index = -1
+		)
+
+		switch {
+		case strings.HasPrefix(path, "."):
+			path = path[1:]
+			end := strings.IndexAny(path, ".[")
+			if end < 0 {
+				end = len(path)
+			}
+			key, path = path[:end], path[end:]
+			if key == "" {
+				return nil, errors.Wrap(ErrJSONPath, expr)
+			}
+
+		case strings.HasPrefix(path, "['"):
+			end := strings.Index(path, "']")
+			if end < 0 {
+				return nil, errors.Wrap(ErrJSONPath, expr)
+			}
+			key, path = path[2:end], path[end+2:]
+
+		case strings.HasPrefix(path, "["):
+			end := strings.Index(path, "]")
+			if end < 0 {
+				return nil, errors.Wrap(ErrJSONPath, expr)
+			}
+			i, err := strconv.Atoi(path[1:end])
+			if err != nil || i < 0 {
+				return nil, errors.Wrap(ErrJSONPath, expr)
+			}
+			index, path = i, path[end+1:]
+
+		default:
+			return nil, errors.Wrap(ErrJSONPath, expr)
+		}
+
+		if index >= 0 {
+			list, ok := current.([]any)
+			if !ok || index >= len(list) {
+				return nil, errors.Wrapf(ErrExtractNotFound, "json %s", expr)
+			}
+			current = list[index]
+			continue
+		}
+
+		object, ok := current.(map[string]any)
+		if !ok {
+			return nil, errors.Wrapf(ErrExtractNotFound, "json %s", expr)
+		}
+		if current, ok = object[key]; !ok {
+			return nil, errors.Wrapf(ErrExtractNotFound, "json %s", expr)
+		}
+	}
+
+	return current, nil
+}
+
+func stringify(value any) (string, error) {
+	switch v := value.(type) {
+	case string:
+		return v, nil
+	case nil:
+		return "", nil
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	default:
+		data, err := json.Marshal(v)
+		if err != nil {
+			return "", err
+		}
+		return string(data), nil
+	}
+}
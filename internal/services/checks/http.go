@@ -0,0 +1,57 @@
+package checks
+
+import (
+	"context"
+	"io"
+	"net/http"
+
+	"github.com/pkg/errors"
+	"github.com/vishenosik/CherryWatch/internal/services/models"
+)
+
+var (
+	// unexpected response status code
+	ErrStatusCode = errors.New("unexpected status code")
+)
+
+type httpChecker struct {
+	client *http.Client
+}
+
+// NewHttpChecker creates checker requesting endpoint URL and matching response status code.
+func NewHttpChecker(client *http.Client) *httpChecker {
+	return &httpChecker{
+		client: client,
+	}
+}
+
+func (hc *httpChecker) Check(ctx context.Context, endpoint *models.Endpoint) *models.CheckResult {
+
+	result := newResult(endpoint)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint.URL, nil)
+	if err != nil {
+		return result.fail(err)
+	}
+
+	res, err := hc.client.Do(req)
+	if err != nil {
+		return result.fail(err)
+	}
+	defer res.Body.Close()
+
+	// drain body to reuse connection
+	_, _ = io.Copy(io.Discard, io.LimitReader(res.Body, maxBodySize))
+
+	result.StatusCode = res.StatusCode
+
+	if res.TLS != nil && len(res.TLS.PeerCertificates) > 0 {
+		result.CertExpiresAt = res.TLS.PeerCertificates[0].NotAfter
+	}
+
+	if !codeAccepted(endpoint.SuccessCodes, res.StatusCode) {
+		return result.fail(errors.Wrapf(ErrStatusCode, "code %d", res.StatusCode))
+	}
+
+	return result.succeed()
+}
@@ -0,0 +1,263 @@
+package checks
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	// sqlite is supported out of the box
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/pkg/errors"
+	"github.com/vishenosik/CherryWatch/internal/services/models"
+)
+
+const (
+	// default timeout of a database query check
+	defaultSQLTimeout = 10 * time.Second
+)
+
+var (
+	// query result does not match the expectation
+	ErrExpectation = errors.New("expectation failed")
+)
+
+type sqlChecker struct {
+	enabled bool
+}
+
+// NewSqlChecker creates checker running a query and asserting on its result.
+// Any driver registered within database/sql may be used, sqlite3 is available out of the box.
+// sqlite3 and postgres connections are opened read-only, other drivers get a read-only transaction only.
+// Queries reach any database the server can, e.g. its own one, so a disabled checker fails every check.
+func NewSqlChecker(enabled bool) *sqlChecker {
+	return &sqlChecker{
+		enabled: enabled,
+	}
+}
+
+// WithSQLChecks registers sql checker enabled or disabled.
+func WithSQLChecks(enabled bool) Option {
+	return WithChecker(models.CheckSQL, NewSqlChecker(enabled))
+}
+
+func (sc *sqlChecker) Check(ctx context.Context, endpoint *models.Endpoint) *models.CheckResult {
+
+	result := newResult(endpoint)
+
+	if !sc.enabled {
+		return result.fail(models.ErrSQLDisabled)
+	}
+
+	check := endpoint.SQL
+	if err := check.Validate(); err != nil {
+		return result.fail(err)
+	}
+
+	timeout := check.Timeout
+	if timeout == 0 {
+		timeout = defaultSQLTimeout
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	actual, err := query(ctx, check)
+	if err != nil {
+		return result.fail(err)
+	}
+
+	ok, err := compare(actual, check.Expect.Operator, check.Expect.Value)
+	if err != nil {
+		return result.fail(err)
+	}
+
+	if !ok {
+		return result.fail(expectationError(check.Expect, actual))
+	}
+
+	return result.succeed()
+}
+
+// expectationError describes the failed assertion. Queried values never get
+// into results, which are shown to viewers, row counts only.
+func expectationError(expect models.Expectation, actual string) error {
+	if expect.SubjectOrDefault() == models.SQLRowCount {
+		return errors.Wrapf(ErrExpectation, "%s %s %s %s", models.SQLRowCount, actual, expect.Operator, expect.Value)
+	}
+	return errors.Wrapf(ErrExpectation, "%s is not %s %s", expect.SubjectOrDefault(), expect.Operator, expect.Value)
+}
+
+// query runs check query within a read-only transaction
+// and returns the asserted part of the result.
+func query(ctx context.Context, check *models.SQLCheck) (string, error) {
+
+	db, err := sql.Open(check.Driver, readOnlyDSN(check.Driver, check.DSN))
+	if err != nil {
+		return "", errors.Wrap(err, "failed to open database")
+	}
+	defer db.Close()
+
+	// not every driver honours it, e.g. sqlite3 doesn't
+	tx, err := db.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return "", errors.Wrap(err, "failed to begin transaction")
+	}
+	// never commit anything
+	defer tx.Rollback()
+
+	rows, err := tx.QueryContext(ctx, check.Query)
+	if err != nil {
+		return "", errors.Wrap(err, "query failed")
+	}
+	defer rows.Close()
+
+	var (
+		count int
+		value string
+	)
+
+	for rows.Next() {
+		if count == 0 && check.Expect.SubjectOrDefault() == models.SQLScalar {
+			if value, err = scanScalar(rows); err != nil {
+				return "", err
+			}
+		}
+		count++
+	}
+
+	if err := rows.Err(); err != nil {
+		return "", errors.Wrap(err, "query failed")
+	}
+
+	if check.Expect.SubjectOrDefault() == models.SQLRowCount {
+		return strconv.Itoa(count), nil
+	}
+
+	if count == 0 {
+		return "", errors.Wrap(ErrExpectation, "query returned no rows")
+	}
+
+	return value, nil
+}
+
+// readOnlyDSN makes drivers open read-only connections, so statements passing
+// validation can't write either, e.g. "WITH ... DELETE". DSNs of other drivers are kept as is.
+func readOnlyDSN(driver, dsn string) string {
+	switch driver {
+	case "sqlite3":
+		return sqliteReadOnlyDSN(dsn)
+	case "pgx", "pgx/v5", "postgres":
+		return postgresReadOnlyDSN(dsn)
+	default:
+		return dsn
+	}
+}
+
+// sqliteReadOnlyDSN turns DSN into a "file:" URI opened with mode=ro
+// and query_only pragma set.
+func sqliteReadOnlyDSN(dsn string) string {
+
+	path, rawQuery, _ := strings.Cut(dsn, "?")
+
+	params, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		params = url.Values{}
+	}
+	params.Set("mode", "ro")
+	params.Set("_query_only", "true")
+
+	if !strings.HasPrefix(path, "file:") {
+		path = "file:" + strings.NewReplacer("%", "%25", "#", "%23").Replace(path)
+	}
+
+	return path + "?" + params.Encode()
+}
+
+// postgresReadOnlyDSN sets default_transaction_read_only for URL
+// and keyword/value DSNs, the latter value wins over the former one.
+func postgresReadOnlyDSN(dsn string) string {
+
+	if strings.HasPrefix(dsn, "postgres://") || strings.HasPrefix(dsn, "postgresql://") {
+		if u, err := url.Parse(dsn); err == nil {
+			params := u.Query()
+			params.Set("default_transaction_read_only", "on")
+			u.RawQuery = params.Encode()
+			return u.String()
+		}
+	}
+
+	return strings.TrimSpace(dsn) + " default_transaction_read_only=on"
+}
+
+// scanScalar scans the first column of the current row as a string.
+func scanScalar(rows *sql.Rows) (string, error) {
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return "", err
+	}
+
+	values := make([]any, len(columns))
+	pointers := make([]any, len(columns))
+	for i := range values {
+		pointers[i] = &values[i]
+	}
+
+	if err := rows.Scan(pointers...); err != nil {
+		return "", errors.Wrap(err, "failed to scan row")
+	}
+
+	switch value := values[0].(type) {
+	case nil:
+		return "NULL", nil
+	case []byte:
+		return string(value), nil
+	case time.Time:
+		return value.Format(time.RFC3339), nil
+	default:
+		return fmt.Sprint(value), nil
+	}
+}
+
+// compare applies operator to actual and expected values.
+// Values are compared as numbers if both are parseable, as strings otherwise.
+func compare(actual string, op models.Operator, expected string) (bool, error) {
+
+	var cmp int
+
+	a, errA := strconv.ParseFloat(strings.TrimSpace(actual), 64)
+	e, errE := strconv.ParseFloat(strings.TrimSpace(expected), 64)
+
+	switch {
+	case errA == nil && errE == nil:
+		switch {
+		case a < e:
+			cmp = -1
+		case a > e:
+			cmp = 1
+		}
+	default:
+		cmp = strings.Compare(actual, expected)
+	}
+
+	switch op {
+	case models.OpEqual:
+		return cmp == 0, nil
+	case models.OpNotEqual:
+		return cmp != 0, nil
+	case models.OpLess:
+		return cmp < 0, nil
+	case models.OpLessOrEqual:
+		return cmp <= 0, nil
+	case models.OpGreater:
+		return cmp > 0, nil
+	case models.OpGreaterOrEqual:
+		return cmp >= 0, nil
+	default:
+		return false, errors.Wrapf(models.ErrOperator, "operator %q", op)
+	}
+}
@@ -0,0 +1,204 @@
+package checks
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vishenosik/CherryWatch/internal/services/models"
+)
+
+func newTestDatabase(t *testing.T) string {
+	t.Helper()
+
+	dsn := filepath.Join(t.TempDir(), "jobs.db")
+
+	db, err := sql.Open("sqlite3", dsn)
+	require.NoError(t, err)
+	defer db.Close()
+
+	_, err = db.Exec(`
+		CREATE TABLE jobs (id INTEGER PRIMARY KEY, state TEXT NOT NULL);
+		INSERT INTO jobs (state) VALUES ('pending'), ('pending'), ('done');
+		CREATE TABLE replication (lag REAL NOT NULL);
+		INSERT INTO replication (lag) VALUES (12.5);
+	`)
+	require.NoError(t, err)
+
+	return dsn
+}
+
+func sqlEndpoint(dsn, query string, expect models.Expectation) *models.Endpoint {
+	return &models.Endpoint{
+		ID:          uuid.NewString(),
+		ServiceName: "database",
+		Interval:    time.Minute,
+		Type:        models.CheckSQL,
+		SQL: &models.SQLCheck{
+			Driver: "sqlite3",
+			DSN:    dsn,
+			Query:  query,
+			Expect: expect,
+		},
+	}
+}
+
+func Test_SqlCheck(t *testing.T) {
+
+	dsn := newTestDatabase(t)
+
+	testingTable := []struct {
+		name     string
+		endpoint *models.Endpoint
+		success  bool
+	}{
+		{
+			name: "pending jobs below limit",
+			endpoint: sqlEndpoint(dsn, "SELECT id FROM jobs WHERE state = 'pending'",
+				models.Expectation{Operator: models.OpLess, Value: "500"}),
+			success: true,
+		},
+		{
+			name: "pending jobs above limit",
+			endpoint: sqlEndpoint(dsn, "SELECT id FROM jobs WHERE state = 'pending'",
+				models.Expectation{Subject: models.SQLRowCount, Operator: models.OpLess, Value: "2"}),
+		},
+		{
+			name: "replication lag",
+			endpoint: sqlEndpoint(dsn, "SELECT lag FROM replication",
+				models.Expectation{Subject: models.SQLScalar, Operator: models.OpLess, Value: "30"}),
+			success: true,
+		},
+		{
+			name: "string scalar",
+			endpoint: sqlEndpoint(dsn, "SELECT state FROM jobs ORDER BY id DESC",
+				models.Expectation{Subject: models.SQLScalar, Operator: models.OpEqual, Value: "done"}),
+			success: true,
+		},
+		{
+			name: "scalar of empty result",
+			endpoint: sqlEndpoint(dsn, "SELECT lag FROM replication WHERE lag > 100",
+				models.Expectation{Subject: models.SQLScalar, Operator: models.OpLess, Value: "30"}),
+		},
+		{
+			name: "write statement",
+			endpoint: sqlEndpoint(dsn, "DELETE FROM jobs",
+				models.Expectation{Operator: models.OpEqual, Value: "0"}),
+		},
+		{
+			name: "writing cte",
+			endpoint: sqlEndpoint(dsn, "WITH done AS (SELECT id FROM jobs WHERE state = 'done') DELETE FROM jobs WHERE id IN (SELECT id FROM done)",
+				models.Expectation{Operator: models.OpEqual, Value: "0"}),
+		},
+		{
+			name: "writing cte of a file uri",
+			endpoint: sqlEndpoint("file:"+dsn+"?mode=rw", "WITH s AS (SELECT 'done' AS state) INSERT INTO jobs (state) SELECT state FROM s",
+				models.Expectation{Operator: models.OpEqual, Value: "0"}),
+		},
+		{
+			name: "several statements",
+			endpoint: sqlEndpoint(dsn, "SELECT 1; DELETE FROM jobs",
+				models.Expectation{Operator: models.OpEqual, Value: "1"}),
+		},
+		{
+			name: "broken query",
+			endpoint: sqlEndpoint(dsn, "SELECT * FROM missing",
+				models.Expectation{Operator: models.OpEqual, Value: "0"}),
+		},
+	}
+
+	checks := NewChecks(nil, WithSQLChecks(true))
+
+	for _, tt := range testingTable {
+		t.Run(tt.name, func(t *testing.T) {
+			result := checks.Check(context.Background(), tt.endpoint)
+			assert.Equal(t, tt.success, result.Success, result.Message)
+			assert.Equal(t, models.CheckSQL, result.Type)
+		})
+	}
+
+	// write statements must not be executed
+	result := checks.Check(context.Background(), sqlEndpoint(dsn, "SELECT id FROM jobs",
+		models.Expectation{Operator: models.OpEqual, Value: "3"}))
+	assert.True(t, result.Success, result.Message)
+}
+
+func Test_readOnlyDSN(t *testing.T) {
+	tests := []struct {
+		driver string
+		dsn    string
+		want   string
+	}{
+		{"sqlite3", "/data/jobs.db", "file:/data/jobs.db?_query_only=true&mode=ro"},
+		{"sqlite3", "/data/50%#1.db?_busy_timeout=100", "file:/data/50%25%231.db?_busy_timeout=100&_query_only=true&mode=ro"},
+		{"sqlite3", "file:jobs.db?mode=rwc", "file:jobs.db?_query_only=true&mode=ro"},
+		{"pgx", "postgres://app@db/jobs?sslmode=disable", "postgres://app@db/jobs?default_transaction_read_only=on&sslmode=disable"},
+		{"postgres", "host=db dbname=jobs default_transaction_read_only=off", "host=db dbname=jobs default_transaction_read_only=off default_transaction_read_only=on"},
+		{"mysql", "app@tcp(db)/jobs", "app@tcp(db)/jobs"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.driver+" "+tt.dsn, func(t *testing.T) {
+			assert.Equal(t, tt.want, readOnlyDSN(tt.driver, tt.dsn))
+		})
+	}
+}
+
+func Test_SqlCheckMessage(t *testing.T) {
+
+	dsn := newTestDatabase(t)
+	checks := NewChecks(nil, WithSQLChecks(true))
+
+	// queried values aren't leaked through results
+	result := checks.Check(context.Background(), sqlEndpoint(dsn, "SELECT state FROM jobs ORDER BY id DESC",
+		models.Expectation{Subject: models.SQLScalar, Operator: models.OpEqual, Value: "pending"}))
+	assert.False(t, result.Success)
+	assert.Equal(t, "value is not == pending: expectation failed", result.Message)
+	assert.NotContains(t, result.Message, "done")
+
+	result = checks.Check(context.Background(), sqlEndpoint(dsn, "SELECT id FROM jobs",
+		models.Expectation{Operator: models.OpLess, Value: "2"}))
+	assert.Equal(t, "rows 3 < 2: expectation failed", result.Message)
+}
+
+func Test_SqlCheckDisabled(t *testing.T) {
+	result := NewChecks(nil).Check(
+		context.Background(),
+		sqlEndpoint(newTestDatabase(t), "SELECT id FROM jobs", models.Expectation{Operator: models.OpEqual, Value: "3"}),
+	)
+	assert.False(t, result.Success)
+	assert.Equal(t, models.ErrSQLDisabled.Error(), result.Message)
+}
+
+func Test_compare(t *testing.T) {
+	tests := []struct {
+		actual   string
+		op       models.Operator
+		expected string
+		want     bool
+	}{
+		{"10", models.OpLess, "9.5", false},
+		{"10", models.OpGreater, "9.5", true},
+		{"10.0", models.OpEqual, "10", true},
+		{"abc", models.OpEqual, "abc", true},
+		{"abc", models.OpNotEqual, "abd", true},
+		{"2", models.OpLessOrEqual, "2", true},
+		{"2", models.OpGreaterOrEqual, "3", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.actual+string(tt.op)+tt.expected, func(t *testing.T) {
+			got, err := compare(tt.actual, tt.op, tt.expected)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	_, err := compare("1", "~", "1")
+	assert.ErrorIs(t, err, models.ErrOperator)
+}
@@ -0,0 +1,153 @@
+package checks
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"text/template"
+
+	"github.com/pkg/errors"
+	"github.com/vishenosik/CherryWatch/internal/services/models"
+)
+
+var (
+	// template rendering failed
+	ErrTemplate = errors.New("failed to render template")
+)
+
+type transactionChecker struct {
+	client *http.Client
+}
+
+// NewTransactionChecker creates checker running transaction steps in order.
+// Variables extracted from a step response are available in templates of the following steps.
+func NewTransactionChecker(client *http.Client) *transactionChecker {
+	return &transactionChecker{
+		client: client,
+	}
+}
+
+func (tc *transactionChecker) Check(ctx context.Context, endpoint *models.Endpoint) *models.CheckResult {
+
+	result := newResult(endpoint)
+
+	if endpoint.Transaction == nil || len(endpoint.Transaction.Steps) == 0 {
+		return result.fail(models.ErrNoSteps)
+	}
+
+	vars := make(map[string]string)
+
+	for i := range endpoint.Transaction.Steps {
+		step := &endpoint.Transaction.Steps[i]
+
+		code, err := tc.runStep(ctx, step, vars)
+		result.StatusCode = code
+		if err != nil {
+			result.FailedStep = step.Title(i)
+			return result.fail(errors.Wrapf(err, "step %s", result.FailedStep))
+		}
+	}
+
+	return result.succeed()
+}
+
+// runStep performs step request and stores extracted variables into vars.
+func (tc *transactionChecker) runStep(
+	ctx context.Context,
+	step *models.TransactionStep,
+	vars map[string]string,
+) (int, error) {
+
+	req, err := buildRequest(ctx, step, vars)
+	if err != nil {
+		return 0, err
+	}
+
+	res, err := tc.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer res.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(res.Body, maxBodySize))
+	if err != nil {
+		return res.StatusCode, errors.Wrap(err, "failed to read response body")
+	}
+
+	if !codeAccepted(step.SuccessCodes, res.StatusCode) {
+		return res.StatusCode, errors.Wrapf(ErrStatusCode, "code %d", res.StatusCode)
+	}
+
+	for _, extraction := range step.Extract {
+		value, err := extract(extraction, res.Header, body)
+		if err != nil {
+			return res.StatusCode, errors.Wrapf(err, "variable %s", extraction.Variable)
+		}
+		vars[extraction.Variable] = value
+	}
+
+	return res.StatusCode, nil
+}
+
+func buildRequest(
+	ctx context.Context,
+	step *models.TransactionStep,
+	vars map[string]string,
+) (*http.Request, error) {
+
+	url, err := render(step.URL, vars)
+	if err != nil {
+		return nil, errors.Wrap(err, "url")
+	}
+
+	var body io.Reader
+	if step.Body != "" {
+		rendered, err := render(step.Body, vars)
+		if err != nil {
+			return nil, errors.Wrap(err, "body")
+		}
+		body = bytes.NewBufferString(rendered)
+	}
+
+	method := strings.ToUpper(step.Method)
+	if method == "" {
+		method = http.MethodGet
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, url, body)
+	if err != nil {
+		return nil, err
+	}
+
+	for name, value := range step.Headers {
+		rendered, err := render(value, vars)
+		if err != nil {
+			return nil, errors.Wrapf(err, "header %s", name)
+		}
+		req.Header.Set(name, rendered)
+	}
+
+	return req, nil
+}
+
+// render executes text as a template over vars failing on unknown variables.
+func render(text string, vars map[string]string) (string, error) {
+
+	if !strings.Contains(text, "{{") {
+		return text, nil
+	}
+
+	tmpl, err := template.New("").Option("missingkey=error").Parse(text)
+	if err != nil {
+		return "", errors.Wrap(ErrTemplate, err.Error())
+	}
+
+	var buf strings.Builder
+	if err := tmpl.Execute(&buf, vars); err != nil {
+		return "", errors.Wrap(ErrTemplate, err.Error())
+	}
+
+	return buf.String(), nil
+}
@@ -0,0 +1,193 @@
+package checks
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vishenosik/CherryWatch/internal/services/models"
+)
+
+const testToken = "secret-token"
+
+func newTransactionServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("POST /login", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Session", "session-1")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"data": map[string]any{
+				"token": testToken,
+				"users": []any{map[string]any{"id": 42}},
+			},
+		})
+	})
+
+	mux.HandleFunc("GET /users/{id}", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer "+testToken {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if r.PathValue("id") != "42" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(`<p>user: alice</p>`))
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func transactionEndpoint(steps ...models.TransactionStep) *models.Endpoint {
+	return &models.Endpoint{
+		ID:          uuid.NewString(),
+		ServiceName: "login_flow",
+		Interval:    time.Minute,
+		Type:        models.CheckTransaction,
+		Transaction: &models.Transaction{Steps: steps},
+	}
+}
+
+func Test_TransactionCheck(t *testing.T) {
+
+	server := newTransactionServer(t)
+
+	login := models.TransactionStep{
+		Name:   "login",
+		Method: http.MethodPost,
+		URL:    server.URL + "/login",
+		Body:   `{"user":"alice"}`,
+		Extract: []models.Extraction{
+			{Variable: "token", Source: models.ExtractJSON, Expression: "$.data.token"},
+			{Variable: "user_id", Source: models.ExtractJSON, Expression: "$.data.users[0].id"},
+			{Variable: "session", Source: models.ExtractHeader, Expression: "X-Session"},
+		},
+	}
+
+	profile := models.TransactionStep{
+		Name:    "profile",
+		URL:     server.URL + "/users/{{.user_id}}",
+		Headers: map[string]string{"Authorization": "Bearer {{.token}}"},
+		Extract: []models.Extraction{
+			{Variable: "name", Source: models.ExtractRegex, Expression: `user: (\w+)`},
+		},
+	}
+
+	testingTable := []struct {
+		name       string
+		endpoint   *models.Endpoint
+		success    bool
+		failedStep string
+		statusCode int
+	}{
+		{
+			name:       "full flow",
+			endpoint:   transactionEndpoint(login, profile),
+			success:    true,
+			statusCode: http.StatusOK,
+		},
+		{
+			name: "missing token",
+			endpoint: transactionEndpoint(login, models.TransactionStep{
+				Name: "profile",
+				URL:  server.URL + "/users/{{.user_id}}",
+			}),
+			failedStep: "profile",
+			statusCode: http.StatusUnauthorized,
+		},
+		{
+			name: "unknown variable",
+			endpoint: transactionEndpoint(login, models.TransactionStep{
+				URL: server.URL + "/users/{{.unknown}}",
+			}),
+			failedStep: "#2",
+		},
+		{
+			name: "value not extracted",
+			endpoint: transactionEndpoint(models.TransactionStep{
+				Name:   "login",
+				Method: http.MethodPost,
+				URL:    server.URL + "/login",
+				Extract: []models.Extraction{
+					{Variable: "token", Source: models.ExtractJSON, Expression: "$.data.missing"},
+				},
+			}),
+			failedStep: "login",
+			statusCode: http.StatusOK,
+		},
+		{
+			name: "custom success codes",
+			endpoint: transactionEndpoint(models.TransactionStep{
+				URL:          server.URL + "/users/1",
+				SuccessCodes: []int{http.StatusUnauthorized},
+			}),
+			success:    true,
+			statusCode: http.StatusUnauthorized,
+		},
+	}
+
+	checks := NewChecks(server.Client())
+
+	for _, tt := range testingTable {
+		t.Run(tt.name, func(t *testing.T) {
+			result := checks.Check(context.Background(), tt.endpoint)
+			require.NotNil(t, result)
+			assert.Equal(t, tt.success, result.Success, result.Message)
+			assert.Equal(t, tt.failedStep, result.FailedStep)
+			assert.Equal(t, tt.statusCode, result.StatusCode)
+			assert.Equal(t, tt.endpoint.ID, result.EndpointID)
+			assert.Equal(t, models.CheckTransaction, result.Type)
+		})
+	}
+}
+
+func Test_jsonPath(t *testing.T) {
+
+	var data any
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"a": {"b": "c", "n": 1000000, "ok": true},
+		"items": [{"id": 1}, {"id": 2}],
+		"dash-key": "d"
+	}`), &data))
+
+	tests := []struct {
+		expr      string
+		want      string
+		wantError bool
+	}{
+		{expr: "$.a.b", want: "c"},
+		{expr: "$.a.n", want: "1000000"},
+		{expr: "$.a.ok", want: "true"},
+		{expr: "$.items[1].id", want: "2"},
+		{expr: "$['dash-key']", want: "d"},
+		{expr: "$.items[0]", want: `{"id":1}`},
+		{expr: "$.items[5].id", wantError: true},
+		{expr: "$.a.missing", wantError: true},
+		{expr: "$.items[x]", wantError: true},
+		{expr: "$a", wantError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			value, err := jsonPath(data, tt.expr)
+			if tt.wantError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			got, err := stringify(value)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,291 @@
+package declarative
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"maps"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/pkg/errors"
+	"github.com/vishenosik/CherryWatch/internal/services/audit"
+	"github.com/vishenosik/CherryWatch/internal/services/models"
+	attrs "github.com/vishenosik/web-tools/log"
+	"github.com/vishenosik/web-tools/operation"
+)
+
+type Source interface {
+	Load() (*models.Manifest, error)
+}
+
+type Endpoints interface {
+	Endpoints(ctx context.Context) (models.Endpoints, error)
+	SaveEndpoints(ctx context.Context, endpoints models.Endpoints) (models.Endpoints, error)
+	DeleteEndpoint(ctx context.Context, id string) error
+}
+
+type Config struct {
+	// Delete config endpoints missing from the manifest
+	Prune bool
+	// Only log the plan without applying it
+	DryRun bool
+}
+
+// Reconciler brings endpoints to the state declared in the source
+// on start and on every SIGHUP.
+type Reconciler struct {
+	log       *slog.Logger
+	source    Source
+	endpoints Endpoints
+	config    Config
+	stop      chan struct{}
+	done      chan struct{}
+}
+
+func NewReconciler(
+	log *slog.Logger,
+	source Source,
+	endpoints Endpoints,
+	config Config,
+) *Reconciler {
+	return &Reconciler{
+		log:       log.With(slog.String("component", "declarative")),
+		source:    source,
+		endpoints: endpoints,
+		config:    config,
+		stop:      make(chan struct{}),
+		done:      make(chan struct{}),
+	}
+}
+
+func (rec *Reconciler) MustRun() {
+	if err := rec.Run(); err != nil {
+		panic(err)
+	}
+}
+
+func (rec *Reconciler) Run() error {
+
+	defer close(rec.done)
+
+	reload := make(chan os.Signal, 1)
+	signal.Notify(reload, syscall.SIGHUP)
+	defer signal.Stop(reload)
+
+	for {
+		rec.reconcile()
+
+		select {
+		case <-rec.stop:
+			return nil
+		case <-reload:
+			rec.log.Info("reloading declarative config")
+		}
+	}
+}
+
+// Stop stops listening for reloads waiting for the current reconciliation to finish.
+func (rec *Reconciler) Stop(ctx context.Context) {
+
+	rec.log.Info("stopping declarative config reconciler")
+
+	close(rec.stop)
+
+	select {
+	case <-rec.done:
+	case <-ctx.Done():
+	}
+}
+
+func (rec *Reconciler) reconcile() {
+
+	plan, err := rec.Reconcile(context.Background())
+	if err != nil {
+		rec.log.Error("failed to reconcile declarative config", attrs.Error(err))
+		return
+	}
+
+	for _, step := range plan {
+		rec.log.Info("declarative config change",
+			slog.Bool("dry_run", rec.config.DryRun),
+			slog.String("change", step.String()),
+		)
+	}
+
+	rec.log.Info("declarative config reconciled",
+		slog.Bool("dry_run", rec.config.DryRun),
+		slog.Int("changes", len(plan)),
+	)
+}
+
+// Reconcile creates missing, updates changed and, if pruning, deletes removed endpoints.
+// Nothing is changed in dry-run mode, the plan is returned either way.
+func (rec *Reconciler) Reconcile(ctx context.Context) ([]*models.PlanStep, error) {
+
+	op := operation.ServicesOperation("declarative", "Reconcile")
+
+	manifest, err := rec.source.Load()
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	workspaceID := manifest.WorkspaceID
+	if workspaceID == "" {
+		workspaceID = models.DefaultWorkspace
+	}
+	ctx = models.WithWorkspace(ctx, workspaceID)
+
+	current, err := rec.endpoints.Endpoints(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	plan, save, remove, err := rec.plan(manifest.Endpoints, current)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	if rec.config.DryRun || len(plan) == 0 {
+		return plan, nil
+	}
+
+	if len(save) > 0 {
+		if _, err := rec.endpoints.SaveEndpoints(ctx, save); err != nil {
+			return nil, errors.Wrap(err, op)
+		}
+	}
+
+	for _, id := range remove {
+		if err := rec.endpoints.DeleteEndpoint(ctx, id); err != nil {
+			return nil, errors.Wrap(err, op)
+		}
+	}
+
+	return plan, nil
+}
+
+// plan matches desired endpoints with current ones by id, or by service name if id is omitted.
+func (rec *Reconciler) plan(
+	desired, current models.Endpoints,
+) (plan []*models.PlanStep, save models.Endpoints, remove []string, err error) {
+
+	matcher := models.NewEndpointMatcher(current)
+	matched := make(map[string]struct{}, len(desired))
+
+	for _, endpoint := range desired {
+
+		existing, err := matcher.Match(endpoint)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+
+		endpoint.Labels = managed(endpoint.Labels)
+		endpoint.Type = endpoint.CheckType()
+
+		if existing == nil {
+			step, err := newStep(models.AuditCreate, nil, endpoint)
+			if err != nil {
+				return nil, nil, nil, err
+			}
+			plan = append(plan, step)
+			save = append(save, endpoint)
+			continue
+		}
+
+		matched[existing.ID] = struct{}{}
+
+		endpoint.Replace(existing)
+
+		equal, err := sameEndpoints(existing, endpoint)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		if equal {
+			continue
+		}
+
+		step, err := newStep(models.AuditUpdate, existing, endpoint)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		plan = append(plan, step)
+		save = append(save, endpoint)
+	}
+
+	if !rec.config.Prune {
+		return plan, save, nil, nil
+	}
+
+	for _, endpoint := range current {
+		if _, ok := matched[endpoint.ID]; ok || endpoint.Labels[models.ManagedLabel] != models.ManagedByConfig {
+			continue
+		}
+		step, err := newStep(models.AuditDelete, endpoint, nil)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		plan = append(plan, step)
+		remove = append(remove, endpoint.ID)
+	}
+
+	return plan, save, remove, nil
+}
+
+// managed marks endpoint labels as owned by the declarative config.
+func managed(labels map[string]string) map[string]string {
+	labels = maps.Clone(labels)
+	if labels == nil {
+		labels = make(map[string]string, 1)
+	}
+	labels[models.ManagedLabel] = models.ManagedByConfig
+	return labels
+}
+
+func sameEndpoints(a, b *models.Endpoint) (bool, error) {
+	left, err := json.Marshal(a)
+	if err != nil {
+		return false, err
+	}
+	right, err := json.Marshal(b)
+	if err != nil {
+		return false, err
+	}
+	diff, err := audit.Diff(left, right)
+	if err != nil {
+		return false, err
+	}
+	return len(diff) == 0, nil
+}
+
+func newStep(action models.AuditAction, before, after *models.Endpoint) (*models.PlanStep, error) {
+
+	step := &models.PlanStep{Action: action}
+
+	var beforeState, afterState any
+	if before != nil {
+		step.EndpointID, step.ServiceName = before.ID, before.ServiceName
+		beforeState = before
+	}
+	if after != nil {
+		step.EndpointID, step.ServiceName = after.ID, after.ServiceName
+		afterState = after
+	}
+
+	beforeJSON, err := audit.Snapshot(beforeState)
+	if err != nil {
+		return nil, err
+	}
+
+	afterJSON, err := audit.Snapshot(afterState)
+	if err != nil {
+		return nil, err
+	}
+
+	if step.Diff, err = audit.Diff(beforeJSON, afterJSON); err != nil {
+		return nil, err
+	}
+
+	return step, nil
+}
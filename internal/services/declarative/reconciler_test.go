@@ -0,0 +1,145 @@
+package declarative
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vishenosik/CherryWatch/internal/services/models"
+)
+
+type sourceMock struct {
+	manifest *models.Manifest
+}
+
+func (sm *sourceMock) Load() (*models.Manifest, error) {
+	// reconciler mutates endpoints, every load returns a fresh copy like a file does
+	endpoints := make(models.Endpoints, 0, len(sm.manifest.Endpoints))
+	for _, endpoint := range sm.manifest.Endpoints {
+		copied := *endpoint
+		endpoints = append(endpoints, &copied)
+	}
+	return &models.Manifest{WorkspaceID: sm.manifest.WorkspaceID, Endpoints: endpoints}, nil
+}
+
+type endpointsMock struct {
+	endpoints map[string]*models.Endpoint
+	saved     int
+}
+
+func (em *endpointsMock) Endpoints(_ context.Context) (models.Endpoints, error) {
+	endpoints := make(models.Endpoints, 0, len(em.endpoints))
+	for _, endpoint := range em.endpoints {
+		copied := *endpoint
+		endpoints = append(endpoints, &copied)
+	}
+	return endpoints, nil
+}
+
+func (em *endpointsMock) SaveEndpoints(ctx context.Context, endpoints models.Endpoints) (models.Endpoints, error) {
+	for _, endpoint := range endpoints {
+		if endpoint.ID == "" {
+			endpoint.ID = uuid.NewString()
+		}
+		endpoint.WorkspaceID = models.OwnerWorkspace(ctx, endpoint.WorkspaceID)
+		em.endpoints[endpoint.ID] = endpoint
+		em.saved++
+	}
+	return endpoints, nil
+}
+
+func (em *endpointsMock) DeleteEndpoint(_ context.Context, id string) error {
+	delete(em.endpoints, id)
+	return nil
+}
+
+func actions(plan []*models.PlanStep) []models.AuditAction {
+	result := make([]models.AuditAction, 0, len(plan))
+	for _, step := range plan {
+		result = append(result, step.Action)
+	}
+	return result
+}
+
+func Test_Reconcile(t *testing.T) {
+
+	ctx := context.Background()
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	manual := &models.Endpoint{ID: "manual", ServiceName: "manual", URL: "https://manual.example.com", Interval: time.Minute}
+
+	source := &sourceMock{manifest: &models.Manifest{
+		WorkspaceID: "team-a",
+		Endpoints: models.Endpoints{
+			{ServiceName: "api", URL: "https://api.example.com", Interval: time.Minute, Labels: map[string]string{"env": "prod"}},
+			{ServiceName: "web", URL: "https://web.example.com", Interval: time.Minute},
+		},
+	}}
+	store := &endpointsMock{endpoints: map[string]*models.Endpoint{manual.ID: manual}}
+
+	dryRun := NewReconciler(log, source, store, Config{Prune: true, DryRun: true})
+
+	plan, err := dryRun.Reconcile(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []models.AuditAction{models.AuditCreate, models.AuditCreate}, actions(plan))
+	assert.Len(t, store.endpoints, 1)
+
+	rec := NewReconciler(log, source, store, Config{Prune: true})
+
+	_, err = rec.Reconcile(ctx)
+	require.NoError(t, err)
+	require.Len(t, store.endpoints, 3)
+
+	for _, endpoint := range store.endpoints {
+		if endpoint.ID == manual.ID {
+			continue
+		}
+		assert.Equal(t, "team-a", endpoint.WorkspaceID)
+		assert.Equal(t, models.ManagedByConfig, endpoint.Labels[models.ManagedLabel])
+	}
+
+	// nothing changed, nothing saved
+	saved := store.saved
+	plan, err = rec.Reconcile(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, plan)
+	assert.Equal(t, saved, store.saved)
+
+	// web is changed, api removed, manual endpoint isn't pruned
+	source.manifest.Endpoints = models.Endpoints{
+		{ServiceName: "web", URL: "https://www.example.com", Interval: time.Minute},
+	}
+
+	plan, err = rec.Reconcile(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []models.AuditAction{models.AuditUpdate, models.AuditDelete}, actions(plan))
+	assert.Equal(t, "web", plan[0].ServiceName)
+	require.Len(t, plan[0].Diff, 1)
+	assert.Equal(t, "URL", plan[0].Diff[0].Path)
+	assert.Equal(t, "api", plan[1].ServiceName)
+
+	assert.Len(t, store.endpoints, 2)
+	assert.Contains(t, store.endpoints, manual.ID)
+}
+
+func Test_ReconcileDuplicateNames(t *testing.T) {
+
+	source := &sourceMock{manifest: &models.Manifest{
+		Endpoints: models.Endpoints{
+			{ServiceName: "api", URL: "https://a.example.com", Interval: time.Minute},
+			{ServiceName: "api", URL: "https://b.example.com", Interval: time.Minute},
+		},
+	}}
+	store := &endpointsMock{endpoints: make(map[string]*models.Endpoint)}
+
+	rec := NewReconciler(slog.New(slog.NewTextHandler(io.Discard, nil)), source, store, Config{})
+
+	_, err := rec.Reconcile(context.Background())
+	assert.ErrorIs(t, err, models.ErrDuplicateName)
+	assert.Empty(t, store.endpoints)
+}
@@ -1 +1,535 @@
 package endpoints
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"log/slog"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"github.com/vishenosik/CherryWatch/internal/services/models"
+	storeModels "github.com/vishenosik/CherryWatch/internal/store/models"
+	attrs "github.com/vishenosik/web-tools/log"
+	"github.com/vishenosik/web-tools/operation"
+)
+
+type Store interface {
+	SaveEndpoints(ctx context.Context, endpoints models.Endpoints) error
+	CreateEndpoints(ctx context.Context, endpoints models.Endpoints) error
+	Endpoint(ctx context.Context, id string) (*models.Endpoint, error)
+	Endpoints(ctx context.Context) (models.Endpoints, error)
+	DeleteEndpoint(ctx context.Context, id string) error
+}
+
+type Checker interface {
+	Check(ctx context.Context, endpoint *models.Endpoint) *models.CheckResult
+}
+
+type Incidents interface {
+	Open(ctx context.Context, endpoint *models.Endpoint, cause string) (*models.Incident, bool, error)
+	Resolve(ctx context.Context, endpoint *models.Endpoint) (*models.Incident, error)
+}
+
+type Publisher interface {
+	Publish(event *models.Event)
+}
+
+type Auditor interface {
+	Record(ctx context.Context, change models.Change)
+}
+
+type History interface {
+	RecordResult(ctx context.Context, endpoint *models.Endpoint, result *models.CheckResult) error
+}
+
+// ResultObserver receives every check result, unlike events subscribers.
+type ResultObserver interface {
+	ObserveResult(endpoint *models.Endpoint, result *models.CheckResult)
+}
+
+type Service struct {
+	log         *slog.Logger
+	store       Store
+	checker     Checker
+	incidents   Incidents
+	publisher   Publisher
+	auditor     Auditor
+	history     History
+	results     ResultObserver
+	execEnabled bool
+	sqlEnabled  bool
+}
+
+type Option func(*Service)
+
+// WithAudit records endpoint changes into the audit log.
+func WithAudit(auditor Auditor) Option {
+	return func(srv *Service) {
+		srv.auditor = auditor
+	}
+}
+
+// WithHistory keeps daily check results totals for uptime reports.
+func WithHistory(history History) Option {
+	return func(srv *Service) {
+		srv.history = history
+	}
+}
+
+// WithResultObserver reports every check result to the observer.
+func WithResultObserver(observer ResultObserver) Option {
+	return func(srv *Service) {
+		srv.results = observer
+	}
+}
+
+// WithExecChecks allows endpoints of exec check type.
+func WithExecChecks(enabled bool) Option {
+	return func(srv *Service) {
+		srv.execEnabled = enabled
+	}
+}
+
+// WithSQLChecks allows endpoints of sql check type.
+func WithSQLChecks(enabled bool) Option {
+	return func(srv *Service) {
+		srv.sqlEnabled = enabled
+	}
+}
+
+func NewEndpointsService(
+	log *slog.Logger,
+	store Store,
+	checker Checker,
+	incidents Incidents,
+	publisher Publisher,
+	opts ...Option,
+) *Service {
+
+	srv := &Service{
+		log:       log,
+		store:     store,
+		checker:   checker,
+		incidents: incidents,
+		publisher: publisher,
+	}
+
+	for _, opt := range opts {
+		opt(srv)
+	}
+
+	return srv
+}
+
+// SaveEndpoints validates and stores endpoints inserting new and replacing existing ones
+// of the caller's workspace.
+// Missing identifiers and heartbeat tokens are generated.
+func (srv *Service) SaveEndpoints(
+	ctx context.Context,
+	endpoints models.Endpoints,
+) (added models.Endpoints, err error) {
+
+	op := operation.ServicesOperation("endpoints", "SaveEndpoints")
+
+	current := make(models.Endpoints, len(endpoints))
+
+	for i, endpoint := range endpoints {
+		if err := srv.prepare(ctx, endpoint); err != nil {
+			return nil, errors.Wrap(err, op)
+		}
+		current[i] = srv.current(ctx, endpoint.ID)
+	}
+
+	if err := srv.save(ctx, endpoints...); err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	for i, endpoint := range endpoints {
+		srv.audit(ctx, current[i], endpoint)
+	}
+
+	return endpoints, nil
+}
+
+// ImportEndpoints stores endpoints in a single transaction. Imported endpoints are matched
+// with existing ones by id, or by service name if id is omitted; matches are handled by mode.
+// Imported endpoints without id sharing a service name fail with models.ErrDuplicateName.
+func (srv *Service) ImportEndpoints(
+	ctx context.Context,
+	endpoints models.Endpoints,
+	mode models.ImportMode,
+) (*models.ImportResult, error) {
+
+	op := operation.ServicesOperation("endpoints", "ImportEndpoints")
+
+	if err := mode.Validate(); err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	current, err := srv.store.Endpoints(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	var (
+		matcher = models.NewEndpointMatcher(current)
+		result  = &models.ImportResult{}
+		save    = make(models.Endpoints, 0, len(endpoints))
+		before  = make(models.Endpoints, 0, len(endpoints))
+	)
+
+	for _, endpoint := range endpoints {
+
+		existing, err := matcher.Match(endpoint)
+		if err != nil {
+			return nil, errors.Wrap(err, op)
+		}
+
+		if existing != nil {
+			switch mode {
+			case models.ImportFail:
+				return nil, errors.Wrap(errors.Wrap(models.ErrEndpointExists, endpoint.ServiceName), op)
+			case models.ImportSkip:
+				result.Skipped = append(result.Skipped, existing)
+				continue
+			}
+
+			endpoint.Replace(existing)
+		}
+
+		if err := srv.prepare(ctx, endpoint); err != nil {
+			return nil, errors.Wrap(err, op)
+		}
+
+		if existing != nil {
+			result.Updated = append(result.Updated, endpoint)
+		} else {
+			result.Created = append(result.Created, endpoint)
+		}
+		save = append(save, endpoint)
+		before = append(before, existing)
+	}
+
+	if len(save) > 0 {
+		if err := srv.save(ctx, save...); err != nil {
+			return nil, errors.Wrap(err, op)
+		}
+	}
+
+	for i, endpoint := range save {
+		srv.audit(ctx, before[i], endpoint)
+	}
+
+	return result, nil
+}
+
+// CreateEndpoints validates and stores new endpoints in a single transaction
+// failing with models.ErrEndpointExists if any id is taken.
+// Missing identifiers and heartbeat tokens are generated.
+func (srv *Service) CreateEndpoints(
+	ctx context.Context,
+	endpoints models.Endpoints,
+) (models.Endpoints, error) {
+
+	op := operation.ServicesOperation("endpoints", "CreateEndpoints")
+
+	for _, endpoint := range endpoints {
+		if err := srv.prepare(ctx, endpoint); err != nil {
+			return nil, errors.Wrap(err, op)
+		}
+	}
+
+	if err := srv.write(ctx, srv.store.CreateEndpoints, endpoints); err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	for _, endpoint := range endpoints {
+		srv.audit(ctx, nil, endpoint)
+	}
+
+	return endpoints, nil
+}
+
+// CreateEndpoint stores a new endpoint failing if its id is taken.
+func (srv *Service) CreateEndpoint(
+	ctx context.Context,
+	endpoint *models.Endpoint,
+) (*models.Endpoint, error) {
+
+	op := operation.ServicesOperation("endpoints", "CreateEndpoint")
+
+	if _, err := srv.CreateEndpoints(ctx, models.Endpoints{endpoint}); err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	return endpoint, nil
+}
+
+// UpdateEndpoint replaces an existing endpoint.
+// Heartbeat token and DSN are kept unless new ones are provided.
+func (srv *Service) UpdateEndpoint(
+	ctx context.Context,
+	endpoint *models.Endpoint,
+) (*models.Endpoint, error) {
+
+	op := operation.ServicesOperation("endpoints", "UpdateEndpoint")
+
+	current, err := srv.Endpoint(ctx, endpoint.ID)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	if endpoint.WorkspaceID == "" {
+		endpoint.WorkspaceID = current.WorkspaceID
+	}
+
+	endpoint.KeepSecrets(current)
+
+	if err := srv.prepare(ctx, endpoint); err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	if err := srv.save(ctx, endpoint); err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	srv.audit(ctx, current, endpoint)
+
+	return endpoint, nil
+}
+
+// Endpoint returns endpoint by id.
+func (srv *Service) Endpoint(ctx context.Context, id string) (*models.Endpoint, error) {
+
+	op := operation.ServicesOperation("endpoints", "Endpoint")
+
+	endpoint, err := srv.store.Endpoint(ctx, id)
+	if err != nil {
+		if errors.Is(err, storeModels.ErrNotFound) {
+			return nil, errors.Wrap(models.ErrNotFound, op)
+		}
+		return nil, errors.Wrap(err, op)
+	}
+
+	return endpoint, nil
+}
+
+// Endpoints returns all endpoints.
+func (srv *Service) Endpoints(ctx context.Context) (models.Endpoints, error) {
+
+	op := operation.ServicesOperation("endpoints", "Endpoints")
+
+	endpoints, err := srv.store.Endpoints(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	return endpoints, nil
+}
+
+// DeleteEndpoint deletes endpoint by id.
+func (srv *Service) DeleteEndpoint(ctx context.Context, id string) error {
+
+	op := operation.ServicesOperation("endpoints", "DeleteEndpoint")
+
+	current := srv.current(ctx, id)
+
+	if err := srv.store.DeleteEndpoint(ctx, id); err != nil {
+		if errors.Is(err, storeModels.ErrNotFound) {
+			return errors.Wrap(models.ErrNotFound, op)
+		}
+		return errors.Wrap(err, op)
+	}
+
+	srv.audit(ctx, current, nil)
+
+	return nil
+}
+
+// TriggerCheck checks endpoint immediately opening or resolving its incident.
+func (srv *Service) TriggerCheck(ctx context.Context, id string) (*models.CheckResult, error) {
+
+	op := operation.ServicesOperation("endpoints", "TriggerCheck")
+
+	endpoint, err := srv.Endpoint(ctx, id)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	if endpoint.CheckType() == models.CheckHeartbeat {
+		return nil, errors.Wrap(models.ErrPassiveCheck, op)
+	}
+
+	if endpoint.Probed() {
+		return nil, errors.Wrap(models.ErrProbedCheck, op)
+	}
+
+	result, err := srv.RunCheck(ctx, endpoint)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	return result, nil
+}
+
+// RunCheck checks endpoint, publishes the result and opens or resolves its incident.
+func (srv *Service) RunCheck(ctx context.Context, endpoint *models.Endpoint) (*models.CheckResult, error) {
+
+	op := operation.ServicesOperation("endpoints", "RunCheck")
+
+	result := srv.checker.Check(ctx, endpoint)
+
+	if err := srv.ApplyResult(ctx, endpoint, result); err != nil {
+		return result, errors.Wrap(err, op)
+	}
+
+	return result, nil
+}
+
+// ApplyResult publishes the result of a check made elsewhere (by agents)
+// and opens or resolves the endpoint incident.
+func (srv *Service) ApplyResult(ctx context.Context, endpoint *models.Endpoint, result *models.CheckResult) error {
+
+	op := operation.ServicesOperation("endpoints", "ApplyResult")
+
+	event := models.NewEndpointEvent(models.EventCheckResult, endpoint)
+	event.Result = result
+	srv.publisher.Publish(event)
+
+	if srv.results != nil {
+		srv.results.ObserveResult(endpoint, result)
+	}
+
+	if srv.history != nil {
+		if err := srv.history.RecordResult(ctx, endpoint, result); err != nil {
+			srv.log.Error("failed to record check result",
+				slog.String("endpoint_id", endpoint.ID),
+				attrs.Error(err),
+			)
+		}
+	}
+
+	var err error
+	if result.Success {
+		_, err = srv.incidents.Resolve(ctx, endpoint)
+	} else {
+		_, _, err = srv.incidents.Open(ctx, endpoint, result.Message)
+	}
+	if err != nil {
+		return errors.Wrap(err, op)
+	}
+
+	return nil
+}
+
+func (srv *Service) save(ctx context.Context, endpoints ...*models.Endpoint) error {
+	return srv.write(ctx, srv.store.SaveEndpoints, endpoints)
+}
+
+// write validates endpoints and stores them with the store method.
+func (srv *Service) write(
+	ctx context.Context,
+	store func(ctx context.Context, endpoints models.Endpoints) error,
+	endpoints models.Endpoints,
+) error {
+
+	for _, endpoint := range endpoints {
+		if err := srv.validate(endpoint); err != nil {
+			return errors.Wrapf(models.ErrInvalidEndpoint, "%s: %s", endpoint.ServiceName, err)
+		}
+	}
+
+	if err := store(ctx, endpoints); err != nil {
+		switch {
+		case errors.Is(err, storeModels.ErrAlreadyExists):
+			return models.ErrEndpointExists
+		case errors.Is(err, storeModels.ErrNotFound):
+			return models.ErrNotFound
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (srv *Service) validate(endpoint *models.Endpoint) error {
+	if endpoint.CheckType() == models.CheckExec && !srv.execEnabled {
+		return models.ErrExecDisabled
+	}
+	if endpoint.CheckType() == models.CheckSQL && !srv.sqlEnabled {
+		return models.ErrSQLDisabled
+	}
+	return endpoint.Validate()
+}
+
+// prepare fills generated fields of an endpoint.
+func (srv *Service) prepare(ctx context.Context, endpoint *models.Endpoint) error {
+
+	endpoint.WorkspaceID = models.OwnerWorkspace(ctx, endpoint.WorkspaceID)
+
+	if endpoint.ID == "" {
+		endpoint.ID = uuid.NewString()
+	}
+
+	if endpoint.CheckType() == models.CheckHeartbeat {
+		if endpoint.Heartbeat == nil {
+			endpoint.Heartbeat = &models.Heartbeat{}
+		}
+		if endpoint.Heartbeat.Token == "" {
+			token, err := newToken()
+			if err != nil {
+				return err
+			}
+			endpoint.Heartbeat.Token = token
+		}
+	}
+
+	return nil
+}
+
+// current returns stored state of the endpoint for auditing, nil if unknown.
+func (srv *Service) current(ctx context.Context, id string) *models.Endpoint {
+	if srv.auditor == nil || id == "" {
+		return nil
+	}
+	endpoint, err := srv.store.Endpoint(ctx, id)
+	if err != nil {
+		return nil
+	}
+	return endpoint
+}
+
+// audit records endpoint change, before is nil for creation and after for deletion.
+func (srv *Service) audit(ctx context.Context, before, after *models.Endpoint) {
+
+	if srv.auditor == nil {
+		return
+	}
+
+	change := models.Change{
+		Resource: models.AuditEndpoint,
+		Action:   models.AuditUpdate,
+	}
+
+	switch {
+	case before == nil:
+		change.Action = models.AuditCreate
+		change.WorkspaceID, change.ResourceID, change.After = after.WorkspaceID, after.ID, after
+	case after == nil:
+		change.Action = models.AuditDelete
+		change.WorkspaceID, change.ResourceID, change.Before = before.WorkspaceID, before.ID, before
+	default:
+		change.WorkspaceID, change.ResourceID, change.Before, change.After = after.WorkspaceID, after.ID, before, after
+	}
+
+	srv.auditor.Record(ctx, change)
+}
+
+func newToken() (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
@@ -0,0 +1,132 @@
+package endpoints
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vishenosik/CherryWatch/internal/services/models"
+	storeModels "github.com/vishenosik/CherryWatch/internal/store/models"
+)
+
+type storeMock struct {
+	endpoints map[string]*models.Endpoint
+}
+
+func (sm *storeMock) SaveEndpoints(_ context.Context, endpoints models.Endpoints) error {
+	for _, endpoint := range endpoints {
+		sm.endpoints[endpoint.ID] = endpoint
+	}
+	return nil
+}
+
+func (sm *storeMock) CreateEndpoints(_ context.Context, endpoints models.Endpoints) error {
+	for _, endpoint := range endpoints {
+		if _, ok := sm.endpoints[endpoint.ID]; ok {
+			return storeModels.ErrAlreadyExists
+		}
+	}
+	return sm.SaveEndpoints(context.Background(), endpoints)
+}
+
+func (sm *storeMock) Endpoint(_ context.Context, id string) (*models.Endpoint, error) {
+	endpoint, ok := sm.endpoints[id]
+	if !ok {
+		return nil, storeModels.ErrNotFound
+	}
+	return endpoint, nil
+}
+
+func (sm *storeMock) Endpoints(_ context.Context) (models.Endpoints, error) {
+	endpoints := make(models.Endpoints, 0, len(sm.endpoints))
+	for _, endpoint := range sm.endpoints {
+		endpoints = append(endpoints, endpoint)
+	}
+	return endpoints, nil
+}
+
+func (sm *storeMock) DeleteEndpoint(_ context.Context, id string) error {
+	delete(sm.endpoints, id)
+	return nil
+}
+
+func Test_ImportEndpoints(t *testing.T) {
+
+	ctx := models.WithWorkspace(context.Background(), models.DefaultWorkspace)
+
+	newStore := func() *storeMock {
+		return &storeMock{endpoints: map[string]*models.Endpoint{
+			"1": {ID: "1", WorkspaceID: models.DefaultWorkspace, ServiceName: "api", URL: "https://api.example.com", Interval: time.Minute},
+			"2": {ID: "2", WorkspaceID: models.DefaultWorkspace, ServiceName: "web", URL: "https://web.example.com", Interval: time.Minute},
+		}}
+	}
+
+	imported := func() models.Endpoints {
+		return models.Endpoints{
+			// matched by id
+			{ID: "1", ServiceName: "api", URL: "https://api2.example.com", Interval: time.Minute},
+			// matched by name
+			{ServiceName: "web", URL: "https://web2.example.com", Interval: time.Minute},
+			{ServiceName: "docs", URL: "https://docs.example.com", Interval: time.Minute},
+		}
+	}
+
+	testingTable := []struct {
+		mode                      models.ImportMode
+		created, updated, skipped int
+		err                       error
+		stored                    int
+	}{
+		{mode: models.ImportUpsert, created: 1, updated: 2, stored: 3},
+		{mode: models.ImportSkip, created: 1, skipped: 2, stored: 3},
+		{mode: models.ImportFail, err: models.ErrEndpointExists, stored: 2},
+		{mode: "merge", err: models.ErrImportMode, stored: 2},
+	}
+
+	for _, tt := range testingTable {
+		t.Run(string(tt.mode), func(t *testing.T) {
+
+			store := newStore()
+			srv := NewEndpointsService(slog.New(slog.NewTextHandler(io.Discard, nil)), store, nil, nil, nil)
+
+			result, err := srv.ImportEndpoints(ctx, imported(), tt.mode)
+			assert.Len(t, store.endpoints, tt.stored)
+
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Len(t, result.Created, tt.created)
+			assert.Len(t, result.Updated, tt.updated)
+			assert.Len(t, result.Skipped, tt.skipped)
+
+			if tt.mode == models.ImportUpsert {
+				assert.Equal(t, "https://web2.example.com", store.endpoints["2"].URL)
+			} else {
+				assert.Equal(t, "https://web.example.com", store.endpoints["2"].URL)
+			}
+		})
+	}
+}
+
+func Test_ImportEndpoints_duplicateNames(t *testing.T) {
+
+	ctx := models.WithWorkspace(context.Background(), models.DefaultWorkspace)
+
+	store := &storeMock{endpoints: make(map[string]*models.Endpoint)}
+	srv := NewEndpointsService(slog.New(slog.NewTextHandler(io.Discard, nil)), store, nil, nil, nil)
+
+	// both would be created, or replace the same endpoint
+	_, err := srv.ImportEndpoints(ctx, models.Endpoints{
+		{ServiceName: "api", URL: "https://a.example.com", Interval: time.Minute},
+		{ServiceName: "api", URL: "https://b.example.com", Interval: time.Minute},
+	}, models.ImportUpsert)
+	assert.ErrorIs(t, err, models.ErrDuplicateName)
+	assert.Empty(t, store.endpoints)
+}
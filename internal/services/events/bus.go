@@ -0,0 +1,125 @@
+package events
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/vishenosik/CherryWatch/internal/services/models"
+)
+
+const (
+	// default number of events buffered per subscriber
+	defaultBufferSize = 256
+)
+
+// Bus fans events out to subscribers.
+//
+// Publishing never blocks: if a subscriber buffer is full the event is dropped
+// for that subscriber and it receives a lagged event once it catches up,
+// so that a slow consumer can't block the scheduler.
+type Bus struct {
+	bufferSize int
+
+	mu          sync.RWMutex
+	subscribers map[*Subscription]struct{}
+}
+
+func NewBus(bufferSize int) *Bus {
+
+	if bufferSize <= 0 {
+		bufferSize = defaultBufferSize
+	}
+
+	return &Bus{
+		bufferSize:  bufferSize,
+		subscribers: make(map[*Subscription]struct{}),
+	}
+}
+
+// Publish delivers event to matching subscribers without blocking.
+func (bus *Bus) Publish(event *models.Event) {
+
+	bus.mu.RLock()
+	defer bus.mu.RUnlock()
+
+	for sub := range bus.subscribers {
+		if !sub.filter.Match(event) {
+			continue
+		}
+		select {
+		case sub.events <- event:
+		default:
+			sub.dropped.Add(1)
+		}
+	}
+}
+
+// Subscribe registers a subscriber receiving events matching the filter.
+// Subscription must be closed when it is not needed anymore.
+func (bus *Bus) Subscribe(filter models.EventsFilter) *Subscription {
+
+	sub := &Subscription{
+		bus:    bus,
+		filter: filter,
+		events: make(chan *models.Event, bus.bufferSize),
+		done:   make(chan struct{}),
+	}
+
+	bus.mu.Lock()
+	bus.subscribers[sub] = struct{}{}
+	bus.mu.Unlock()
+
+	return sub
+}
+
+// Subscribers returns number of active subscriptions.
+func (bus *Bus) Subscribers() int {
+	bus.mu.RLock()
+	defer bus.mu.RUnlock()
+	return len(bus.subscribers)
+}
+
+func (bus *Bus) unsubscribe(sub *Subscription) {
+	bus.mu.Lock()
+	delete(bus.subscribers, sub)
+	bus.mu.Unlock()
+}
+
+// Subscription is a single subscriber of the bus.
+type Subscription struct {
+	bus     *Bus
+	filter  models.EventsFilter
+	events  chan *models.Event
+	dropped atomic.Uint64
+	done    chan struct{}
+	once    sync.Once
+}
+
+// Next waits for the next event.
+// A lagged event is returned first if some events were dropped.
+// It returns nil if the context is done or the subscription is closed.
+func (sub *Subscription) Next(ctx context.Context) *models.Event {
+
+	if dropped := sub.dropped.Swap(0); dropped > 0 {
+		return &models.Event{Kind: models.EventLagged, Dropped: dropped, Time: time.Now()}
+	}
+
+	select {
+	case event := <-sub.events:
+		return event
+	case <-sub.done:
+		return nil
+	case <-ctx.Done():
+		return nil
+	}
+}
+
+// Close unsubscribes from the bus.
+func (sub *Subscription) Close() {
+	sub.once.Do(func() {
+		sub.bus.unsubscribe(sub)
+		close(sub.done)
+	})
+}
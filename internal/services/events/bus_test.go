@@ -0,0 +1,67 @@
+package events
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vishenosik/CherryWatch/internal/services/models"
+)
+
+func Test_Bus_Filter(t *testing.T) {
+
+	bus := NewBus(0)
+
+	sub := bus.Subscribe(models.EventsFilter{EndpointIDs: []string{"1"}})
+	defer sub.Close()
+
+	bus.Publish(&models.Event{Kind: models.EventCheckResult, EndpointID: "2"})
+	bus.Publish(&models.Event{Kind: models.EventCheckResult, EndpointID: "1"})
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	event := sub.Next(ctx)
+	require.NotNil(t, event)
+	assert.Equal(t, "1", event.EndpointID)
+}
+
+func Test_Bus_Backpressure(t *testing.T) {
+
+	bus := NewBus(2)
+
+	sub := bus.Subscribe(models.EventsFilter{})
+	defer sub.Close()
+
+	for range 5 {
+		bus.Publish(&models.Event{Kind: models.EventCheckResult, EndpointID: "1"})
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	lagged := sub.Next(ctx)
+	require.NotNil(t, lagged)
+	assert.Equal(t, models.EventLagged, lagged.Kind)
+	assert.Equal(t, uint64(3), lagged.Dropped)
+
+	for range 2 {
+		event := sub.Next(ctx)
+		require.NotNil(t, event)
+		assert.Equal(t, models.EventCheckResult, event.Kind)
+	}
+}
+
+func Test_Subscription_Close(t *testing.T) {
+
+	bus := NewBus(0)
+	sub := bus.Subscribe(models.EventsFilter{})
+
+	sub.Close()
+	sub.Close()
+
+	assert.Nil(t, sub.Next(context.Background()))
+	assert.Zero(t, bus.Subscribers())
+}
@@ -0,0 +1,171 @@
+package heartbeat
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/vishenosik/CherryWatch/internal/services/models"
+	storeModels "github.com/vishenosik/CherryWatch/internal/store/models"
+	attrs "github.com/vishenosik/web-tools/log"
+	"github.com/vishenosik/web-tools/operation"
+)
+
+const (
+	// default period of overdue monitors lookup
+	defaultCheckInterval = 30 * time.Second
+)
+
+type Store interface {
+	HeartbeatByToken(ctx context.Context, token string) (*models.HeartbeatState, error)
+	Heartbeats(ctx context.Context) ([]*models.HeartbeatState, error)
+	SavePing(ctx context.Context, state *models.HeartbeatState) error
+}
+
+type Incidents interface {
+	Open(ctx context.Context, endpoint *models.Endpoint, cause string) (*models.Incident, bool, error)
+	Resolve(ctx context.Context, endpoint *models.Endpoint) (*models.Incident, error)
+}
+
+// Service accepts heartbeat pings and opens incidents for monitors
+// which have not pinged within the expected period plus grace time.
+type Service struct {
+	log           *slog.Logger
+	store         Store
+	incidents     Incidents
+	checkInterval time.Duration
+	stop          chan struct{}
+	done          chan struct{}
+}
+
+func NewHeartbeatService(
+	log *slog.Logger,
+	store Store,
+	incidents Incidents,
+	checkInterval time.Duration,
+) *Service {
+
+	if checkInterval <= 0 {
+		checkInterval = defaultCheckInterval
+	}
+
+	return &Service{
+		log:           log,
+		store:         store,
+		incidents:     incidents,
+		checkInterval: checkInterval,
+		stop:          make(chan struct{}),
+		done:          make(chan struct{}),
+	}
+}
+
+// Ping registers a ping of the monitor owning the token.
+// Fail pings open an incident, success pings resolve the active one.
+// The token identifies the monitor in any workspace, pings are unauthenticated.
+func (srv *Service) Ping(ctx context.Context, token string, kind models.PingKind) error {
+
+	op := operation.ServicesOperation("heartbeat", "Ping")
+
+	ctx = models.WithAllWorkspaces(ctx)
+
+	state, err := srv.store.HeartbeatByToken(ctx, token)
+	if err != nil {
+		if errors.Is(err, storeModels.ErrNotFound) {
+			return errors.Wrap(models.ErrNotFound, op)
+		}
+		return errors.Wrap(err, op)
+	}
+
+	now := time.Now()
+
+	switch kind {
+	case models.PingStart:
+		state.StartedAt = now
+	case models.PingSuccess, models.PingFail:
+		state.LastPing = now
+		state.LastKind = kind
+	default:
+		return errors.Wrapf(models.ErrPingKind, "%s: %q", op, kind)
+	}
+
+	if err := srv.store.SavePing(ctx, state); err != nil {
+		return errors.Wrap(err, op)
+	}
+
+	switch kind {
+	case models.PingFail:
+		_, _, err = srv.incidents.Open(ctx, state.Endpoint, "job reported failure")
+	case models.PingSuccess:
+		_, err = srv.incidents.Resolve(ctx, state.Endpoint)
+	}
+	if err != nil {
+		return errors.Wrap(err, op)
+	}
+
+	return nil
+}
+
+// MustRun starts overdue monitors lookup and blocks until Stop is called.
+func (srv *Service) MustRun() {
+	if err := srv.Run(); err != nil {
+		panic(err)
+	}
+}
+
+func (srv *Service) Run() error {
+
+	defer close(srv.done)
+
+	ticker := time.NewTicker(srv.checkInterval)
+	defer ticker.Stop()
+
+	srv.log.Info("heartbeat watcher is running", slog.Duration("interval", srv.checkInterval))
+
+	for {
+		select {
+		case <-srv.stop:
+			return nil
+		case now := <-ticker.C:
+			srv.checkOverdue(models.WithAllWorkspaces(context.Background()), now)
+		}
+	}
+}
+
+// Stop stops overdue monitors lookup waiting for the current one to finish.
+func (srv *Service) Stop(ctx context.Context) {
+
+	srv.log.Info("stopping heartbeat watcher")
+
+	close(srv.stop)
+
+	select {
+	case <-srv.done:
+	case <-ctx.Done():
+	}
+}
+
+func (srv *Service) checkOverdue(ctx context.Context, now time.Time) {
+
+	op := operation.ServicesOperation("heartbeat", "checkOverdue")
+	log := srv.log.With(attrs.Operation(op))
+
+	states, err := srv.store.Heartbeats(ctx)
+	if err != nil {
+		log.Error("failed to load heartbeats", attrs.Error(err))
+		return
+	}
+
+	for _, state := range states {
+		cause := state.Overdue(now)
+		if cause == "" {
+			continue
+		}
+		if _, _, err := srv.incidents.Open(ctx, state.Endpoint, cause); err != nil {
+			log.Error("failed to open incident",
+				slog.String("endpoint_id", state.Endpoint.ID),
+				attrs.Error(err),
+			)
+		}
+	}
+}
@@ -0,0 +1,122 @@
+package heartbeat
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vishenosik/CherryWatch/internal/services/models"
+	storeModels "github.com/vishenosik/CherryWatch/internal/store/models"
+)
+
+type storeMock struct {
+	states map[string]*models.HeartbeatState
+}
+
+func (sm *storeMock) HeartbeatByToken(_ context.Context, token string) (*models.HeartbeatState, error) {
+	state, ok := sm.states[token]
+	if !ok {
+		return nil, storeModels.ErrNotFound
+	}
+	return state, nil
+}
+
+func (sm *storeMock) Heartbeats(_ context.Context) ([]*models.HeartbeatState, error) {
+	states := make([]*models.HeartbeatState, 0, len(sm.states))
+	for _, state := range sm.states {
+		states = append(states, state)
+	}
+	return states, nil
+}
+
+func (sm *storeMock) SavePing(_ context.Context, state *models.HeartbeatState) error {
+	sm.states[state.Endpoint.Heartbeat.Token] = state
+	return nil
+}
+
+type incidentsMock struct {
+	open map[string]string
+}
+
+func (im *incidentsMock) Open(_ context.Context, endpoint *models.Endpoint, cause string) (*models.Incident, bool, error) {
+	_, exists := im.open[endpoint.ID]
+	if !exists {
+		im.open[endpoint.ID] = cause
+	}
+	return &models.Incident{EndpointID: endpoint.ID, Cause: cause}, !exists, nil
+}
+
+func (im *incidentsMock) Resolve(_ context.Context, endpoint *models.Endpoint) (*models.Incident, error) {
+	delete(im.open, endpoint.ID)
+	return nil, nil
+}
+
+func newTestService() (*Service, *storeMock, *incidentsMock) {
+	store := &storeMock{
+		states: map[string]*models.HeartbeatState{
+			"token": {
+				Endpoint: &models.Endpoint{
+					ID:        "job",
+					Interval:  time.Hour,
+					Type:      models.CheckHeartbeat,
+					Heartbeat: &models.Heartbeat{Token: "token", Grace: time.Minute},
+				},
+				CreatedAt: time.Now().Add(-2 * time.Hour),
+			},
+		},
+	}
+	incidents := &incidentsMock{open: make(map[string]string)}
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+	return NewHeartbeatService(log, store, incidents, time.Second), store, incidents
+}
+
+func Test_Ping(t *testing.T) {
+
+	ctx := context.Background()
+	srv, store, incidents := newTestService()
+
+	err := srv.Ping(ctx, "unknown", models.PingSuccess)
+	assert.ErrorIs(t, err, models.ErrNotFound)
+
+	require.NoError(t, srv.Ping(ctx, "token", models.PingStart))
+	assert.False(t, store.states["token"].StartedAt.IsZero())
+	assert.Empty(t, incidents.open)
+
+	require.NoError(t, srv.Ping(ctx, "token", models.PingFail))
+	assert.Equal(t, models.PingFail, store.states["token"].LastKind)
+	assert.Contains(t, incidents.open, "job")
+
+	require.NoError(t, srv.Ping(ctx, "token", models.PingSuccess))
+	assert.Equal(t, models.PingSuccess, store.states["token"].LastKind)
+	assert.Empty(t, incidents.open)
+}
+
+func Test_checkOverdue(t *testing.T) {
+
+	ctx := context.Background()
+	srv, _, incidents := newTestService()
+
+	srv.checkOverdue(ctx, time.Now())
+	assert.Contains(t, incidents.open, "job")
+
+	require.NoError(t, srv.Ping(ctx, "token", models.PingSuccess))
+	srv.checkOverdue(ctx, time.Now())
+	assert.Empty(t, incidents.open)
+}
+
+func Test_RunStop(t *testing.T) {
+
+	srv, _, _ := newTestService()
+
+	go srv.MustRun()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	srv.Stop(ctx)
+	assert.NoError(t, ctx.Err())
+}
@@ -0,0 +1,218 @@
+package incidents
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"github.com/vishenosik/CherryWatch/internal/services/models"
+	storeModels "github.com/vishenosik/CherryWatch/internal/store/models"
+	attrs "github.com/vishenosik/web-tools/log"
+	"github.com/vishenosik/web-tools/operation"
+)
+
+type Store interface {
+	Incident(ctx context.Context, id string) (*models.Incident, error)
+	Incidents(ctx context.Context, filter models.IncidentsFilter) (models.Incidents, error)
+	ActiveIncident(ctx context.Context, endpointID string) (*models.Incident, error)
+	SaveIncident(ctx context.Context, incident *models.Incident) error
+	SaveIncidentUpdate(ctx context.Context, update *models.IncidentUpdate) error
+	IncidentUpdates(ctx context.Context, incidentIDs []string) ([]*models.IncidentUpdate, error)
+	SavePostmortem(ctx context.Context, postmortem *models.Postmortem) error
+	Postmortems(ctx context.Context, incidentID string) ([]*models.Postmortem, error)
+}
+
+type Publisher interface {
+	Publish(event *models.Event)
+}
+
+// IncidentObserver receives every opened incident, unlike events subscribers.
+type IncidentObserver interface {
+	ObserveIncident(endpoint *models.Endpoint)
+}
+
+type Service struct {
+	log       *slog.Logger
+	store     Store
+	publisher Publisher
+	opened    IncidentObserver
+}
+
+type Option func(*Service)
+
+// WithIncidentObserver reports every opened incident to the observer.
+func WithIncidentObserver(observer IncidentObserver) Option {
+	return func(srv *Service) {
+		srv.opened = observer
+	}
+}
+
+func NewIncidentsService(
+	log *slog.Logger,
+	store Store,
+	publisher Publisher,
+	opts ...Option,
+) *Service {
+
+	srv := &Service{
+		log:       log,
+		store:     store,
+		publisher: publisher,
+	}
+
+	for _, opt := range opts {
+		opt(srv)
+	}
+
+	return srv
+}
+
+// Incidents returns incidents matching the filter, newest first.
+func (srv *Service) Incidents(
+	ctx context.Context,
+	filter models.IncidentsFilter,
+) (models.Incidents, error) {
+
+	op := operation.ServicesOperation("incidents", "Incidents")
+
+	incidents, err := srv.store.Incidents(ctx, filter)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	return incidents, nil
+}
+
+// Acknowledge marks the active incident as taken by the context principal.
+// Acknowledging it again keeps the first acknowledgement.
+func (srv *Service) Acknowledge(ctx context.Context, id string) (*models.Incident, error) {
+
+	op := operation.ServicesOperation("incidents", "Acknowledge")
+
+	incident, err := srv.store.Incident(ctx, id)
+	if err != nil {
+		if errors.Is(err, storeModels.ErrNotFound) {
+			return nil, errors.Wrap(models.ErrNotFound, op)
+		}
+		return nil, errors.Wrap(err, op)
+	}
+
+	if !incident.Active() {
+		return nil, errors.Wrap(models.ErrIncidentResolved, op)
+	}
+
+	if !incident.AcknowledgedAt.IsZero() {
+		return incident, nil
+	}
+
+	incident.AcknowledgedAt = time.Now()
+	if principal, ok := models.PrincipalFrom(ctx); ok {
+		incident.AcknowledgedBy = principal.Name()
+	}
+
+	if err := srv.store.SaveIncident(ctx, incident); err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	srv.log.Info("incident acknowledged",
+		attrs.Operation(op),
+		slog.String("incident_id", incident.ID),
+		slog.String("by", incident.AcknowledgedBy),
+	)
+
+	return incident, nil
+}
+
+// Open opens an incident for the endpoint unless there is an active one already.
+// It returns the active incident and whether it has just been opened.
+func (srv *Service) Open(
+	ctx context.Context,
+	endpoint *models.Endpoint,
+	cause string,
+) (*models.Incident, bool, error) {
+
+	op := operation.ServicesOperation("incidents", "Open")
+
+	active, err := srv.store.ActiveIncident(ctx, endpoint.ID)
+	switch {
+	case err == nil:
+		return active, false, nil
+	case !errors.Is(err, storeModels.ErrNotFound):
+		return nil, false, errors.Wrap(err, op)
+	}
+
+	incident := &models.Incident{
+		ID:          uuid.NewString(),
+		EndpointID:  endpoint.ID,
+		WorkspaceID: endpoint.WorkspaceID,
+		Cause:       cause,
+		OpenedAt:    time.Now(),
+	}
+
+	if err := srv.store.SaveIncident(ctx, incident); err != nil {
+		// a concurrent check opened the incident first
+		if errors.Is(err, storeModels.ErrAlreadyExists) {
+			active, err := srv.store.ActiveIncident(ctx, endpoint.ID)
+			if err != nil {
+				return nil, false, errors.Wrap(err, op)
+			}
+			return active, false, nil
+		}
+		return nil, false, errors.Wrap(err, op)
+	}
+
+	srv.log.Warn("incident opened",
+		attrs.Operation(op),
+		slog.String("endpoint_id", endpoint.ID),
+		slog.String("cause", cause),
+	)
+
+	srv.publish(models.EventIncidentOpened, endpoint, incident)
+
+	if srv.opened != nil {
+		srv.opened.ObserveIncident(endpoint)
+	}
+
+	return incident, true, nil
+}
+
+// Resolve resolves the active incident of the endpoint.
+// It returns nil incident if there is no active one.
+func (srv *Service) Resolve(
+	ctx context.Context,
+	endpoint *models.Endpoint,
+) (*models.Incident, error) {
+
+	op := operation.ServicesOperation("incidents", "Resolve")
+
+	incident, err := srv.store.ActiveIncident(ctx, endpoint.ID)
+	switch {
+	case errors.Is(err, storeModels.ErrNotFound):
+		return nil, nil
+	case err != nil:
+		return nil, errors.Wrap(err, op)
+	}
+
+	incident.ResolvedAt = time.Now()
+
+	if err := srv.store.SaveIncident(ctx, incident); err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	srv.log.Info("incident resolved",
+		attrs.Operation(op),
+		slog.String("endpoint_id", endpoint.ID),
+	)
+
+	srv.publish(models.EventIncidentResolved, endpoint, incident)
+
+	return incident, nil
+}
+
+func (srv *Service) publish(kind models.EventKind, endpoint *models.Endpoint, incident *models.Incident) {
+	event := models.NewEndpointEvent(kind, endpoint)
+	event.Incident = incident
+	srv.publisher.Publish(event)
+}
@@ -0,0 +1,308 @@
+package incidents
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vishenosik/CherryWatch/internal/services/models"
+	storeModels "github.com/vishenosik/CherryWatch/internal/store/models"
+)
+
+type storeMock struct {
+	incidents   map[string]*models.Incident
+	updates     []*models.IncidentUpdate
+	postmortems []*models.Postmortem
+}
+
+func (sm *storeMock) Incident(_ context.Context, id string) (*models.Incident, error) {
+	incident, ok := sm.incidents[id]
+	if !ok {
+		return nil, storeModels.ErrNotFound
+	}
+	copied := *incident
+	return &copied, nil
+}
+
+func (sm *storeMock) Incidents(_ context.Context, _ models.IncidentsFilter) (models.Incidents, error) {
+	return nil, nil
+}
+
+func (sm *storeMock) ActiveIncident(_ context.Context, endpointID string) (*models.Incident, error) {
+	for _, incident := range sm.incidents {
+		if incident.EndpointID == endpointID && incident.Active() {
+			return incident, nil
+		}
+	}
+	return nil, storeModels.ErrNotFound
+}
+
+func (sm *storeMock) SaveIncident(_ context.Context, incident *models.Incident) error {
+	// an endpoint has a single active incident, as the unique index keeps it
+	if _, ok := sm.incidents[incident.ID]; !ok {
+		if _, err := sm.ActiveIncident(context.Background(), incident.EndpointID); err == nil {
+			return storeModels.ErrAlreadyExists
+		}
+	}
+	sm.incidents[incident.ID] = incident
+	return nil
+}
+
+// racingStore misses the active incident on the first lookup,
+// as a check running concurrently with the one opening it does.
+type racingStore struct {
+	*storeMock
+	missed bool
+}
+
+func (rs *racingStore) ActiveIncident(ctx context.Context, endpointID string) (*models.Incident, error) {
+	if !rs.missed {
+		rs.missed = true
+		return nil, storeModels.ErrNotFound
+	}
+	return rs.storeMock.ActiveIncident(ctx, endpointID)
+}
+
+func (sm *storeMock) SaveIncidentUpdate(_ context.Context, update *models.IncidentUpdate) error {
+	sm.updates = append(sm.updates, update)
+	return nil
+}
+
+func (sm *storeMock) IncidentUpdates(_ context.Context, _ []string) ([]*models.IncidentUpdate, error) {
+	return sm.updates, nil
+}
+
+func (sm *storeMock) SavePostmortem(_ context.Context, postmortem *models.Postmortem) error {
+	postmortem.Version = len(sm.postmortems) + 1
+	sm.postmortems = append([]*models.Postmortem{postmortem}, sm.postmortems...)
+	return nil
+}
+
+func (sm *storeMock) Postmortems(_ context.Context, _ string) ([]*models.Postmortem, error) {
+	return sm.postmortems, nil
+}
+
+type publisherMock struct {
+	events []*models.Event
+}
+
+func (pm *publisherMock) Publish(event *models.Event) {
+	pm.events = append(pm.events, event)
+}
+
+func Test_Acknowledge(t *testing.T) {
+
+	ackedAt := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	newStore := func() *storeMock {
+		return &storeMock{incidents: map[string]*models.Incident{
+			"active":   {ID: "active", EndpointID: "1", OpenedAt: ackedAt.Add(-time.Hour)},
+			"resolved": {ID: "resolved", EndpointID: "1", OpenedAt: ackedAt.Add(-time.Hour), ResolvedAt: ackedAt},
+			"acked":    {ID: "acked", EndpointID: "1", OpenedAt: ackedAt.Add(-time.Hour), AcknowledgedAt: ackedAt, AcknowledgedBy: "user:first"},
+		}}
+	}
+
+	ctx, err := models.WithPrincipal(context.Background(), &models.Principal{
+		WorkspaceID: models.DefaultWorkspace,
+		AppID:       "app",
+		AppName:     "cli",
+		Role:        models.RoleEditor,
+	})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		id      string
+		wantBy  string
+		wantErr error
+	}{
+		{name: "active", id: "active", wantBy: "app:cli"},
+		{name: "keeps first acknowledgement", id: "acked", wantBy: "user:first"},
+		{name: "resolved", id: "resolved", wantErr: models.ErrIncidentResolved},
+		{name: "missing", id: "missing", wantErr: models.ErrNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			store := newStore()
+			srv := NewIncidentsService(slog.New(slog.NewTextHandler(io.Discard, nil)), store, nil)
+
+			incident, err := srv.Acknowledge(ctx, tt.id)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.wantBy, incident.AcknowledgedBy)
+			assert.False(t, incident.AcknowledgedAt.IsZero())
+			assert.Equal(t, tt.wantBy, store.incidents[tt.id].AcknowledgedBy)
+		})
+	}
+}
+
+type observerMock struct {
+	opened []string
+}
+
+func (om *observerMock) ObserveIncident(endpoint *models.Endpoint) {
+	om.opened = append(om.opened, endpoint.ID)
+}
+
+func Test_Open(t *testing.T) {
+
+	ctx := context.Background()
+	endpoint := &models.Endpoint{ID: "1", WorkspaceID: models.DefaultWorkspace}
+
+	store := &storeMock{incidents: make(map[string]*models.Incident)}
+	publisher := &publisherMock{}
+	observer := &observerMock{}
+
+	srv := NewIncidentsService(
+		slog.New(slog.NewTextHandler(io.Discard, nil)),
+		store,
+		publisher,
+		WithIncidentObserver(observer),
+	)
+
+	incident, opened, err := srv.Open(ctx, endpoint, "timeout")
+	require.NoError(t, err)
+	assert.True(t, opened)
+
+	// the active incident is returned while the endpoint is down
+	active, opened, err := srv.Open(ctx, endpoint, "timeout")
+	require.NoError(t, err)
+	assert.False(t, opened)
+	assert.Equal(t, incident.ID, active.ID)
+
+	assert.Len(t, publisher.events, 1)
+	assert.Equal(t, []string{"1"}, observer.opened)
+}
+
+func Test_Open_concurrent(t *testing.T) {
+
+	ctx := context.Background()
+	endpoint := &models.Endpoint{ID: "1", WorkspaceID: models.DefaultWorkspace}
+
+	store := &racingStore{storeMock: &storeMock{incidents: map[string]*models.Incident{
+		"first": {ID: "first", EndpointID: "1", WorkspaceID: models.DefaultWorkspace, OpenedAt: time.Now()},
+	}}}
+	publisher := &publisherMock{}
+
+	srv := NewIncidentsService(slog.New(slog.NewTextHandler(io.Discard, nil)), store, publisher)
+
+	incident, opened, err := srv.Open(ctx, endpoint, "timeout")
+	require.NoError(t, err)
+	assert.False(t, opened)
+	assert.Equal(t, "first", incident.ID)
+	assert.Len(t, store.incidents, 1)
+	assert.Empty(t, publisher.events)
+}
+
+func Test_Updates(t *testing.T) {
+
+	openedAt := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	store := &storeMock{incidents: map[string]*models.Incident{
+		"active":   {ID: "active", EndpointID: "1", WorkspaceID: models.DefaultWorkspace, Cause: "timeout", OpenedAt: openedAt},
+		"resolved": {ID: "resolved", EndpointID: "1", WorkspaceID: models.DefaultWorkspace, Cause: "timeout", OpenedAt: openedAt, ResolvedAt: openedAt.Add(time.Hour)},
+	}}
+	publisher := &publisherMock{}
+
+	srv := NewIncidentsService(slog.New(slog.NewTextHandler(io.Discard, nil)), store, publisher)
+
+	ctx, err := models.WithPrincipal(context.Background(), &models.Principal{
+		WorkspaceID: models.DefaultWorkspace,
+		UserID:      "42",
+		Role:        models.RoleEditor,
+	})
+	require.NoError(t, err)
+
+	t.Run("post update", func(t *testing.T) {
+
+		update, err := srv.PostUpdate(ctx, "active", &models.IncidentUpdate{
+			State: models.IncidentIdentified,
+			Body:  "Database failover is in progress.",
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, "active", update.IncidentID)
+		assert.Equal(t, "user:42", update.Author)
+
+		require.Len(t, publisher.events, 1)
+		assert.Equal(t, models.EventIncidentUpdated, publisher.events[0].Kind)
+		assert.Equal(t, update, publisher.events[0].Update)
+
+		_, err = srv.PostUpdate(ctx, "active", &models.IncidentUpdate{State: "fixed", Body: "Done"})
+		assert.ErrorIs(t, err, models.ErrInvalidIncidentUpdate)
+
+		_, err = srv.PostUpdate(ctx, "missing", &models.IncidentUpdate{State: models.IncidentMonitoring, Body: "Done"})
+		assert.ErrorIs(t, err, models.ErrNotFound)
+	})
+
+	t.Run("postmortem versions", func(t *testing.T) {
+
+		_, err := srv.SavePostmortem(ctx, "active", "# Summary")
+		assert.ErrorIs(t, err, models.ErrIncidentActive)
+
+		first, err := srv.SavePostmortem(ctx, "resolved", "# Summary")
+		require.NoError(t, err)
+		second, err := srv.SavePostmortem(ctx, "resolved", "# Summary\n\nRoot cause found.")
+		require.NoError(t, err)
+
+		assert.Equal(t, 1, first.Version)
+		assert.Equal(t, 2, second.Version)
+
+		postmortems, err := srv.Postmortems(ctx, "resolved")
+		require.NoError(t, err)
+		require.Len(t, postmortems, 2)
+		assert.Equal(t, 2, postmortems[0].Version)
+	})
+}
+
+func Test_timeline(t *testing.T) {
+
+	openedAt := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	incident := &models.Incident{
+		ID:             "1",
+		Cause:          "timeout",
+		OpenedAt:       openedAt,
+		AcknowledgedAt: openedAt.Add(5 * time.Minute),
+		AcknowledgedBy: "user:42",
+		ResolvedAt:     openedAt.Add(time.Hour),
+	}
+
+	updates := []*models.IncidentUpdate{
+		{State: models.IncidentInvestigating, Body: "Looking into it", CreatedAt: openedAt.Add(time.Minute)},
+		{State: models.IncidentMonitoring, Body: "Fixed", CreatedAt: openedAt.Add(30 * time.Minute)},
+	}
+
+	postmortems := []*models.Postmortem{
+		{Version: 2, Body: "v2", CreatedAt: openedAt.Add(48 * time.Hour)},
+		{Version: 1, Body: "v1", CreatedAt: openedAt.Add(24 * time.Hour)},
+	}
+
+	got := timeline(incident, updates, postmortems)
+
+	kinds := make([]models.TimelineEntryKind, 0, len(got.Entries))
+	for _, entry := range got.Entries {
+		kinds = append(kinds, entry.Kind)
+	}
+
+	assert.Equal(t, []models.TimelineEntryKind{
+		models.TimelineOpened,
+		models.TimelineUpdate,
+		models.TimelineAcknowledged,
+		models.TimelineUpdate,
+		models.TimelineResolved,
+		models.TimelinePostmortem,
+		models.TimelinePostmortem,
+	}, kinds)
+	assert.Equal(t, 1, got.Entries[5].Version)
+}
@@ -0,0 +1,231 @@
+package incidents
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"github.com/vishenosik/CherryWatch/internal/services/models"
+	storeModels "github.com/vishenosik/CherryWatch/internal/store/models"
+	attrs "github.com/vishenosik/web-tools/log"
+	"github.com/vishenosik/web-tools/operation"
+)
+
+// PostUpdate posts an operator update onto the incident and announces it to subscribers.
+// Updates don't change the incident itself, it is resolved by checks.
+func (srv *Service) PostUpdate(
+	ctx context.Context,
+	incidentID string,
+	update *models.IncidentUpdate,
+) (*models.IncidentUpdate, error) {
+
+	op := operation.ServicesOperation("incidents", "PostUpdate")
+
+	incident, err := srv.incident(ctx, incidentID)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	if err := update.Validate(); err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	update.ID = uuid.NewString()
+	update.IncidentID = incident.ID
+	update.WorkspaceID = incident.WorkspaceID
+	update.Author = author(ctx)
+	update.CreatedAt = time.Now()
+
+	if err := srv.store.SaveIncidentUpdate(ctx, update); err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	srv.log.Info("incident update posted",
+		attrs.Operation(op),
+		slog.String("incident_id", incident.ID),
+		slog.String("state", string(update.State)),
+		slog.String("by", update.Author),
+	)
+
+	srv.publisher.Publish(&models.Event{
+		Kind:        models.EventIncidentUpdated,
+		EndpointID:  incident.EndpointID,
+		WorkspaceID: incident.WorkspaceID,
+		Incident:    incident,
+		Update:      update,
+		Time:        update.CreatedAt,
+	})
+
+	return update, nil
+}
+
+// IncidentUpdates returns updates of the incidents, oldest first.
+func (srv *Service) IncidentUpdates(ctx context.Context, incidentIDs []string) ([]*models.IncidentUpdate, error) {
+
+	op := operation.ServicesOperation("incidents", "IncidentUpdates")
+
+	updates, err := srv.store.IncidentUpdates(ctx, incidentIDs)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	return updates, nil
+}
+
+// SavePostmortem stores a new version of the resolved incident postmortem.
+func (srv *Service) SavePostmortem(ctx context.Context, incidentID, body string) (*models.Postmortem, error) {
+
+	op := operation.ServicesOperation("incidents", "SavePostmortem")
+
+	incident, err := srv.incident(ctx, incidentID)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	if incident.Active() {
+		return nil, errors.Wrap(models.ErrIncidentActive, op)
+	}
+
+	if strings.TrimSpace(body) == "" {
+		return nil, errors.Wrap(errors.Wrap(models.ErrInvalidIncidentUpdate, "body is required"), op)
+	}
+
+	postmortem := &models.Postmortem{
+		IncidentID:  incident.ID,
+		WorkspaceID: incident.WorkspaceID,
+		Body:        body,
+		Author:      author(ctx),
+		CreatedAt:   time.Now(),
+	}
+
+	if err := srv.store.SavePostmortem(ctx, postmortem); err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	srv.log.Info("postmortem saved",
+		attrs.Operation(op),
+		slog.String("incident_id", incident.ID),
+		slog.Int("version", postmortem.Version),
+		slog.String("by", postmortem.Author),
+	)
+
+	return postmortem, nil
+}
+
+// Postmortems returns every version of the incident postmortem, newest first.
+func (srv *Service) Postmortems(ctx context.Context, incidentID string) ([]*models.Postmortem, error) {
+
+	op := operation.ServicesOperation("incidents", "Postmortems")
+
+	if _, err := srv.incident(ctx, incidentID); err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	postmortems, err := srv.store.Postmortems(ctx, incidentID)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	return postmortems, nil
+}
+
+// Timeline returns the incident history: its transitions, updates and postmortem versions.
+func (srv *Service) Timeline(ctx context.Context, incidentID string) (*models.Timeline, error) {
+
+	op := operation.ServicesOperation("incidents", "Timeline")
+
+	incident, err := srv.incident(ctx, incidentID)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	updates, err := srv.store.IncidentUpdates(ctx, []string{incident.ID})
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	postmortems, err := srv.store.Postmortems(ctx, incident.ID)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	return timeline(incident, updates, postmortems), nil
+}
+
+func timeline(incident *models.Incident, updates []*models.IncidentUpdate, postmortems []*models.Postmortem) *models.Timeline {
+
+	entries := []*models.TimelineEntry{{
+		Kind: models.TimelineOpened,
+		Time: incident.OpenedAt,
+		Body: incident.Cause,
+	}}
+
+	if !incident.AcknowledgedAt.IsZero() {
+		entries = append(entries, &models.TimelineEntry{
+			Kind:   models.TimelineAcknowledged,
+			Time:   incident.AcknowledgedAt,
+			Author: incident.AcknowledgedBy,
+		})
+	}
+
+	for _, update := range updates {
+		entries = append(entries, &models.TimelineEntry{
+			Kind:   models.TimelineUpdate,
+			Time:   update.CreatedAt,
+			Author: update.Author,
+			State:  update.State,
+			Body:   update.Body,
+		})
+	}
+
+	if !incident.Active() {
+		entries = append(entries, &models.TimelineEntry{
+			Kind: models.TimelineResolved,
+			Time: incident.ResolvedAt,
+		})
+	}
+
+	for _, postmortem := range postmortems {
+		entries = append(entries, &models.TimelineEntry{
+			Kind:    models.TimelinePostmortem,
+			Time:    postmortem.CreatedAt,
+			Author:  postmortem.Author,
+			Body:    postmortem.Body,
+			Version: postmortem.Version,
+		})
+	}
+
+	slices.SortStableFunc(entries, func(a, b *models.TimelineEntry) int {
+		return a.Time.Compare(b.Time)
+	})
+
+	return &models.Timeline{
+		Incident: incident,
+		Entries:  entries,
+	}
+}
+
+func (srv *Service) incident(ctx context.Context, id string) (*models.Incident, error) {
+
+	incident, err := srv.store.Incident(ctx, id)
+	if err != nil {
+		if errors.Is(err, storeModels.ErrNotFound) {
+			return nil, models.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return incident, nil
+}
+
+// author names the context principal.
+func author(ctx context.Context) string {
+	if principal, ok := models.PrincipalFrom(ctx); ok {
+		return principal.Name()
+	}
+	return ""
+}
@@ -0,0 +1,80 @@
+package maintenances
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"github.com/vishenosik/CherryWatch/internal/services/models"
+	storeModels "github.com/vishenosik/CherryWatch/internal/store/models"
+	"github.com/vishenosik/web-tools/operation"
+)
+
+type Store interface {
+	SaveMaintenance(ctx context.Context, mnt *models.Maintenance) error
+	Maintenances(ctx context.Context, filter models.MaintenancesFilter) (models.Maintenances, error)
+	DeleteMaintenance(ctx context.Context, id string) error
+}
+
+type Service struct {
+	log   *slog.Logger
+	store Store
+}
+
+func NewMaintenancesService(
+	log *slog.Logger,
+	store Store,
+) *Service {
+	return &Service{
+		log:   log,
+		store: store,
+	}
+}
+
+// CreateMaintenance validates and stores a maintenance announcement.
+func (srv *Service) CreateMaintenance(ctx context.Context, mnt *models.Maintenance) (*models.Maintenance, error) {
+
+	op := operation.ServicesOperation("maintenances", "CreateMaintenance")
+
+	mnt.ID = uuid.NewString()
+	mnt.WorkspaceID = models.OwnerWorkspace(ctx, mnt.WorkspaceID)
+
+	if err := mnt.Validate(); err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	if err := srv.store.SaveMaintenance(ctx, mnt); err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	return mnt, nil
+}
+
+// Maintenances returns maintenances matching the filter ordered by start.
+func (srv *Service) Maintenances(ctx context.Context, filter models.MaintenancesFilter) (models.Maintenances, error) {
+
+	op := operation.ServicesOperation("maintenances", "Maintenances")
+
+	maintenances, err := srv.store.Maintenances(ctx, filter)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	return maintenances, nil
+}
+
+// DeleteMaintenance deletes a maintenance by id.
+func (srv *Service) DeleteMaintenance(ctx context.Context, id string) error {
+
+	op := operation.ServicesOperation("maintenances", "DeleteMaintenance")
+
+	if err := srv.store.DeleteMaintenance(ctx, id); err != nil {
+		if errors.Is(err, storeModels.ErrNotFound) {
+			return errors.Wrap(models.ErrNotFound, op)
+		}
+		return errors.Wrap(err, op)
+	}
+
+	return nil
+}
@@ -0,0 +1,87 @@
+package metrics
+
+import (
+	"sync"
+	"time"
+
+	"github.com/vishenosik/CherryWatch/internal/services/models"
+)
+
+var (
+	// check duration buckets, seconds
+	durationBounds = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30}
+	// scheduler queue lag buckets, seconds
+	lagBounds = []float64{0.1, 0.5, 1, 2.5, 5, 10, 30, 60, 300}
+)
+
+// Recorder accumulates endpoint statistics of every workspace and the scheduler queue lag.
+// It's called by the services checking endpoints and opening incidents,
+// so that unlike events nothing is dropped.
+type Recorder struct {
+	mu       sync.Mutex
+	stats    map[string]*endpointStats
+	queueLag models.Histogram
+}
+
+func NewRecorder() *Recorder {
+	return &Recorder{
+		stats:    make(map[string]*endpointStats),
+		queueLag: models.NewHistogram(lagBounds...),
+	}
+}
+
+// ObserveResult records a check result of the endpoint.
+func (rec *Recorder) ObserveResult(endpoint *models.Endpoint, result *models.CheckResult) {
+
+	rec.mu.Lock()
+	defer rec.mu.Unlock()
+
+	stats := rec.endpointStats(endpoint)
+
+	stats.Up = result.Success
+	stats.Latency = result.Latency
+	stats.CertExpiresAt = result.CertExpiresAt
+	stats.CheckedAt = result.CheckedAt
+	stats.Checks++
+	if !result.Success {
+		stats.Failures++
+	}
+	stats.Durations.Observe(result.Latency.Seconds())
+}
+
+// ObserveIncident records an incident opened for the endpoint.
+func (rec *Recorder) ObserveIncident(endpoint *models.Endpoint) {
+
+	rec.mu.Lock()
+	defer rec.mu.Unlock()
+
+	rec.endpointStats(endpoint).IncidentsOpened++
+}
+
+// ObserveQueueLag records a delay of a due check.
+func (rec *Recorder) ObserveQueueLag(lag time.Duration) {
+
+	rec.mu.Lock()
+	defer rec.mu.Unlock()
+
+	rec.queueLag.Observe(lag.Seconds())
+}
+
+func (rec *Recorder) endpointStats(endpoint *models.Endpoint) *endpointStats {
+
+	stats, ok := rec.stats[endpoint.ID]
+	if !ok {
+		stats = &endpointStats{
+			workspaceID:   endpoint.WorkspaceID,
+			EndpointStats: models.EndpointStats{Durations: models.NewHistogram(durationBounds...)},
+		}
+		rec.stats[endpoint.ID] = stats
+	}
+
+	return stats
+}
+
+type endpointStats struct {
+	workspaceID string
+	models.EndpointStats
+}
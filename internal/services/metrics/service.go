@@ -0,0 +1,101 @@
+package metrics
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/pkg/errors"
+	"github.com/vishenosik/CherryWatch/internal/services/models"
+	"github.com/vishenosik/web-tools/operation"
+)
+
+type Endpoints interface {
+	Endpoints(ctx context.Context) (models.Endpoints, error)
+}
+
+type Incidents interface {
+	Incidents(ctx context.Context, filter models.IncidentsFilter) (models.Incidents, error)
+}
+
+// Service reports endpoint statistics accumulated by the recorder.
+type Service struct {
+	log       *slog.Logger
+	endpoints Endpoints
+	incidents Incidents
+	recorder  *Recorder
+}
+
+func NewMetricsService(
+	log *slog.Logger,
+	endpoints Endpoints,
+	incidents Incidents,
+	recorder *Recorder,
+) *Service {
+	return &Service{
+		log:       log.With(slog.String("component", "metrics")),
+		endpoints: endpoints,
+		incidents: incidents,
+		recorder:  recorder,
+	}
+}
+
+// Metrics returns statistics of the context workspace endpoints.
+// Statistics of deleted endpoints are forgotten.
+func (srv *Service) Metrics(ctx context.Context) (*models.Metrics, error) {
+
+	op := operation.ServicesOperation("metrics", "Metrics")
+
+	endpoints, err := srv.endpoints.Endpoints(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	incidents, err := srv.incidents.Incidents(ctx, models.IncidentsFilter{ActiveOnly: true})
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	open := make(map[string]int, len(incidents))
+	for _, incident := range incidents {
+		open[incident.EndpointID]++
+	}
+
+	rec := srv.recorder
+
+	rec.mu.Lock()
+	defer rec.mu.Unlock()
+
+	metrics := &models.Metrics{
+		Endpoints: make([]models.EndpointMetrics, 0, len(endpoints)),
+		QueueLag:  rec.queueLag.Clone(),
+	}
+
+	known := make(map[string]struct{}, len(endpoints))
+
+	for _, endpoint := range endpoints {
+
+		known[endpoint.ID] = struct{}{}
+
+		endpointMetrics := models.EndpointMetrics{
+			Endpoint:      endpoint,
+			OpenIncidents: open[endpoint.ID],
+		}
+		if stats, ok := rec.stats[endpoint.ID]; ok {
+			endpointMetrics.Stats = stats.EndpointStats
+			endpointMetrics.Stats.Durations = stats.Durations.Clone()
+		}
+
+		metrics.Endpoints = append(metrics.Endpoints, endpointMetrics)
+	}
+
+	// endpoints of other workspaces are unknown in scoped contexts
+	scope, _ := models.WorkspaceFrom(ctx)
+	for id, stats := range rec.stats {
+		if _, ok := known[id]; ok || (scope != "" && scope != stats.workspaceID) {
+			continue
+		}
+		delete(rec.stats, id)
+	}
+
+	return metrics, nil
+}
@@ -0,0 +1,100 @@
+package metrics
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vishenosik/CherryWatch/internal/services/models"
+)
+
+type endpointsMock struct {
+	endpoints models.Endpoints
+}
+
+func (em *endpointsMock) Endpoints(ctx context.Context) (models.Endpoints, error) {
+	scope, _ := models.WorkspaceFrom(ctx)
+	endpoints := make(models.Endpoints, 0, len(em.endpoints))
+	for _, endpoint := range em.endpoints {
+		if scope == "" || endpoint.WorkspaceID == scope {
+			endpoints = append(endpoints, endpoint)
+		}
+	}
+	return endpoints, nil
+}
+
+type incidentsMock struct {
+	incidents models.Incidents
+}
+
+func (im *incidentsMock) Incidents(_ context.Context, _ models.IncidentsFilter) (models.Incidents, error) {
+	return im.incidents, nil
+}
+
+func Test_Metrics(t *testing.T) {
+
+	api := &models.Endpoint{ID: "api", WorkspaceID: models.DefaultWorkspace}
+	web := &models.Endpoint{ID: "web", WorkspaceID: "other"}
+
+	endpoints := &endpointsMock{endpoints: models.Endpoints{api, web}}
+	incidents := &incidentsMock{incidents: models.Incidents{{ID: "1", EndpointID: "api"}}}
+
+	recorder := NewRecorder()
+	srv := NewMetricsService(slog.New(slog.NewTextHandler(io.Discard, nil)), endpoints, incidents, recorder)
+
+	checkedAt := time.Now()
+	expiresAt := checkedAt.Add(24 * time.Hour)
+
+	observe := func(endpoint *models.Endpoint, success bool, latency time.Duration) {
+		recorder.ObserveResult(endpoint, &models.CheckResult{
+			EndpointID:    endpoint.ID,
+			Success:       success,
+			Latency:       latency,
+			CheckedAt:     checkedAt,
+			CertExpiresAt: expiresAt,
+		})
+	}
+
+	observe(api, true, 20*time.Millisecond)
+	observe(api, false, 2*time.Second)
+	observe(web, true, time.Millisecond)
+	recorder.ObserveIncident(api)
+
+	recorder.ObserveQueueLag(3 * time.Second)
+
+	ctx := models.WithWorkspace(context.Background(), models.DefaultWorkspace)
+
+	metrics, err := srv.Metrics(ctx)
+	require.NoError(t, err)
+	require.Len(t, metrics.Endpoints, 1)
+
+	stats := metrics.Endpoints[0].Stats
+	assert.Equal(t, api, metrics.Endpoints[0].Endpoint)
+	assert.Equal(t, 1, metrics.Endpoints[0].OpenIncidents)
+	assert.False(t, stats.Up)
+	assert.Equal(t, 2*time.Second, stats.Latency)
+	assert.Equal(t, expiresAt, stats.CertExpiresAt)
+	assert.EqualValues(t, 2, stats.Checks)
+	assert.EqualValues(t, 1, stats.Failures)
+	assert.EqualValues(t, 1, stats.IncidentsOpened)
+	assert.EqualValues(t, 2, stats.Durations.Count)
+	// 20ms fits the 25ms bucket, 2s only the 2.5s one
+	assert.EqualValues(t, 1, stats.Durations.Counts[2])
+	assert.EqualValues(t, 2, stats.Durations.Counts[8])
+	assert.EqualValues(t, 1, metrics.QueueLag.Count)
+
+	// deleted endpoint statistics are forgotten by its workspace scrape only
+	endpoints.endpoints = nil
+
+	_, err = srv.Metrics(ctx)
+	require.NoError(t, err)
+
+	recorder.mu.Lock()
+	assert.NotContains(t, recorder.stats, "api")
+	assert.Contains(t, recorder.stats, "web")
+	recorder.mu.Unlock()
+}
@@ -0,0 +1,42 @@
+package models
+
+import (
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-multierror"
+)
+
+// Agent is a probe agent checking endpoints assigned to its location
+// and reporting results to the server.
+type Agent struct {
+	// Agent identifier, kept while the server runs
+	ID string
+	// Owning workspace, agents check its endpoints only
+	WorkspaceID string
+	// Name unique within the workspace, usually the host name
+	Name string
+	// Location the agent checks endpoints from
+	Location string
+	// Time the agent registered first
+	RegisteredAt time.Time
+	// Time of the last agent request
+	LastSeenAt time.Time
+}
+
+type Agents = []*Agent
+
+func (agent *Agent) Validate() error {
+
+	var errs *multierror.Error
+
+	if strings.TrimSpace(agent.Name) == "" {
+		errs = multierror.Append(errs, ErrAgentName)
+	}
+
+	if strings.TrimSpace(agent.Location) == "" {
+		errs = multierror.Append(errs, ErrAgentLocation)
+	}
+
+	return errs.ErrorOrNil()
+}
@@ -0,0 +1,78 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type AuditAction string
+
+const (
+	AuditCreate AuditAction = "create"
+	AuditUpdate AuditAction = "update"
+	AuditDelete AuditAction = "delete"
+)
+
+type AuditResource string
+
+const (
+	AuditEndpoint  AuditResource = "endpoint"
+	AuditUser      AuditResource = "user"
+	AuditWorkspace AuditResource = "workspace"
+)
+
+// Change is a configuration change to be recorded into the audit log.
+type Change struct {
+	// Workspace the changed resource belongs to
+	WorkspaceID string
+	Action      AuditAction
+	Resource    AuditResource
+	ResourceID  string
+	// State before the change, nil for creation
+	Before any
+	// State after the change, nil for deletion
+	After any
+}
+
+// AuditEntry is an immutable audit log record.
+type AuditEntry struct {
+	ID          string
+	WorkspaceID string
+	// Actor user, empty for API keys
+	UserID string
+	// Actor app, empty for changes made by CherryWatch itself
+	AppID   string
+	AppName string
+	Action  AuditAction
+	// Kind of the changed resource
+	Resource   AuditResource
+	ResourceID string
+	// JSON states with secrets redacted
+	Before json.RawMessage
+	After  json.RawMessage
+	// Changed fields
+	Diff []FieldChange
+	Time time.Time
+}
+
+type AuditEntries = []*AuditEntry
+
+// FieldChange is a difference of a single field, path is dot separated.
+type FieldChange struct {
+	Path   string          `json:"path"`
+	Before json.RawMessage `json:"before,omitempty"`
+	After  json.RawMessage `json:"after,omitempty"`
+}
+
+// AuditFilter narrows audit log lookups, zero fields match everything.
+type AuditFilter struct {
+	Resource   AuditResource
+	ResourceID string
+	Action     AuditAction
+	// User or app identifier
+	Actor string
+	Since time.Time
+	Until time.Time
+	// Max number of entries, newest first
+	Limit int
+}
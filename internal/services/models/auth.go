@@ -0,0 +1,62 @@
+package models
+
+import (
+	"context"
+	"time"
+
+	pkgctx "github.com/vishenosik/web-tools/context"
+)
+
+type principalKey struct{}
+
+// Principal is an authenticated caller.
+type Principal struct {
+	// Workspace the caller belongs to
+	WorkspaceID string
+	// App the caller is authenticated with
+	AppID string
+	// App name, used in logs
+	AppName string
+	// User the caller is logged in as, empty for API keys
+	UserID string
+	// Granted role
+	Role Role
+}
+
+// Allows reports if the caller has the required role.
+func (principal *Principal) Allows(required Role) bool {
+	return principal != nil && principal.Role.Allows(required)
+}
+
+// Name identifies the caller in records: user id if logged in, app name otherwise.
+func (principal *Principal) Name() string {
+	if principal.UserID != "" {
+		return "user:" + principal.UserID
+	}
+	return "app:" + principal.AppName
+}
+
+func (principal *Principal) Key() principalKey {
+	return principalKey{}
+}
+
+// WithPrincipal puts authenticated caller into the context
+// and limits the context to caller's workspace.
+// Callers without a workspace are refused with ErrNoWorkspace.
+func WithPrincipal(ctx context.Context, principal *Principal) (context.Context, error) {
+	if principal.WorkspaceID == "" {
+		return nil, ErrNoWorkspace
+	}
+	return WithWorkspace(pkgctx.With(ctx, principal), principal.WorkspaceID), nil
+}
+
+// PrincipalFrom returns authenticated caller of the context.
+func PrincipalFrom(ctx context.Context) (*Principal, bool) {
+	return pkgctx.From[*Principal](ctx)
+}
+
+// Token is a short-lived access token.
+type Token struct {
+	AccessToken string
+	ExpiresAt   time.Time
+}
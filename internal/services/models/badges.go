@@ -0,0 +1,155 @@
+package models
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+var (
+	// badge request validation failed
+	ErrInvalidBadge = errors.New("invalid badge")
+)
+
+const (
+	// longest uptime and response time window of badges
+	MaxBadgeDays = 365
+)
+
+// BadgeKind is a value shown on a badge.
+type BadgeKind string
+
+const (
+	// Current status
+	BadgeStatus BadgeKind = "status"
+	// Share of successful checks over the window
+	BadgeUptime BadgeKind = "uptime"
+	// Average check duration over the window
+	BadgeResponse BadgeKind = "response"
+)
+
+// BadgeColor is a shields.io color name.
+type BadgeColor string
+
+const (
+	BadgeBrightGreen BadgeColor = "brightgreen"
+	BadgeGreen       BadgeColor = "green"
+	BadgeYellow      BadgeColor = "yellow"
+	BadgeOrange      BadgeColor = "orange"
+	BadgeRed         BadgeColor = "red"
+	BadgeBlue        BadgeColor = "blue"
+	BadgeGrey        BadgeColor = "lightgrey"
+)
+
+// BadgeRequest defines a badge of an endpoint or a group of endpoints.
+type BadgeRequest struct {
+	// Endpoint identifier, group badge if empty
+	EndpointID string
+	// Workspace of the group (default if empty)
+	WorkspaceID string
+	// Component name of the group endpoints, see ComponentName
+	Group string
+	// Shown value (status if empty)
+	Kind BadgeKind
+	// Number of days the uptime and response time are computed over, today included
+	Days int
+}
+
+func (req *BadgeRequest) Validate() error {
+
+	switch req.Kind {
+	case BadgeStatus, BadgeUptime, BadgeResponse:
+	default:
+		return errors.Wrapf(ErrInvalidBadge, "unknown badge type %q", req.Kind)
+	}
+
+	if req.Days < 1 || req.Days > MaxBadgeDays {
+		return errors.Wrapf(ErrInvalidBadge, "window must be 1-%d days", MaxBadgeDays)
+	}
+
+	if req.EndpointID == "" && req.Group == "" {
+		return errors.Wrap(ErrInvalidBadge, "endpoint or group is required")
+	}
+
+	return nil
+}
+
+// Badge is a label and a colored message, like shields.io badges.
+type Badge struct {
+	Label   string
+	Message string
+	Color   BadgeColor
+}
+
+// StatusBadge shows the status.
+func StatusBadge(label string, status Status) *Badge {
+	badge := &Badge{Label: label}
+	switch status {
+	case StatusMaintenance:
+		badge.Message, badge.Color = "maintenance", BadgeBlue
+	case StatusDegraded:
+		badge.Message, badge.Color = "degraded", BadgeYellow
+	case StatusOutage:
+		badge.Message, badge.Color = "down", BadgeRed
+	default:
+		badge.Message, badge.Color = "up", BadgeBrightGreen
+	}
+	return badge
+}
+
+// UptimeBadge shows the share of successful checks.
+func UptimeBadge(label string, checks *DailyChecks) *Badge {
+
+	badge := &Badge{Label: label}
+
+	if checks.Checks == 0 {
+		badge.Message, badge.Color = "no data", BadgeGrey
+		return badge
+	}
+
+	uptime := checks.Uptime() * 100
+
+	badge.Message = fmt.Sprintf("%.2f%%", uptime)
+	switch {
+	case uptime >= 99.9:
+		badge.Color = BadgeBrightGreen
+	case uptime >= 99:
+		badge.Color = BadgeGreen
+	case uptime >= 97:
+		badge.Color = BadgeYellow
+	case uptime >= 95:
+		badge.Color = BadgeOrange
+	default:
+		badge.Color = BadgeRed
+	}
+	return badge
+}
+
+// ResponseBadge shows the average check duration.
+func ResponseBadge(label string, checks *DailyChecks) *Badge {
+
+	badge := &Badge{Label: label}
+
+	if checks.Checks == 0 {
+		badge.Message, badge.Color = "no data", BadgeGrey
+		return badge
+	}
+
+	latency := checks.AvgLatency()
+
+	badge.Message = fmt.Sprintf("%dms", latency.Milliseconds())
+	switch {
+	case latency < 200*time.Millisecond:
+		badge.Color = BadgeBrightGreen
+	case latency < 500*time.Millisecond:
+		badge.Color = BadgeGreen
+	case latency < time.Second:
+		badge.Color = BadgeYellow
+	case latency < 2*time.Second:
+		badge.Color = BadgeOrange
+	default:
+		badge.Color = BadgeRed
+	}
+	return badge
+}
@@ -0,0 +1,58 @@
+package models
+
+import (
+	"net/mail"
+	"net/url"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+var (
+	// channel validation failed
+	ErrInvalidChannel = errors.New("invalid channel")
+	// workspace has a channel of the name already
+	ErrChannelExists = errors.New("channel exists already")
+)
+
+// Channel delivers incident alerts of the workspace endpoints
+// naming it among their notification services.
+type Channel struct {
+	// Channel identifier (uuid4)
+	ID string
+	// Owning workspace
+	WorkspaceID string
+	// Name endpoints refer to the channel by, unique in the workspace
+	Name string
+	// Delivery channel, email or webhook
+	Kind ChannelKind
+	// Email address or webhook URL
+	Target    string
+	CreatedAt time.Time
+}
+
+type Channels = []*Channel
+
+func (ch *Channel) Validate() error {
+
+	if ch.Name == "" {
+		return errors.Wrap(ErrInvalidChannel, "name is required")
+	}
+
+	switch ch.Kind {
+	case ChannelEmail:
+		address, err := mail.ParseAddress(ch.Target)
+		if err != nil || address.Address != ch.Target {
+			return errors.Wrap(ErrInvalidChannel, "target must be an email address")
+		}
+	case ChannelWebhook:
+		target, err := url.Parse(ch.Target)
+		if err != nil || (target.Scheme != "http" && target.Scheme != "https") || target.Host == "" {
+			return errors.Wrap(ErrInvalidChannel, "target must be an http(s) URL")
+		}
+	default:
+		return errors.Wrapf(ErrInvalidChannel, "unknown type %q", ch.Kind)
+	}
+
+	return nil
+}
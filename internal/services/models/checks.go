@@ -0,0 +1,56 @@
+package models
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+var (
+	// unknown check type
+	ErrCheckType = errors.New("unknown check type")
+)
+
+// CheckType defines the way an endpoint is probed.
+type CheckType string
+
+const (
+	// Single HTTP request to the endpoint URL
+	CheckHTTP CheckType = "http"
+	// Ordered HTTP steps sharing extracted variables
+	CheckTransaction CheckType = "transaction"
+	// Passive check expecting pings from the monitored job
+	CheckHeartbeat CheckType = "heartbeat"
+	// Read-only database query asserted against an expected result
+	CheckSQL CheckType = "sql"
+	// Local command checked by its exit code
+	CheckExec CheckType = "exec"
+)
+
+// CheckResult is an outcome of a single endpoint check.
+type CheckResult struct {
+	// Checked endpoint identifier
+	EndpointID string
+	// Check type performed
+	Type CheckType
+	// Whether the check is considered successful
+	Success bool
+	// Last HTTP status code received (zero if none)
+	StatusCode int
+	// Exit code of a command (exec checks only)
+	ExitCode int
+	// Truncated command output (exec checks only)
+	Output string
+	// Failure reason (empty on success)
+	Message string
+	// Name of the transaction step which failed (transaction checks only)
+	FailedStep string
+	// Time taken by the whole check
+	Latency time.Duration
+	// Expiry of the server certificate (https checks only, zero otherwise)
+	CertExpiresAt time.Time
+	// Time the check started
+	CheckedAt time.Time
+	// Location of the agent reporting the result, empty for checks of the server
+	Location string
+}
@@ -0,0 +1,48 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	// Label marking endpoints owned by the declarative config, only those are pruned
+	ManagedLabel = "managed-by"
+	// ManagedLabel value of declarative config endpoints
+	ManagedByConfig = "config"
+)
+
+// Manifest is a desired state of workspace endpoints.
+type Manifest struct {
+	// Workspace endpoints belong to (default if empty)
+	WorkspaceID string
+	Endpoints   Endpoints
+}
+
+// PlanStep is a change needed to bring the store to the manifest state.
+type PlanStep struct {
+	Action      AuditAction
+	EndpointID  string
+	ServiceName string
+	// Changed fields, secrets redacted
+	Diff []FieldChange
+}
+
+func (step *PlanStep) String() string {
+	changes := make([]string, 0, len(step.Diff))
+	for _, change := range step.Diff {
+		changes = append(changes, change.String())
+	}
+	return fmt.Sprintf("%s endpoint %q (%s) %s", step.Action, step.ServiceName, step.EndpointID, strings.Join(changes, "; "))
+}
+
+func (change FieldChange) String() string {
+	return fmt.Sprintf("%s: %s -> %s", change.Path, orNull(change.Before), orNull(change.After))
+}
+
+func orNull(data []byte) string {
+	if len(data) == 0 {
+		return "null"
+	}
+	return string(data)
+}
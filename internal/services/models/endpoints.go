@@ -23,6 +23,8 @@ var (
 type Endpoint struct {
 	// Endpoint identifier (uuid4 only)
 	ID string
+	// Owning workspace
+	WorkspaceID string
 	// Name of checked service (ascii symbols only)
 	ServiceName string
 	// URL string to trigger during checks
@@ -33,6 +35,22 @@ type Endpoint struct {
 	NotificationServices []string
 	// Time interval between checks
 	Interval time.Duration
+	// Arbitrary key-value labels used for grouping & filtering
+	Labels map[string]string
+	// Failure importance (critical if empty)
+	Severity Severity
+	// Kind of check performed (http if empty)
+	Type CheckType
+	// Steps of a transaction check
+	Transaction *Transaction
+	// Settings of a heartbeat monitor (Interval is the expected ping period)
+	Heartbeat *Heartbeat
+	// Settings of a database query check
+	SQL *SQLCheck
+	// Settings of a local command check
+	Exec *ExecCheck
+	// Agents checking the endpoint instead of the server, if set
+	Probes *Probes
 }
 
 type Endpoints = []*Endpoint
@@ -51,10 +69,44 @@ func (ep *Endpoint) Validate() error {
 		}
 	}
 
+	if err := ep.Severity.Validate(); err != nil {
+		errs = multierror.Append(errs, err)
+	}
+
 	valid := validator.New()
 
-	if err := valid.Var(ep.URL, "url"); err != nil {
-		errs = multierror.Append(errs, ErrURL)
+	switch ep.CheckType() {
+	case CheckHTTP:
+		if err := valid.Var(ep.URL, "url"); err != nil {
+			errs = multierror.Append(errs, ErrURL)
+		}
+	case CheckTransaction:
+		if err := ep.Transaction.Validate(); err != nil {
+			errs = multierror.Append(errs, err)
+		}
+	case CheckHeartbeat:
+		if err := ep.Heartbeat.Validate(); err != nil {
+			errs = multierror.Append(errs, err)
+		}
+	case CheckSQL:
+		if err := ep.SQL.Validate(); err != nil {
+			errs = multierror.Append(errs, err)
+		}
+	case CheckExec:
+		if err := ep.Exec.Validate(); err != nil {
+			errs = multierror.Append(errs, err)
+		}
+	default:
+		errs = multierror.Append(errs, errors.Wrapf(ErrCheckType, "type %q", ep.Type))
+	}
+
+	if ep.Probes != nil {
+		if ep.CheckType() == CheckHeartbeat {
+			errs = multierror.Append(errs, ErrPassiveProbes)
+		}
+		if err := ep.Probes.Validate(); err != nil {
+			errs = multierror.Append(errs, err)
+		}
 	}
 
 	if err := valid.Var(ep.ServiceName, "ascii"); err != nil {
@@ -63,3 +115,49 @@ func (ep *Endpoint) Validate() error {
 
 	return errs.ErrorOrNil()
 }
+
+// CheckType returns endpoint check type defaulting to http.
+func (ep *Endpoint) CheckType() CheckType {
+	if ep.Type == "" {
+		return CheckHTTP
+	}
+	return ep.Type
+}
+
+// Replace makes the endpoint a new version of the existing one: it keeps the id,
+// the workspace and secrets the endpoint was submitted without.
+func (ep *Endpoint) Replace(existing *Endpoint) {
+	ep.ID = existing.ID
+	ep.WorkspaceID = existing.WorkspaceID
+	ep.KeepSecrets(existing)
+}
+
+// KeepSecrets copies secrets the endpoint was submitted without from its stored state:
+// DSNs are never returned by the API and heartbeat tokens are kept unless replaced.
+func (ep *Endpoint) KeepSecrets(current *Endpoint) {
+
+	if current == nil {
+		return
+	}
+
+	if ep.Heartbeat != nil && ep.Heartbeat.Token == "" && current.Heartbeat != nil {
+		ep.Heartbeat.Token = current.Heartbeat.Token
+	}
+
+	if ep.SQL != nil && ep.SQL.DSN == "" && current.SQL != nil {
+		ep.SQL.DSN = current.SQL.DSN
+	}
+}
+
+// Probed reports if the endpoint is checked by agents instead of the server.
+func (ep *Endpoint) Probed() bool {
+	return ep.Probes != nil
+}
+
+// SeverityOrDefault returns endpoint severity defaulting to critical.
+func (ep *Endpoint) SeverityOrDefault() Severity {
+	if ep.Severity == "" {
+		return SeverityCritical
+	}
+	return ep.Severity
+}
@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
 )
 
 func Test_EndpointValidation(t *testing.T) {
@@ -118,6 +119,72 @@ func Test_EndpointValidation(t *testing.T) {
 			},
 			expectError: true,
 		},
+		{
+			name: "valid transaction",
+			endpoint: &Endpoint{
+				ID:          uuid.NewString(),
+				ServiceName: "login_flow",
+				Interval:    time.Minute,
+				Type:        CheckTransaction,
+				Transaction: &Transaction{
+					Steps: []TransactionStep{
+						{
+							Name:   "login",
+							Method: "POST",
+							URL:    "https://example.com/login",
+							Extract: []Extraction{
+								{Variable: "token", Source: ExtractJSON, Expression: "$.token"},
+							},
+						},
+						{
+							URL:     "https://example.com/me",
+							Headers: map[string]string{"Authorization": "Bearer {{.token}}"},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "transaction without steps",
+			endpoint: &Endpoint{
+				ID:          uuid.NewString(),
+				ServiceName: "login_flow",
+				Interval:    time.Minute,
+				Type:        CheckTransaction,
+			},
+			expectError: true,
+		},
+		{
+			name: "transaction with invalid extraction",
+			endpoint: &Endpoint{
+				ID:          uuid.NewString(),
+				ServiceName: "login_flow",
+				Interval:    time.Minute,
+				Type:        CheckTransaction,
+				Transaction: &Transaction{
+					Steps: []TransactionStep{
+						{
+							URL: "https://example.com/login",
+							Extract: []Extraction{
+								{Variable: "1token", Source: "xml", Expression: "$.token"},
+							},
+						},
+					},
+				},
+			},
+			expectError: true,
+		},
+		{
+			name: "unknown check type",
+			endpoint: &Endpoint{
+				ID:          uuid.NewString(),
+				ServiceName: "valid_service",
+				URL:         "https://example.com",
+				Interval:    time.Minute,
+				Type:        "smtp",
+			},
+			expectError: true,
+		},
 	}
 
 	for _, tt := range testingTable {
@@ -158,3 +225,65 @@ func Test_EndpointEdgeCases(t *testing.T) {
 		}
 	})
 }
+
+func Test_EndpointKeepSecrets(t *testing.T) {
+
+	current := &Endpoint{
+		Type:      CheckSQL,
+		SQL:       &SQLCheck{Driver: "postgres", DSN: "postgres://user:pass@db"},
+		Heartbeat: &Heartbeat{Token: "token"},
+	}
+
+	// secrets are omitted by clients
+	submitted := &Endpoint{Type: CheckSQL, SQL: &SQLCheck{Driver: "postgres"}, Heartbeat: &Heartbeat{}}
+	submitted.KeepSecrets(current)
+	assert.Equal(t, "postgres://user:pass@db", submitted.SQL.DSN)
+	assert.Equal(t, "token", submitted.Heartbeat.Token)
+
+	// or replaced
+	replaced := &Endpoint{Type: CheckSQL, SQL: &SQLCheck{Driver: "postgres", DSN: "postgres://other@db"}}
+	replaced.KeepSecrets(current)
+	assert.Equal(t, "postgres://other@db", replaced.SQL.DSN)
+
+	created := &Endpoint{Type: CheckSQL, SQL: &SQLCheck{}}
+	created.KeepSecrets(nil)
+	assert.Empty(t, created.SQL.DSN)
+}
+
+func Test_EndpointMatcher(t *testing.T) {
+
+	matcher := NewEndpointMatcher(Endpoints{
+		{ID: "1", ServiceName: "api"},
+		{ID: "2", ServiceName: "web"},
+	})
+
+	tests := []struct {
+		name     string
+		endpoint *Endpoint
+		want     string
+		err      error
+	}{
+		{name: "by id", endpoint: &Endpoint{ID: "2", ServiceName: "api"}, want: "2"},
+		{name: "unknown id", endpoint: &Endpoint{ID: "3", ServiceName: "api"}},
+		{name: "by name", endpoint: &Endpoint{ServiceName: "api"}, want: "1"},
+		{name: "new", endpoint: &Endpoint{ServiceName: "docs"}},
+		{name: "duplicate name", endpoint: &Endpoint{ServiceName: "api"}, err: ErrDuplicateName},
+		{name: "duplicate new name", endpoint: &Endpoint{ServiceName: "docs"}, err: ErrDuplicateName},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			existing, err := matcher.Match(tt.endpoint)
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+				return
+			}
+			assert.NoError(t, err)
+			if tt.want == "" {
+				assert.Nil(t, existing)
+				return
+			}
+			assert.Equal(t, tt.want, existing.ID)
+		})
+	}
+}
@@ -0,0 +1,46 @@
+package models
+
+import "github.com/pkg/errors"
+
+var (
+	// requested entity not found
+	ErrNotFound = errors.New("not found")
+	// endpoint validation failed
+	ErrInvalidEndpoint = errors.New("invalid endpoint")
+	// endpoint conflicts with an existing one
+	ErrEndpointExists = errors.New("endpoint exists already")
+	// several submitted endpoints without id share a service name
+	ErrDuplicateName = errors.New("duplicate service name")
+	// passive checks (heartbeats) can't be triggered
+	ErrPassiveCheck = errors.New("passive checks can't be triggered")
+	// endpoints checked by agents can't be triggered on the server
+	ErrProbedCheck = errors.New("endpoints checked by agents can't be triggered")
+	// request carries no valid credentials
+	ErrUnauthenticated = errors.New("unauthenticated")
+	// provided credentials are wrong
+	ErrInvalidCredentials = errors.New("invalid credentials")
+	// caller's role doesn't allow the action
+	ErrForbidden = errors.New("forbidden")
+	// user validation failed
+	ErrInvalidUser = errors.New("invalid user")
+	// user nickname or email is taken
+	ErrUserExists = errors.New("user exists already")
+	// resolved incidents can't be acknowledged
+	ErrIncidentResolved = errors.New("incident is resolved")
+	// postmortems are written once the incident is resolved
+	ErrIncidentActive = errors.New("incident is active")
+	// workspace name is taken
+	ErrWorkspaceExists = errors.New("workspace exists already")
+	// store driver can't be backed up, only sqlite can
+	ErrBackupNotSupported = errors.New("backup is not supported by the store")
+	// agent validation failed
+	ErrInvalidAgent = errors.New("invalid agent")
+	// agent name must be set
+	ErrAgentName = errors.New("agent name must be set")
+	// agent location must be set
+	ErrAgentLocation = errors.New("agent location must be set")
+	// result is reported for an endpoint not assigned to the agent location
+	ErrNotAssigned = errors.New("endpoint is not assigned to the agent location")
+	// backup is damaged or its schema is newer than the server one
+	ErrInvalidBackup = errors.New("invalid backup")
+)
@@ -0,0 +1,104 @@
+package models
+
+import (
+	"slices"
+	"time"
+)
+
+// EventKind defines what happened to an endpoint.
+type EventKind string
+
+const (
+	// Endpoint has been checked
+	EventCheckResult EventKind = "check_result"
+	// Incident has been opened
+	EventIncidentOpened EventKind = "incident_opened"
+	// Incident has been resolved
+	EventIncidentResolved EventKind = "incident_resolved"
+	// Operator posted an incident update
+	EventIncidentUpdated EventKind = "incident_updated"
+	// Subscriber was too slow, Dropped events were skipped
+	EventLagged EventKind = "lagged"
+)
+
+// Event is a check result or an incident transition streamed to subscribers.
+type Event struct {
+	// Event kind
+	Kind EventKind
+	// Endpoint the event relates to
+	EndpointID string
+	// Workspace of the endpoint
+	WorkspaceID string
+	// Endpoint labels at the moment of the event
+	Labels map[string]string
+	// Endpoint severity at the moment of the event
+	Severity Severity
+	// Check result (check_result events only)
+	Result *CheckResult
+	// Incident (incident events only)
+	Incident *Incident
+	// Posted update (incident_updated events only)
+	Update *IncidentUpdate
+	// Number of skipped events (lagged events only)
+	Dropped uint64
+	// Time the event happened
+	Time time.Time
+}
+
+// NewEndpointEvent creates event of the endpoint.
+func NewEndpointEvent(kind EventKind, endpoint *Endpoint) *Event {
+	return &Event{
+		Kind:        kind,
+		EndpointID:  endpoint.ID,
+		WorkspaceID: endpoint.WorkspaceID,
+		Labels:      endpoint.Labels,
+		Severity:    endpoint.SeverityOrDefault(),
+		Time:        time.Now(),
+	}
+}
+
+// EventsFilter selects events for a subscriber, empty fields match everything.
+type EventsFilter struct {
+	// Events of the workspace only (lagged events excepted), set by the server
+	WorkspaceID string
+	// Events of any of the kinds (lagged events excepted)
+	Kinds []EventKind
+	// Events of any of the endpoints
+	EndpointIDs []string
+	// Events of endpoints having all the labels
+	Labels map[string]string
+	// Events of endpoints with any of the severities
+	Severities []Severity
+}
+
+// Match reports if event passes the filter. Lagged events always pass.
+func (filter EventsFilter) Match(event *Event) bool {
+
+	if event.Kind == EventLagged {
+		return true
+	}
+
+	if filter.WorkspaceID != "" && filter.WorkspaceID != event.WorkspaceID {
+		return false
+	}
+
+	if len(filter.Kinds) > 0 && !slices.Contains(filter.Kinds, event.Kind) {
+		return false
+	}
+
+	if len(filter.EndpointIDs) > 0 && !slices.Contains(filter.EndpointIDs, event.EndpointID) {
+		return false
+	}
+
+	if len(filter.Severities) > 0 && !slices.Contains(filter.Severities, event.Severity) {
+		return false
+	}
+
+	for key, value := range filter.Labels {
+		if actual, ok := event.Labels[key]; !ok || actual != value {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,84 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_EventsFilterMatch(t *testing.T) {
+
+	event := &Event{
+		Kind:        EventCheckResult,
+		EndpointID:  "1",
+		WorkspaceID: "team-a",
+		Labels:      map[string]string{"team": "core", "env": "prod"},
+		Severity:    SeverityWarning,
+	}
+
+	testingTable := []struct {
+		name   string
+		filter EventsFilter
+		match  bool
+	}{
+		{
+			name:  "empty filter",
+			match: true,
+		},
+		{
+			name:   "kind matches",
+			filter: EventsFilter{Kinds: []EventKind{EventIncidentOpened, EventCheckResult}},
+			match:  true,
+		},
+		{
+			name:   "kind differs",
+			filter: EventsFilter{Kinds: []EventKind{EventIncidentOpened}},
+		},
+		{
+			name:   "endpoint matches",
+			filter: EventsFilter{EndpointIDs: []string{"2", "1"}},
+			match:  true,
+		},
+		{
+			name:   "endpoint differs",
+			filter: EventsFilter{EndpointIDs: []string{"2"}},
+		},
+		{
+			name:   "labels subset",
+			filter: EventsFilter{Labels: map[string]string{"team": "core"}},
+			match:  true,
+		},
+		{
+			name:   "label value differs",
+			filter: EventsFilter{Labels: map[string]string{"team": "web"}},
+		},
+		{
+			name:   "severity matches",
+			filter: EventsFilter{Severities: []Severity{SeverityWarning, SeverityCritical}},
+			match:  true,
+		},
+		{
+			name:   "severity differs",
+			filter: EventsFilter{Severities: []Severity{SeverityCritical}},
+		},
+		{
+			name:   "workspace matches",
+			filter: EventsFilter{WorkspaceID: "team-a"},
+			match:  true,
+		},
+		{
+			name:   "workspace differs",
+			filter: EventsFilter{WorkspaceID: "team-b"},
+		},
+	}
+
+	for _, tt := range testingTable {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.match, tt.filter.Match(event))
+		})
+	}
+
+	lagged := &Event{Kind: EventLagged}
+	assert.True(t, EventsFilter{EndpointIDs: []string{"2"}}.Match(lagged))
+	assert.True(t, EventsFilter{Kinds: []EventKind{EventIncidentOpened}}.Match(lagged))
+}
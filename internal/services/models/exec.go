@@ -0,0 +1,58 @@
+package models
+
+import (
+	"time"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/pkg/errors"
+)
+
+var (
+	// exec check settings must be set
+	ErrNoExec = errors.New("exec check settings must be set")
+	// command must be set
+	ErrCommand = errors.New("command must be set")
+	// exec checks are disabled
+	ErrExecDisabled = errors.New("exec checks are disabled")
+	// exit code can't be negative
+	ErrExitCode = errors.New("exit code can't be negative")
+)
+
+// ExecCheck is a local command considered successful by its exit code.
+type ExecCheck struct {
+	// Executable name or path
+	Command string
+	// Command arguments
+	Args []string
+	// Extra environment variables
+	Env map[string]string
+	// Command timeout (30s by default)
+	Timeout time.Duration
+	// Exit codes which are considered successful (0 by default)
+	SuccessCodes []int
+}
+
+func (check *ExecCheck) Validate() error {
+
+	if check == nil {
+		return ErrNoExec
+	}
+
+	var errs *multierror.Error
+
+	if check.Command == "" {
+		errs = multierror.Append(errs, ErrCommand)
+	}
+
+	if check.Timeout < 0 {
+		errs = multierror.Append(errs, ErrTimeout)
+	}
+
+	for _, code := range check.SuccessCodes {
+		if code < 0 {
+			errs = multierror.Append(errs, errors.Wrapf(ErrExitCode, "code %d", code))
+		}
+	}
+
+	return errs.ErrorOrNil()
+}
@@ -0,0 +1,98 @@
+package models
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+var (
+	// heartbeat settings must be set
+	ErrNoHeartbeat = errors.New("heartbeat settings must be set")
+	// grace time can't be negative
+	ErrGrace = errors.New("grace time can't be negative")
+	// unknown heartbeat ping kind
+	ErrPingKind = errors.New("unknown ping kind")
+)
+
+// PingKind defines the meaning of a heartbeat ping.
+type PingKind string
+
+const (
+	// Job finished successfully
+	PingSuccess PingKind = "success"
+	// Job started, it has Grace time to report success
+	PingStart PingKind = "start"
+	// Job reported failure
+	PingFail PingKind = "fail"
+)
+
+// Heartbeat is a passive (dead man's switch) monitor settings.
+// The monitored job is expected to ping every Endpoint.Interval.
+type Heartbeat struct {
+	// Unique ping token, generated on creation if empty
+	Token string
+	// Extra time to wait for a ping before opening an incident,
+	// also the time a started job has to finish (the interval if zero)
+	Grace time.Duration
+}
+
+// HeartbeatState is a last known state of a heartbeat monitor.
+type HeartbeatState struct {
+	// Heartbeat endpoint
+	Endpoint *Endpoint
+	// Time monitor was created (used until the first ping)
+	CreatedAt time.Time
+	// Time of the last success or failure ping
+	LastPing time.Time
+	// Kind of the last success or failure ping
+	LastKind PingKind
+	// Time of the last start ping
+	StartedAt time.Time
+}
+
+func (hb *Heartbeat) Validate() error {
+	if hb == nil {
+		return ErrNoHeartbeat
+	}
+	if hb.Grace < 0 {
+		return ErrGrace
+	}
+	return nil
+}
+
+// Overdue reports why the monitor is late at the moment now.
+// An empty string means the monitor is fine.
+func (state *HeartbeatState) Overdue(now time.Time) string {
+
+	var grace time.Duration
+	if state.Endpoint.Heartbeat != nil {
+		grace = state.Endpoint.Heartbeat.Grace
+	}
+
+	// without grace a started job has the whole period to finish,
+	// otherwise it'd be late by the next scheduler tick
+	runTime := grace
+	if runTime == 0 {
+		runTime = state.Endpoint.Interval
+	}
+
+	// job started after the last ping and has not finished in time
+	if state.StartedAt.After(state.LastPing) && now.After(state.StartedAt.Add(runTime)) {
+		return "job started at " + state.StartedAt.Format(time.RFC3339) + " did not finish in time"
+	}
+
+	last := state.LastPing
+	if last.IsZero() {
+		last = state.CreatedAt
+	}
+
+	if now.After(last.Add(state.Endpoint.Interval + grace)) {
+		if state.LastPing.IsZero() {
+			return "no ping received"
+		}
+		return "no ping received since " + state.LastPing.Format(time.RFC3339)
+	}
+
+	return ""
+}
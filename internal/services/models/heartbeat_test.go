@@ -0,0 +1,119 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_HeartbeatOverdue(t *testing.T) {
+
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	endpoint := &Endpoint{
+		Interval:  time.Hour,
+		Type:      CheckHeartbeat,
+		Heartbeat: &Heartbeat{Grace: 5 * time.Minute},
+	}
+
+	testingTable := []struct {
+		name    string
+		state   HeartbeatState
+		overdue bool
+	}{
+		{
+			name:  "never pinged, within period",
+			state: HeartbeatState{CreatedAt: now.Add(-30 * time.Minute)},
+		},
+		{
+			name:    "never pinged, period and grace passed",
+			state:   HeartbeatState{CreatedAt: now.Add(-66 * time.Minute)},
+			overdue: true,
+		},
+		{
+			name: "pinged within grace",
+			state: HeartbeatState{
+				CreatedAt: now.Add(-24 * time.Hour),
+				LastPing:  now.Add(-63 * time.Minute),
+			},
+		},
+		{
+			name: "ping is late",
+			state: HeartbeatState{
+				CreatedAt: now.Add(-24 * time.Hour),
+				LastPing:  now.Add(-2 * time.Hour),
+			},
+			overdue: true,
+		},
+		{
+			name: "job is running",
+			state: HeartbeatState{
+				CreatedAt: now.Add(-24 * time.Hour),
+				LastPing:  now.Add(-50 * time.Minute),
+				StartedAt: now.Add(-time.Minute),
+			},
+		},
+		{
+			name: "job started and did not finish",
+			state: HeartbeatState{
+				CreatedAt: now.Add(-24 * time.Hour),
+				LastPing:  now.Add(-50 * time.Minute),
+				StartedAt: now.Add(-10 * time.Minute),
+			},
+			overdue: true,
+		},
+	}
+
+	for _, tt := range testingTable {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.state.Endpoint = endpoint
+			cause := tt.state.Overdue(now)
+			assert.Equal(t, tt.overdue, cause != "", cause)
+		})
+	}
+}
+
+func Test_HeartbeatOverdue_noGrace(t *testing.T) {
+
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	endpoint := &Endpoint{
+		Interval:  time.Hour,
+		Type:      CheckHeartbeat,
+		Heartbeat: &Heartbeat{},
+	}
+
+	testingTable := []struct {
+		name      string
+		startedAt time.Time
+		overdue   bool
+	}{
+		{
+			name:      "job just started",
+			startedAt: now.Add(-time.Second),
+		},
+		{
+			name:      "job is running within the period",
+			startedAt: now.Add(-50 * time.Minute),
+		},
+		{
+			name:      "job is running longer than the period",
+			startedAt: now.Add(-61 * time.Minute),
+			overdue:   true,
+		},
+	}
+
+	for _, tt := range testingTable {
+		t.Run(tt.name, func(t *testing.T) {
+			state := HeartbeatState{
+				Endpoint:  endpoint,
+				CreatedAt: now.Add(-24 * time.Hour),
+				LastPing:  tt.startedAt.Add(-time.Second),
+				StartedAt: tt.startedAt,
+			}
+			cause := state.Overdue(now)
+			assert.Equal(t, tt.overdue, cause != "", cause)
+		})
+	}
+}
@@ -0,0 +1,44 @@
+package models
+
+import "time"
+
+// DailyChecks are check results of an endpoint aggregated over a UTC day.
+type DailyChecks struct {
+	EndpointID string
+	// Start of the day (UTC)
+	Day time.Time
+	// Number of checks performed
+	Checks uint64
+	// Number of failed checks
+	Failures uint64
+	// Total duration of the checks
+	Latency time.Duration
+}
+
+// Day truncates time to the start of its UTC day.
+func Day(t time.Time) time.Time {
+	return t.UTC().Truncate(24 * time.Hour)
+}
+
+// Add sums up checks of the same period.
+func (dc *DailyChecks) Add(other *DailyChecks) {
+	dc.Checks += other.Checks
+	dc.Failures += other.Failures
+	dc.Latency += other.Latency
+}
+
+// Uptime returns the share of successful checks, 1 if there were none.
+func (dc *DailyChecks) Uptime() float64 {
+	if dc.Checks == 0 {
+		return 1
+	}
+	return float64(dc.Checks-dc.Failures) / float64(dc.Checks)
+}
+
+// AvgLatency returns the average check duration.
+func (dc *DailyChecks) AvgLatency() time.Duration {
+	if dc.Checks == 0 {
+		return 0
+	}
+	return dc.Latency / time.Duration(dc.Checks)
+}
@@ -0,0 +1,81 @@
+package models
+
+import "github.com/pkg/errors"
+
+// ImportMode defines handling of imported endpoints matching existing ones.
+type ImportMode string
+
+const (
+	// Replace existing endpoints
+	ImportUpsert ImportMode = "upsert"
+	// Keep existing endpoints untouched
+	ImportSkip ImportMode = "skip"
+	// Reject the whole import
+	ImportFail ImportMode = "fail"
+)
+
+var (
+	// import mode isn't one of upsert, skip or fail
+	ErrImportMode = errors.New("import mode must be one of: upsert, skip, fail")
+)
+
+func (mode ImportMode) Validate() error {
+	switch mode {
+	case ImportUpsert, ImportSkip, ImportFail:
+		return nil
+	}
+	return ErrImportMode
+}
+
+// ImportResult reports what happened to imported endpoints.
+type ImportResult struct {
+	Created Endpoints
+	Updated Endpoints
+	// Existing endpoints left untouched
+	Skipped Endpoints
+}
+
+// EndpointMatcher matches submitted endpoints with existing ones by id,
+// or by service name if id is omitted. Imports and the declarative config
+// match endpoints the same way.
+type EndpointMatcher struct {
+	byID   map[string]*Endpoint
+	byName map[string]*Endpoint
+	// names of matched endpoints without id
+	names map[string]struct{}
+}
+
+func NewEndpointMatcher(current Endpoints) *EndpointMatcher {
+
+	matcher := &EndpointMatcher{
+		byID:   make(map[string]*Endpoint, len(current)),
+		byName: make(map[string]*Endpoint, len(current)),
+		names:  make(map[string]struct{}),
+	}
+
+	for _, endpoint := range current {
+		matcher.byID[endpoint.ID] = endpoint
+		if _, ok := matcher.byName[endpoint.ServiceName]; !ok {
+			matcher.byName[endpoint.ServiceName] = endpoint
+		}
+	}
+
+	return matcher
+}
+
+// Match returns the existing endpoint the submitted one replaces, nil for a new one.
+// Endpoints without id sharing a service name fail with ErrDuplicateName,
+// as they would replace the same endpoint.
+func (matcher *EndpointMatcher) Match(endpoint *Endpoint) (*Endpoint, error) {
+
+	if endpoint.ID != "" {
+		return matcher.byID[endpoint.ID], nil
+	}
+
+	if _, ok := matcher.names[endpoint.ServiceName]; ok {
+		return nil, errors.Wrap(ErrDuplicateName, endpoint.ServiceName)
+	}
+	matcher.names[endpoint.ServiceName] = struct{}{}
+
+	return matcher.byName[endpoint.ServiceName], nil
+}
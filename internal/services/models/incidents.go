@@ -0,0 +1,38 @@
+package models
+
+import "time"
+
+// Incident is a period of an endpoint being unhealthy.
+type Incident struct {
+	// Incident identifier (uuid4)
+	ID string
+	// Failed endpoint identifier
+	EndpointID string
+	// Workspace of the endpoint
+	WorkspaceID string
+	// Failure reason
+	Cause string
+	// Time the incident was opened
+	OpenedAt time.Time
+	// Time the incident was resolved (zero while open)
+	ResolvedAt time.Time
+	// Time an operator took the incident (zero if nobody did)
+	AcknowledgedAt time.Time
+	// User or app acknowledged the incident
+	AcknowledgedBy string
+}
+
+type Incidents = []*Incident
+
+// IncidentsFilter narrows incidents listing.
+type IncidentsFilter struct {
+	// Only incidents of the endpoint if set
+	EndpointID string
+	// Only unresolved incidents if set
+	ActiveOnly bool
+}
+
+// Active reports if the incident is not resolved yet.
+func (inc *Incident) Active() bool {
+	return inc.ResolvedAt.IsZero()
+}
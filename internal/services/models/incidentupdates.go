@@ -0,0 +1,100 @@
+package models
+
+import (
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+var (
+	// incident update or postmortem validation failed
+	ErrInvalidIncidentUpdate = errors.New("invalid incident update")
+)
+
+// IncidentState is the progress of an incident announced by operators.
+type IncidentState string
+
+const (
+	IncidentInvestigating IncidentState = "investigating"
+	IncidentIdentified    IncidentState = "identified"
+	IncidentMonitoring    IncidentState = "monitoring"
+	IncidentResolvedState IncidentState = "resolved"
+)
+
+// IncidentUpdate is a public message posted by an operator onto an incident.
+type IncidentUpdate struct {
+	// Update identifier (uuid4)
+	ID         string
+	IncidentID string
+	// Workspace of the incident
+	WorkspaceID string
+	// Announced incident state
+	State IncidentState
+	// Message (markdown)
+	Body string
+	// User or app posted the update
+	Author    string
+	CreatedAt time.Time
+}
+
+func (upd *IncidentUpdate) Validate() error {
+
+	switch upd.State {
+	case IncidentInvestigating, IncidentIdentified, IncidentMonitoring, IncidentResolvedState:
+	default:
+		return errors.Wrapf(ErrInvalidIncidentUpdate, "unknown state %q", upd.State)
+	}
+
+	if strings.TrimSpace(upd.Body) == "" {
+		return errors.Wrap(ErrInvalidIncidentUpdate, "body is required")
+	}
+
+	return nil
+}
+
+// Postmortem is a version of the incident analysis written after it is resolved.
+// Every edit is stored as a new version.
+type Postmortem struct {
+	IncidentID string
+	// Workspace of the incident
+	WorkspaceID string
+	// Version number, starting from 1
+	Version int
+	// Document (markdown)
+	Body string
+	// User or app saved the version
+	Author    string
+	CreatedAt time.Time
+}
+
+// TimelineEntryKind defines what happened to an incident.
+type TimelineEntryKind string
+
+const (
+	TimelineOpened       TimelineEntryKind = "opened"
+	TimelineAcknowledged TimelineEntryKind = "acknowledged"
+	TimelineUpdate       TimelineEntryKind = "update"
+	TimelineResolved     TimelineEntryKind = "resolved"
+	TimelinePostmortem   TimelineEntryKind = "postmortem"
+)
+
+// TimelineEntry is an event in the incident history.
+type TimelineEntry struct {
+	Kind TimelineEntryKind
+	Time time.Time
+	// User or app behind the entry, empty for automatic ones
+	Author string
+	// Announced state (updates only)
+	State IncidentState
+	// Failure cause, update message or postmortem document
+	Body string
+	// Postmortem version (postmortems only)
+	Version int
+}
+
+// Timeline is the incident history ordered by time.
+type Timeline struct {
+	Incident *Incident
+	Entries  []*TimelineEntry
+}
@@ -0,0 +1,60 @@
+package models
+
+import (
+	"slices"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+var (
+	// maintenance validation failed
+	ErrInvalidMaintenance = errors.New("invalid maintenance")
+)
+
+// Maintenance is a planned period of endpoints being unavailable, announced on status pages.
+type Maintenance struct {
+	// Maintenance identifier (uuid4)
+	ID string
+	// Owning workspace
+	WorkspaceID string
+	// Short notice
+	Title string
+	// Details shown along with the title
+	Message string
+	// Planned period
+	StartsAt time.Time
+	EndsAt   time.Time
+	// Affected endpoints, every endpoint of the workspace if empty
+	EndpointIDs []string
+}
+
+type Maintenances = []*Maintenance
+
+// MaintenancesFilter narrows maintenances listing.
+type MaintenancesFilter struct {
+	// Only maintenances not finished by the time if set
+	EndsAfter time.Time
+}
+
+func (mnt *Maintenance) Validate() error {
+	switch {
+	case mnt.Title == "":
+		return errors.Wrap(ErrInvalidMaintenance, "title is required")
+	case mnt.StartsAt.IsZero() || mnt.EndsAt.IsZero():
+		return errors.Wrap(ErrInvalidMaintenance, "starts_at and ends_at are required")
+	case !mnt.EndsAt.After(mnt.StartsAt):
+		return errors.Wrap(ErrInvalidMaintenance, "ends_at must be after starts_at")
+	}
+	return nil
+}
+
+// Active reports if the maintenance is in progress at the moment.
+func (mnt *Maintenance) Active(now time.Time) bool {
+	return !now.Before(mnt.StartsAt) && now.Before(mnt.EndsAt)
+}
+
+// Covers reports if the endpoint is affected by the maintenance.
+func (mnt *Maintenance) Covers(endpointID string) bool {
+	return len(mnt.EndpointIDs) == 0 || slices.Contains(mnt.EndpointIDs, endpointID)
+}
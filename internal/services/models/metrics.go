@@ -0,0 +1,75 @@
+package models
+
+import "time"
+
+// Histogram is a cumulative histogram of observed values.
+type Histogram struct {
+	// Bucket upper bounds, ascending
+	Bounds []float64
+	// Number of observations less or equal to the bound of the same index
+	Counts []uint64
+	// Total number of observations
+	Count uint64
+	// Sum of observed values
+	Sum float64
+}
+
+// NewHistogram creates an empty histogram with the bucket upper bounds.
+func NewHistogram(bounds ...float64) Histogram {
+	return Histogram{
+		Bounds: bounds,
+		Counts: make([]uint64, len(bounds)),
+	}
+}
+
+// Observe adds the value to the histogram.
+func (h *Histogram) Observe(value float64) {
+	for i, bound := range h.Bounds {
+		if value <= bound {
+			h.Counts[i]++
+		}
+	}
+	h.Count++
+	h.Sum += value
+}
+
+// Clone returns a copy not sharing the buckets.
+func (h Histogram) Clone() Histogram {
+	h.Counts = append([]uint64(nil), h.Counts...)
+	return h
+}
+
+// EndpointStats are check statistics of an endpoint accumulated since the server start.
+type EndpointStats struct {
+	// Whether the last check succeeded
+	Up bool
+	// Duration of the last check
+	Latency time.Duration
+	// Certificate expiry reported by the last https check
+	CertExpiresAt time.Time
+	// Time of the last check (zero if not checked yet)
+	CheckedAt time.Time
+	// Number of checks performed
+	Checks uint64
+	// Number of failed checks
+	Failures uint64
+	// Number of incidents opened
+	IncidentsOpened uint64
+	// Check durations, seconds
+	Durations Histogram
+}
+
+// EndpointMetrics are the endpoint statistics along with its state.
+type EndpointMetrics struct {
+	Endpoint *Endpoint
+	Stats    EndpointStats
+	// Number of unresolved incidents
+	OpenIncidents int
+}
+
+// Metrics is a snapshot of the workspace endpoints statistics and the server internals.
+type Metrics struct {
+	Endpoints []EndpointMetrics
+	// Delays between checks being due and taken by a scheduler worker, seconds
+	QueueLag Histogram
+}
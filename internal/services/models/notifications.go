@@ -0,0 +1,37 @@
+package models
+
+import (
+	"github.com/pkg/errors"
+)
+
+var (
+	// no sender delivers messages of the channel kind
+	ErrUnsupportedChannel = errors.New("notification channel is not supported")
+	// recipient didn't accept the message
+	ErrNotDelivered = errors.New("notification not delivered")
+	// messages can't be sent to the recipient address
+	ErrForbiddenRecipient = errors.New("recipient address is not allowed")
+)
+
+// ChannelKind defines how a notification is delivered.
+type ChannelKind string
+
+const (
+	// Email sent over SMTP
+	ChannelEmail ChannelKind = "email"
+	// JSON posted to an HTTP(S) URL
+	ChannelWebhook ChannelKind = "webhook"
+)
+
+// Message is a notification to deliver to a single recipient.
+type Message struct {
+	// Delivery channel
+	Kind ChannelKind
+	// Email address or webhook URL
+	To      string
+	Subject string
+	// Plain text body
+	Text string
+	// Machine-readable payload posted to webhooks
+	Data any
+}
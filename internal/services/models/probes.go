@@ -0,0 +1,98 @@
+package models
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/pkg/errors"
+)
+
+var (
+	// probes must list at least one location
+	ErrNoLocations = errors.New("at least one location must be set")
+	// location is empty or listed twice
+	ErrLocation = errors.New("location must be non-empty and unique")
+	// quorum is out of [0, number of locations]
+	ErrQuorum = errors.New("quorum must be in [0, number of locations]")
+	// heartbeats are pinged, there's nothing for agents to check
+	ErrPassiveProbes = errors.New("passive checks can't be probed by agents")
+)
+
+// Probes hands checks of an endpoint to agents running in several locations
+// instead of the server.
+type Probes struct {
+	// Locations of agents checking the endpoint
+	Locations []string
+	// Number of failing locations making the endpoint fail, a majority if zero
+	Quorum int
+}
+
+func (probes *Probes) Validate() error {
+
+	var errs *multierror.Error
+
+	if len(probes.Locations) == 0 {
+		errs = multierror.Append(errs, ErrNoLocations)
+	}
+
+	for i, location := range probes.Locations {
+		if strings.TrimSpace(location) == "" || slices.Index(probes.Locations, location) != i {
+			errs = multierror.Append(errs, errors.Wrapf(ErrLocation, "location %q", location))
+		}
+	}
+
+	if probes.Quorum < 0 || probes.Quorum > len(probes.Locations) {
+		errs = multierror.Append(errs, errors.Wrapf(ErrQuorum, "quorum %d", probes.Quorum))
+	}
+
+	return errs.ErrorOrNil()
+}
+
+// QuorumOrDefault returns the number of failing locations making the endpoint fail.
+func (probes *Probes) QuorumOrDefault() int {
+	if probes.Quorum == 0 {
+		return len(probes.Locations)/2 + 1
+	}
+	return probes.Quorum
+}
+
+// Assigned reports if agents of the location check the endpoint.
+func (probes *Probes) Assigned(location string) bool {
+	return probes != nil && slices.Contains(probes.Locations, location)
+}
+
+// Verdict combines latest results of the locations into the endpoint result.
+// Locations missing in results are not counted as failing nor passing,
+// so that a stopped agent doesn't flap the endpoint.
+//
+// The combined result is a copy of the reported one with success & message
+// replaced by the quorum decision.
+func (probes *Probes) Verdict(reported *CheckResult, results map[string]*CheckResult) *CheckResult {
+
+	var failing []string
+
+	for _, location := range probes.Locations {
+		result, ok := results[location]
+		if !ok || result.Success {
+			continue
+		}
+		failing = append(failing, fmt.Sprintf("%s: %s", location, result.Message))
+	}
+
+	verdict := *reported
+	verdict.Success = len(failing) < probes.QuorumOrDefault()
+	verdict.Message = ""
+
+	if len(failing) > 0 {
+		verdict.Message = fmt.Sprintf("failing in %d of %d locations (quorum %d): %s",
+			len(failing),
+			len(probes.Locations),
+			probes.QuorumOrDefault(),
+			strings.Join(failing, "; "),
+		)
+	}
+
+	return &verdict
+}
@@ -0,0 +1,69 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ProbesValidation(t *testing.T) {
+
+	tests := []struct {
+		name   string
+		probes *Probes
+		err    error
+	}{
+		{name: "valid", probes: &Probes{Locations: []string{"eu", "us"}, Quorum: 2}},
+		{name: "majority by default", probes: &Probes{Locations: []string{"eu"}}},
+		{name: "no locations", probes: &Probes{}, err: ErrNoLocations},
+		{name: "empty location", probes: &Probes{Locations: []string{"eu", " "}}, err: ErrLocation},
+		{name: "duplicate location", probes: &Probes{Locations: []string{"eu", "eu"}}, err: ErrLocation},
+		{name: "quorum above locations", probes: &Probes{Locations: []string{"eu"}, Quorum: 2}, err: ErrQuorum},
+		{name: "negative quorum", probes: &Probes{Locations: []string{"eu"}, Quorum: -1}, err: ErrQuorum},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.probes.Validate()
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func Test_ProbesQuorum(t *testing.T) {
+
+	tests := []struct {
+		locations int
+		quorum    int
+		want      int
+	}{
+		{locations: 1, want: 1},
+		{locations: 2, want: 2},
+		{locations: 3, want: 2},
+		{locations: 4, want: 3},
+		{locations: 4, quorum: 1, want: 1},
+	}
+
+	for _, tt := range tests {
+		probes := &Probes{Locations: make([]string, tt.locations), Quorum: tt.quorum}
+		assert.Equal(t, tt.want, probes.QuorumOrDefault(), "%d locations, quorum %d", tt.locations, tt.quorum)
+	}
+}
+
+func Test_ProbedHeartbeat(t *testing.T) {
+
+	endpoint := &Endpoint{
+		ServiceName: "job",
+		Interval:    time.Minute,
+		Type:        CheckHeartbeat,
+		Heartbeat:   &Heartbeat{},
+		Probes:      &Probes{Locations: []string{"eu"}},
+	}
+
+	assert.ErrorIs(t, endpoint.Validate(), ErrPassiveProbes)
+}
@@ -0,0 +1,27 @@
+package models
+
+import (
+	"github.com/pkg/errors"
+)
+
+var (
+	// unknown severity
+	ErrSeverity = errors.New("unknown severity")
+)
+
+// Severity defines how important an endpoint failure is.
+type Severity string
+
+const (
+	SeverityInfo     Severity = "info"
+	SeverityWarning  Severity = "warning"
+	SeverityCritical Severity = "critical"
+)
+
+func (sev Severity) Validate() error {
+	switch sev {
+	case "", SeverityInfo, SeverityWarning, SeverityCritical:
+		return nil
+	}
+	return errors.Wrapf(ErrSeverity, "severity %q", sev)
+}
@@ -0,0 +1,58 @@
+package models
+
+import (
+	"slices"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+var (
+	// silence validation failed
+	ErrInvalidSilence = errors.New("invalid silence")
+)
+
+// Silence mutes incident alerts of the workspace endpoints for a period.
+type Silence struct {
+	// Silence identifier (uuid4)
+	ID string
+	// Owning workspace
+	WorkspaceID string
+	// Reason of muting
+	Comment string
+	// Principal who created the silence
+	CreatedBy string
+	// Muted period
+	StartsAt time.Time
+	EndsAt   time.Time
+	// Muted endpoints of the workspace, every endpoint of the workspace if empty
+	EndpointIDs []string
+}
+
+type Silences = []*Silence
+
+// SilencesFilter narrows silences listing.
+type SilencesFilter struct {
+	// Only silences not expired by the time if set
+	EndsAfter time.Time
+}
+
+func (sil *Silence) Validate() error {
+	switch {
+	case sil.EndsAt.IsZero():
+		return errors.Wrap(ErrInvalidSilence, "ends_at is required")
+	case !sil.EndsAt.After(sil.StartsAt):
+		return errors.Wrap(ErrInvalidSilence, "ends_at must be after starts_at")
+	}
+	return nil
+}
+
+// Active reports if the silence mutes alerts at the moment.
+func (sil *Silence) Active(now time.Time) bool {
+	return !now.Before(sil.StartsAt) && now.Before(sil.EndsAt)
+}
+
+// Covers reports if the endpoint is muted by the silence.
+func (sil *Silence) Covers(endpointID string) bool {
+	return len(sil.EndpointIDs) == 0 || slices.Contains(sil.EndpointIDs, endpointID)
+}
@@ -0,0 +1,140 @@
+package models
+
+import (
+	"database/sql"
+	"regexp"
+	"slices"
+	"time"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/pkg/errors"
+)
+
+var (
+	// sql check settings must be set
+	ErrNoSQL = errors.New("sql check settings must be set")
+	// database driver is not registered
+	ErrDriver = errors.New("database driver is not registered")
+	// DSN must be set
+	ErrDSN = errors.New("dsn must be set")
+	// query must be a single read-only statement
+	ErrQuery = errors.New("query must be a single read-only statement")
+	// unknown assertion subject
+	ErrSubject = errors.New("unknown assertion subject")
+	// unknown comparison operator
+	ErrOperator = errors.New("unknown comparison operator")
+	// timeout can't be negative
+	ErrTimeout = errors.New("timeout can't be negative")
+	// sql checks are disabled
+	ErrSQLDisabled = errors.New("sql checks are disabled")
+)
+
+// readOnlyQueryRegexp rejects writing statements early. It can't tell
+// writing CTEs apart, those are stopped by read-only connections.
+var readOnlyQueryRegexp = regexp.MustCompile(`(?is)^\s*(select|with|values|show|explain)\s[^;]*;?\s*$`)
+
+// SQLSubject defines the query result part an assertion is made on.
+type SQLSubject string
+
+const (
+	// Number of rows returned
+	SQLRowCount SQLSubject = "rows"
+	// First column of the first row
+	SQLScalar SQLSubject = "value"
+)
+
+// Operator compares an actual value with an expected one.
+type Operator string
+
+const (
+	OpEqual          Operator = "=="
+	OpNotEqual       Operator = "!="
+	OpLess           Operator = "<"
+	OpLessOrEqual    Operator = "<="
+	OpGreater        Operator = ">"
+	OpGreaterOrEqual Operator = ">="
+)
+
+var operators = []Operator{OpEqual, OpNotEqual, OpLess, OpLessOrEqual, OpGreater, OpGreaterOrEqual}
+
+// SQLCheck is a read-only query asserted against an expected result,
+// e.g. "pending jobs < 500".
+type SQLCheck struct {
+	// database/sql driver name ("sqlite3" is built in), sqlite3 and postgres
+	// connections are read-only, other drivers rely on a read-only transaction
+	Driver string
+	// Data source name passed to the driver
+	DSN string
+	// Single read-only statement
+	Query string
+	// Query timeout (10s by default)
+	Timeout time.Duration
+	// Assertion on the query result
+	Expect Expectation
+}
+
+// Expectation compares a query result part with a value.
+type Expectation struct {
+	// Compared result part (rows by default)
+	Subject SQLSubject
+	// Comparison operator
+	Operator Operator
+	// Expected value, compared numerically if both sides are numbers
+	Value string
+}
+
+func (check *SQLCheck) Validate() error {
+
+	if check == nil {
+		return ErrNoSQL
+	}
+
+	var errs *multierror.Error
+
+	if !slices.Contains(sql.Drivers(), check.Driver) {
+		errs = multierror.Append(errs, errors.Wrapf(ErrDriver, "driver %q", check.Driver))
+	}
+
+	if check.DSN == "" {
+		errs = multierror.Append(errs, ErrDSN)
+	}
+
+	if !readOnlyQueryRegexp.MatchString(check.Query) {
+		errs = multierror.Append(errs, ErrQuery)
+	}
+
+	if check.Timeout < 0 {
+		errs = multierror.Append(errs, ErrTimeout)
+	}
+
+	if err := check.Expect.Validate(); err != nil {
+		errs = multierror.Append(errs, err)
+	}
+
+	return errs.ErrorOrNil()
+}
+
+// SubjectOrDefault returns assertion subject defaulting to row count.
+func (exp Expectation) SubjectOrDefault() SQLSubject {
+	if exp.Subject == "" {
+		return SQLRowCount
+	}
+	return exp.Subject
+}
+
+func (exp Expectation) Validate() error {
+
+	var errs *multierror.Error
+
+	switch exp.SubjectOrDefault() {
+	case SQLRowCount, SQLScalar:
+	default:
+		errs = multierror.Append(errs, errors.Wrapf(ErrSubject, "subject %q", exp.Subject))
+	}
+
+	if !slices.Contains(operators, exp.Operator) {
+		errs = multierror.Append(errs, errors.Wrapf(ErrOperator, "operator %q", exp.Operator))
+	}
+
+	return errs.ErrorOrNil()
+}
@@ -0,0 +1,101 @@
+package models
+
+import "time"
+
+const (
+	// Endpoints sharing the label value are shown as one status page component,
+	// endpoints without it are components of their own named after the service
+	ComponentLabel = "component"
+)
+
+// Status is a public state of a component.
+type Status string
+
+const (
+	StatusOperational Status = "operational"
+	StatusMaintenance Status = "maintenance"
+	StatusDegraded    Status = "degraded"
+	StatusOutage      Status = "outage"
+)
+
+var statusOrder = map[Status]int{
+	StatusOperational: 0,
+	StatusMaintenance: 1,
+	StatusDegraded:    2,
+	StatusOutage:      3,
+}
+
+// Worst returns the more severe of the statuses.
+func (status Status) Worst(other Status) Status {
+	if statusOrder[other] > statusOrder[status] {
+		return other
+	}
+	return status
+}
+
+// ComponentName returns the component the endpoint is shown under: its component label, or service name.
+func ComponentName(endpoint *Endpoint) string {
+	if name := endpoint.Labels[ComponentLabel]; name != "" {
+		return name
+	}
+	return endpoint.ServiceName
+}
+
+// EndpointStatus returns status of the endpoint by its active incident (nil if none)
+// and maintenance in progress. Failing critical endpoints are in outage, others are degraded.
+func EndpointStatus(endpoint *Endpoint, incident *Incident, inMaintenance bool) Status {
+	switch {
+	case inMaintenance:
+		return StatusMaintenance
+	case incident == nil:
+		return StatusOperational
+	case endpoint.SeverityOrDefault() == SeverityCritical:
+		return StatusOutage
+	}
+	return StatusDegraded
+}
+
+// StatusPage is the public state of endpoints shown on a page.
+type StatusPage struct {
+	Slug    string
+	Title   string
+	LogoURL string
+	// Worst status of the components
+	Status     Status
+	Components []*Component
+	// Unresolved incidents, newest first
+	Incidents []*StatusIncident
+	// Maintenances in progress or planned
+	Maintenances Maintenances
+	// Number of days covered by uptime history
+	Days      int
+	UpdatedAt time.Time
+}
+
+// Component is a group of endpoints shown as a single service.
+type Component struct {
+	Name string
+	// Worst status of the endpoints
+	Status    Status
+	Endpoints []*ComponentEndpoint
+	// Checks of the component endpoints per day, oldest first
+	History []*DailyChecks
+	// Checks of the whole history
+	Total DailyChecks
+}
+
+// ComponentEndpoint is a component part, endpoint URLs are never shown.
+type ComponentEndpoint struct {
+	ID          string
+	ServiceName string
+	Status      Status
+}
+
+// StatusIncident is an active incident along with its public names.
+type StatusIncident struct {
+	*Incident
+	ServiceName string
+	Component   string
+	// Operator updates, newest first
+	Updates []*IncidentUpdate
+}
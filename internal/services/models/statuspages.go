@@ -0,0 +1,121 @@
+package models
+
+import (
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+var (
+	// status page validation failed
+	ErrInvalidStatusPage = errors.New("invalid status page")
+	// status page slug or host is taken
+	ErrStatusPageExists = errors.New("status page slug or host is taken")
+)
+
+// Visibility defines who can see a status page.
+type Visibility string
+
+const (
+	// Anyone can see the page
+	VisibilityPublic Visibility = "public"
+	// Only requests carrying the page token can see it
+	VisibilityPrivate Visibility = "private"
+)
+
+var (
+	slugPattern = regexp.MustCompile(`^[a-z0-9]([a-z0-9-]{0,62}[a-z0-9])?$`)
+	hostPattern = regexp.MustCompile(`^([a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?\.)*[a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?$`)
+)
+
+// StatusPageConfig defines a status page: what it shows and who can see it.
+type StatusPageConfig struct {
+	// Status page identifier (uuid4)
+	ID string
+	// Owning workspace, the page shows its endpoints only
+	WorkspaceID string
+	// Path of the page: /status/<slug>
+	Slug  string
+	Title string
+	// Logo image address (http or https)
+	LogoURL string
+	// Shown endpoints, or endpoints matching Selector if empty
+	EndpointIDs []string
+	// Shown endpoints have all the labels, every endpoint of the workspace if both are empty
+	Selector map[string]string
+	// Public (default) or private
+	Visibility Visibility
+	// Access token of private pages (generated by the server)
+	Token string
+	// Host name the page is served at the root of, e.g. status.example.com
+	Host string
+}
+
+type StatusPageConfigs = []*StatusPageConfig
+
+// StatusPageLookup identifies the requested status page.
+type StatusPageLookup struct {
+	// Page slug, the page bound to Host or the default one is looked up if empty
+	Slug string
+	// Request host name without port
+	Host string
+	// Access token provided (private pages only)
+	Token string
+}
+
+// Normalize lowercases the host and defaults visibility.
+func (page *StatusPageConfig) Normalize() {
+	page.Host = strings.ToLower(strings.TrimSpace(page.Host))
+	if page.Visibility == "" {
+		page.Visibility = VisibilityPublic
+	}
+}
+
+func (page *StatusPageConfig) Validate() error {
+
+	if !slugPattern.MatchString(page.Slug) {
+		return errors.Wrap(ErrInvalidStatusPage, "slug must be 1-64 lowercase letters, digits or dashes")
+	}
+
+	if page.Title == "" {
+		return errors.Wrap(ErrInvalidStatusPage, "title is required")
+	}
+
+	if page.LogoURL != "" {
+		logo, err := url.Parse(page.LogoURL)
+		if err != nil || (logo.Scheme != "http" && logo.Scheme != "https") || logo.Host == "" {
+			return errors.Wrap(ErrInvalidStatusPage, "logo_url must be an http(s) URL")
+		}
+	}
+
+	switch page.Visibility {
+	case VisibilityPublic, VisibilityPrivate:
+	default:
+		return errors.Wrapf(ErrInvalidStatusPage, "unknown visibility %q", page.Visibility)
+	}
+
+	if page.Host != "" && !hostPattern.MatchString(page.Host) {
+		return errors.Wrap(ErrInvalidStatusPage, "host must be a host name without port")
+	}
+
+	return nil
+}
+
+// Shows reports if the endpoint is shown on the page.
+func (page *StatusPageConfig) Shows(endpoint *Endpoint) bool {
+
+	if len(page.EndpointIDs) > 0 {
+		return slices.Contains(page.EndpointIDs, endpoint.ID)
+	}
+
+	for key, value := range page.Selector {
+		if actual, ok := endpoint.Labels[key]; !ok || actual != value {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,69 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_StatusPageConfig_Validate(t *testing.T) {
+
+	testingTable := []struct {
+		name    string
+		page    StatusPageConfig
+		invalid bool
+	}{
+		{
+			name: "valid",
+			page: StatusPageConfig{Slug: "team-a", Title: "Team A", LogoURL: "https://example.com/logo.png", Host: "Status.Team-A.example"},
+		},
+		{
+			name:    "slug with slash",
+			page:    StatusPageConfig{Slug: "team/a", Title: "Team A"},
+			invalid: true,
+		},
+		{
+			name:    "no title",
+			page:    StatusPageConfig{Slug: "team-a"},
+			invalid: true,
+		},
+		{
+			name:    "logo not http",
+			page:    StatusPageConfig{Slug: "team-a", Title: "Team A", LogoURL: "javascript:alert(1)"},
+			invalid: true,
+		},
+		{
+			name:    "unknown visibility",
+			page:    StatusPageConfig{Slug: "team-a", Title: "Team A", Visibility: "hidden"},
+			invalid: true,
+		},
+		{
+			name:    "host with port",
+			page:    StatusPageConfig{Slug: "team-a", Title: "Team A", Host: "status.example:8080"},
+			invalid: true,
+		},
+	}
+
+	for _, tt := range testingTable {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.page.Normalize()
+			err := tt.page.Validate()
+			if tt.invalid {
+				assert.ErrorIs(t, err, ErrInvalidStatusPage)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func Test_StatusPageConfig_Shows(t *testing.T) {
+
+	endpoint := &Endpoint{ID: "1", Labels: map[string]string{"team": "a", "env": "prod"}}
+
+	assert.True(t, (&StatusPageConfig{}).Shows(endpoint))
+	assert.True(t, (&StatusPageConfig{EndpointIDs: []string{"1"}}).Shows(endpoint))
+	assert.False(t, (&StatusPageConfig{EndpointIDs: []string{"2"}, Selector: map[string]string{"team": "a"}}).Shows(endpoint))
+	assert.True(t, (&StatusPageConfig{Selector: map[string]string{"team": "a", "env": "prod"}}).Shows(endpoint))
+	assert.False(t, (&StatusPageConfig{Selector: map[string]string{"team": "b"}}).Shows(endpoint))
+}
@@ -0,0 +1,72 @@
+package models
+
+import (
+	"net/mail"
+	"net/url"
+	"slices"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+var (
+	// subscription validation failed
+	ErrInvalidSubscription = errors.New("invalid subscription")
+	// recipient is subscribed to the page already
+	ErrSubscriptionExists = errors.New("subscription exists already")
+	// page or client made too many subscribe requests lately
+	ErrTooManySubscriptions = errors.New("too many subscription requests")
+)
+
+// Subscription delivers incident updates of a status page to an external recipient.
+type Subscription struct {
+	// Subscription identifier (uuid4)
+	ID string
+	// Status page subscribed to
+	PageID string
+	// Workspace of the page
+	WorkspaceID string
+	// Delivery channel, email or webhook
+	Kind ChannelKind
+	// Email address or webhook URL
+	Target string
+	// Components updates are sent about, every page component if empty
+	Components []string
+	// Secret of the confirmation and unsubscribe links (generated by the server)
+	Token string
+	// Time the recipient confirmed the subscription (zero until then)
+	ConfirmedAt time.Time
+	CreatedAt   time.Time
+}
+
+type Subscriptions = []*Subscription
+
+func (sub *Subscription) Validate() error {
+
+	switch sub.Kind {
+	case ChannelEmail:
+		address, err := mail.ParseAddress(sub.Target)
+		if err != nil || address.Address != sub.Target {
+			return errors.Wrap(ErrInvalidSubscription, "target must be an email address")
+		}
+	case ChannelWebhook:
+		target, err := url.Parse(sub.Target)
+		if err != nil || (target.Scheme != "http" && target.Scheme != "https") || target.Host == "" {
+			return errors.Wrap(ErrInvalidSubscription, "target must be an http(s) URL")
+		}
+	default:
+		return errors.Wrapf(ErrInvalidSubscription, "unknown type %q", sub.Kind)
+	}
+
+	return nil
+}
+
+// Confirmed reports if the recipient confirmed the subscription.
+func (sub *Subscription) Confirmed() bool {
+	return !sub.ConfirmedAt.IsZero()
+}
+
+// Wants reports if updates of the component are sent to the subscriber.
+func (sub *Subscription) Wants(component string) bool {
+	return len(sub.Components) == 0 || slices.Contains(sub.Components, component)
+}
@@ -0,0 +1,157 @@
+package models
+
+import (
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/pkg/errors"
+)
+
+var (
+	// transaction must contain at least one step
+	ErrNoSteps = errors.New("transaction must contain at least one step")
+	// step URL must be set
+	ErrStepURL = errors.New("step URL must be set")
+	// unsupported HTTP method
+	ErrMethod = errors.New("unsupported HTTP method")
+	// unknown extraction source
+	ErrExtractSource = errors.New("unknown extraction source")
+	// extraction variable must be an identifier
+	ErrExtractVariable = errors.New("extraction variable must be an identifier")
+	// extraction expression must be set
+	ErrExtractExpression = errors.New("extraction expression must be set")
+)
+
+var variableRegexp = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// ExtractSource defines the part of a response a variable is extracted from.
+type ExtractSource string
+
+const (
+	// JSONPath-like expression over the response body ("$.data.token")
+	ExtractJSON ExtractSource = "json"
+	// Response header name
+	ExtractHeader ExtractSource = "header"
+	// Regular expression over the response body (first group or whole match)
+	ExtractRegex ExtractSource = "regex"
+)
+
+// Transaction is a multi-step HTTP flow checked as a whole.
+type Transaction struct {
+	// Steps executed in order, the check fails on the first broken one
+	Steps []TransactionStep
+}
+
+// TransactionStep is a single HTTP request of a transaction.
+//
+// URL, Headers and Body are Go templates rendered against
+// variables extracted by previous steps, e.g. "Bearer {{.token}}".
+type TransactionStep struct {
+	// Step name used in failure reports
+	Name string
+	// HTTP method (GET by default)
+	Method string
+	// URL template
+	URL string
+	// Header templates
+	Headers map[string]string
+	// Body template
+	Body string
+	// HTTP codes which are considered successful (2xx by default)
+	SuccessCodes []int
+	// Variables extracted from the response
+	Extract []Extraction
+}
+
+// Extraction describes how to take a variable out of a step response.
+type Extraction struct {
+	// Variable name available to the following steps
+	Variable string
+	// Response part to extract from
+	Source ExtractSource
+	// JSONPath, header name or regular expression
+	Expression string
+}
+
+func (tr *Transaction) Validate() error {
+
+	if tr == nil || len(tr.Steps) == 0 {
+		return ErrNoSteps
+	}
+
+	var errs *multierror.Error
+
+	for i := range tr.Steps {
+		if err := tr.Steps[i].Validate(); err != nil {
+			errs = multierror.Append(errs, errors.Wrapf(err, "step %s", tr.Steps[i].Title(i)))
+		}
+	}
+
+	return errs.ErrorOrNil()
+}
+
+// Title returns step name or its position if name is not set.
+func (step *TransactionStep) Title(index int) string {
+	if step.Name != "" {
+		return step.Name
+	}
+	return "#" + strconv.Itoa(index+1)
+}
+
+func (step *TransactionStep) Validate() error {
+
+	var errs *multierror.Error
+
+	if strings.TrimSpace(step.URL) == "" {
+		errs = multierror.Append(errs, ErrStepURL)
+	}
+
+	switch strings.ToUpper(step.Method) {
+	case "", http.MethodGet, http.MethodHead, http.MethodPost, http.MethodPut,
+		http.MethodPatch, http.MethodDelete, http.MethodOptions:
+	default:
+		errs = multierror.Append(errs, errors.Wrapf(ErrMethod, "method %s", step.Method))
+	}
+
+	for _, code := range step.SuccessCodes {
+		if code <= 0 || code >= 600 {
+			errs = multierror.Append(errs, errors.Wrapf(ErrCode, "code %d", code))
+		}
+	}
+
+	for _, extract := range step.Extract {
+		if err := extract.Validate(); err != nil {
+			errs = multierror.Append(errs, err)
+		}
+	}
+
+	return errs.ErrorOrNil()
+}
+
+func (ex Extraction) Validate() error {
+
+	var errs *multierror.Error
+
+	if !variableRegexp.MatchString(ex.Variable) {
+		errs = multierror.Append(errs, errors.Wrapf(ErrExtractVariable, "variable %q", ex.Variable))
+	}
+
+	if ex.Expression == "" {
+		errs = multierror.Append(errs, ErrExtractExpression)
+	}
+
+	switch ex.Source {
+	case ExtractJSON, ExtractHeader:
+	case ExtractRegex:
+		if _, err := regexp.Compile(ex.Expression); err != nil {
+			errs = multierror.Append(errs, errors.Wrap(err, "invalid regex"))
+		}
+	default:
+		errs = multierror.Append(errs, errors.Wrapf(ErrExtractSource, "source %q", ex.Source))
+	}
+
+	return errs.ErrorOrNil()
+}
@@ -0,0 +1,93 @@
+package models
+
+import (
+	"github.com/go-playground/validator/v10"
+	"github.com/hashicorp/go-multierror"
+	"github.com/pkg/errors"
+)
+
+var (
+	// unknown role
+	ErrRole = errors.New("unknown role, must be one of: viewer, editor, admin")
+	// invalid email
+	ErrEmail = errors.New("invalid email")
+	// password is too short
+	ErrPassword = errors.New("password must be at least 8 characters long")
+	// nickname is empty or has non-ascii symbols
+	ErrNickname = errors.New("nickname must be non-empty and consist of only ascii characters")
+)
+
+const (
+	// minimal password length
+	minPasswordLength = 8
+)
+
+// Role defines what a caller is allowed to do.
+type Role string
+
+const (
+	// Reads endpoints, incidents & events
+	RoleViewer Role = "viewer"
+	// Viewer, also manages endpoints & triggers checks
+	RoleEditor Role = "editor"
+	// Editor, also manages users & apps
+	RoleAdmin Role = "admin"
+)
+
+var roleRanks = map[Role]int{
+	RoleViewer: 1,
+	RoleEditor: 2,
+	RoleAdmin:  3,
+}
+
+func (role Role) Validate() error {
+	if _, ok := roleRanks[role]; !ok {
+		return errors.Wrapf(ErrRole, "role %q", role)
+	}
+	return nil
+}
+
+// Allows reports if role grants permissions of the required one.
+func (role Role) Allows(required Role) bool {
+	rank, ok := roleRanks[role]
+	return ok && rank >= roleRanks[required]
+}
+
+type User struct {
+	ID          string
+	WorkspaceID string
+	Nickname    string
+	Email       string
+	Role        Role
+}
+
+type Users = []*User
+
+func (user *User) Validate() error {
+
+	var errs *multierror.Error
+
+	valid := validator.New()
+
+	if err := valid.Var(user.Nickname, "required,ascii"); err != nil {
+		errs = multierror.Append(errs, ErrNickname)
+	}
+
+	if err := valid.Var(user.Email, "email"); err != nil {
+		errs = multierror.Append(errs, ErrEmail)
+	}
+
+	if err := user.Role.Validate(); err != nil {
+		errs = multierror.Append(errs, err)
+	}
+
+	return errs.ErrorOrNil()
+}
+
+// ValidatePassword checks password strength.
+func ValidatePassword(password string) error {
+	if len(password) < minPasswordLength {
+		return ErrPassword
+	}
+	return nil
+}
@@ -0,0 +1,42 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_RoleAllows(t *testing.T) {
+
+	testingTable := []struct {
+		role     Role
+		required Role
+		allows   bool
+	}{
+		{role: RoleViewer, required: RoleViewer, allows: true},
+		{role: RoleViewer, required: RoleEditor},
+		{role: RoleEditor, required: RoleViewer, allows: true},
+		{role: RoleEditor, required: RoleAdmin},
+		{role: RoleAdmin, required: RoleEditor, allows: true},
+		{role: "root", required: RoleViewer},
+		{role: "", required: RoleViewer},
+	}
+
+	for _, tt := range testingTable {
+		t.Run(string(tt.role)+">="+string(tt.required), func(t *testing.T) {
+			assert.Equal(t, tt.allows, tt.role.Allows(tt.required))
+		})
+	}
+}
+
+func Test_UserValidate(t *testing.T) {
+
+	valid := User{Nickname: "jane", Email: "jane@example.com", Role: RoleEditor}
+	assert.NoError(t, valid.Validate())
+
+	invalid := User{Nickname: "жанна", Email: "jane", Role: "root"}
+	err := invalid.Validate()
+	assert.ErrorIs(t, err, ErrNickname)
+	assert.ErrorIs(t, err, ErrEmail)
+	assert.ErrorIs(t, err, ErrRole)
+}
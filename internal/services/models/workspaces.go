@@ -0,0 +1,69 @@
+package models
+
+import (
+	"context"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/pkg/errors"
+	storeModels "github.com/vishenosik/CherryWatch/internal/store/models"
+)
+
+const (
+	// Workspace existing data is migrated to, its admins manage other workspaces
+	DefaultWorkspace = "default"
+)
+
+var (
+	// workspace name is empty or has non-ascii symbols
+	ErrWorkspaceName = errors.New("workspace name must be non-empty and consist of only ascii characters")
+	// authenticated caller doesn't belong to a workspace
+	ErrNoWorkspace = errors.New("caller has no workspace")
+)
+
+// Workspace is a team sharing the deployment,
+// it owns endpoints, incidents, users & apps.
+type Workspace struct {
+	ID   string
+	Name string
+}
+
+type Workspaces = []*Workspace
+
+func (ws *Workspace) Validate() error {
+	if err := validator.New().Var(ws.Name, "required,ascii"); err != nil {
+		return ErrWorkspaceName
+	}
+	return nil
+}
+
+// WithWorkspace limits data access of the context to the workspace.
+// An empty workspace grants access to nothing.
+func WithWorkspace(ctx context.Context, workspaceID string) context.Context {
+	return storeModels.WithWorkspace(ctx, workspaceID)
+}
+
+// WithAllWorkspaces grants access to data of every workspace,
+// it's meant for background processes only (scheduler, heartbeat monitor).
+func WithAllWorkspaces(ctx context.Context) context.Context {
+	return storeModels.WithAllWorkspaces(ctx)
+}
+
+// OwnerWorkspace returns workspace data created within the context belongs to:
+// the scope one, or the given one (default if empty) for all workspaces scope.
+func OwnerWorkspace(ctx context.Context, workspaceID string) string {
+	if scope, _ := WorkspaceFrom(ctx); scope != "" {
+		return scope
+	}
+	if workspaceID == "" {
+		return DefaultWorkspace
+	}
+	return workspaceID
+}
+
+// WorkspaceFrom returns workspace scope of the context.
+// Empty id means all workspaces, which only WithAllWorkspaces grants;
+// ok is false if there's no scope or its workspace is empty.
+func WorkspaceFrom(ctx context.Context) (id string, ok bool) {
+	id, err := storeModels.WorkspaceScope(ctx)
+	return id, err == nil
+}
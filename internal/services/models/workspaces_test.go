@@ -0,0 +1,69 @@
+package models
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_WorkspaceScope(t *testing.T) {
+
+	ctx := context.Background()
+
+	_, ok := WorkspaceFrom(ctx)
+	assert.False(t, ok)
+
+	id, ok := WorkspaceFrom(WithAllWorkspaces(ctx))
+	assert.True(t, ok)
+	assert.Empty(t, id)
+
+	principal, err := WithPrincipal(ctx, &Principal{WorkspaceID: "team-a", Role: RoleAdmin})
+	require.NoError(t, err)
+
+	id, ok = WorkspaceFrom(principal)
+	assert.True(t, ok)
+	assert.Equal(t, "team-a", id)
+
+	// an empty workspace never means all of them
+	_, ok = WorkspaceFrom(WithWorkspace(ctx, ""))
+	assert.False(t, ok)
+
+	_, err = WithPrincipal(ctx, &Principal{Role: RoleAdmin})
+	assert.ErrorIs(t, err, ErrNoWorkspace)
+}
+
+func Test_OwnerWorkspace(t *testing.T) {
+
+	testingTable := []struct {
+		name      string
+		ctx       context.Context
+		workspace string
+		expected  string
+	}{
+		{
+			name:      "scope wins",
+			ctx:       WithWorkspace(context.Background(), "team-a"),
+			workspace: "team-b",
+			expected:  "team-a",
+		},
+		{
+			name:      "all workspaces keep given",
+			ctx:       WithAllWorkspaces(context.Background()),
+			workspace: "team-b",
+			expected:  "team-b",
+		},
+		{
+			name:     "default",
+			ctx:      context.Background(),
+			expected: DefaultWorkspace,
+		},
+	}
+
+	for _, tt := range testingTable {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, OwnerWorkspace(tt.ctx, tt.workspace))
+		})
+	}
+}
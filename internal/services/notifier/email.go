@@ -0,0 +1,67 @@
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"mime"
+	"net"
+	"net/smtp"
+	"strings"
+	"time"
+
+	"github.com/vishenosik/CherryWatch/internal/services/models"
+)
+
+type EmailConfig struct {
+	// SMTP server host:port
+	Addr string
+	// PLAIN authentication is used if set
+	Username string
+	Password string
+	// Sender address
+	From string
+}
+
+// EmailSender sends messages as plain text emails over SMTP.
+type EmailSender struct {
+	config EmailConfig
+	auth   smtp.Auth
+}
+
+func NewEmailSender(config EmailConfig) *EmailSender {
+
+	sender := &EmailSender{config: config}
+
+	if config.Username != "" {
+		host, _, _ := net.SplitHostPort(config.Addr)
+		sender.auth = smtp.PlainAuth("", config.Username, config.Password, host)
+	}
+
+	return sender
+}
+
+// Send sends the email, smtp doesn't support contexts so cancellation isn't honoured.
+func (es *EmailSender) Send(_ context.Context, msg *models.Message) error {
+	return smtp.SendMail(es.config.Addr, es.auth, es.config.From, []string{msg.To}, compose(es.config.From, msg, time.Now()))
+}
+
+// compose formats the message as an RFC 5322 email.
+func compose(from string, msg *models.Message, now time.Time) []byte {
+
+	// header values must not break out of their lines
+	clean := strings.NewReplacer("\r", "", "\n", " ")
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "From: %s\r\n", clean.Replace(from))
+	fmt.Fprintf(&buf, "To: %s\r\n", clean.Replace(msg.To))
+	fmt.Fprintf(&buf, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", clean.Replace(msg.Subject)))
+	fmt.Fprintf(&buf, "Date: %s\r\n", now.Format(time.RFC1123Z))
+	buf.WriteString("MIME-Version: 1.0\r\n")
+	buf.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	buf.WriteString("\r\n")
+	buf.WriteString(strings.ReplaceAll(strings.ReplaceAll(msg.Text, "\r\n", "\n"), "\n", "\r\n"))
+	buf.WriteString("\r\n")
+
+	return buf.Bytes()
+}
@@ -0,0 +1,99 @@
+package notifier
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/pkg/errors"
+	"github.com/vishenosik/CherryWatch/internal/services/models"
+	"github.com/vishenosik/web-tools/operation"
+)
+
+// Sender delivers messages of a channel kind.
+type Sender interface {
+	Send(ctx context.Context, msg *models.Message) error
+}
+
+// Verifier is a sender checking recipient addresses before messages are sent.
+type Verifier interface {
+	Verify(ctx context.Context, to string) error
+}
+
+// Notifier delivers messages through the sender of their channel kind.
+type Notifier struct {
+	log     *slog.Logger
+	senders map[models.ChannelKind]Sender
+}
+
+type Option func(*Notifier)
+
+// WithSender delivers messages of the kind through the sender.
+func WithSender(kind models.ChannelKind, sender Sender) Option {
+	return func(ntf *Notifier) {
+		ntf.senders[kind] = sender
+	}
+}
+
+func NewNotifier(
+	log *slog.Logger,
+	opts ...Option,
+) *Notifier {
+
+	ntf := &Notifier{
+		log:     log,
+		senders: make(map[models.ChannelKind]Sender),
+	}
+
+	for _, opt := range opts {
+		opt(ntf)
+	}
+
+	return ntf
+}
+
+// Supports reports if messages of the kind can be delivered.
+func (ntf *Notifier) Supports(kind models.ChannelKind) bool {
+	_, ok := ntf.senders[kind]
+	return ok
+}
+
+// Verify checks that messages of the kind may be sent to the recipient,
+// failing with models.ErrForbiddenRecipient if they may not.
+func (ntf *Notifier) Verify(ctx context.Context, kind models.ChannelKind, to string) error {
+
+	op := operation.ServicesOperation("notifier", "Verify")
+
+	sender, ok := ntf.senders[kind]
+	if !ok {
+		return errors.Wrap(errors.Wrap(models.ErrUnsupportedChannel, string(kind)), op)
+	}
+
+	verifier, ok := sender.(Verifier)
+	if !ok {
+		return nil
+	}
+
+	if err := verifier.Verify(ctx, to); err != nil {
+		return errors.Wrap(fmt.Errorf("%w: %w", models.ErrForbiddenRecipient, err), op)
+	}
+
+	return nil
+}
+
+// Notify delivers the message.
+func (ntf *Notifier) Notify(ctx context.Context, msg *models.Message) error {
+
+	op := operation.ServicesOperation("notifier", "Notify")
+
+	sender, ok := ntf.senders[msg.Kind]
+	if !ok {
+		return errors.Wrap(errors.Wrap(models.ErrUnsupportedChannel, string(msg.Kind)), op)
+	}
+
+	if err := sender.Send(ctx, msg); err != nil {
+		return errors.Wrap(fmt.Errorf("%w: %w", models.ErrNotDelivered, err), op)
+	}
+
+	return nil
+}
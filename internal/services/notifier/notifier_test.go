@@ -0,0 +1,136 @@
+package notifier
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vishenosik/CherryWatch/internal/services/models"
+)
+
+func Test_Notify_Webhook(t *testing.T) {
+
+	var payload webhookPayload
+
+	hook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+		if r.URL.Path == "/fail" {
+			w.WriteHeader(http.StatusBadGateway)
+		}
+	}))
+	defer hook.Close()
+
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	t.Run("delivered", func(t *testing.T) {
+
+		ntf := NewNotifier(log, WithSender(models.ChannelWebhook, NewWebhookSender(time.Second, true)))
+
+		err := ntf.Notify(context.Background(), &models.Message{
+			Kind:    models.ChannelWebhook,
+			To:      hook.URL,
+			Subject: "API is down",
+			Data:    map[string]string{"status": "outage"},
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, "API is down", payload.Subject)
+		assert.Equal(t, map[string]any{"status": "outage"}, payload.Data)
+	})
+
+	t.Run("error status", func(t *testing.T) {
+
+		ntf := NewNotifier(log, WithSender(models.ChannelWebhook, NewWebhookSender(time.Second, true)))
+
+		err := ntf.Notify(context.Background(), &models.Message{Kind: models.ChannelWebhook, To: hook.URL + "/fail"})
+		assert.ErrorIs(t, err, models.ErrNotDelivered)
+		assert.ErrorContains(t, err, "502")
+	})
+
+	t.Run("private address refused", func(t *testing.T) {
+
+		ntf := NewNotifier(log, WithSender(models.ChannelWebhook, NewWebhookSender(time.Second, false)))
+
+		err := ntf.Notify(context.Background(), &models.Message{Kind: models.ChannelWebhook, To: hook.URL})
+		assert.ErrorIs(t, err, ErrPrivateAddress)
+	})
+
+	t.Run("unsupported channel", func(t *testing.T) {
+
+		ntf := NewNotifier(log)
+
+		assert.False(t, ntf.Supports(models.ChannelEmail))
+		err := ntf.Notify(context.Background(), &models.Message{Kind: models.ChannelEmail, To: "ops@example.com"})
+		assert.ErrorIs(t, err, models.ErrUnsupportedChannel)
+	})
+}
+
+func Test_compose(t *testing.T) {
+
+	email := compose("status@example.com", &models.Message{
+		To:      "ops@example.com",
+		Subject: "API is down\r\nBcc: victim@example.com",
+		Text:    "line 1\nline 2",
+	}, time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC))
+
+	assert.Equal(t, "From: status@example.com\r\n"+
+		"To: ops@example.com\r\n"+
+		"Subject: API is down Bcc: victim@example.com\r\n"+
+		"Date: Mon, 10 Mar 2025 12:00:00 +0000\r\n"+
+		"MIME-Version: 1.0\r\n"+
+		"Content-Type: text/plain; charset=utf-8\r\n"+
+		"\r\n"+
+		"line 1\r\nline 2\r\n", string(email))
+}
+
+func Test_Verify_Webhook(t *testing.T) {
+
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	resolved := map[string][]net.IPAddr{
+		"hooks.example.com":    {{IP: net.ParseIP("93.184.216.34")}},
+		"internal.example.com": {{IP: net.ParseIP("93.184.216.34")}, {IP: net.ParseIP("10.0.0.7")}},
+		"127.0.0.1":            {{IP: net.ParseIP("127.0.0.1")}},
+	}
+
+	newNotifier := func(allowPrivate bool) *Notifier {
+		sender := NewWebhookSender(time.Second, allowPrivate)
+		sender.lookupIP = func(_ context.Context, host string) ([]net.IPAddr, error) {
+			return resolved[host], nil
+		}
+		return NewNotifier(log, WithSender(models.ChannelWebhook, sender))
+	}
+
+	tests := []struct {
+		name         string
+		to           string
+		allowPrivate bool
+		err          error
+	}{
+		{name: "public", to: "https://hooks.example.com/status"},
+		{name: "any private address", to: "https://internal.example.com/status", err: ErrPrivateAddress},
+		{name: "loopback", to: "http://127.0.0.1:8080/", err: ErrPrivateAddress},
+		{name: "private allowed", to: "http://127.0.0.1:8080/", allowPrivate: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := newNotifier(tt.allowPrivate).Verify(context.Background(), models.ChannelWebhook, tt.to)
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+				assert.ErrorIs(t, err, models.ErrForbiddenRecipient)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
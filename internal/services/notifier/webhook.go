@@ -0,0 +1,154 @@
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"syscall"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/vishenosik/CherryWatch/internal/services/models"
+)
+
+const (
+	// request timeout if not set
+	defaultTimeout = 10 * time.Second
+)
+
+var (
+	// webhook resolves to a loopback, private or link-local address
+	ErrPrivateAddress = errors.New("webhook address is not public")
+)
+
+// WebhookSender posts messages as JSON.
+type WebhookSender struct {
+	client       *http.Client
+	allowPrivate bool
+	// resolves webhook hosts when they are verified
+	lookupIP func(ctx context.Context, host string) ([]net.IPAddr, error)
+}
+
+type webhookPayload struct {
+	Subject string `json:"subject"`
+	Text    string `json:"text"`
+	Data    any    `json:"data,omitempty"`
+}
+
+// NewWebhookSender creates a sender with the request timeout.
+// Unless allowPrivate is set, addresses not reachable from the internet are refused,
+// so that webhooks registered by outsiders can't reach internal services.
+func NewWebhookSender(timeout time.Duration, allowPrivate bool) *WebhookSender {
+
+	if timeout <= 0 {
+		timeout = defaultTimeout
+	}
+
+	dialer := &net.Dialer{Timeout: timeout}
+	if !allowPrivate {
+		dialer.Control = publicOnly
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.DialContext = dialer.DialContext
+	transport.Proxy = nil
+
+	return &WebhookSender{
+		client: &http.Client{
+			Timeout:   timeout,
+			Transport: transport,
+		},
+		allowPrivate: allowPrivate,
+		lookupIP:     net.DefaultResolver.LookupIPAddr,
+	}
+}
+
+// Verify refuses webhooks resolving to addresses not reachable from the internet,
+// unless private ones are allowed. Connections are checked again once dialed,
+// as the name may resolve differently by then.
+func (ws *WebhookSender) Verify(ctx context.Context, to string) error {
+
+	if ws.allowPrivate {
+		return nil
+	}
+
+	target, err := url.Parse(to)
+	if err != nil {
+		return err
+	}
+
+	addrs, err := ws.lookupIP(ctx, target.Hostname())
+	if err != nil {
+		return err
+	}
+
+	for _, addr := range addrs {
+		if !public(addr.IP) {
+			return errors.Wrap(ErrPrivateAddress, target.Hostname())
+		}
+	}
+
+	return nil
+}
+
+func (ws *WebhookSender) Send(ctx context.Context, msg *models.Message) error {
+
+	body, err := json.Marshal(webhookPayload{
+		Subject: msg.Subject,
+		Text:    msg.Text,
+		Data:    msg.Data,
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, msg.To, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "CherryWatch")
+
+	resp, err := ws.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("webhook responded %s", resp.Status)
+	}
+
+	return nil
+}
+
+// publicOnly refuses connections to addresses not reachable from the internet,
+// checked after name resolution.
+func publicOnly(_, address string, _ syscall.RawConn) error {
+
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+
+	if !public(net.ParseIP(host)) {
+		return errors.Wrap(ErrPrivateAddress, host)
+	}
+
+	return nil
+}
+
+// public reports if the address is reachable from the internet.
+func public(ip net.IP) bool {
+	return ip != nil &&
+		!ip.IsLoopback() &&
+		!ip.IsPrivate() &&
+		!ip.IsUnspecified() &&
+		!ip.IsLinkLocalUnicast() &&
+		!ip.IsLinkLocalMulticast() &&
+		!ip.IsInterfaceLocalMulticast()
+}
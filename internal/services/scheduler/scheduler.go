@@ -0,0 +1,268 @@
+package scheduler
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/vishenosik/CherryWatch/internal/services/models"
+	attrs "github.com/vishenosik/web-tools/log"
+)
+
+const (
+	// default number of concurrent checks
+	defaultWorkers = 4
+	// default period of due endpoints lookup
+	defaultTick = 5 * time.Second
+)
+
+type Endpoints interface {
+	Endpoints(ctx context.Context) (models.Endpoints, error)
+	RunCheck(ctx context.Context, endpoint *models.Endpoint) (*models.CheckResult, error)
+}
+
+// LagObserver receives delays between checks being due and taken by a worker.
+type LagObserver interface {
+	ObserveQueueLag(lag time.Duration)
+}
+
+type Config struct {
+	// Number of concurrent checks
+	Workers int
+	// Period of due endpoints lookup
+	Tick time.Duration
+}
+
+// Scheduler checks active endpoints every endpoint interval.
+// Passive (heartbeat) endpoints and ones checked by agents are skipped.
+type Scheduler struct {
+	log       *slog.Logger
+	endpoints Endpoints
+	tick      time.Duration
+	workers   int
+	lag       LagObserver
+
+	next map[string]time.Time
+
+	// due checks waiting for a worker in due order, one per endpoint at most
+	mu      sync.Mutex
+	ready   *sync.Cond
+	queue   []job
+	queued  map[string]struct{}
+	stopped bool
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+	done   chan struct{}
+}
+
+type job struct {
+	endpoint *models.Endpoint
+	due      time.Time
+}
+
+type Option func(*Scheduler)
+
+// WithLagObserver reports queue lag of every check to the observer.
+func WithLagObserver(observer LagObserver) Option {
+	return func(sch *Scheduler) {
+		sch.lag = observer
+	}
+}
+
+func NewScheduler(
+	log *slog.Logger,
+	endpoints Endpoints,
+	config Config,
+	opts ...Option,
+) *Scheduler {
+
+	if config.Workers <= 0 {
+		config.Workers = defaultWorkers
+	}
+
+	if config.Tick <= 0 {
+		config.Tick = defaultTick
+	}
+
+	// scheduler checks endpoints of every workspace
+	ctx, cancel := context.WithCancel(models.WithAllWorkspaces(context.Background()))
+
+	sch := &Scheduler{
+		log:       log.With(slog.String("component", "scheduler")),
+		endpoints: endpoints,
+		tick:      config.Tick,
+		workers:   config.Workers,
+		next:      make(map[string]time.Time),
+		queued:    make(map[string]struct{}),
+		ctx:       ctx,
+		cancel:    cancel,
+		done:      make(chan struct{}),
+	}
+
+	sch.ready = sync.NewCond(&sch.mu)
+
+	for _, opt := range opts {
+		opt(sch)
+	}
+
+	return sch
+}
+
+// MustRun starts scheduling and blocks until Stop is called.
+func (sch *Scheduler) MustRun() {
+	if err := sch.Run(); err != nil {
+		panic(err)
+	}
+}
+
+func (sch *Scheduler) Run() error {
+
+	defer close(sch.done)
+
+	for range sch.workers {
+		sch.wg.Add(1)
+		go sch.work()
+	}
+
+	ticker := time.NewTicker(sch.tick)
+	defer ticker.Stop()
+
+	sch.log.Info("scheduler is running",
+		slog.Int("workers", sch.workers),
+		slog.Duration("tick", sch.tick),
+	)
+
+	sch.schedule(time.Now())
+
+	for {
+		select {
+		case <-sch.ctx.Done():
+			sch.mu.Lock()
+			sch.stopped = true
+			sch.ready.Broadcast()
+			sch.mu.Unlock()
+			sch.wg.Wait()
+			return nil
+		case now := <-ticker.C:
+			sch.schedule(now)
+		}
+	}
+}
+
+// Stop stops scheduling and cancels running checks.
+func (sch *Scheduler) Stop(ctx context.Context) {
+
+	sch.log.Info("stopping scheduler")
+
+	sch.cancel()
+
+	select {
+	case <-sch.done:
+	case <-ctx.Done():
+	}
+}
+
+// schedule enqueues endpoints due at the moment now. The queue holds
+// a check per endpoint at most, so every due endpoint fits in it, and
+// one whose previous check is still waiting skips the period.
+func (sch *Scheduler) schedule(now time.Time) {
+
+	endpoints, err := sch.endpoints.Endpoints(sch.ctx)
+	if err != nil {
+		sch.log.Error("failed to load endpoints", attrs.Error(err))
+		return
+	}
+
+	known := make(map[string]struct{}, len(endpoints))
+	behind := 0
+
+	sch.mu.Lock()
+
+	for _, endpoint := range endpoints {
+
+		if endpoint.CheckType() == models.CheckHeartbeat || endpoint.Probed() {
+			continue
+		}
+
+		known[endpoint.ID] = struct{}{}
+
+		due, ok := sch.next[endpoint.ID]
+		if ok && now.Before(due) {
+			continue
+		}
+		if !ok {
+			due = now
+		}
+
+		sch.next[endpoint.ID] = now.Add(endpoint.Interval)
+
+		if _, waiting := sch.queued[endpoint.ID]; waiting {
+			behind++
+			continue
+		}
+
+		sch.queue = append(sch.queue, job{endpoint: endpoint, due: due})
+		sch.queued[endpoint.ID] = struct{}{}
+	}
+
+	sch.ready.Broadcast()
+	sch.mu.Unlock()
+
+	if behind > 0 {
+		sch.log.Warn("checks are behind schedule, due checks skipped while previous ones wait for a worker",
+			slog.Int("endpoints", behind),
+		)
+	}
+
+	// forget deleted endpoints
+	for id := range sch.next {
+		if _, ok := known[id]; !ok {
+			delete(sch.next, id)
+		}
+	}
+}
+
+// take waits for the longest due check, ok is false once the scheduler is stopped.
+func (sch *Scheduler) take() (job, bool) {
+
+	sch.mu.Lock()
+	defer sch.mu.Unlock()
+
+	for len(sch.queue) == 0 && !sch.stopped {
+		sch.ready.Wait()
+	}
+
+	if sch.stopped {
+		return job{}, false
+	}
+
+	next := sch.queue[0]
+	sch.queue[0] = job{}
+	sch.queue = sch.queue[1:]
+	delete(sch.queued, next.endpoint.ID)
+
+	return next, true
+}
+
+func (sch *Scheduler) work() {
+	defer sch.wg.Done()
+
+	for {
+		job, ok := sch.take()
+		if !ok {
+			return
+		}
+		if sch.lag != nil {
+			sch.lag.ObserveQueueLag(time.Since(job.due))
+		}
+		if _, err := sch.endpoints.RunCheck(sch.ctx, job.endpoint); err != nil {
+			sch.log.Error("check failed",
+				slog.String("endpoint_id", job.endpoint.ID),
+				attrs.Error(err),
+			)
+		}
+	}
+}
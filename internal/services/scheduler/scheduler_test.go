@@ -0,0 +1,107 @@
+package scheduler
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vishenosik/CherryWatch/internal/services/models"
+)
+
+type endpointsMock struct {
+	endpoints models.Endpoints
+
+	mu      sync.Mutex
+	checked map[string]int
+}
+
+func (em *endpointsMock) Endpoints(_ context.Context) (models.Endpoints, error) {
+	return em.endpoints, nil
+}
+
+func (em *endpointsMock) RunCheck(_ context.Context, endpoint *models.Endpoint) (*models.CheckResult, error) {
+	em.mu.Lock()
+	defer em.mu.Unlock()
+	em.checked[endpoint.ID]++
+	return &models.CheckResult{EndpointID: endpoint.ID, Success: true}, nil
+}
+
+func (em *endpointsMock) count(id string) int {
+	em.mu.Lock()
+	defer em.mu.Unlock()
+	return em.checked[id]
+}
+
+func Test_Scheduler(t *testing.T) {
+
+	endpoints := &endpointsMock{
+		endpoints: models.Endpoints{
+			{ID: "http", Interval: time.Hour},
+			{ID: "heartbeat", Interval: time.Hour, Type: models.CheckHeartbeat},
+			{ID: "probed", Interval: time.Hour, Probes: &models.Probes{Locations: []string{"eu"}}},
+		},
+		checked: make(map[string]int),
+	}
+
+	sch := NewScheduler(
+		slog.New(slog.NewTextHandler(io.Discard, nil)),
+		endpoints,
+		Config{Workers: 1, Tick: 10 * time.Millisecond},
+	)
+
+	go sch.MustRun()
+
+	assert.Eventually(t, func() bool { return endpoints.count("http") == 1 }, time.Second, 5*time.Millisecond)
+
+	// next check isn't due for an hour
+	time.Sleep(50 * time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	sch.Stop(ctx)
+
+	assert.Equal(t, 1, endpoints.count("http"))
+	assert.Zero(t, endpoints.count("heartbeat"))
+	assert.Zero(t, endpoints.count("probed"))
+}
+
+func Test_schedule(t *testing.T) {
+
+	endpoints := &endpointsMock{checked: make(map[string]int)}
+	for _, id := range []string{"1", "2", "3", "4", "5", "6"} {
+		endpoints.endpoints = append(endpoints.endpoints, &models.Endpoint{ID: id, Interval: time.Minute})
+	}
+
+	sch := NewScheduler(
+		slog.New(slog.NewTextHandler(io.Discard, nil)),
+		endpoints,
+		Config{Workers: 1},
+	)
+
+	now := time.Now()
+
+	// every due endpoint is queued, however few the workers are
+	sch.schedule(now)
+	assert.Len(t, sch.queue, 6)
+
+	// endpoints due again while their checks still wait aren't queued twice,
+	// and the waiting checks keep their due time
+	sch.schedule(now.Add(time.Minute))
+	assert.Len(t, sch.queue, 6)
+	for _, job := range sch.queue {
+		assert.Equal(t, now, job.due)
+	}
+
+	job, ok := sch.take()
+	assert.True(t, ok)
+	assert.Equal(t, "1", job.endpoint.ID)
+
+	sch.schedule(now.Add(2 * time.Minute))
+	assert.Len(t, sch.queue, 6)
+	assert.Equal(t, "1", sch.queue[5].endpoint.ID)
+	assert.Equal(t, now.Add(2*time.Minute), sch.queue[5].due)
+}
@@ -0,0 +1,136 @@
+package silences
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"github.com/vishenosik/CherryWatch/internal/services/models"
+	storeModels "github.com/vishenosik/CherryWatch/internal/store/models"
+	"github.com/vishenosik/web-tools/operation"
+)
+
+type Store interface {
+	SaveSilence(ctx context.Context, sil *models.Silence) error
+	Silences(ctx context.Context, filter models.SilencesFilter) (models.Silences, error)
+	DeleteSilence(ctx context.Context, id string) error
+}
+
+type Endpoints interface {
+	Endpoint(ctx context.Context, id string) (*models.Endpoint, error)
+}
+
+type Service struct {
+	log       *slog.Logger
+	store     Store
+	endpoints Endpoints
+}
+
+func NewSilencesService(
+	log *slog.Logger,
+	store Store,
+	endpoints Endpoints,
+) *Service {
+	return &Service{
+		log:       log,
+		store:     store,
+		endpoints: endpoints,
+	}
+}
+
+// CreateSilence validates and stores a silence starting now unless set.
+// Muted endpoints must belong to the silence workspace.
+func (srv *Service) CreateSilence(ctx context.Context, sil *models.Silence) (*models.Silence, error) {
+
+	op := operation.ServicesOperation("silences", "CreateSilence")
+
+	sil.ID = uuid.NewString()
+	sil.WorkspaceID = models.OwnerWorkspace(ctx, sil.WorkspaceID)
+	sil.CreatedBy = ""
+	if principal, ok := models.PrincipalFrom(ctx); ok {
+		sil.CreatedBy = principal.Name()
+	}
+	if sil.StartsAt.IsZero() {
+		sil.StartsAt = time.Now()
+	}
+
+	if err := sil.Validate(); err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	scoped := models.WithWorkspace(ctx, sil.WorkspaceID)
+	for _, id := range sil.EndpointIDs {
+		if _, err := srv.endpoints.Endpoint(scoped, id); err != nil {
+			if errors.Is(err, models.ErrNotFound) {
+				return nil, errors.Wrap(errors.Wrapf(models.ErrInvalidSilence, "unknown endpoint %q", id), op)
+			}
+			return nil, errors.Wrap(err, op)
+		}
+	}
+
+	if err := srv.store.SaveSilence(ctx, sil); err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	srv.log.Info("silence created",
+		slog.String("silence_id", sil.ID),
+		slog.String("by", sil.CreatedBy),
+	)
+
+	return sil, nil
+}
+
+// Silences returns silences matching the filter ordered by start.
+func (srv *Service) Silences(ctx context.Context, filter models.SilencesFilter) (models.Silences, error) {
+
+	op := operation.ServicesOperation("silences", "Silences")
+
+	silences, err := srv.store.Silences(ctx, filter)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	return silences, nil
+}
+
+// DeleteSilence deletes a silence by id, unmuting its endpoints.
+func (srv *Service) DeleteSilence(ctx context.Context, id string) error {
+
+	op := operation.ServicesOperation("silences", "DeleteSilence")
+
+	if err := srv.store.DeleteSilence(ctx, id); err != nil {
+		if errors.Is(err, storeModels.ErrNotFound) {
+			return errors.Wrap(models.ErrNotFound, op)
+		}
+		return errors.Wrap(err, op)
+	}
+
+	return nil
+}
+
+// Silenced reports if alerts of the endpoint are muted at the moment,
+// only silences of the endpoint workspace are taken into account.
+func (srv *Service) Silenced(ctx context.Context, endpoint *models.Endpoint) (bool, error) {
+
+	op := operation.ServicesOperation("silences", "Silenced")
+
+	now := time.Now()
+
+	silences, err := srv.store.Silences(
+		models.WithWorkspace(ctx, endpoint.WorkspaceID),
+		models.SilencesFilter{EndsAfter: now},
+	)
+	if err != nil {
+		return false, errors.Wrap(err, op)
+	}
+
+	for _, sil := range silences {
+		if sil.Active(now) && sil.Covers(endpoint.ID) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
@@ -0,0 +1,153 @@
+package silences
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vishenosik/CherryWatch/internal/services/models"
+	storeModels "github.com/vishenosik/CherryWatch/internal/store/models"
+)
+
+type storeMock struct {
+	silences models.Silences
+}
+
+func (sm *storeMock) SaveSilence(_ context.Context, sil *models.Silence) error {
+	sm.silences = append(sm.silences, sil)
+	return nil
+}
+
+func (sm *storeMock) Silences(ctx context.Context, filter models.SilencesFilter) (models.Silences, error) {
+	scope, _ := models.WorkspaceFrom(ctx)
+	silences := make(models.Silences, 0, len(sm.silences))
+	for _, sil := range sm.silences {
+		if (scope == "" || sil.WorkspaceID == scope) && sil.EndsAt.After(filter.EndsAfter) {
+			silences = append(silences, sil)
+		}
+	}
+	return silences, nil
+}
+
+func (sm *storeMock) DeleteSilence(_ context.Context, id string) error {
+	for i, sil := range sm.silences {
+		if sil.ID == id {
+			sm.silences = append(sm.silences[:i], sm.silences[i+1:]...)
+			return nil
+		}
+	}
+	return storeModels.ErrNotFound
+}
+
+type endpointsMock struct {
+	endpoints models.Endpoints
+}
+
+func (em *endpointsMock) Endpoint(ctx context.Context, id string) (*models.Endpoint, error) {
+	scope, _ := models.WorkspaceFrom(ctx)
+	for _, endpoint := range em.endpoints {
+		if endpoint.ID == id && (scope == "" || endpoint.WorkspaceID == scope) {
+			return endpoint, nil
+		}
+	}
+	return nil, models.ErrNotFound
+}
+
+var (
+	api  = &models.Endpoint{ID: "api", WorkspaceID: "team-a"}
+	shop = &models.Endpoint{ID: "shop", WorkspaceID: "team-b"}
+)
+
+func newTestService() *Service {
+	return NewSilencesService(
+		slog.New(slog.NewTextHandler(io.Discard, nil)),
+		&storeMock{},
+		&endpointsMock{endpoints: models.Endpoints{api, shop}},
+	)
+}
+
+func Test_CreateSilence(t *testing.T) {
+
+	teamA := models.WithWorkspace(context.Background(), "team-a")
+	endsAt := time.Now().Add(time.Hour)
+
+	tests := []struct {
+		name    string
+		silence *models.Silence
+		err     error
+	}{
+		{
+			name:    "endpoint of the workspace",
+			silence: &models.Silence{EndsAt: endsAt, EndpointIDs: []string{"api"}},
+		},
+		{
+			name:    "whole workspace",
+			silence: &models.Silence{EndsAt: endsAt},
+		},
+		{
+			name:    "endpoint of another workspace",
+			silence: &models.Silence{EndsAt: endsAt, EndpointIDs: []string{"shop"}},
+			err:     models.ErrInvalidSilence,
+		},
+		{
+			name:    "another workspace requested",
+			silence: &models.Silence{WorkspaceID: "team-b", EndsAt: endsAt, EndpointIDs: []string{"shop"}},
+			err:     models.ErrInvalidSilence,
+		},
+		{
+			name:    "no end",
+			silence: &models.Silence{EndpointIDs: []string{"api"}},
+			err:     models.ErrInvalidSilence,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sil, err := newTestService().CreateSilence(teamA, tt.silence)
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "team-a", sil.WorkspaceID)
+			assert.False(t, sil.StartsAt.IsZero())
+		})
+	}
+}
+
+func Test_Silenced(t *testing.T) {
+
+	ctx := context.Background()
+	now := time.Now()
+
+	srv := newTestService()
+	store := srv.store.(*storeMock)
+
+	// silences muting every endpoint of team-b, one expired, one not started yet
+	store.silences = models.Silences{
+		{ID: "1", WorkspaceID: "team-b", StartsAt: now.Add(-time.Hour), EndsAt: now.Add(time.Hour)},
+		{ID: "2", WorkspaceID: "team-a", StartsAt: now.Add(-2 * time.Hour), EndsAt: now.Add(-time.Hour)},
+		{ID: "3", WorkspaceID: "team-a", StartsAt: now.Add(time.Hour), EndsAt: now.Add(2 * time.Hour)},
+	}
+
+	silenced, err := srv.Silenced(ctx, shop)
+	require.NoError(t, err)
+	assert.True(t, silenced)
+
+	// silences of other workspaces never mute the endpoint
+	silenced, err = srv.Silenced(ctx, api)
+	require.NoError(t, err)
+	assert.False(t, silenced)
+
+	store.silences = append(store.silences, &models.Silence{
+		ID: "4", WorkspaceID: "team-a", StartsAt: now.Add(-time.Minute), EndsAt: now.Add(time.Hour), EndpointIDs: []string{"api"},
+	})
+
+	silenced, err = srv.Silenced(ctx, api)
+	require.NoError(t, err)
+	assert.True(t, silenced)
+}
@@ -0,0 +1,179 @@
+package statuspage
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"slices"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"github.com/vishenosik/CherryWatch/internal/services/models"
+	storeModels "github.com/vishenosik/CherryWatch/internal/store/models"
+	"github.com/vishenosik/web-tools/operation"
+)
+
+// CreateStatusPage validates and stores a status page, private pages get a token generated.
+func (srv *Service) CreateStatusPage(ctx context.Context, page *models.StatusPageConfig) (*models.StatusPageConfig, error) {
+
+	op := operation.ServicesOperation("statuspage", "CreateStatusPage")
+
+	page.ID = uuid.NewString()
+	page.WorkspaceID = models.OwnerWorkspace(ctx, page.WorkspaceID)
+	page.Token = ""
+
+	if err := srv.save(ctx, page); err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	return page, nil
+}
+
+// UpdateStatusPage replaces an existing status page.
+// Token of a private page is kept unless rotateToken is set.
+func (srv *Service) UpdateStatusPage(
+	ctx context.Context,
+	page *models.StatusPageConfig,
+	rotateToken bool,
+) (*models.StatusPageConfig, error) {
+
+	op := operation.ServicesOperation("statuspage", "UpdateStatusPage")
+
+	current, err := srv.store.StatusPage(ctx, page.ID)
+	if err != nil {
+		if errors.Is(err, storeModels.ErrNotFound) {
+			return nil, errors.Wrap(models.ErrNotFound, op)
+		}
+		return nil, errors.Wrap(err, op)
+	}
+
+	page.WorkspaceID = current.WorkspaceID
+	page.Token = current.Token
+	if rotateToken {
+		page.Token = ""
+	}
+
+	if err := srv.save(ctx, page); err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	return page, nil
+}
+
+// StatusPageConfig returns a status page by id.
+func (srv *Service) StatusPageConfig(ctx context.Context, id string) (*models.StatusPageConfig, error) {
+
+	op := operation.ServicesOperation("statuspage", "StatusPageConfig")
+
+	page, err := srv.store.StatusPage(ctx, id)
+	if err != nil {
+		if errors.Is(err, storeModels.ErrNotFound) {
+			return nil, errors.Wrap(models.ErrNotFound, op)
+		}
+		return nil, errors.Wrap(err, op)
+	}
+
+	return page, nil
+}
+
+// StatusPageConfigs returns all status pages.
+func (srv *Service) StatusPageConfigs(ctx context.Context) (models.StatusPageConfigs, error) {
+
+	op := operation.ServicesOperation("statuspage", "StatusPageConfigs")
+
+	pages, err := srv.store.StatusPages(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	return pages, nil
+}
+
+// Published returns the endpoints shown on a public status page of the workspace,
+// the default page included. Endpoints of other workspaces are never returned.
+func (srv *Service) Published(ctx context.Context, workspaceID string, endpoints models.Endpoints) (models.Endpoints, error) {
+
+	op := operation.ServicesOperation("statuspage", "Published")
+
+	pages, err := srv.store.StatusPages(models.WithWorkspace(ctx, workspaceID))
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	if srv.config.Default != nil && srv.config.Default.WorkspaceID == workspaceID {
+		pages = append(pages, srv.config.Default)
+	}
+
+	published := make(models.Endpoints, 0, len(endpoints))
+	for _, endpoint := range endpoints {
+		if endpoint.WorkspaceID != workspaceID {
+			continue
+		}
+		if slices.ContainsFunc(pages, func(page *models.StatusPageConfig) bool {
+			return page.Visibility == models.VisibilityPublic && page.Shows(endpoint)
+		}) {
+			published = append(published, endpoint)
+		}
+	}
+
+	return published, nil
+}
+
+// DeleteStatusPage deletes a status page by id.
+func (srv *Service) DeleteStatusPage(ctx context.Context, id string) error {
+
+	op := operation.ServicesOperation("statuspage", "DeleteStatusPage")
+
+	if err := srv.store.DeleteStatusPage(ctx, id); err != nil {
+		if errors.Is(err, storeModels.ErrNotFound) {
+			return errors.Wrap(models.ErrNotFound, op)
+		}
+		return errors.Wrap(err, op)
+	}
+
+	srv.uncachePage(ctx, id)
+
+	return nil
+}
+
+func (srv *Service) save(ctx context.Context, page *models.StatusPageConfig) error {
+
+	page.Normalize()
+
+	if err := page.Validate(); err != nil {
+		return err
+	}
+
+	switch {
+	case page.Visibility == models.VisibilityPublic:
+		page.Token = ""
+	case page.Token == "":
+		token, err := newToken()
+		if err != nil {
+			return err
+		}
+		page.Token = token
+	}
+
+	if err := srv.store.SaveStatusPage(ctx, page); err != nil {
+		switch {
+		case errors.Is(err, storeModels.ErrAlreadyExists):
+			return models.ErrStatusPageExists
+		case errors.Is(err, storeModels.ErrNotFound):
+			return models.ErrNotFound
+		}
+		return err
+	}
+
+	srv.uncachePage(ctx, page.ID)
+
+	return nil
+}
+
+func newToken() (string, error) {
+	token := make([]byte, 24)
+	if _, err := rand.Read(token); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(token), nil
+}
@@ -0,0 +1,442 @@
+package statuspage
+
+import (
+	"context"
+	"crypto/subtle"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/vishenosik/CherryWatch/internal/services/models"
+	storeModels "github.com/vishenosik/CherryWatch/internal/store/models"
+	attrs "github.com/vishenosik/web-tools/log"
+	"github.com/vishenosik/web-tools/operation"
+)
+
+const (
+	// default number of days of uptime history
+	defaultDays = 90
+	// default page heading
+	defaultTitle = "Status"
+)
+
+type Store interface {
+	StatusPage(ctx context.Context, id string) (*models.StatusPageConfig, error)
+	StatusPageBySlug(ctx context.Context, slug string) (*models.StatusPageConfig, error)
+	StatusPageByHost(ctx context.Context, host string) (*models.StatusPageConfig, error)
+	StatusPages(ctx context.Context) (models.StatusPageConfigs, error)
+	SaveStatusPage(ctx context.Context, page *models.StatusPageConfig) error
+	DeleteStatusPage(ctx context.Context, id string) error
+}
+
+type Endpoints interface {
+	Endpoints(ctx context.Context) (models.Endpoints, error)
+}
+
+type Incidents interface {
+	Incidents(ctx context.Context, filter models.IncidentsFilter) (models.Incidents, error)
+	IncidentUpdates(ctx context.Context, incidentIDs []string) ([]*models.IncidentUpdate, error)
+}
+
+type History interface {
+	DailyChecks(ctx context.Context, endpointIDs []string, since time.Time) ([]*models.DailyChecks, error)
+}
+
+type Maintenances interface {
+	Maintenances(ctx context.Context, filter models.MaintenancesFilter) (models.Maintenances, error)
+}
+
+type Config struct {
+	// Page served when no page is bound to the request host, none if nil
+	Default *models.StatusPageConfig
+	// Number of days of uptime history
+	Days int
+}
+
+// Service manages status pages and builds their public state.
+type Service struct {
+	log          *slog.Logger
+	store        Store
+	endpoints    Endpoints
+	incidents    Incidents
+	history      History
+	maintenances Maintenances
+	config       Config
+	cache        storeModels.CacheProvider
+	cacheTTL     time.Duration
+}
+
+type Option func(*Service)
+
+// WithCache keeps built pages for the ttl, a page is built at most once per ttl however often it's requested.
+// Pages are dropped on change, endpoint status shown may be behind by the ttl.
+func WithCache(cache storeModels.CacheProvider, ttl time.Duration) Option {
+	return func(srv *Service) {
+		srv.cache = cache
+		srv.cacheTTL = ttl
+	}
+}
+
+func NewStatusPageService(
+	log *slog.Logger,
+	store Store,
+	endpoints Endpoints,
+	incidents Incidents,
+	history History,
+	maintenances Maintenances,
+	config Config,
+	opts ...Option,
+) *Service {
+
+	if config.Days <= 0 {
+		config.Days = defaultDays
+	}
+
+	if config.Default != nil {
+		config.Default.Normalize()
+		if config.Default.Title == "" {
+			config.Default.Title = defaultTitle
+		}
+		if config.Default.WorkspaceID == "" {
+			config.Default.WorkspaceID = models.DefaultWorkspace
+		}
+	}
+
+	srv := &Service{
+		log:          log,
+		store:        store,
+		endpoints:    endpoints,
+		incidents:    incidents,
+		history:      history,
+		maintenances: maintenances,
+		config:       config,
+	}
+
+	for _, opt := range opts {
+		opt(srv)
+	}
+
+	return srv
+}
+
+// StatusPage returns current state of the looked up page endpoints.
+// Private pages require the page token.
+func (srv *Service) StatusPage(ctx context.Context, lookup models.StatusPageLookup) (*models.StatusPage, error) {
+
+	op := operation.ServicesOperation("statuspage", "StatusPage")
+
+	config, err := srv.Page(ctx, lookup)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	if page, ok := srv.cachedPage(ctx, config.ID); ok {
+		return page, nil
+	}
+
+	page, err := srv.collect(ctx, config)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	srv.cachePage(ctx, config.ID, page)
+
+	return page, nil
+}
+
+// collect builds current state of the page endpoints.
+func (srv *Service) collect(ctx context.Context, config *models.StatusPageConfig) (*models.StatusPage, error) {
+
+	// the page is public, data access is limited to the page workspace
+	ctx = models.WithWorkspace(ctx, config.WorkspaceID)
+
+	now := time.Now()
+
+	endpoints, err := srv.endpoints.Endpoints(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	shown := make(models.Endpoints, 0, len(endpoints))
+	for _, endpoint := range endpoints {
+		if config.Shows(endpoint) {
+			shown = append(shown, endpoint)
+		}
+	}
+	endpoints = shown
+
+	incidents, err := srv.incidents.Incidents(ctx, models.IncidentsFilter{ActiveOnly: true})
+	if err != nil {
+		return nil, err
+	}
+
+	maintenances, err := srv.maintenances.Maintenances(ctx, models.MaintenancesFilter{EndsAfter: now})
+	if err != nil {
+		return nil, err
+	}
+
+	since := models.Day(now).AddDate(0, 0, 1-srv.config.Days)
+
+	ids := make([]string, 0, len(endpoints))
+	for _, endpoint := range endpoints {
+		ids = append(ids, endpoint.ID)
+	}
+
+	history, err := srv.history.DailyChecks(ctx, ids, since)
+	if err != nil {
+		return nil, err
+	}
+
+	page := build(config, srv.config.Days, now, since, endpoints, incidents, maintenances, history)
+
+	if len(page.Incidents) == 0 {
+		return page, nil
+	}
+
+	incidentIDs := make([]string, 0, len(page.Incidents))
+	for _, incident := range page.Incidents {
+		incidentIDs = append(incidentIDs, incident.ID)
+	}
+
+	updates, err := srv.incidents.IncidentUpdates(ctx, incidentIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	attachUpdates(page, updates)
+
+	return page, nil
+}
+
+// cachedPage returns the page built recently.
+func (srv *Service) cachedPage(ctx context.Context, id string) (*models.StatusPage, bool) {
+
+	if srv.cache == nil {
+		return nil, false
+	}
+
+	cached, err := srv.cache.Get(ctx, pageCacheKey(id))
+	if err != nil {
+		return nil, false
+	}
+
+	page := &models.StatusPage{}
+	if err := storeModels.DecodeCacheValue(cached, page); err != nil {
+		return nil, false
+	}
+
+	return page, true
+}
+
+// cachePage keeps the built page, failures are logged only: pages are built again.
+func (srv *Service) cachePage(ctx context.Context, id string, page *models.StatusPage) {
+
+	if srv.cache == nil {
+		return
+	}
+
+	data, err := storeModels.EncodeCacheValue(page)
+	if err == nil {
+		err = srv.cache.Set(ctx, pageCacheKey(id), data, srv.cacheTTL)
+	}
+
+	if err != nil {
+		srv.log.Warn("failed to cache status page", slog.String("page_id", id), attrs.Error(err))
+	}
+}
+
+// uncachePage drops the page built before its configuration changed.
+func (srv *Service) uncachePage(ctx context.Context, id string) {
+	if srv.cache != nil {
+		if err := srv.cache.Delete(ctx, pageCacheKey(id)); err != nil {
+			srv.log.Warn("failed to drop cached status page", slog.String("page_id", id), attrs.Error(err))
+		}
+	}
+}
+
+// pageCacheKey keys built pages by id, the default page has none.
+func pageCacheKey(id string) string {
+	return fmt.Sprintf("statuspage:%s", id)
+}
+
+// attachUpdates adds updates given oldest first onto the page incidents, newest first.
+func attachUpdates(page *models.StatusPage, updates []*models.IncidentUpdate) {
+
+	incidents := make(map[string]*models.StatusIncident, len(page.Incidents))
+	for _, incident := range page.Incidents {
+		incidents[incident.ID] = incident
+	}
+
+	for _, update := range slices.Backward(updates) {
+		if incident, ok := incidents[update.IncidentID]; ok {
+			incident.Updates = append(incident.Updates, update)
+		}
+	}
+}
+
+// Page returns the looked up page configuration.
+// Private pages require the page token.
+func (srv *Service) Page(ctx context.Context, lookup models.StatusPageLookup) (*models.StatusPageConfig, error) {
+
+	op := operation.ServicesOperation("statuspage", "Page")
+
+	config, err := srv.lookup(ctx, lookup)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	if config.Visibility == models.VisibilityPrivate &&
+		subtle.ConstantTimeCompare([]byte(config.Token), []byte(lookup.Token)) != 1 {
+		return nil, errors.Wrap(models.ErrUnauthenticated, op)
+	}
+
+	return config, nil
+}
+
+// lookup finds the page by slug, or the page bound to the host falling back to the default one.
+func (srv *Service) lookup(ctx context.Context, lookup models.StatusPageLookup) (*models.StatusPageConfig, error) {
+
+	// pages are looked up by public names across workspaces
+	ctx = models.WithAllWorkspaces(ctx)
+
+	var (
+		page *models.StatusPageConfig
+		err  error
+	)
+
+	switch {
+	case lookup.Slug != "":
+		page, err = srv.store.StatusPageBySlug(ctx, lookup.Slug)
+	default:
+		page, err = srv.store.StatusPageByHost(ctx, strings.ToLower(lookup.Host))
+		if errors.Is(err, storeModels.ErrNotFound) && srv.config.Default != nil {
+			return srv.config.Default, nil
+		}
+	}
+
+	if err != nil {
+		if errors.Is(err, storeModels.ErrNotFound) {
+			return nil, models.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return page, nil
+}
+
+// build groups endpoints into components, sorted by name.
+func build(
+	config *models.StatusPageConfig,
+	days int,
+	now, since time.Time,
+	endpoints models.Endpoints,
+	incidents models.Incidents,
+	maintenances models.Maintenances,
+	history []*models.DailyChecks,
+) *models.StatusPage {
+
+	page := &models.StatusPage{
+		Slug:      config.Slug,
+		Title:     config.Title,
+		LogoURL:   config.LogoURL,
+		Status:    models.StatusOperational,
+		Days:      days,
+		UpdatedAt: now,
+	}
+
+	active := make(map[string]*models.Incident, len(incidents))
+	for _, incident := range incidents {
+		// incidents are newest first, the latest one defines the cause
+		if _, ok := active[incident.EndpointID]; !ok {
+			active[incident.EndpointID] = incident
+		}
+	}
+
+	// maintenances of other endpoints are not announced
+	for _, mnt := range maintenances {
+		if slices.ContainsFunc(endpoints, func(endpoint *models.Endpoint) bool { return mnt.Covers(endpoint.ID) }) {
+			page.Maintenances = append(page.Maintenances, mnt)
+		}
+	}
+
+	components := make(map[string]*models.Component)
+	owners := make(map[string]*models.Component, len(endpoints))
+
+	for _, endpoint := range endpoints {
+
+		name := models.ComponentName(endpoint)
+
+		component, ok := components[name]
+		if !ok {
+			component = &models.Component{
+				Name:    name,
+				Status:  models.StatusOperational,
+				History: emptyHistory(since, days),
+			}
+			components[name] = component
+			page.Components = append(page.Components, component)
+		}
+		owners[endpoint.ID] = component
+
+		inMaintenance := slices.ContainsFunc(page.Maintenances, func(mnt *models.Maintenance) bool {
+			return mnt.Active(now) && mnt.Covers(endpoint.ID)
+		})
+
+		status := models.EndpointStatus(endpoint, active[endpoint.ID], inMaintenance)
+
+		component.Endpoints = append(component.Endpoints, &models.ComponentEndpoint{
+			ID:          endpoint.ID,
+			ServiceName: endpoint.ServiceName,
+			Status:      status,
+		})
+		component.Status = component.Status.Worst(status)
+		page.Status = page.Status.Worst(status)
+	}
+
+	for _, checks := range history {
+		component, ok := owners[checks.EndpointID]
+		if !ok {
+			continue
+		}
+		day := int(checks.Day.Sub(since) / (24 * time.Hour))
+		if day < 0 || day >= len(component.History) {
+			continue
+		}
+		component.History[day].Add(checks)
+		component.Total.Add(checks)
+	}
+
+	slices.SortFunc(page.Components, func(a, b *models.Component) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	for _, incident := range incidents {
+		component, ok := owners[incident.EndpointID]
+		if !ok {
+			continue
+		}
+		statusIncident := &models.StatusIncident{
+			Incident:  incident,
+			Component: component.Name,
+		}
+		for _, endpoint := range component.Endpoints {
+			if endpoint.ID == incident.EndpointID {
+				statusIncident.ServiceName = endpoint.ServiceName
+			}
+		}
+		page.Incidents = append(page.Incidents, statusIncident)
+	}
+
+	return page
+}
+
+func emptyHistory(since time.Time, days int) []*models.DailyChecks {
+	history := make([]*models.DailyChecks, days)
+	for i := range history {
+		history[i] = &models.DailyChecks{Day: since.AddDate(0, 0, i)}
+	}
+	return history
+}
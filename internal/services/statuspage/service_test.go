@@ -0,0 +1,442 @@
+package statuspage
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vishenosik/CherryWatch/internal/services/models"
+	"github.com/vishenosik/CherryWatch/internal/store/cache/providers/memory"
+	storeModels "github.com/vishenosik/CherryWatch/internal/store/models"
+)
+
+type storeMock struct {
+	Store
+	pages models.StatusPageConfigs
+}
+
+func (sm *storeMock) find(match func(page *models.StatusPageConfig) bool) (*models.StatusPageConfig, error) {
+	for _, page := range sm.pages {
+		if match(page) {
+			return page, nil
+		}
+	}
+	return nil, storeModels.ErrNotFound
+}
+
+func (sm *storeMock) StatusPageBySlug(_ context.Context, slug string) (*models.StatusPageConfig, error) {
+	return sm.find(func(page *models.StatusPageConfig) bool { return page.Slug == slug })
+}
+
+func (sm *storeMock) StatusPage(_ context.Context, id string) (*models.StatusPageConfig, error) {
+	return sm.find(func(page *models.StatusPageConfig) bool { return page.ID == id })
+}
+
+func (sm *storeMock) SaveStatusPage(_ context.Context, page *models.StatusPageConfig) error {
+	for i := range sm.pages {
+		if sm.pages[i].ID == page.ID {
+			sm.pages[i] = page
+		}
+	}
+	return nil
+}
+
+func (sm *storeMock) StatusPageByHost(_ context.Context, host string) (*models.StatusPageConfig, error) {
+	return sm.find(func(page *models.StatusPageConfig) bool { return host != "" && page.Host == host })
+}
+
+func (sm *storeMock) StatusPages(ctx context.Context) (models.StatusPageConfigs, error) {
+	workspaceID, _ := models.WorkspaceFrom(ctx)
+	pages := make(models.StatusPageConfigs, 0)
+	for _, page := range sm.pages {
+		if workspaceID == "" || page.WorkspaceID == workspaceID {
+			pages = append(pages, page)
+		}
+	}
+	return pages, nil
+}
+
+type sourcesMock struct {
+	endpoints models.Endpoints
+	// workspace the data was requested in
+	workspace string
+	// number of endpoints listings
+	reads int
+}
+
+func (sm *sourcesMock) Endpoints(ctx context.Context) (models.Endpoints, error) {
+	sm.workspace, _ = models.WorkspaceFrom(ctx)
+	sm.reads++
+	return sm.endpoints, nil
+}
+
+func (sm *sourcesMock) Incidents(context.Context, models.IncidentsFilter) (models.Incidents, error) {
+	return nil, nil
+}
+
+func (sm *sourcesMock) IncidentUpdates(context.Context, []string) ([]*models.IncidentUpdate, error) {
+	return nil, nil
+}
+
+func (sm *sourcesMock) DailyChecks(context.Context, []string, time.Time) ([]*models.DailyChecks, error) {
+	return nil, nil
+}
+
+func (sm *sourcesMock) Maintenances(context.Context, models.MaintenancesFilter) (models.Maintenances, error) {
+	return nil, nil
+}
+
+func Test_StatusPage(t *testing.T) {
+
+	store := &storeMock{pages: models.StatusPageConfigs{
+		{ID: "1", WorkspaceID: "team-a", Slug: "team-a", Title: "Team A", Host: "status.team-a.example", Visibility: models.VisibilityPublic},
+		{ID: "2", WorkspaceID: "team-b", Slug: "team-b", Title: "Team B", Visibility: models.VisibilityPrivate, Token: "secret"},
+		{ID: "3", WorkspaceID: "team-a", Slug: "payments", Title: "Payments", Selector: map[string]string{"team": "payments"}},
+	}}
+
+	sources := &sourcesMock{endpoints: models.Endpoints{
+		{ID: "api", ServiceName: "api"},
+		{ID: "billing", ServiceName: "billing", Labels: map[string]string{"team": "payments"}},
+	}}
+
+	tests := []struct {
+		name          string
+		defaultPage   *models.StatusPageConfig
+		lookup        models.StatusPageLookup
+		wantTitle     string
+		wantWorkspace string
+		wantEndpoints int
+		wantErr       error
+	}{
+		{
+			name:          "by slug",
+			lookup:        models.StatusPageLookup{Slug: "team-a"},
+			wantTitle:     "Team A",
+			wantWorkspace: "team-a",
+			wantEndpoints: 2,
+		},
+		{
+			name:          "by host",
+			lookup:        models.StatusPageLookup{Host: "Status.Team-A.example"},
+			wantTitle:     "Team A",
+			wantWorkspace: "team-a",
+			wantEndpoints: 2,
+		},
+		{
+			name:          "selected endpoints",
+			lookup:        models.StatusPageLookup{Slug: "payments"},
+			wantTitle:     "Payments",
+			wantWorkspace: "team-a",
+			wantEndpoints: 1,
+		},
+		{
+			name:          "default page",
+			defaultPage:   &models.StatusPageConfig{},
+			lookup:        models.StatusPageLookup{Host: "localhost"},
+			wantTitle:     "Status",
+			wantWorkspace: models.DefaultWorkspace,
+			wantEndpoints: 2,
+		},
+		{
+			name:    "unknown host without default page",
+			lookup:  models.StatusPageLookup{Host: "localhost"},
+			wantErr: models.ErrNotFound,
+		},
+		{
+			name:        "unknown slug",
+			defaultPage: &models.StatusPageConfig{},
+			lookup:      models.StatusPageLookup{Slug: "team-c"},
+			wantErr:     models.ErrNotFound,
+		},
+		{
+			name:    "private without token",
+			lookup:  models.StatusPageLookup{Slug: "team-b"},
+			wantErr: models.ErrUnauthenticated,
+		},
+		{
+			name:    "private with wrong token",
+			lookup:  models.StatusPageLookup{Slug: "team-b", Token: "guess"},
+			wantErr: models.ErrUnauthenticated,
+		},
+		{
+			name:          "private with token",
+			lookup:        models.StatusPageLookup{Slug: "team-b", Token: "secret"},
+			wantTitle:     "Team B",
+			wantWorkspace: "team-b",
+			wantEndpoints: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			srv := NewStatusPageService(
+				slog.New(slog.NewTextHandler(io.Discard, nil)),
+				store, sources, sources, sources, sources,
+				Config{Default: tt.defaultPage, Days: 7},
+			)
+
+			page, err := srv.StatusPage(context.Background(), tt.lookup)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.wantTitle, page.Title)
+			assert.Equal(t, tt.wantWorkspace, sources.workspace)
+
+			var endpoints int
+			for _, component := range page.Components {
+				endpoints += len(component.Endpoints)
+			}
+			assert.Equal(t, tt.wantEndpoints, endpoints)
+		})
+	}
+}
+
+func Test_build(t *testing.T) {
+
+	now := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+	since := models.Day(now).AddDate(0, 0, -2)
+
+	endpoints := models.Endpoints{
+		{ID: "api-eu", ServiceName: "api-eu", Labels: map[string]string{models.ComponentLabel: "API"}},
+		{ID: "api-us", ServiceName: "api-us", Labels: map[string]string{models.ComponentLabel: "API"}, Severity: models.SeverityWarning},
+		{ID: "web", ServiceName: "web"},
+		{ID: "db", ServiceName: "db"},
+	}
+
+	incidents := models.Incidents{
+		{ID: "1", EndpointID: "api-us", OpenedAt: now.Add(-time.Hour)},
+	}
+
+	maintenances := models.Maintenances{
+		{ID: "active", Title: "DB upgrade", StartsAt: now.Add(-time.Hour), EndsAt: now.Add(time.Hour), EndpointIDs: []string{"db"}},
+		{ID: "planned", Title: "Network", StartsAt: now.Add(time.Hour), EndsAt: now.Add(2 * time.Hour)},
+	}
+
+	hidden := &models.Maintenance{ID: "other", Title: "Queue", StartsAt: now, EndsAt: now.Add(time.Hour), EndpointIDs: []string{"queue"}}
+
+	history := []*models.DailyChecks{
+		{EndpointID: "api-eu", Day: since, Checks: 10, Failures: 1},
+		{EndpointID: "api-us", Day: since, Checks: 10},
+		{EndpointID: "api-eu", Day: models.Day(now), Checks: 5},
+		// older than the page history
+		{EndpointID: "web", Day: since.AddDate(0, 0, -1), Checks: 5},
+	}
+
+	page := build(&models.StatusPageConfig{Title: "Status"}, 3, now, since, endpoints, incidents, append(maintenances, hidden), history)
+
+	assert.Equal(t, models.StatusDegraded, page.Status)
+	assert.Equal(t, maintenances, page.Maintenances)
+
+	require.Len(t, page.Components, 3)
+
+	api, db, web := page.Components[0], page.Components[1], page.Components[2]
+
+	assert.Equal(t, "API", api.Name)
+	assert.Equal(t, models.StatusDegraded, api.Status)
+	assert.Len(t, api.Endpoints, 2)
+	require.Len(t, api.History, 3)
+	assert.EqualValues(t, 20, api.History[0].Checks)
+	assert.EqualValues(t, 1, api.History[0].Failures)
+	assert.EqualValues(t, 0, api.History[1].Checks)
+	assert.EqualValues(t, 5, api.History[2].Checks)
+	assert.EqualValues(t, 25, api.Total.Checks)
+
+	assert.Equal(t, "db", db.Name)
+	assert.Equal(t, models.StatusMaintenance, db.Status)
+
+	assert.Equal(t, "web", web.Name)
+	assert.Equal(t, models.StatusOperational, web.Status)
+	assert.EqualValues(t, 0, web.Total.Checks)
+
+	require.Len(t, page.Incidents, 1)
+	assert.Equal(t, "API", page.Incidents[0].Component)
+	assert.Equal(t, "api-us", page.Incidents[0].ServiceName)
+}
+
+func Test_attachUpdates(t *testing.T) {
+
+	page := &models.StatusPage{Incidents: []*models.StatusIncident{
+		{Incident: &models.Incident{ID: "1"}},
+		{Incident: &models.Incident{ID: "2"}},
+	}}
+
+	attachUpdates(page, []*models.IncidentUpdate{
+		{ID: "a", IncidentID: "1"},
+		{ID: "b", IncidentID: "2"},
+		{ID: "c", IncidentID: "1"},
+		{ID: "d", IncidentID: "3"},
+	})
+
+	require.Len(t, page.Incidents[0].Updates, 2)
+	assert.Equal(t, "c", page.Incidents[0].Updates[0].ID)
+	assert.Equal(t, "a", page.Incidents[0].Updates[1].ID)
+	require.Len(t, page.Incidents[1].Updates, 1)
+	assert.Equal(t, "b", page.Incidents[1].Updates[0].ID)
+}
+
+func Test_EndpointStatus(t *testing.T) {
+
+	incident := &models.Incident{ID: "1"}
+
+	tests := []struct {
+		name          string
+		severity      models.Severity
+		incident      *models.Incident
+		inMaintenance bool
+		want          models.Status
+	}{
+		{name: "healthy", want: models.StatusOperational},
+		{name: "critical failure", incident: incident, want: models.StatusOutage},
+		{name: "warning failure", severity: models.SeverityWarning, incident: incident, want: models.StatusDegraded},
+		{name: "failure in maintenance", incident: incident, inMaintenance: true, want: models.StatusMaintenance},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			endpoint := &models.Endpoint{ID: "1", Severity: tt.severity}
+			assert.Equal(t, tt.want, models.EndpointStatus(endpoint, tt.incident, tt.inMaintenance))
+		})
+	}
+}
+
+func Test_StatusPage_cache(t *testing.T) {
+
+	ctx := context.Background()
+
+	store := &storeMock{pages: models.StatusPageConfigs{
+		{ID: "1", WorkspaceID: "team-a", Slug: "team-a", Title: "Team A", Visibility: models.VisibilityPublic},
+		{ID: "2", WorkspaceID: "team-b", Slug: "team-b", Title: "Team B", Visibility: models.VisibilityPrivate, Token: "secret"},
+	}}
+
+	sources := &sourcesMock{endpoints: models.Endpoints{{ID: "api", ServiceName: "api"}}}
+
+	srv := NewStatusPageService(
+		slog.New(slog.NewTextHandler(io.Discard, nil)),
+		store, sources, sources, sources, sources,
+		Config{Days: 7},
+		WithCache(memory.NewMemoryCache(0), time.Minute),
+	)
+
+	first, err := srv.StatusPage(ctx, models.StatusPageLookup{Slug: "team-a"})
+	require.NoError(t, err)
+
+	cached, err := srv.StatusPage(ctx, models.StatusPageLookup{Slug: "team-a"})
+	require.NoError(t, err)
+	// cached copies have no monotonic clock reading
+	first.UpdatedAt = first.UpdatedAt.Round(0)
+	assert.Equal(t, first, cached)
+	assert.Equal(t, 1, sources.reads)
+
+	// pages are cached separately and access is checked before the cache is looked up
+	_, err = srv.StatusPage(ctx, models.StatusPageLookup{Slug: "team-b", Token: "secret"})
+	require.NoError(t, err)
+	assert.Equal(t, 2, sources.reads)
+
+	_, err = srv.StatusPage(ctx, models.StatusPageLookup{Slug: "team-b"})
+	assert.ErrorIs(t, err, models.ErrUnauthenticated)
+
+	// changed pages are built again
+	_, err = srv.UpdateStatusPage(ctx, &models.StatusPageConfig{ID: "1", Slug: "team-a", Title: "Renamed", Visibility: models.VisibilityPublic}, false)
+	require.NoError(t, err)
+
+	page, err := srv.StatusPage(ctx, models.StatusPageLookup{Slug: "team-a"})
+	require.NoError(t, err)
+	assert.Equal(t, "Renamed", page.Title)
+	assert.Equal(t, 3, sources.reads)
+}
+
+func Test_StatusPage_cacheEncoding(t *testing.T) {
+
+	now := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+	since := models.Day(now).AddDate(0, 0, -2)
+
+	page := build(
+		&models.StatusPageConfig{Slug: "status", Title: "Status"},
+		3, now, since,
+		models.Endpoints{{ID: "api", ServiceName: "api"}, {ID: "db", ServiceName: "db"}},
+		models.Incidents{{ID: "1", EndpointID: "api", OpenedAt: now.Add(-time.Hour)}},
+		models.Maintenances{{ID: "1", Title: "upgrade", StartsAt: now, EndsAt: now.Add(time.Hour), EndpointIDs: []string{"db"}}},
+		[]*models.DailyChecks{{EndpointID: "api", Day: since, Checks: 10, Failures: 1, Latency: time.Second}},
+	)
+	attachUpdates(page, []*models.IncidentUpdate{{ID: "u1", IncidentID: "1", State: models.IncidentIdentified, Body: "text", CreatedAt: now}})
+
+	data, err := storeModels.EncodeCacheValue(page)
+	require.NoError(t, err)
+
+	decoded := &models.StatusPage{}
+	require.NoError(t, storeModels.DecodeCacheValue(string(data), decoded))
+	assert.Equal(t, page, decoded)
+}
+
+func Test_Published(t *testing.T) {
+
+	store := &storeMock{pages: models.StatusPageConfigs{
+		{ID: "1", WorkspaceID: "team-a", Slug: "team-a", Visibility: models.VisibilityPublic, Selector: map[string]string{"public": "yes"}},
+		{ID: "2", WorkspaceID: "team-a", Slug: "internal", Visibility: models.VisibilityPrivate},
+		{ID: "3", WorkspaceID: "team-b", Slug: "team-b", Visibility: models.VisibilityPublic},
+	}}
+
+	endpoints := models.Endpoints{
+		{ID: "web", WorkspaceID: "team-a", Labels: map[string]string{"public": "yes"}},
+		{ID: "billing", WorkspaceID: "team-a"},
+		{ID: "api", WorkspaceID: "team-b"},
+		{ID: "db", WorkspaceID: models.DefaultWorkspace},
+	}
+
+	tests := []struct {
+		name        string
+		defaultPage *models.StatusPageConfig
+		workspaceID string
+		want        []string
+	}{
+		{
+			name:        "shown on a public page",
+			workspaceID: "team-a",
+			want:        []string{"web"},
+		},
+		{
+			name:        "pages of another workspace",
+			workspaceID: "team-b",
+			want:        []string{"api"},
+		},
+		{
+			name:        "no pages",
+			workspaceID: models.DefaultWorkspace,
+			want:        []string{},
+		},
+		{
+			name:        "default page",
+			defaultPage: &models.StatusPageConfig{Title: "Status"},
+			workspaceID: models.DefaultWorkspace,
+			want:        []string{"db"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			srv := NewStatusPageService(
+				slog.New(slog.NewTextHandler(io.Discard, nil)),
+				store, nil, nil, nil, nil,
+				Config{Default: tt.defaultPage},
+			)
+
+			published, err := srv.Published(context.Background(), tt.workspaceID, endpoints)
+			require.NoError(t, err)
+
+			ids := make([]string, 0, len(published))
+			for _, endpoint := range published {
+				ids = append(ids, endpoint.ID)
+			}
+			assert.Equal(t, tt.want, ids)
+		})
+	}
+}
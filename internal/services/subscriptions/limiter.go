@@ -0,0 +1,49 @@
+package subscriptions
+
+import (
+	"sync"
+	"time"
+)
+
+// limiter counts requests by key within fixed windows.
+type limiter struct {
+	limit  int
+	window time.Duration
+
+	mu     sync.Mutex
+	start  time.Time
+	counts map[string]int
+}
+
+func newLimiter(limit int, window time.Duration) *limiter {
+	return &limiter{
+		limit:  limit,
+		window: window,
+		counts: make(map[string]int),
+	}
+}
+
+// allow counts the request of the key and reports if it's within the limit,
+// zero limit allows every request. Counts are dropped once the window passes.
+func (lim *limiter) allow(key string, now time.Time) bool {
+
+	if lim.limit <= 0 {
+		return true
+	}
+
+	lim.mu.Lock()
+	defer lim.mu.Unlock()
+
+	if now.Sub(lim.start) >= lim.window {
+		lim.start = now
+		clear(lim.counts)
+	}
+
+	if lim.counts[key] >= lim.limit {
+		return false
+	}
+
+	lim.counts[key]++
+
+	return true
+}
@@ -0,0 +1,145 @@
+package subscriptions
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/vishenosik/CherryWatch/internal/services/models"
+)
+
+// payload is the data posted to webhook subscribers.
+type payload struct {
+	// One of: subscription_confirmation, incident_opened, incident_updated, incident_resolved
+	Type      string `json:"type"`
+	Page      string `json:"page"`
+	Component string `json:"component,omitempty"`
+	Status    string `json:"status,omitempty"`
+	// Announced incident state and message in markdown (updates only)
+	State   string    `json:"state,omitempty"`
+	Body    string    `json:"body,omitempty"`
+	Time    time.Time `json:"time"`
+	PageURL string    `json:"page_url"`
+	// Link confirming the subscription (confirmation only)
+	ConfirmURL     string `json:"confirm_url,omitempty"`
+	UnsubscribeURL string `json:"unsubscribe_url"`
+}
+
+func (srv *Service) confirmationMessage(page *models.StatusPageConfig, sub *models.Subscription) *models.Message {
+
+	confirm := srv.subscriptionLink(page, sub, "confirm")
+	unsubscribe := srv.subscriptionLink(page, sub, "unsubscribe")
+
+	return &models.Message{
+		Kind:    sub.Kind,
+		To:      sub.Target,
+		Subject: fmt.Sprintf("Confirm your subscription to %s", page.Title),
+		Text: fmt.Sprintf(
+			"You asked to receive incident updates of %s.\n\n"+
+				"Confirm the subscription: %s\n\n"+
+				"If you did not subscribe, ignore this message or cancel it: %s",
+			page.Title, confirm, unsubscribe,
+		),
+		Data: payload{
+			Type:           "subscription_confirmation",
+			Page:           page.Slug,
+			Time:           sub.CreatedAt.UTC(),
+			PageURL:        srv.pageLink(page),
+			ConfirmURL:     confirm,
+			UnsubscribeURL: unsubscribe,
+		},
+	}
+}
+
+// incidentMessage tells about an incident transition, failure causes are not shared.
+func (srv *Service) incidentMessage(
+	page *models.StatusPageConfig,
+	sub *models.Subscription,
+	endpoint *models.Endpoint,
+	event *models.Event,
+) *models.Message {
+
+	component := models.ComponentName(endpoint)
+
+	status := models.StatusOperational
+	summary := fmt.Sprintf("%s is operational again.", component)
+
+	if event.Kind == models.EventIncidentOpened {
+		status = models.EndpointStatus(endpoint, event.Incident, false)
+		summary = fmt.Sprintf("%s is experiencing %s since %s.", component, statusPhrase(status), event.Time.UTC().Format(time.RFC1123))
+	}
+
+	unsubscribe := srv.subscriptionLink(page, sub, "unsubscribe")
+
+	return &models.Message{
+		Kind:    sub.Kind,
+		To:      sub.Target,
+		Subject: fmt.Sprintf("[%s] %s: %s", page.Title, component, status),
+		Text: fmt.Sprintf(
+			"%s\n\nCurrent status: %s\n\nUnsubscribe: %s",
+			summary, srv.pageLink(page), unsubscribe,
+		),
+		Data: payload{
+			Type:           string(event.Kind),
+			Page:           page.Slug,
+			Component:      component,
+			Status:         string(status),
+			Time:           event.Time.UTC(),
+			PageURL:        srv.pageLink(page),
+			UnsubscribeURL: unsubscribe,
+		},
+	}
+}
+
+// updateMessage passes an operator update on, its author is not shared.
+func (srv *Service) updateMessage(
+	page *models.StatusPageConfig,
+	sub *models.Subscription,
+	endpoint *models.Endpoint,
+	update *models.IncidentUpdate,
+) *models.Message {
+
+	component := models.ComponentName(endpoint)
+	unsubscribe := srv.subscriptionLink(page, sub, "unsubscribe")
+
+	return &models.Message{
+		Kind:    sub.Kind,
+		To:      sub.Target,
+		Subject: fmt.Sprintf("[%s] %s: %s", page.Title, component, update.State),
+		Text: fmt.Sprintf(
+			"Update on the %s incident (%s):\n\n%s\n\nCurrent status: %s\n\nUnsubscribe: %s",
+			component, update.State, update.Body, srv.pageLink(page), unsubscribe,
+		),
+		Data: payload{
+			Type:           string(models.EventIncidentUpdated),
+			Page:           page.Slug,
+			Component:      component,
+			State:          string(update.State),
+			Body:           update.Body,
+			Time:           update.CreatedAt.UTC(),
+			PageURL:        srv.pageLink(page),
+			UnsubscribeURL: unsubscribe,
+		},
+	}
+}
+
+func statusPhrase(status models.Status) string {
+	if status == models.StatusOutage {
+		return "an outage"
+	}
+	return "degraded performance"
+}
+
+// pageLink returns the page address, with the token of a private page.
+func (srv *Service) pageLink(page *models.StatusPageConfig) string {
+	link := srv.config.PublicURL + "/status/" + url.PathEscape(page.Slug)
+	if page.Visibility == models.VisibilityPrivate {
+		link += "?" + url.Values{"token": {page.Token}}.Encode()
+	}
+	return link
+}
+
+func (srv *Service) subscriptionLink(page *models.StatusPageConfig, sub *models.Subscription, action string) string {
+	return srv.config.PublicURL + "/status/" + url.PathEscape(page.Slug) + "/subscriptions/" + action +
+		"?" + url.Values{"token": {sub.Token}}.Encode()
+}
@@ -0,0 +1,426 @@
+package subscriptions
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"github.com/vishenosik/CherryWatch/internal/services/events"
+	"github.com/vishenosik/CherryWatch/internal/services/models"
+	storeModels "github.com/vishenosik/CherryWatch/internal/store/models"
+	attrs "github.com/vishenosik/web-tools/log"
+	"github.com/vishenosik/web-tools/operation"
+)
+
+type Store interface {
+	SaveSubscription(ctx context.Context, sub *models.Subscription) error
+	SubscriptionByToken(ctx context.Context, token string) (*models.Subscription, error)
+	Subscriptions(ctx context.Context, pageID string) (models.Subscriptions, error)
+	ConfirmSubscription(ctx context.Context, id string, at time.Time) error
+	DeleteSubscription(ctx context.Context, id string) error
+}
+
+type Pages interface {
+	Page(ctx context.Context, lookup models.StatusPageLookup) (*models.StatusPageConfig, error)
+	StatusPageConfigs(ctx context.Context) (models.StatusPageConfigs, error)
+}
+
+type Endpoints interface {
+	Endpoint(ctx context.Context, id string) (*models.Endpoint, error)
+}
+
+type Notifier interface {
+	Supports(kind models.ChannelKind) bool
+	Verify(ctx context.Context, kind models.ChannelKind, to string) error
+	Notify(ctx context.Context, msg *models.Message) error
+}
+
+type Events interface {
+	Subscribe(filter models.EventsFilter) *events.Subscription
+}
+
+// window subscribe requests are limited within
+const limitWindow = time.Hour
+
+const (
+	// messages waiting to be sent, more are dropped
+	queueSize = 1024
+	// messages sent at once
+	senders = 4
+	// time a message is given to be sent
+	sendTimeout = 30 * time.Second
+)
+
+// incident events sent to subscribers
+var incidentEvents = []models.EventKind{
+	models.EventIncidentOpened,
+	models.EventIncidentResolved,
+	models.EventIncidentUpdated,
+}
+
+type Config struct {
+	// External address of the server, prefixes links sent to subscribers
+	PublicURL string
+	// Subscribe requests accepted per page an hour, unlimited if zero
+	PageLimit int
+	// Subscribe requests accepted per client address an hour, unlimited if zero
+	ClientLimit int
+}
+
+// Service manages status page subscriptions and sends incident updates to subscribers.
+type Service struct {
+	log       *slog.Logger
+	store     Store
+	pages     Pages
+	endpoints Endpoints
+	notifier  Notifier
+	sub       *events.Subscription
+	queue     chan *models.Message
+	config    Config
+
+	pageLimit   *limiter
+	clientLimit *limiter
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+func NewSubscriptionsService(
+	log *slog.Logger,
+	store Store,
+	pages Pages,
+	endpoints Endpoints,
+	notifier Notifier,
+	events Events,
+	config Config,
+) *Service {
+
+	config.PublicURL = strings.TrimSuffix(config.PublicURL, "/")
+
+	return &Service{
+		log:       log.With(slog.String("component", "subscriptions")),
+		store:     store,
+		pages:     pages,
+		endpoints: endpoints,
+		notifier:  notifier,
+		// subscribed right away not to miss events published before Run
+		sub:         events.Subscribe(models.EventsFilter{Kinds: incidentEvents}),
+		queue:       make(chan *models.Message, queueSize),
+		config:      config,
+		pageLimit:   newLimiter(config.PageLimit, limitWindow),
+		clientLimit: newLimiter(config.ClientLimit, limitWindow),
+		stop:        make(chan struct{}),
+		done:        make(chan struct{}),
+	}
+}
+
+// Subscribe stores an unconfirmed subscription to the looked up page
+// and sends the recipient a confirmation link. Requests are limited
+// per page and per client address, as the route is public.
+func (srv *Service) Subscribe(
+	ctx context.Context,
+	lookup models.StatusPageLookup,
+	sub *models.Subscription,
+	client string,
+) (*models.Subscription, error) {
+
+	op := operation.ServicesOperation("subscriptions", "Subscribe")
+
+	now := time.Now()
+
+	if !srv.clientLimit.allow(client, now) {
+		return nil, errors.Wrap(models.ErrTooManySubscriptions, op)
+	}
+
+	page, err := srv.pages.Page(ctx, lookup)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	// the default page is configured by the environment and can't be subscribed to
+	if page.ID == "" {
+		return nil, errors.Wrap(models.ErrNotFound, op)
+	}
+
+	if !srv.pageLimit.allow(page.ID, now) {
+		return nil, errors.Wrap(models.ErrTooManySubscriptions, op)
+	}
+
+	if err := sub.Validate(); err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	if !srv.notifier.Supports(sub.Kind) {
+		return nil, errors.Wrap(errors.Wrap(models.ErrUnsupportedChannel, string(sub.Kind)), op)
+	}
+
+	// webhooks of outsiders must not reach internal services
+	if err := srv.notifier.Verify(ctx, sub.Kind, sub.Target); err != nil {
+		if errors.Is(err, models.ErrForbiddenRecipient) {
+			return nil, errors.Wrap(errors.Wrap(models.ErrInvalidSubscription, "target address is not allowed"), op)
+		}
+		return nil, errors.Wrap(err, op)
+	}
+
+	token, err := newToken()
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	sub.ID = uuid.NewString()
+	sub.PageID = page.ID
+	sub.WorkspaceID = page.WorkspaceID
+	sub.Token = token
+	sub.ConfirmedAt = time.Time{}
+	sub.CreatedAt = now
+
+	if err := srv.store.SaveSubscription(ctx, sub); err != nil {
+		if errors.Is(err, storeModels.ErrAlreadyExists) {
+			return nil, errors.Wrap(models.ErrSubscriptionExists, op)
+		}
+		return nil, errors.Wrap(err, op)
+	}
+
+	if err := srv.notifier.Notify(ctx, srv.confirmationMessage(page, sub)); err != nil {
+		// the recipient can't confirm, so that subscribing again is allowed
+		if err := srv.store.DeleteSubscription(ctx, sub.ID); err != nil {
+			srv.log.Error("failed to delete unconfirmable subscription", attrs.Error(err))
+		}
+		return nil, errors.Wrap(err, op)
+	}
+
+	srv.log.Info("subscription created",
+		slog.String("page_id", page.ID),
+		slog.String("type", string(sub.Kind)),
+	)
+
+	return sub, nil
+}
+
+// Confirm confirms the subscription of the link token, confirming it again is a no-op.
+func (srv *Service) Confirm(ctx context.Context, token string) (*models.Subscription, error) {
+
+	op := operation.ServicesOperation("subscriptions", "Confirm")
+
+	sub, err := srv.byToken(ctx, token)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	if !sub.Confirmed() {
+		sub.ConfirmedAt = time.Now()
+		if err := srv.store.ConfirmSubscription(ctx, sub.ID, sub.ConfirmedAt); err != nil {
+			if errors.Is(err, storeModels.ErrNotFound) {
+				return nil, errors.Wrap(models.ErrNotFound, op)
+			}
+			return nil, errors.Wrap(err, op)
+		}
+	}
+
+	return sub, nil
+}
+
+// Unsubscribe deletes the subscription of the link token.
+func (srv *Service) Unsubscribe(ctx context.Context, token string) error {
+
+	op := operation.ServicesOperation("subscriptions", "Unsubscribe")
+
+	sub, err := srv.byToken(ctx, token)
+	if err != nil {
+		return errors.Wrap(err, op)
+	}
+
+	if err := srv.store.DeleteSubscription(ctx, sub.ID); err != nil {
+		if errors.Is(err, storeModels.ErrNotFound) {
+			return errors.Wrap(models.ErrNotFound, op)
+		}
+		return errors.Wrap(err, op)
+	}
+
+	srv.log.Info("subscription deleted", slog.String("page_id", sub.PageID))
+
+	return nil
+}
+
+// MustRun sends incident updates to subscribers and blocks until Stop is called.
+func (srv *Service) MustRun() {
+	if err := srv.Run(); err != nil {
+		panic(err)
+	}
+}
+
+func (srv *Service) Run() error {
+
+	defer close(srv.done)
+	defer srv.sub.Close()
+
+	// slow recipients hold up senders, not the events subscription
+	var wg sync.WaitGroup
+	for range senders {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for msg := range srv.queue {
+				srv.send(msg)
+			}
+		}()
+	}
+	defer wg.Wait()
+	// queued messages are sent before Run returns
+	defer close(srv.queue)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go func() {
+		select {
+		case <-srv.stop:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	for {
+		event := srv.sub.Next(ctx)
+		if event == nil {
+			return nil
+		}
+		srv.handle(ctx, event)
+	}
+}
+
+// Stop stops sending updates.
+func (srv *Service) Stop(ctx context.Context) {
+
+	srv.log.Info("stopping subscriptions")
+
+	close(srv.stop)
+
+	select {
+	case <-srv.done:
+	case <-ctx.Done():
+	}
+}
+
+// handle sends incident transitions to subscribers of the pages showing the endpoint.
+func (srv *Service) handle(ctx context.Context, event *models.Event) {
+
+	switch event.Kind {
+	case models.EventLagged:
+		srv.log.Warn("subscribers missed incident events, events dropped", slog.Uint64("dropped", event.Dropped))
+		return
+	case models.EventIncidentOpened, models.EventIncidentResolved:
+	case models.EventIncidentUpdated:
+		if event.Update == nil {
+			return
+		}
+	default:
+		return
+	}
+
+	endpoint, err := srv.endpoints.Endpoint(models.WithAllWorkspaces(ctx), event.EndpointID)
+	if err != nil {
+		if !errors.Is(err, models.ErrNotFound) {
+			srv.log.Error("failed to get incident endpoint", attrs.Error(err))
+		}
+		return
+	}
+
+	srv.notify(ctx, endpoint, func(page *models.StatusPageConfig, sub *models.Subscription) *models.Message {
+		if event.Kind == models.EventIncidentUpdated {
+			return srv.updateMessage(page, sub, endpoint, event.Update)
+		}
+		return srv.incidentMessage(page, sub, endpoint, event)
+	})
+}
+
+// notify queues messages to confirmed subscribers of the endpoint component
+// on the pages showing the endpoint.
+func (srv *Service) notify(
+	ctx context.Context,
+	endpoint *models.Endpoint,
+	message func(page *models.StatusPageConfig, sub *models.Subscription) *models.Message,
+) {
+
+	pages, err := srv.pages.StatusPageConfigs(models.WithWorkspace(ctx, endpoint.WorkspaceID))
+	if err != nil {
+		srv.log.Error("failed to get status pages", attrs.Error(err))
+		return
+	}
+
+	component := models.ComponentName(endpoint)
+
+	for _, page := range pages {
+
+		if !page.Shows(endpoint) {
+			continue
+		}
+
+		subs, err := srv.store.Subscriptions(ctx, page.ID)
+		if err != nil {
+			srv.log.Error("failed to get subscriptions", attrs.Error(err))
+			continue
+		}
+
+		for _, sub := range subs {
+			if !sub.Wants(component) {
+				continue
+			}
+			srv.enqueue(message(page, sub))
+		}
+	}
+}
+
+// enqueue queues the message to be sent, dropping it if the queue is full.
+func (srv *Service) enqueue(msg *models.Message) {
+	select {
+	case srv.queue <- msg:
+	default:
+		srv.log.Warn("notifications queue is full, message dropped", slog.String("type", string(msg.Kind)))
+	}
+}
+
+// send sends the message within sendTimeout.
+func (srv *Service) send(msg *models.Message) {
+
+	ctx, cancel := context.WithTimeout(context.Background(), sendTimeout)
+	defer cancel()
+
+	if err := srv.notifier.Notify(ctx, msg); err != nil {
+		srv.log.Warn("failed to notify subscriber",
+			slog.String("type", string(msg.Kind)),
+			attrs.Error(err),
+		)
+	}
+}
+
+func (srv *Service) byToken(ctx context.Context, token string) (*models.Subscription, error) {
+
+	if token == "" {
+		return nil, models.ErrNotFound
+	}
+
+	sub, err := srv.store.SubscriptionByToken(ctx, token)
+	if err != nil {
+		if errors.Is(err, storeModels.ErrNotFound) {
+			return nil, models.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return sub, nil
+}
+
+func newToken() (string, error) {
+	token := make([]byte, 24)
+	if _, err := rand.Read(token); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(token), nil
+}
@@ -0,0 +1,416 @@
+package subscriptions
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vishenosik/CherryWatch/internal/services/events"
+	"github.com/vishenosik/CherryWatch/internal/services/models"
+	"github.com/vishenosik/CherryWatch/internal/services/notifier"
+	storeModels "github.com/vishenosik/CherryWatch/internal/store/models"
+)
+
+type storeMock struct {
+	subs map[string]*models.Subscription
+}
+
+func (sm *storeMock) SaveSubscription(_ context.Context, sub *models.Subscription) error {
+	for _, existing := range sm.subs {
+		if existing.PageID == sub.PageID && existing.Kind == sub.Kind && existing.Target == sub.Target {
+			return storeModels.ErrAlreadyExists
+		}
+	}
+	saved := *sub
+	sm.subs[sub.ID] = &saved
+	return nil
+}
+
+func (sm *storeMock) SubscriptionByToken(_ context.Context, token string) (*models.Subscription, error) {
+	for _, sub := range sm.subs {
+		if sub.Token == token {
+			found := *sub
+			return &found, nil
+		}
+	}
+	return nil, storeModels.ErrNotFound
+}
+
+func (sm *storeMock) Subscriptions(_ context.Context, pageID string) (models.Subscriptions, error) {
+	subs := make(models.Subscriptions, 0)
+	for _, sub := range sm.subs {
+		if sub.PageID == pageID && sub.Confirmed() {
+			subs = append(subs, sub)
+		}
+	}
+	return subs, nil
+}
+
+func (sm *storeMock) ConfirmSubscription(_ context.Context, id string, at time.Time) error {
+	sm.subs[id].ConfirmedAt = at
+	return nil
+}
+
+func (sm *storeMock) DeleteSubscription(_ context.Context, id string) error {
+	if _, ok := sm.subs[id]; !ok {
+		return storeModels.ErrNotFound
+	}
+	delete(sm.subs, id)
+	return nil
+}
+
+type pagesMock struct {
+	pages models.StatusPageConfigs
+}
+
+func (pm *pagesMock) Page(_ context.Context, lookup models.StatusPageLookup) (*models.StatusPageConfig, error) {
+	for _, page := range pm.pages {
+		if page.Slug == lookup.Slug {
+			return page, nil
+		}
+	}
+	return nil, models.ErrNotFound
+}
+
+func (pm *pagesMock) StatusPageConfigs(context.Context) (models.StatusPageConfigs, error) {
+	return pm.pages, nil
+}
+
+type endpointsMock struct {
+	endpoints models.Endpoints
+}
+
+func (em *endpointsMock) Endpoint(_ context.Context, id string) (*models.Endpoint, error) {
+	for _, endpoint := range em.endpoints {
+		if endpoint.ID == id {
+			return endpoint, nil
+		}
+	}
+	return nil, models.ErrNotFound
+}
+
+type notifierMock struct {
+	sent []*models.Message
+	fail bool
+}
+
+func (nm *notifierMock) Supports(kind models.ChannelKind) bool {
+	return kind == models.ChannelWebhook
+}
+
+func (nm *notifierMock) Verify(context.Context, models.ChannelKind, string) error {
+	return nil
+}
+
+func (nm *notifierMock) Notify(_ context.Context, msg *models.Message) error {
+	if nm.fail {
+		return errors.New("connection refused")
+	}
+	nm.sent = append(nm.sent, msg)
+	return nil
+}
+
+// chanNotifier passes messages to the channel, safe to be used by senders.
+type chanNotifier struct {
+	notifierMock
+	sent chan *models.Message
+}
+
+func (cn *chanNotifier) Notify(_ context.Context, msg *models.Message) error {
+	cn.sent <- msg
+	return nil
+}
+
+func newService(notifier Notifier) (*Service, *storeMock) {
+	return newLimitedService(notifier, 0, 0)
+}
+
+func newLimitedService(notifier Notifier, pageLimit, clientLimit int) (*Service, *storeMock) {
+	return newBusService(notifier, events.NewBus(1), pageLimit, clientLimit)
+}
+
+func newBusService(notifier Notifier, bus Events, pageLimit, clientLimit int) (*Service, *storeMock) {
+
+	store := &storeMock{subs: make(map[string]*models.Subscription)}
+
+	pages := &pagesMock{pages: models.StatusPageConfigs{
+		{ID: "page-a", WorkspaceID: models.DefaultWorkspace, Slug: "team-a", Title: "Team A"},
+		{ID: "page-b", WorkspaceID: models.DefaultWorkspace, Slug: "team-b", Title: "Team B", EndpointIDs: []string{"web"}},
+	}}
+
+	endpoints := &endpointsMock{endpoints: models.Endpoints{
+		{ID: "api", WorkspaceID: models.DefaultWorkspace, ServiceName: "api", Labels: map[string]string{models.ComponentLabel: "API"}},
+	}}
+
+	srv := NewSubscriptionsService(
+		slog.New(slog.NewTextHandler(io.Discard, nil)),
+		store, pages, endpoints, notifier, bus,
+		Config{PublicURL: "https://status.example.com/", PageLimit: pageLimit, ClientLimit: clientLimit},
+	)
+
+	return srv, store
+}
+
+func Test_Subscribe(t *testing.T) {
+
+	ctx := context.Background()
+
+	t.Run("double opt-in", func(t *testing.T) {
+
+		notifier := &notifierMock{}
+		srv, _ := newService(notifier)
+
+		sub, err := srv.Subscribe(ctx, models.StatusPageLookup{Slug: "team-a"}, &models.Subscription{
+			Kind:   models.ChannelWebhook,
+			Target: "https://hooks.example.com/status",
+		}, "198.51.100.1")
+		require.NoError(t, err)
+		assert.Equal(t, "page-a", sub.PageID)
+		assert.False(t, sub.Confirmed())
+
+		require.Len(t, notifier.sent, 1)
+		data := notifier.sent[0].Data.(payload)
+		assert.Equal(t, "subscription_confirmation", data.Type)
+		assert.Equal(t, "https://status.example.com/status/team-a/subscriptions/confirm?token="+sub.Token, data.ConfirmURL)
+
+		_, err = srv.Subscribe(ctx, models.StatusPageLookup{Slug: "team-a"}, &models.Subscription{
+			Kind:   models.ChannelWebhook,
+			Target: "https://hooks.example.com/status",
+		}, "198.51.100.1")
+		assert.ErrorIs(t, err, models.ErrSubscriptionExists)
+
+		confirmed, err := srv.Confirm(ctx, sub.Token)
+		require.NoError(t, err)
+		assert.True(t, confirmed.Confirmed())
+
+		require.NoError(t, srv.Unsubscribe(ctx, sub.Token))
+		assert.ErrorIs(t, srv.Unsubscribe(ctx, sub.Token), models.ErrNotFound)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+
+		srv, _ := newService(&notifierMock{})
+
+		_, err := srv.Subscribe(ctx, models.StatusPageLookup{Slug: "team-a"}, &models.Subscription{
+			Kind:   models.ChannelWebhook,
+			Target: "ftp://hooks.example.com",
+		}, "198.51.100.1")
+		assert.ErrorIs(t, err, models.ErrInvalidSubscription)
+
+		_, err = srv.Subscribe(ctx, models.StatusPageLookup{Slug: "team-a"}, &models.Subscription{
+			Kind:   models.ChannelEmail,
+			Target: "ops@example.com",
+		}, "198.51.100.1")
+		assert.ErrorIs(t, err, models.ErrUnsupportedChannel)
+
+		_, err = srv.Subscribe(ctx, models.StatusPageLookup{Slug: "team-c"}, &models.Subscription{
+			Kind:   models.ChannelWebhook,
+			Target: "https://hooks.example.com/status",
+		}, "198.51.100.1")
+		assert.ErrorIs(t, err, models.ErrNotFound)
+	})
+
+	t.Run("internal webhook refused", func(t *testing.T) {
+
+		webhooks := notifier.NewNotifier(
+			slog.New(slog.NewTextHandler(io.Discard, nil)),
+			notifier.WithSender(models.ChannelWebhook, notifier.NewWebhookSender(time.Second, false)),
+		)
+		srv, store := newService(webhooks)
+
+		for _, target := range []string{
+			"http://127.0.0.1:8080/api/v1/endpoints",
+			"http://localhost/hook",
+			"http://169.254.169.254/latest/meta-data",
+			"http://10.0.0.1/hook",
+		} {
+			_, err := srv.Subscribe(ctx, models.StatusPageLookup{Slug: "team-a"}, &models.Subscription{
+				Kind:   models.ChannelWebhook,
+				Target: target,
+			}, "198.51.100.1")
+			assert.ErrorIs(t, err, models.ErrInvalidSubscription, target)
+		}
+
+		assert.Empty(t, store.subs)
+	})
+
+	t.Run("rate limited", func(t *testing.T) {
+
+		subscribe := func(srv *Service, slug, client string) error {
+			_, err := srv.Subscribe(ctx, models.StatusPageLookup{Slug: slug}, &models.Subscription{
+				Kind:   models.ChannelWebhook,
+				Target: "https://hooks.example.com/" + uuid.NewString(),
+			}, client)
+			return err
+		}
+
+		srv, _ := newLimitedService(&notifierMock{}, 0, 2)
+		require.NoError(t, subscribe(srv, "team-a", "198.51.100.1"))
+		require.NoError(t, subscribe(srv, "team-b", "198.51.100.1"))
+		assert.ErrorIs(t, subscribe(srv, "team-a", "198.51.100.1"), models.ErrTooManySubscriptions)
+		assert.NoError(t, subscribe(srv, "team-a", "198.51.100.2"))
+
+		srv, _ = newLimitedService(&notifierMock{}, 2, 0)
+		require.NoError(t, subscribe(srv, "team-a", "198.51.100.1"))
+		require.NoError(t, subscribe(srv, "team-a", "198.51.100.2"))
+		assert.ErrorIs(t, subscribe(srv, "team-a", "198.51.100.3"), models.ErrTooManySubscriptions)
+		assert.NoError(t, subscribe(srv, "team-b", "198.51.100.3"))
+	})
+
+	t.Run("undeliverable confirmation", func(t *testing.T) {
+
+		srv, store := newService(&notifierMock{fail: true})
+
+		_, err := srv.Subscribe(ctx, models.StatusPageLookup{Slug: "team-a"}, &models.Subscription{
+			Kind:   models.ChannelWebhook,
+			Target: "https://hooks.example.com/status",
+		}, "198.51.100.1")
+		assert.Error(t, err)
+		assert.Empty(t, store.subs)
+	})
+}
+
+func Test_handle(t *testing.T) {
+
+	ctx := context.Background()
+	now := time.Now()
+
+	notifier := &notifierMock{}
+	srv, store := newService(notifier)
+
+	subscribe := func(id, pageID string, components []string, confirmed bool) {
+		sub := &models.Subscription{ID: id, PageID: pageID, Kind: models.ChannelWebhook, Target: id, Components: components, Token: id}
+		if confirmed {
+			sub.ConfirmedAt = now
+		}
+		require.NoError(t, store.SaveSubscription(ctx, sub))
+	}
+
+	subscribe("all", "page-a", nil, true)
+	subscribe("api", "page-a", []string{"API"}, true)
+	subscribe("db", "page-a", []string{"DB"}, true)
+	subscribe("unconfirmed", "page-a", nil, false)
+	// the page doesn't show the endpoint
+	subscribe("other-page", "page-b", nil, true)
+
+	srv.handle(ctx, &models.Event{
+		Kind:       models.EventIncidentOpened,
+		EndpointID: "api",
+		Incident:   &models.Incident{ID: "1", EndpointID: "api", Cause: "dial tcp 10.0.0.1:443"},
+		Time:       now,
+	})
+
+	// check results are not sent
+	srv.handle(ctx, &models.Event{Kind: models.EventCheckResult, EndpointID: "api", Time: now})
+	flush(srv)
+
+	recipients := make([]string, 0, len(notifier.sent))
+	for _, msg := range notifier.sent {
+		recipients = append(recipients, msg.To)
+		assert.Equal(t, "[Team A] API: outage", msg.Subject)
+		assert.NotContains(t, msg.Text, "10.0.0.1")
+	}
+	assert.ElementsMatch(t, []string{"all", "api"}, recipients)
+}
+
+func Test_handle_update(t *testing.T) {
+
+	ctx := context.Background()
+	now := time.Now()
+
+	notifier := &notifierMock{}
+	srv, store := newService(notifier)
+
+	require.NoError(t, store.SaveSubscription(ctx, &models.Subscription{
+		ID: "1", PageID: "page-a", Kind: models.ChannelWebhook, Target: "hook", Token: "1", ConfirmedAt: now,
+	}))
+
+	srv.handle(ctx, &models.Event{
+		Kind:       models.EventIncidentUpdated,
+		EndpointID: "api",
+		Incident:   &models.Incident{ID: "1", EndpointID: "api"},
+		Update: &models.IncidentUpdate{
+			IncidentID: "1",
+			State:      models.IncidentIdentified,
+			Body:       "The database is overloaded",
+			Author:     "user:1",
+			CreatedAt:  now,
+		},
+		Time: now,
+	})
+	flush(srv)
+
+	require.Len(t, notifier.sent, 1)
+	msg := notifier.sent[0]
+	assert.Equal(t, "[Team A] API: identified", msg.Subject)
+	assert.Contains(t, msg.Text, "The database is overloaded")
+	assert.NotContains(t, msg.Text, "user:1")
+
+	data, ok := msg.Data.(payload)
+	require.True(t, ok)
+	assert.Equal(t, "incident_updated", data.Type)
+	assert.Equal(t, "identified", data.State)
+}
+
+func Test_Run(t *testing.T) {
+
+	ctx := context.Background()
+	now := time.Now()
+
+	bus := events.NewBus(8)
+	notifier := &chanNotifier{notifierMock{}, make(chan *models.Message, 8)}
+	srv, store := newBusService(notifier, bus, 0, 0)
+
+	require.NoError(t, store.SaveSubscription(ctx, &models.Subscription{
+		ID: "1", PageID: "page-a", Kind: models.ChannelWebhook, Target: "hook", Token: "1", ConfirmedAt: now,
+	}))
+
+	endpoint := &models.Endpoint{ID: "api", WorkspaceID: models.DefaultWorkspace}
+	incident := &models.Incident{ID: "1", EndpointID: "api", OpenedAt: now}
+
+	bus.Publish(models.NewEndpointEvent(models.EventCheckResult, endpoint))
+	opened := models.NewEndpointEvent(models.EventIncidentOpened, endpoint)
+	opened.Incident = incident
+	bus.Publish(opened)
+
+	go srv.MustRun()
+
+	select {
+	case msg := <-notifier.sent:
+		assert.Equal(t, "[Team A] API: outage", msg.Subject)
+	case <-time.After(time.Second):
+		t.Fatal("subscriber not notified")
+	}
+
+	stop, cancel := context.WithTimeout(ctx, time.Second)
+	defer cancel()
+	srv.Stop(stop)
+
+	// check results are filtered out by the subscription
+	assert.Empty(t, notifier.sent)
+}
+
+func Test_enqueue(t *testing.T) {
+
+	srv, _ := newService(&notifierMock{})
+
+	// a full queue drops messages instead of holding up events
+	for range queueSize + 1 {
+		srv.enqueue(&models.Message{Kind: models.ChannelWebhook, To: "hook"})
+	}
+
+	assert.Len(t, srv.queue, queueSize)
+}
+
+// flush sends the queued messages.
+func flush(srv *Service) {
+	for len(srv.queue) > 0 {
+		srv.send(<-srv.queue)
+	}
+}
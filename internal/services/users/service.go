@@ -0,0 +1,280 @@
+package users
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"github.com/vishenosik/CherryWatch/internal/services/models"
+	storeModels "github.com/vishenosik/CherryWatch/internal/store/models"
+	devCol "github.com/vishenosik/CherryWatch/pkg/collections"
+	"github.com/vishenosik/web-tools/operation"
+	"golang.org/x/crypto/bcrypt"
+)
+
+type Store interface {
+	User(ctx context.Context, id string) (*storeModels.User, error)
+	UserByEmail(ctx context.Context, email string) (*storeModels.User, error)
+	Users(ctx context.Context) ([]*storeModels.User, error)
+	SaveUser(ctx context.Context, user *storeModels.User) error
+	DeleteUser(ctx context.Context, id string) error
+}
+
+type Auditor interface {
+	Record(ctx context.Context, change models.Change)
+}
+
+type Service struct {
+	log     *slog.Logger
+	store   Store
+	auditor Auditor
+	// compared against when the user is missing, so that timing doesn't reveal emails
+	dummyHash []byte
+}
+
+type Option func(*Service)
+
+// WithAudit records user changes into the audit log.
+func WithAudit(auditor Auditor) Option {
+	return func(srv *Service) {
+		srv.auditor = auditor
+	}
+}
+
+func NewUsersService(
+	log *slog.Logger,
+	store Store,
+	opts ...Option,
+) *Service {
+
+	dummyHash, _ := bcrypt.GenerateFromPassword([]byte("cherrywatch"), bcrypt.DefaultCost)
+
+	srv := &Service{
+		log:       log,
+		store:     store,
+		dummyHash: dummyHash,
+	}
+
+	for _, opt := range opts {
+		opt(srv)
+	}
+
+	return srv
+}
+
+// CreateUser validates and stores a new user with the password hashed.
+func (srv *Service) CreateUser(ctx context.Context, user *models.User, password string) (*models.User, error) {
+
+	op := operation.ServicesOperation("users", "CreateUser")
+
+	user.ID = uuid.NewString()
+	user.WorkspaceID = models.OwnerWorkspace(ctx, user.WorkspaceID)
+
+	if err := validate(user); err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	if err := models.ValidatePassword(password); err != nil {
+		return nil, errors.Wrap(errors.Wrap(models.ErrInvalidUser, err.Error()), op)
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	if err := srv.save(ctx, user, hash); err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	srv.audit(ctx, models.AuditCreate, user.WorkspaceID, user.ID, nil, &auditState{User: user, Password: password})
+
+	return user, nil
+}
+
+// UpdateUser replaces an existing user. Password is kept if empty.
+func (srv *Service) UpdateUser(ctx context.Context, user *models.User, password string) (*models.User, error) {
+
+	op := operation.ServicesOperation("users", "UpdateUser")
+
+	current, err := srv.store.User(ctx, user.ID)
+	if err != nil {
+		if errors.Is(err, storeModels.ErrNotFound) {
+			return nil, errors.Wrap(models.ErrNotFound, op)
+		}
+		return nil, errors.Wrap(err, op)
+	}
+
+	user.WorkspaceID = current.WorkspaceID
+
+	if err := validate(user); err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	hash := current.PasswordHash
+
+	if password != "" {
+		if err := models.ValidatePassword(password); err != nil {
+			return nil, errors.Wrap(errors.Wrap(models.ErrInvalidUser, err.Error()), op)
+		}
+		if hash, err = bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost); err != nil {
+			return nil, errors.Wrap(err, op)
+		}
+	}
+
+	if err := srv.save(ctx, user, hash); err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	srv.audit(ctx, models.AuditUpdate, user.WorkspaceID, user.ID,
+		&auditState{User: fromStoreUser(current)},
+		&auditState{User: user, Password: password},
+	)
+
+	return user, nil
+}
+
+// User returns a user by id.
+func (srv *Service) User(ctx context.Context, id string) (*models.User, error) {
+
+	op := operation.ServicesOperation("users", "User")
+
+	user, err := srv.store.User(ctx, id)
+	if err != nil {
+		if errors.Is(err, storeModels.ErrNotFound) {
+			return nil, errors.Wrap(models.ErrNotFound, op)
+		}
+		return nil, errors.Wrap(err, op)
+	}
+
+	return fromStoreUser(user), nil
+}
+
+// Users returns all users.
+func (srv *Service) Users(ctx context.Context) (models.Users, error) {
+
+	op := operation.ServicesOperation("users", "Users")
+
+	users, err := srv.store.Users(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	return devCol.ConvertSlice(users, fromStoreUser), nil
+}
+
+// DeleteUser deletes a user by id.
+func (srv *Service) DeleteUser(ctx context.Context, id string) error {
+
+	op := operation.ServicesOperation("users", "DeleteUser")
+
+	current, err := srv.store.User(ctx, id)
+	if err != nil {
+		if errors.Is(err, storeModels.ErrNotFound) {
+			return errors.Wrap(models.ErrNotFound, op)
+		}
+		return errors.Wrap(err, op)
+	}
+
+	if err := srv.store.DeleteUser(ctx, id); err != nil {
+		if errors.Is(err, storeModels.ErrNotFound) {
+			return errors.Wrap(models.ErrNotFound, op)
+		}
+		return errors.Wrap(err, op)
+	}
+
+	srv.audit(ctx, models.AuditDelete, current.WorkspaceID, id, &auditState{User: fromStoreUser(current)}, nil)
+
+	return nil
+}
+
+// Verify returns the user if the password matches.
+func (srv *Service) Verify(ctx context.Context, email, password string) (*models.User, error) {
+
+	op := operation.ServicesOperation("users", "Verify")
+
+	user, err := srv.store.UserByEmail(ctx, email)
+	if err != nil {
+		if errors.Is(err, storeModels.ErrNotFound) {
+			_ = bcrypt.CompareHashAndPassword(srv.dummyHash, []byte(password))
+			return nil, errors.Wrap(models.ErrInvalidCredentials, op)
+		}
+		return nil, errors.Wrap(err, op)
+	}
+
+	if err := bcrypt.CompareHashAndPassword(user.PasswordHash, []byte(password)); err != nil {
+		return nil, errors.Wrap(models.ErrInvalidCredentials, op)
+	}
+
+	return fromStoreUser(user), nil
+}
+
+func (srv *Service) save(ctx context.Context, user *models.User, hash []byte) error {
+
+	err := srv.store.SaveUser(ctx, &storeModels.User{
+		ID:           user.ID,
+		WorkspaceID:  user.WorkspaceID,
+		Nickname:     user.Nickname,
+		Email:        user.Email,
+		PasswordHash: hash,
+		Role:         string(user.Role),
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, storeModels.ErrAlreadyExists):
+			return models.ErrUserExists
+		case errors.Is(err, storeModels.ErrNotFound):
+			return models.ErrNotFound
+		}
+		return err
+	}
+
+	return nil
+}
+
+// auditState is an audited user state, password is redacted by the auditor
+// and only shows up in the diff when it's changed.
+type auditState struct {
+	*models.User
+	Password string `json:",omitempty"`
+}
+
+func (srv *Service) audit(ctx context.Context, action models.AuditAction, workspaceID, id string, before, after *auditState) {
+
+	if srv.auditor == nil {
+		return
+	}
+
+	change := models.Change{
+		WorkspaceID: workspaceID,
+		Action:      action,
+		Resource:    models.AuditUser,
+		ResourceID:  id,
+	}
+	if before != nil {
+		change.Before = before
+	}
+	if after != nil {
+		change.After = after
+	}
+
+	srv.auditor.Record(ctx, change)
+}
+
+func validate(user *models.User) error {
+	if err := user.Validate(); err != nil {
+		return errors.Wrap(models.ErrInvalidUser, err.Error())
+	}
+	return nil
+}
+
+func fromStoreUser(user *storeModels.User) *models.User {
+	return &models.User{
+		ID:          user.ID,
+		WorkspaceID: user.WorkspaceID,
+		Nickname:    user.Nickname,
+		Email:       user.Email,
+		Role:        models.Role(user.Role),
+	}
+}
@@ -0,0 +1,87 @@
+package users
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vishenosik/CherryWatch/internal/services/models"
+	storeModels "github.com/vishenosik/CherryWatch/internal/store/models"
+)
+
+type storeMock struct {
+	users map[string]*storeModels.User
+}
+
+func (sm *storeMock) User(_ context.Context, id string) (*storeModels.User, error) {
+	user, ok := sm.users[id]
+	if !ok {
+		return nil, storeModels.ErrNotFound
+	}
+	return user, nil
+}
+
+func (sm *storeMock) UserByEmail(_ context.Context, email string) (*storeModels.User, error) {
+	for _, user := range sm.users {
+		if user.Email == email {
+			return user, nil
+		}
+	}
+	return nil, storeModels.ErrNotFound
+}
+
+func (sm *storeMock) Users(_ context.Context) ([]*storeModels.User, error) {
+	users := make([]*storeModels.User, 0, len(sm.users))
+	for _, user := range sm.users {
+		users = append(users, user)
+	}
+	return users, nil
+}
+
+func (sm *storeMock) SaveUser(_ context.Context, user *storeModels.User) error {
+	sm.users[user.ID] = user
+	return nil
+}
+
+func (sm *storeMock) DeleteUser(_ context.Context, id string) error {
+	if _, ok := sm.users[id]; !ok {
+		return storeModels.ErrNotFound
+	}
+	delete(sm.users, id)
+	return nil
+}
+
+func Test_Users(t *testing.T) {
+
+	ctx := context.Background()
+	store := &storeMock{users: make(map[string]*storeModels.User)}
+	srv := NewUsersService(slog.New(slog.NewTextHandler(io.Discard, nil)), store)
+
+	_, err := srv.CreateUser(ctx, &models.User{Nickname: "jane", Email: "jane@example.com", Role: models.RoleViewer}, "short")
+	assert.ErrorIs(t, err, models.ErrInvalidUser)
+
+	user, err := srv.CreateUser(ctx, &models.User{Nickname: "jane", Email: "jane@example.com", Role: models.RoleViewer}, "long enough")
+	require.NoError(t, err)
+	assert.NotEqual(t, []byte("long enough"), store.users[user.ID].PasswordHash)
+
+	_, err = srv.Verify(ctx, "jane@example.com", "wrong password")
+	assert.ErrorIs(t, err, models.ErrInvalidCredentials)
+
+	_, err = srv.Verify(ctx, "john@example.com", "long enough")
+	assert.ErrorIs(t, err, models.ErrInvalidCredentials)
+
+	// password is kept when not provided
+	user.Role = models.RoleEditor
+	_, err = srv.UpdateUser(ctx, user, "")
+	require.NoError(t, err)
+
+	verified, err := srv.Verify(ctx, "jane@example.com", "long enough")
+	require.NoError(t, err)
+	assert.Equal(t, models.RoleEditor, verified.Role)
+
+	require.NoError(t, srv.DeleteUser(ctx, user.ID))
+	assert.ErrorIs(t, srv.DeleteUser(ctx, user.ID), models.ErrNotFound)
+}
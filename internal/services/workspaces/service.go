@@ -0,0 +1,159 @@
+package workspaces
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"github.com/vishenosik/CherryWatch/internal/services/models"
+	storeModels "github.com/vishenosik/CherryWatch/internal/store/models"
+	attrs "github.com/vishenosik/web-tools/log"
+	"github.com/vishenosik/web-tools/operation"
+)
+
+// suffix of the workspace admin app name, app names are unique across workspaces
+const adminAppSuffix = "-admin"
+
+type Store interface {
+	Workspace(ctx context.Context, id string) (*models.Workspace, error)
+	Workspaces(ctx context.Context) (models.Workspaces, error)
+	SaveWorkspace(ctx context.Context, ws *models.Workspace) error
+	DeleteWorkspace(ctx context.Context, id string) error
+}
+
+type Apps interface {
+	CreateApp(ctx context.Context, workspaceID, name string, role models.Role) (*storeModels.App, string, error)
+}
+
+type Auditor interface {
+	Record(ctx context.Context, change models.Change)
+}
+
+type Service struct {
+	log     *slog.Logger
+	store   Store
+	apps    Apps
+	auditor Auditor
+}
+
+type Option func(*Service)
+
+// WithAudit records workspace creation into the audit log.
+func WithAudit(auditor Auditor) Option {
+	return func(srv *Service) {
+		srv.auditor = auditor
+	}
+}
+
+func NewWorkspacesService(
+	log *slog.Logger,
+	store Store,
+	apps Apps,
+	opts ...Option,
+) *Service {
+
+	srv := &Service{
+		log:   log,
+		store: store,
+		apps:  apps,
+	}
+
+	for _, opt := range opts {
+		opt(srv)
+	}
+
+	return srv
+}
+
+// CreateWorkspace creates a workspace with an admin app and returns the app API key.
+// Only the default workspace is allowed to create workspaces.
+func (srv *Service) CreateWorkspace(ctx context.Context, ws *models.Workspace) (*models.Workspace, string, error) {
+
+	op := operation.ServicesOperation("workspaces", "CreateWorkspace")
+
+	if !manager(ctx) {
+		return nil, "", errors.Wrap(models.ErrForbidden, op)
+	}
+
+	if err := ws.Validate(); err != nil {
+		return nil, "", errors.Wrap(err, op)
+	}
+
+	ws.ID = uuid.NewString()
+
+	if err := srv.store.SaveWorkspace(ctx, ws); err != nil {
+		if errors.Is(err, storeModels.ErrAlreadyExists) {
+			return nil, "", errors.Wrap(models.ErrWorkspaceExists, op)
+		}
+		return nil, "", errors.Wrap(err, op)
+	}
+
+	_, apiKey, err := srv.apps.CreateApp(ctx, ws.ID, ws.Name+adminAppSuffix, models.RoleAdmin)
+	if err != nil {
+		// a workspace without an admin app can't be managed, roll it back
+		if delErr := srv.store.DeleteWorkspace(ctx, ws.ID); delErr != nil {
+			srv.log.Error("failed to roll back workspace",
+				slog.String("workspace_id", ws.ID),
+				attrs.Error(delErr),
+			)
+		}
+		return nil, "", errors.Wrap(err, op)
+	}
+
+	if srv.auditor != nil {
+		srv.auditor.Record(ctx, models.Change{
+			Action:     models.AuditCreate,
+			Resource:   models.AuditWorkspace,
+			ResourceID: ws.ID,
+			After:      ws,
+		})
+	}
+
+	srv.log.Info("workspace created", slog.String("workspace_id", ws.ID), slog.String("name", ws.Name))
+
+	return ws, apiKey, nil
+}
+
+// Workspace returns a workspace visible to the caller.
+func (srv *Service) Workspace(ctx context.Context, id string) (*models.Workspace, error) {
+
+	op := operation.ServicesOperation("workspaces", "Workspace")
+
+	ws, err := srv.store.Workspace(srv.scope(ctx), id)
+	if err != nil {
+		if errors.Is(err, storeModels.ErrNotFound) {
+			return nil, errors.Wrap(models.ErrNotFound, op)
+		}
+		return nil, errors.Wrap(err, op)
+	}
+
+	return ws, nil
+}
+
+// Workspaces returns workspaces visible to the caller:
+// every workspace for the default one, the caller's own otherwise.
+func (srv *Service) Workspaces(ctx context.Context) (models.Workspaces, error) {
+
+	op := operation.ServicesOperation("workspaces", "Workspaces")
+
+	workspaces, err := srv.store.Workspaces(srv.scope(ctx))
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	return workspaces, nil
+}
+
+func (srv *Service) scope(ctx context.Context) context.Context {
+	if manager(ctx) {
+		return models.WithAllWorkspaces(ctx)
+	}
+	return ctx
+}
+
+// manager reports whether the context belongs to the default workspace.
+func manager(ctx context.Context) bool {
+	id, ok := models.WorkspaceFrom(ctx)
+	return ok && (id == "" || id == models.DefaultWorkspace)
+}
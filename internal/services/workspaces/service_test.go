@@ -0,0 +1,136 @@
+package workspaces
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vishenosik/CherryWatch/internal/services/models"
+	storeModels "github.com/vishenosik/CherryWatch/internal/store/models"
+)
+
+type storeMock struct {
+	workspaces map[string]*models.Workspace
+}
+
+func (sm *storeMock) Workspace(ctx context.Context, id string) (*models.Workspace, error) {
+	scope, err := storeModels.WorkspaceScope(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ws, ok := sm.workspaces[id]
+	if !ok || (scope != "" && scope != id) {
+		return nil, storeModels.ErrNotFound
+	}
+	return ws, nil
+}
+
+func (sm *storeMock) Workspaces(ctx context.Context) (models.Workspaces, error) {
+	scope, err := storeModels.WorkspaceScope(ctx)
+	if err != nil {
+		return nil, err
+	}
+	workspaces := make(models.Workspaces, 0)
+	for id, ws := range sm.workspaces {
+		if scope == "" || scope == id {
+			workspaces = append(workspaces, ws)
+		}
+	}
+	return workspaces, nil
+}
+
+func (sm *storeMock) SaveWorkspace(_ context.Context, ws *models.Workspace) error {
+	for _, existing := range sm.workspaces {
+		if existing.Name == ws.Name {
+			return storeModels.ErrAlreadyExists
+		}
+	}
+	sm.workspaces[ws.ID] = ws
+	return nil
+}
+
+func (sm *storeMock) DeleteWorkspace(_ context.Context, id string) error {
+	if _, ok := sm.workspaces[id]; !ok {
+		return storeModels.ErrNotFound
+	}
+	delete(sm.workspaces, id)
+	return nil
+}
+
+type appsMock struct {
+	apps map[string]string
+	err  error
+}
+
+func (am *appsMock) CreateApp(_ context.Context, workspaceID, name string, role models.Role) (*storeModels.App, string, error) {
+	if am.err != nil {
+		return nil, "", am.err
+	}
+	am.apps[name] = workspaceID
+	return &storeModels.App{WorkspaceID: workspaceID, Name: name, Role: string(role)}, "cw_key", nil
+}
+
+func Test_Workspaces(t *testing.T) {
+
+	store := &storeMock{workspaces: map[string]*models.Workspace{
+		models.DefaultWorkspace: {ID: models.DefaultWorkspace, Name: models.DefaultWorkspace},
+	}}
+	apps := &appsMock{apps: make(map[string]string)}
+	srv := NewWorkspacesService(slog.New(slog.NewTextHandler(io.Discard, nil)), store, apps)
+
+	root := models.WithWorkspace(context.Background(), models.DefaultWorkspace)
+
+	ws, apiKey, err := srv.CreateWorkspace(root, &models.Workspace{Name: "payments"})
+	require.NoError(t, err)
+	assert.Equal(t, "cw_key", apiKey)
+	assert.Equal(t, ws.ID, apps.apps["payments-admin"])
+
+	_, _, err = srv.CreateWorkspace(root, &models.Workspace{Name: "payments"})
+	assert.ErrorIs(t, err, models.ErrWorkspaceExists)
+
+	_, _, err = srv.CreateWorkspace(root, &models.Workspace{})
+	assert.ErrorIs(t, err, models.ErrWorkspaceName)
+
+	workspaces, err := srv.Workspaces(root)
+	require.NoError(t, err)
+	assert.Len(t, workspaces, 2)
+
+	team := models.WithWorkspace(context.Background(), ws.ID)
+
+	_, _, err = srv.CreateWorkspace(team, &models.Workspace{Name: "billing"})
+	assert.ErrorIs(t, err, models.ErrForbidden)
+
+	workspaces, err = srv.Workspaces(team)
+	require.NoError(t, err)
+	require.Len(t, workspaces, 1)
+	assert.Equal(t, "payments", workspaces[0].Name)
+
+	_, err = srv.Workspace(team, models.DefaultWorkspace)
+	assert.ErrorIs(t, err, models.ErrNotFound)
+}
+
+func Test_CreateWorkspace_rollback(t *testing.T) {
+
+	store := &storeMock{workspaces: map[string]*models.Workspace{
+		models.DefaultWorkspace: {ID: models.DefaultWorkspace, Name: models.DefaultWorkspace},
+	}}
+	apps := &appsMock{apps: make(map[string]string), err: storeModels.ErrAlreadyExists}
+	srv := NewWorkspacesService(slog.New(slog.NewTextHandler(io.Discard, nil)), store, apps)
+
+	root := models.WithWorkspace(context.Background(), models.DefaultWorkspace)
+
+	_, _, err := srv.CreateWorkspace(root, &models.Workspace{Name: "payments"})
+	require.ErrorIs(t, err, storeModels.ErrAlreadyExists)
+
+	// the workspace without an admin app is rolled back
+	workspaces, err := srv.Workspaces(root)
+	require.NoError(t, err)
+	assert.Len(t, workspaces, 1)
+
+	apps.err = nil
+	_, _, err = srv.CreateWorkspace(root, &models.Workspace{Name: "payments"})
+	assert.NoError(t, err)
+}
@@ -0,0 +1,105 @@
+package apps
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	storeModels "github.com/vishenosik/CherryWatch/internal/store/models"
+)
+
+type Apps interface {
+	App(ctx context.Context, id string) (*storeModels.App, error)
+	AppByName(ctx context.Context, name string) (*storeModels.App, error)
+	AppByAPIKey(ctx context.Context, hash string) (*storeModels.App, error)
+	Apps(ctx context.Context) ([]*storeModels.App, error)
+	SaveApp(ctx context.Context, app *storeModels.App) error
+	SaveAPIKey(ctx context.Context, appID, hash string) error
+}
+
+// embedded under a name not clashing with the Apps method
+type apps = Apps
+
+// Store caches app lookups every authenticated request makes.
+//
+// Apps are cached by id, API key hashes by the id of their app, so that
+// a saved app is invalidated once. Cached apps hold their secrets, so the
+// cache is a local one. It's an optimization only: its failures fall back
+// to the underlying store.
+type Store struct {
+	apps
+	cache storeModels.LocalCacheProvider
+	ttl   time.Duration
+}
+
+func NewAppsStore(apps Apps, cache storeModels.LocalCacheProvider, ttl time.Duration) *Store {
+	return &Store{
+		apps:  apps,
+		cache: cache,
+		ttl:   ttl,
+	}
+}
+
+// App returns an app by id.
+func (store *Store) App(ctx context.Context, id string) (*storeModels.App, error) {
+
+	const op = "cache.apps.App"
+
+	if cached, err := store.cache.Get(ctx, storeModels.AppCacheKey(id)); err == nil {
+		app := &storeModels.App{}
+		if err := storeModels.DecodeCacheValue(cached, app); err == nil {
+			return app, nil
+		}
+	}
+
+	app, err := store.apps.App(ctx, id)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	store.set(ctx, app)
+
+	return app, nil
+}
+
+// AppByAPIKey returns an app owning the API key hash.
+func (store *Store) AppByAPIKey(ctx context.Context, hash string) (*storeModels.App, error) {
+
+	const op = "cache.apps.AppByAPIKey"
+
+	if id, err := store.cache.Get(ctx, storeModels.AppAPIKeyCacheKey(hash)); err == nil {
+		if app, err := store.App(ctx, id); err == nil {
+			return app, nil
+		}
+	}
+
+	app, err := store.apps.AppByAPIKey(ctx, hash)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	_ = store.cache.Set(ctx, storeModels.AppAPIKeyCacheKey(hash), app.ID, store.ttl)
+	store.set(ctx, app)
+
+	return app, nil
+}
+
+// SaveApp saves the app and drops its cached copy.
+func (store *Store) SaveApp(ctx context.Context, app *storeModels.App) error {
+
+	const op = "cache.apps.SaveApp"
+
+	if err := store.apps.SaveApp(ctx, app); err != nil {
+		return errors.Wrap(err, op)
+	}
+
+	_ = store.cache.Delete(ctx, storeModels.AppCacheKey(app.ID))
+
+	return nil
+}
+
+func (store *Store) set(ctx context.Context, app *storeModels.App) {
+	if data, err := storeModels.EncodeCacheValue(app); err == nil {
+		_ = store.cache.Set(ctx, storeModels.AppCacheKey(app.ID), data, store.ttl)
+	}
+}
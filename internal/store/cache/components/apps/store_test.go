@@ -0,0 +1,87 @@
+package apps
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vishenosik/CherryWatch/internal/store/cache/providers/memory"
+	storeModels "github.com/vishenosik/CherryWatch/internal/store/models"
+)
+
+// unimplemented lets the mock embed the interface, its field name would clash with the Apps method.
+type unimplemented = Apps
+
+type appsMock struct {
+	unimplemented
+	apps  map[string]*storeModels.App
+	keys  map[string]string
+	reads int
+}
+
+func (am *appsMock) App(_ context.Context, id string) (*storeModels.App, error) {
+	am.reads++
+	app, ok := am.apps[id]
+	if !ok {
+		return nil, storeModels.ErrNotFound
+	}
+	copied := *app
+	return &copied, nil
+}
+
+func (am *appsMock) AppByAPIKey(ctx context.Context, hash string) (*storeModels.App, error) {
+	id, ok := am.keys[hash]
+	if !ok {
+		am.reads++
+		return nil, storeModels.ErrNotFound
+	}
+	return am.App(ctx, id)
+}
+
+func (am *appsMock) SaveApp(_ context.Context, app *storeModels.App) error {
+	am.apps[app.ID] = app
+	return nil
+}
+
+func Test_Store(t *testing.T) {
+
+	ctx := context.Background()
+
+	mock := &appsMock{
+		apps: map[string]*storeModels.App{
+			"app-1": {ID: "app-1", WorkspaceID: "default", Name: "ci", Secret: "secret", Role: "admin"},
+		},
+		keys: map[string]string{"hash": "app-1"},
+	}
+
+	store := NewAppsStore(mock, memory.NewMemoryCache(0), time.Minute)
+
+	for range 3 {
+		app, err := store.AppByAPIKey(ctx, "hash")
+		require.NoError(t, err)
+		// identifiers and secrets, hidden from JSON, are cached along
+		assert.Equal(t, mock.apps["app-1"], app)
+	}
+	assert.Equal(t, 1, mock.reads)
+
+	_, err := store.App(ctx, "app-1")
+	require.NoError(t, err)
+	assert.Equal(t, 1, mock.reads)
+
+	// misses aren't cached
+	for range 2 {
+		_, err = store.AppByAPIKey(ctx, "unknown")
+		assert.ErrorIs(t, err, storeModels.ErrNotFound)
+	}
+	assert.Equal(t, 3, mock.reads)
+
+	// saved apps are read again
+	require.NoError(t, store.SaveApp(ctx, &storeModels.App{ID: "app-1", Name: "ci", Secret: "rotated", Role: "viewer"}))
+
+	app, err := store.AppByAPIKey(ctx, "hash")
+	require.NoError(t, err)
+	assert.Equal(t, "rotated", app.Secret)
+	assert.Equal(t, 4, mock.reads)
+}
@@ -0,0 +1,100 @@
+package users
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	storeModels "github.com/vishenosik/CherryWatch/internal/store/models"
+)
+
+type Users interface {
+	User(ctx context.Context, id string) (*storeModels.User, error)
+	UserByEmail(ctx context.Context, email string) (*storeModels.User, error)
+	Users(ctx context.Context) ([]*storeModels.User, error)
+	SaveUser(ctx context.Context, user *storeModels.User) error
+	DeleteUser(ctx context.Context, id string) error
+}
+
+// embedded under a name not clashing with the Users method
+type users = Users
+
+// Store caches user lookups by id, every request authenticated with a user token makes one.
+//
+// Users are cached regardless of the workspace scope they were read with,
+// the scope is checked against cached users the way the underlying store does.
+// Cached users hold their password hashes, so the cache is a local one.
+// It's an optimization only: its failures fall back to the underlying store.
+type Store struct {
+	users
+	cache storeModels.LocalCacheProvider
+	ttl   time.Duration
+}
+
+func NewUsersStore(users Users, cache storeModels.LocalCacheProvider, ttl time.Duration) *Store {
+	return &Store{
+		users: users,
+		cache: cache,
+		ttl:   ttl,
+	}
+}
+
+// User returns a user by id.
+func (store *Store) User(ctx context.Context, id string) (*storeModels.User, error) {
+
+	const op = "cache.users.User"
+
+	workspaceID, err := storeModels.WorkspaceScope(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	if cached, err := store.cache.Get(ctx, storeModels.UserCacheKey(id)); err == nil {
+		user := &storeModels.User{}
+		if err := storeModels.DecodeCacheValue(cached, user); err == nil {
+			if workspaceID != "" && user.WorkspaceID != workspaceID {
+				return nil, errors.Wrap(storeModels.ErrNotFound, op)
+			}
+			return user, nil
+		}
+	}
+
+	user, err := store.users.User(ctx, id)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	if data, err := storeModels.EncodeCacheValue(user); err == nil {
+		_ = store.cache.Set(ctx, storeModels.UserCacheKey(id), data, store.ttl)
+	}
+
+	return user, nil
+}
+
+// SaveUser saves the user and drops its cached copy.
+func (store *Store) SaveUser(ctx context.Context, user *storeModels.User) error {
+
+	const op = "cache.users.SaveUser"
+
+	if err := store.users.SaveUser(ctx, user); err != nil {
+		return errors.Wrap(err, op)
+	}
+
+	_ = store.cache.Delete(ctx, storeModels.UserCacheKey(user.ID))
+
+	return nil
+}
+
+// DeleteUser deletes the user and its cached copy.
+func (store *Store) DeleteUser(ctx context.Context, id string) error {
+
+	const op = "cache.users.DeleteUser"
+
+	if err := store.users.DeleteUser(ctx, id); err != nil {
+		return errors.Wrap(err, op)
+	}
+
+	_ = store.cache.Delete(ctx, storeModels.UserCacheKey(id))
+
+	return nil
+}
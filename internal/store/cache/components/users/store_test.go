@@ -0,0 +1,83 @@
+package users
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vishenosik/CherryWatch/internal/services/models"
+	"github.com/vishenosik/CherryWatch/internal/store/cache/providers/memory"
+	storeModels "github.com/vishenosik/CherryWatch/internal/store/models"
+)
+
+// unimplemented lets the mock embed the interface, its field name would clash with the Users method.
+type unimplemented = Users
+
+type usersMock struct {
+	unimplemented
+	users map[string]*storeModels.User
+	reads int
+}
+
+func (um *usersMock) User(ctx context.Context, id string) (*storeModels.User, error) {
+	um.reads++
+	workspaceID, err := storeModels.WorkspaceScope(ctx)
+	if err != nil {
+		return nil, err
+	}
+	user, ok := um.users[id]
+	if !ok || (workspaceID != "" && user.WorkspaceID != workspaceID) {
+		return nil, storeModels.ErrNotFound
+	}
+	copied := *user
+	return &copied, nil
+}
+
+func (um *usersMock) SaveUser(_ context.Context, user *storeModels.User) error {
+	um.users[user.ID] = user
+	return nil
+}
+
+func (um *usersMock) DeleteUser(_ context.Context, id string) error {
+	delete(um.users, id)
+	return nil
+}
+
+func Test_Store(t *testing.T) {
+
+	mock := &usersMock{users: map[string]*storeModels.User{
+		"user-1": {ID: "user-1", WorkspaceID: "team-a", Email: "ops@example.com", PasswordHash: []byte("hash"), Role: "editor"},
+	}}
+
+	store := NewUsersStore(mock, memory.NewMemoryCache(0), time.Minute)
+
+	all := models.WithAllWorkspaces(context.Background())
+	teamA := models.WithWorkspace(context.Background(), "team-a")
+	teamB := models.WithWorkspace(context.Background(), "team-b")
+
+	for _, ctx := range []context.Context{all, teamA, all} {
+		user, err := store.User(ctx, "user-1")
+		require.NoError(t, err)
+		assert.Equal(t, mock.users["user-1"], user)
+	}
+	assert.Equal(t, 1, mock.reads)
+
+	// cached users of other workspaces are hidden as the store hides them
+	_, err := store.User(teamB, "user-1")
+	assert.ErrorIs(t, err, storeModels.ErrNotFound)
+
+	_, err = store.User(context.Background(), "user-1")
+	assert.ErrorIs(t, err, storeModels.ErrNoWorkspace)
+
+	require.NoError(t, store.SaveUser(all, &storeModels.User{ID: "user-1", WorkspaceID: "team-a", Role: "viewer"}))
+	user, err := store.User(all, "user-1")
+	require.NoError(t, err)
+	assert.Equal(t, "viewer", user.Role)
+	assert.Equal(t, 2, mock.reads)
+
+	require.NoError(t, store.DeleteUser(all, "user-1"))
+	_, err = store.User(all, "user-1")
+	assert.ErrorIs(t, err, storeModels.ErrNotFound)
+}
@@ -0,0 +1,183 @@
+package memory
+
+import (
+	"container/list"
+	"context"
+	"hash/maphash"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	storeModels "github.com/vishenosik/CherryWatch/internal/store/models"
+)
+
+const (
+	// default number of cached values
+	defaultSize = 10000
+	// number of independently locked parts of the cache
+	shards = 16
+)
+
+// Cache is an in-memory LRU cache with expiration.
+//
+// Keys are spread over shards locked separately, so concurrent requests
+// rarely wait for each other. Each shard keeps its share of the size and
+// evicts the least recently used values once it's full. Expired values
+// are dropped when they are read or evicted, there's no background sweep.
+type Cache struct {
+	shards [shards]*shard
+	seed   maphash.Seed
+	now    func() time.Time
+}
+
+type shard struct {
+	mu       sync.Mutex
+	capacity int
+	items    map[string]*list.Element
+	// most recently used values first
+	order *list.List
+}
+
+type entry struct {
+	key       string
+	value     string
+	expiresAt time.Time
+}
+
+// NewMemoryCache creates a cache of about size values, 10000 if size isn't positive.
+func NewMemoryCache(size int) *Cache {
+
+	if size <= 0 {
+		size = defaultSize
+	}
+
+	cache := &Cache{
+		seed: maphash.MakeSeed(),
+		now:  time.Now,
+	}
+
+	capacity := (size + shards - 1) / shards
+
+	for i := range cache.shards {
+		cache.shards[i] = &shard{
+			capacity: capacity,
+			items:    make(map[string]*list.Element, capacity),
+			order:    list.New(),
+		}
+	}
+
+	return cache
+}
+
+func (cache *Cache) Set(_ context.Context, key string, value any, expiration time.Duration) error {
+
+	const op = "Cache.memory.Set"
+
+	data, err := storeModels.CacheString(value)
+	if err != nil {
+		return errors.Wrap(err, op)
+	}
+
+	var expiresAt time.Time
+	if expiration > 0 {
+		expiresAt = cache.now().Add(expiration)
+	}
+
+	cache.shard(key).set(key, data, expiresAt)
+
+	return nil
+}
+
+func (cache *Cache) Get(_ context.Context, key string) (string, error) {
+
+	const op = "Cache.memory.Get"
+
+	value, ok := cache.shard(key).get(key, cache.now())
+	if !ok {
+		return "", errors.Wrap(storeModels.ErrNotFound, op)
+	}
+
+	return value, nil
+}
+
+func (cache *Cache) Delete(_ context.Context, key string) error {
+	cache.shard(key).delete(key)
+	return nil
+}
+
+// Local marks the cache as kept in the process memory, it may hold secrets.
+func (cache *Cache) Local() {}
+
+// Len returns the number of cached values, expired ones that weren't dropped yet included.
+func (cache *Cache) Len() int {
+	var length int
+	for _, shard := range cache.shards {
+		shard.mu.Lock()
+		length += shard.order.Len()
+		shard.mu.Unlock()
+	}
+	return length
+}
+
+func (cache *Cache) shard(key string) *shard {
+	return cache.shards[maphash.String(cache.seed, key)%shards]
+}
+
+func (shard *shard) set(key, value string, expiresAt time.Time) {
+
+	shard.mu.Lock()
+	defer shard.mu.Unlock()
+
+	if element, ok := shard.items[key]; ok {
+		entry := element.Value.(*entry)
+		entry.value, entry.expiresAt = value, expiresAt
+		shard.order.MoveToFront(element)
+		return
+	}
+
+	shard.items[key] = shard.order.PushFront(&entry{
+		key:       key,
+		value:     value,
+		expiresAt: expiresAt,
+	})
+
+	for shard.order.Len() > shard.capacity {
+		shard.remove(shard.order.Back())
+	}
+}
+
+func (shard *shard) get(key string, now time.Time) (string, bool) {
+
+	shard.mu.Lock()
+	defer shard.mu.Unlock()
+
+	element, ok := shard.items[key]
+	if !ok {
+		return "", false
+	}
+
+	entry := element.Value.(*entry)
+	if !entry.expiresAt.IsZero() && !now.Before(entry.expiresAt) {
+		shard.remove(element)
+		return "", false
+	}
+
+	shard.order.MoveToFront(element)
+
+	return entry.value, true
+}
+
+func (shard *shard) delete(key string) {
+
+	shard.mu.Lock()
+	defer shard.mu.Unlock()
+
+	if element, ok := shard.items[key]; ok {
+		shard.remove(element)
+	}
+}
+
+func (shard *shard) remove(element *list.Element) {
+	shard.order.Remove(element)
+	delete(shard.items, element.Value.(*entry).key)
+}
@@ -0,0 +1,154 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	storeModels "github.com/vishenosik/CherryWatch/internal/store/models"
+)
+
+func Test_Cache(t *testing.T) {
+
+	ctx := context.Background()
+	cache := NewMemoryCache(0)
+
+	require.NoError(t, cache.Set(ctx, "text", "value", 0))
+	require.NoError(t, cache.Set(ctx, "number", 42, 0))
+	require.NoError(t, cache.Set(ctx, "bytes", []byte{0, 1}, 0))
+
+	tests := []struct {
+		key  string
+		want string
+	}{
+		{key: "text", want: "value"},
+		{key: "number", want: "42"},
+		{key: "bytes", want: "\x00\x01"},
+	}
+
+	for _, tt := range tests {
+		value, err := cache.Get(ctx, tt.key)
+		require.NoError(t, err, tt.key)
+		assert.Equal(t, tt.want, value, tt.key)
+	}
+
+	_, err := cache.Get(ctx, "missing")
+	assert.ErrorIs(t, err, storeModels.ErrNotFound)
+
+	require.NoError(t, cache.Delete(ctx, "text"))
+	_, err = cache.Get(ctx, "text")
+	assert.ErrorIs(t, err, storeModels.ErrNotFound)
+
+	err = cache.Set(ctx, "struct", struct{}{}, 0)
+	assert.ErrorIs(t, err, storeModels.ErrUnsupportedCacheValue)
+}
+
+func Test_Cache_expiration(t *testing.T) {
+
+	ctx := context.Background()
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	cache := NewMemoryCache(0)
+	cache.now = func() time.Time { return now }
+
+	require.NoError(t, cache.Set(ctx, "short", "value", time.Minute))
+	require.NoError(t, cache.Set(ctx, "forever", "value", 0))
+
+	now = now.Add(59 * time.Second)
+	_, err := cache.Get(ctx, "short")
+	assert.NoError(t, err)
+
+	now = now.Add(time.Second)
+	_, err = cache.Get(ctx, "short")
+	assert.ErrorIs(t, err, storeModels.ErrNotFound)
+
+	// expired values are dropped once read
+	assert.Equal(t, 1, cache.Len())
+
+	now = now.Add(24 * time.Hour)
+	_, err = cache.Get(ctx, "forever")
+	assert.NoError(t, err)
+}
+
+// sameShard returns keys landing on a single shard of the cache.
+func sameShard(cache *Cache, n int) []string {
+	var keys []string
+	target := cache.shard("key-0")
+	for i := 0; len(keys) < n; i++ {
+		key := fmt.Sprintf("key-%d", i)
+		if cache.shard(key) == target {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+func Test_Cache_eviction(t *testing.T) {
+
+	ctx := context.Background()
+
+	// shards keep their share of the size
+	cache := NewMemoryCache(shards)
+	keys := sameShard(cache, 2)
+
+	require.NoError(t, cache.Set(ctx, keys[0], "0", 0))
+	require.NoError(t, cache.Set(ctx, keys[1], "1", 0))
+
+	_, err := cache.Get(ctx, keys[0])
+	assert.ErrorIs(t, err, storeModels.ErrNotFound)
+
+	value, err := cache.Get(ctx, keys[1])
+	require.NoError(t, err)
+	assert.Equal(t, "1", value)
+
+	// least recently used values are evicted first
+	cache = NewMemoryCache(2 * shards)
+	keys = sameShard(cache, 3)
+
+	require.NoError(t, cache.Set(ctx, keys[0], "0", 0))
+	require.NoError(t, cache.Set(ctx, keys[1], "1", 0))
+	_, err = cache.Get(ctx, keys[0])
+	require.NoError(t, err)
+	require.NoError(t, cache.Set(ctx, keys[2], "2", 0))
+
+	_, err = cache.Get(ctx, keys[1])
+	assert.ErrorIs(t, err, storeModels.ErrNotFound)
+	_, err = cache.Get(ctx, keys[0])
+	assert.NoError(t, err)
+	assert.Equal(t, 2, cache.Len())
+}
+
+func Test_Cache_concurrent(t *testing.T) {
+
+	ctx := context.Background()
+	cache := NewMemoryCache(100)
+
+	var wg sync.WaitGroup
+	for w := range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range 1000 {
+				key := fmt.Sprintf("key-%d", (w*1000+i)%300)
+				assert.NoError(t, cache.Set(ctx, key, i, time.Minute))
+				_, _ = cache.Get(ctx, key)
+				if i%10 == 0 {
+					assert.NoError(t, cache.Delete(ctx, key))
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.LessOrEqual(t, cache.Len(), 112)
+}
+
+func Test_Cache_local(t *testing.T) {
+	var cache storeModels.CacheProvider = NewMemoryCache(0)
+	_, local := cache.(storeModels.LocalCacheProvider)
+	assert.True(t, local)
+}
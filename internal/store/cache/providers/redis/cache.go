@@ -0,0 +1,268 @@
+package redis
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	storeModels "github.com/vishenosik/CherryWatch/internal/store/models"
+)
+
+const (
+	// default number of idle connections kept open
+	defaultPoolSize = 8
+	// default timeout of a command including the dial
+	defaultTimeout = time.Second
+)
+
+// ErrServer is a reply of the server to a failed command.
+var ErrServer = errors.New("redis error")
+
+type Config struct {
+	// Server host:port
+	Addr string
+	// AUTH password, no authentication if empty
+	Password string
+	// Database index selected on connect
+	DB int
+	// Number of idle connections kept open, 8 by default
+	PoolSize int
+	// Timeout of a command including the dial, a second by default
+	Timeout time.Duration
+}
+
+// Cache stores values in Redis or any server speaking its protocol (RESP).
+//
+// It's a minimal client of SET, GET and DEL: connections are dialed on demand,
+// kept idle up to the pool size and dropped on any failure.
+type Cache struct {
+	config Config
+	dialer net.Dialer
+	idle   chan *conn
+}
+
+type conn struct {
+	net.Conn
+	reader *bufio.Reader
+}
+
+func NewRedisCache(config Config) *Cache {
+
+	if config.PoolSize <= 0 {
+		config.PoolSize = defaultPoolSize
+	}
+
+	if config.Timeout <= 0 {
+		config.Timeout = defaultTimeout
+	}
+
+	return &Cache{
+		config: config,
+		idle:   make(chan *conn, config.PoolSize),
+	}
+}
+
+func (cache *Cache) Set(ctx context.Context, key string, value any, expiration time.Duration) error {
+
+	const op = "Cache.redis.Set"
+
+	data, err := storeModels.CacheString(value)
+	if err != nil {
+		return errors.Wrap(err, op)
+	}
+
+	args := []string{"SET", key, data}
+	if expiration > 0 {
+		args = append(args, "PX", strconv.FormatInt(max(expiration.Milliseconds(), 1), 10))
+	}
+
+	if _, _, err := cache.do(ctx, args...); err != nil {
+		return errors.Wrap(err, op)
+	}
+
+	return nil
+}
+
+func (cache *Cache) Get(ctx context.Context, key string) (string, error) {
+
+	const op = "Cache.redis.Get"
+
+	value, ok, err := cache.do(ctx, "GET", key)
+	if err != nil {
+		return "", errors.Wrap(err, op)
+	}
+
+	if !ok {
+		return "", errors.Wrap(storeModels.ErrNotFound, op)
+	}
+
+	return value, nil
+}
+
+func (cache *Cache) Delete(ctx context.Context, key string) error {
+
+	const op = "Cache.redis.Delete"
+
+	if _, _, err := cache.do(ctx, "DEL", key); err != nil {
+		return errors.Wrap(err, op)
+	}
+
+	return nil
+}
+
+// Stop closes idle connections.
+func (cache *Cache) Stop() error {
+	for {
+		select {
+		case conn := <-cache.idle:
+			conn.Close()
+		default:
+			return nil
+		}
+	}
+}
+
+// do sends the command and reads its reply, ok is false for nil replies.
+func (cache *Cache) do(ctx context.Context, args ...string) (reply string, ok bool, err error) {
+
+	ctx, cancel := context.WithTimeout(ctx, cache.config.Timeout)
+	defer cancel()
+
+	conn, err := cache.conn(ctx)
+	if err != nil {
+		return "", false, err
+	}
+
+	reply, ok, err = conn.do(ctx, args...)
+	if err != nil && !errors.Is(err, ErrServer) {
+		// the connection state is unknown after i/o failures
+		conn.Close()
+		return "", false, err
+	}
+
+	cache.release(conn)
+
+	return reply, ok, err
+}
+
+func (cache *Cache) conn(ctx context.Context) (*conn, error) {
+
+	select {
+	case conn := <-cache.idle:
+		return conn, nil
+	default:
+	}
+
+	netConn, err := cache.dialer.DialContext(ctx, "tcp", cache.config.Addr)
+	if err != nil {
+		return nil, err
+	}
+
+	conn := &conn{Conn: netConn, reader: bufio.NewReader(netConn)}
+
+	if cache.config.Password != "" {
+		if _, _, err := conn.do(ctx, "AUTH", cache.config.Password); err != nil {
+			conn.Close()
+			return nil, err
+		}
+	}
+
+	if cache.config.DB != 0 {
+		if _, _, err := conn.do(ctx, "SELECT", strconv.Itoa(cache.config.DB)); err != nil {
+			conn.Close()
+			return nil, err
+		}
+	}
+
+	return conn, nil
+}
+
+func (cache *Cache) release(conn *conn) {
+	select {
+	case cache.idle <- conn:
+	default:
+		conn.Close()
+	}
+}
+
+func (conn *conn) do(ctx context.Context, args ...string) (string, bool, error) {
+
+	if deadline, ok := ctx.Deadline(); ok {
+		if err := conn.SetDeadline(deadline); err != nil {
+			return "", false, err
+		}
+	}
+
+	if _, err := conn.Write(command(args)); err != nil {
+		return "", false, err
+	}
+
+	return readReply(conn.reader)
+}
+
+// command encodes the arguments as a RESP array of bulk strings.
+func command(args []string) []byte {
+
+	buf := make([]byte, 0, 64)
+	buf = append(buf, '*')
+	buf = strconv.AppendInt(buf, int64(len(args)), 10)
+	buf = append(buf, '\r', '\n')
+
+	for _, arg := range args {
+		buf = append(buf, '$')
+		buf = strconv.AppendInt(buf, int64(len(arg)), 10)
+		buf = append(buf, '\r', '\n')
+		buf = append(buf, arg...)
+		buf = append(buf, '\r', '\n')
+	}
+
+	return buf
+}
+
+// readReply reads a simple string, error, integer or bulk string reply.
+func readReply(reader *bufio.Reader) (string, bool, error) {
+
+	line, err := readLine(reader)
+	if err != nil {
+		return "", false, err
+	}
+
+	if line == "" {
+		return "", false, errors.New("empty reply")
+	}
+
+	switch line[0] {
+	case '+', ':':
+		return line[1:], true, nil
+	case '-':
+		return "", false, errors.Wrap(ErrServer, line[1:])
+	case '$':
+		size, err := strconv.Atoi(line[1:])
+		if err != nil {
+			return "", false, errors.Wrap(err, "bulk string size")
+		}
+		if size < 0 {
+			return "", false, nil
+		}
+		data := make([]byte, size+2)
+		if _, err := io.ReadFull(reader, data); err != nil {
+			return "", false, err
+		}
+		return string(data[:size]), true, nil
+	}
+
+	return "", false, errors.Errorf("unexpected reply %q", line)
+}
+
+func readLine(reader *bufio.Reader) (string, error) {
+	line, err := reader.ReadString('\n')
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(line, "\r\n"), nil
+}
@@ -0,0 +1,258 @@
+package redis
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	storeModels "github.com/vishenosik/CherryWatch/internal/store/models"
+)
+
+// fakeServer speaks enough of RESP to serve the client: AUTH, SELECT, SET with PX, GET & DEL.
+type fakeServer struct {
+	listener net.Listener
+	password string
+
+	mu       sync.Mutex
+	values   map[string]string
+	expires  map[string]time.Time
+	dbs      []string
+	accepted int
+}
+
+func newFakeServer(t *testing.T, password string) *fakeServer {
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	srv := &fakeServer{
+		listener: listener,
+		password: password,
+		values:   map[string]string{},
+		expires:  map[string]time.Time{},
+	}
+
+	go srv.serve()
+	t.Cleanup(func() { listener.Close() })
+
+	return srv
+}
+
+func (srv *fakeServer) serve() {
+	for {
+		conn, err := srv.listener.Accept()
+		if err != nil {
+			return
+		}
+		srv.mu.Lock()
+		srv.accepted++
+		srv.mu.Unlock()
+		go srv.handle(conn)
+	}
+}
+
+func (srv *fakeServer) handle(conn net.Conn) {
+
+	defer conn.Close()
+
+	reader := bufio.NewReader(conn)
+	authenticated := srv.password == ""
+
+	for {
+		args, err := readCommand(reader)
+		if err != nil {
+			return
+		}
+
+		reply := srv.reply(args, &authenticated)
+		if _, err := io.WriteString(conn, reply); err != nil {
+			return
+		}
+	}
+}
+
+func (srv *fakeServer) reply(args []string, authenticated *bool) string {
+
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+
+	command := strings.ToUpper(args[0])
+
+	if command == "AUTH" {
+		if args[1] != srv.password {
+			return "-WRONGPASS invalid password\r\n"
+		}
+		*authenticated = true
+		return "+OK\r\n"
+	}
+
+	if !*authenticated {
+		return "-NOAUTH Authentication required.\r\n"
+	}
+
+	switch command {
+	case "SELECT":
+		srv.dbs = append(srv.dbs, args[1])
+		return "+OK\r\n"
+	case "SET":
+		srv.values[args[1]] = args[2]
+		delete(srv.expires, args[1])
+		if len(args) == 5 && strings.ToUpper(args[3]) == "PX" {
+			ms, _ := strconv.Atoi(args[4])
+			srv.expires[args[1]] = time.Now().Add(time.Duration(ms) * time.Millisecond)
+		}
+		return "+OK\r\n"
+	case "GET":
+		value, ok := srv.values[args[1]]
+		if expires, set := srv.expires[args[1]]; set && !time.Now().Before(expires) {
+			ok = false
+		}
+		if !ok {
+			return "$-1\r\n"
+		}
+		return fmt.Sprintf("$%d\r\n%s\r\n", len(value), value)
+	case "DEL":
+		_, ok := srv.values[args[1]]
+		delete(srv.values, args[1])
+		if ok {
+			return ":1\r\n"
+		}
+		return ":0\r\n"
+	}
+
+	return fmt.Sprintf("-ERR unknown command '%s'\r\n", args[0])
+}
+
+func readCommand(reader *bufio.Reader) ([]string, error) {
+
+	line, err := readLine(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	count, err := strconv.Atoi(strings.TrimPrefix(line, "*"))
+	if err != nil {
+		return nil, err
+	}
+
+	args := make([]string, 0, count)
+	for range count {
+		arg, _, err := readReply(reader)
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, arg)
+	}
+
+	return args, nil
+}
+
+func Test_Cache(t *testing.T) {
+
+	ctx := context.Background()
+	srv := newFakeServer(t, "secret")
+
+	cache := NewRedisCache(Config{Addr: srv.listener.Addr().String(), Password: "secret", DB: 2})
+	defer cache.Stop()
+
+	require.NoError(t, cache.Set(ctx, "key", "multi\r\nline", 0))
+	require.NoError(t, cache.Set(ctx, "number", 42, 0))
+
+	value, err := cache.Get(ctx, "key")
+	require.NoError(t, err)
+	assert.Equal(t, "multi\r\nline", value)
+
+	value, err = cache.Get(ctx, "number")
+	require.NoError(t, err)
+	assert.Equal(t, "42", value)
+
+	_, err = cache.Get(ctx, "missing")
+	assert.ErrorIs(t, err, storeModels.ErrNotFound)
+
+	require.NoError(t, cache.Delete(ctx, "key"))
+	_, err = cache.Get(ctx, "key")
+	assert.ErrorIs(t, err, storeModels.ErrNotFound)
+
+	require.NoError(t, cache.Set(ctx, "short", "value", 20*time.Millisecond))
+	time.Sleep(50 * time.Millisecond)
+	_, err = cache.Get(ctx, "short")
+	assert.ErrorIs(t, err, storeModels.ErrNotFound)
+
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+
+	// sequential commands reuse the connection, the database is selected once
+	assert.Equal(t, 1, srv.accepted)
+	assert.Equal(t, []string{"2"}, srv.dbs)
+}
+
+func Test_Cache_errors(t *testing.T) {
+
+	ctx := context.Background()
+	srv := newFakeServer(t, "secret")
+
+	cache := NewRedisCache(Config{Addr: srv.listener.Addr().String(), Password: "wrong"})
+	defer cache.Stop()
+
+	err := cache.Set(ctx, "key", "value", 0)
+	assert.ErrorIs(t, err, ErrServer)
+	assert.ErrorContains(t, err, "WRONGPASS")
+
+	err = cache.Set(ctx, "key", struct{}{}, 0)
+	assert.ErrorIs(t, err, storeModels.ErrUnsupportedCacheValue)
+
+	// unreachable servers fail within the timeout
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	addr := listener.Addr().String()
+	listener.Close()
+
+	cache = NewRedisCache(Config{Addr: addr, Timeout: 100 * time.Millisecond})
+	_, err = cache.Get(ctx, "key")
+	assert.Error(t, err)
+	assert.NotErrorIs(t, err, storeModels.ErrNotFound)
+}
+
+func Test_Cache_concurrent(t *testing.T) {
+
+	ctx := context.Background()
+	srv := newFakeServer(t, "")
+
+	cache := NewRedisCache(Config{Addr: srv.listener.Addr().String(), PoolSize: 2})
+	defer cache.Stop()
+
+	var wg sync.WaitGroup
+	for w := range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range 50 {
+				key := fmt.Sprintf("key-%d-%d", w, i)
+				assert.NoError(t, cache.Set(ctx, key, i, time.Minute))
+				value, err := cache.Get(ctx, key)
+				assert.NoError(t, err)
+				assert.Equal(t, strconv.Itoa(i), value)
+			}
+		}()
+	}
+	wg.Wait()
+
+	// no more than the pool size of connections is kept idle
+	assert.LessOrEqual(t, len(cache.idle), 2)
+}
+
+func Test_Cache_notLocal(t *testing.T) {
+	// values in Redis leave the process, secrets must not be cached there
+	var cache storeModels.CacheProvider = NewRedisCache(Config{Addr: "127.0.0.1:0"})
+	_, local := cache.(storeModels.LocalCacheProvider)
+	assert.False(t, local)
+}
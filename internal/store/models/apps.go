@@ -3,9 +3,11 @@ package models
 import "fmt"
 
 type App struct {
-	Name   string
-	Secret string
-	ID     string `json:"-"`
+	Name        string
+	Secret      string
+	ID          string `json:"-"`
+	WorkspaceID string
+	Role        string
 }
 
 func (app App) GetID() string {
@@ -19,3 +21,8 @@ func (app App) GetSecret() []byte {
 func AppCacheKey(id string) string {
 	return fmt.Sprintf("app:%s", id)
 }
+
+// AppAPIKeyCacheKey keys the id of the app owning the API key hash.
+func AppAPIKeyCacheKey(hash string) string {
+	return fmt.Sprintf("apikey:%s", hash)
+}
@@ -1,10 +1,22 @@
 package models
 
 import (
+	"bytes"
 	"context"
+	"encoding"
+	"encoding/gob"
+	"strconv"
 	"time"
+
+	"github.com/pkg/errors"
 )
 
+// ErrUnsupportedCacheValue is returned for values cache providers can't store as is,
+// structures are encoded with EncodeCacheValue first.
+var ErrUnsupportedCacheValue = errors.New("unsupported cache value")
+
+// CacheProvider stores string values by key. Get returns ErrNotFound for missing
+// and expired keys, zero expiration keeps the value until it's evicted.
 type CacheProvider interface {
 	Set(
 		ctx context.Context,
@@ -23,3 +35,58 @@ type CacheProvider interface {
 		key string,
 	) error
 }
+
+// LocalCacheProvider is a cache provider keeping values in the process memory.
+// Only these may cache values holding secrets, such as app secrets and password hashes.
+type LocalCacheProvider interface {
+	CacheProvider
+	Local()
+}
+
+// CacheString formats the value the way cache providers store it:
+// strings, bytes, numbers, booleans and binary marshalers are accepted.
+func CacheString(value any) (string, error) {
+	switch value := value.(type) {
+	case string:
+		return value, nil
+	case []byte:
+		return string(value), nil
+	case int:
+		return strconv.Itoa(value), nil
+	case int64:
+		return strconv.FormatInt(value, 10), nil
+	case uint64:
+		return strconv.FormatUint(value, 10), nil
+	case float64:
+		return strconv.FormatFloat(value, 'f', -1, 64), nil
+	case bool:
+		if value {
+			return "1", nil
+		}
+		return "0", nil
+	case time.Time:
+		return value.Format(time.RFC3339Nano), nil
+	case encoding.BinaryMarshaler:
+		data, err := value.MarshalBinary()
+		if err != nil {
+			return "", err
+		}
+		return string(data), nil
+	}
+	return "", errors.Wrapf(ErrUnsupportedCacheValue, "%T", value)
+}
+
+// EncodeCacheValue encodes a structure to be cached. Gob keeps fields hidden from JSON,
+// such as identifiers, so structures holding secrets are cached by LocalCacheProvider only.
+func EncodeCacheValue(value any) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(value); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// DecodeCacheValue decodes a cached structure encoded with EncodeCacheValue.
+func DecodeCacheValue(data string, value any) error {
+	return gob.NewDecoder(bytes.NewBufferString(data)).Decode(value)
+}
@@ -7,4 +7,10 @@ var (
 	ErrNotFound = errors.New("not found")
 	// exists already
 	ErrAlreadyExists = errors.New("exists already")
+	// query context has no workspace scope
+	ErrNoWorkspace = errors.New("workspace scope is missing")
+	// operation isn't supported by the store driver
+	ErrNotSupported = errors.New("not supported by the store")
+	// backup file is damaged or has an unknown schema
+	ErrInvalidBackup = errors.New("invalid backup")
 )
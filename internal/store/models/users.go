@@ -6,8 +6,9 @@ type User struct {
 	Nickname     string
 	Email        string
 	ID           string `json:"-"`
+	WorkspaceID  string
 	PasswordHash []byte `json:"-"`
-	IsAdmin      bool
+	Role         string
 }
 
 func (user User) GetID() string {
@@ -0,0 +1,47 @@
+package models
+
+import (
+	"context"
+
+	pkgctx "github.com/vishenosik/web-tools/context"
+)
+
+type workspaceKey struct{}
+
+// workspaceScope limits data access of a context. Every workspace is accessible
+// through the all flag only, so that an empty workspace id never grants it.
+type workspaceScope struct {
+	id  string
+	all bool
+}
+
+func (scope *workspaceScope) Key() workspaceKey {
+	return workspaceKey{}
+}
+
+// WithWorkspace limits data access of the context to the workspace.
+func WithWorkspace(ctx context.Context, workspaceID string) context.Context {
+	return pkgctx.With(ctx, &workspaceScope{id: workspaceID})
+}
+
+// WithAllWorkspaces grants access to data of every workspace.
+func WithAllWorkspaces(ctx context.Context) context.Context {
+	return pkgctx.With(ctx, &workspaceScope{all: true})
+}
+
+// WorkspaceScope returns workspace the query must be limited to,
+// empty for all workspaces. Queries without a scope or with an empty
+// workspace are refused, so that a forgotten or broken scope never
+// leaks another team's data.
+func WorkspaceScope(ctx context.Context) (string, error) {
+	scope, ok := pkgctx.From[*workspaceScope](ctx)
+	switch {
+	case !ok:
+		return "", ErrNoWorkspace
+	case scope.all:
+		return "", nil
+	case scope.id == "":
+		return "", ErrNoWorkspace
+	}
+	return scope.id, nil
+}
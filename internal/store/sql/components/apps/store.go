@@ -0,0 +1,160 @@
+package apps
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/pkg/errors"
+	storeModels "github.com/vishenosik/CherryWatch/internal/store/models"
+	sqlstore "github.com/vishenosik/CherryWatch/internal/store/sql"
+)
+
+type Store struct {
+	db *sqlstore.DB
+}
+
+func NewAppsStore(db *sqlstore.DB) *Store {
+	return &Store{
+		db: db,
+	}
+}
+
+// App returns an app by id.
+func (store *Store) App(ctx context.Context, id string) (*storeModels.App, error) {
+
+	const op = "store.apps.App"
+
+	row := store.db.QueryRowContext(ctx,
+		`SELECT id, workspace_id, name, secret, role FROM apps WHERE id = ?`,
+		id,
+	)
+
+	app, err := scanApp(row)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	return app, nil
+}
+
+// AppByName returns an app by name.
+func (store *Store) AppByName(ctx context.Context, name string) (*storeModels.App, error) {
+
+	const op = "store.apps.AppByName"
+
+	row := store.db.QueryRowContext(ctx,
+		`SELECT id, workspace_id, name, secret, role FROM apps WHERE name = ?`,
+		name,
+	)
+
+	app, err := scanApp(row)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	return app, nil
+}
+
+// AppByAPIKey returns an app owning the API key hash.
+func (store *Store) AppByAPIKey(ctx context.Context, hash string) (*storeModels.App, error) {
+
+	const op = "store.apps.AppByAPIKey"
+
+	row := store.db.QueryRowContext(ctx, `
+		SELECT a.id, a.workspace_id, a.name, a.secret, a.role
+		FROM api_keys k
+		JOIN apps a ON a.id = k.app_id
+		WHERE k.hash = ?`,
+		hash,
+	)
+
+	app, err := scanApp(row)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	return app, nil
+}
+
+// Apps returns all apps.
+func (store *Store) Apps(ctx context.Context) ([]*storeModels.App, error) {
+
+	const op = "store.apps.Apps"
+
+	rows, err := store.db.QueryContext(ctx, `SELECT id, workspace_id, name, secret, role FROM apps ORDER BY name`)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	defer rows.Close()
+
+	apps := make([]*storeModels.App, 0)
+	for rows.Next() {
+		app, err := scanApp(rows)
+		if err != nil {
+			return nil, errors.Wrap(err, op)
+		}
+		apps = append(apps, app)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	return apps, nil
+}
+
+// SaveApp creates an app.
+func (store *Store) SaveApp(ctx context.Context, app *storeModels.App) error {
+
+	const op = "store.apps.SaveApp"
+
+	_, err := store.db.ExecContext(ctx,
+		`INSERT INTO apps (id, workspace_id, name, secret, role) VALUES (?, ?, ?, ?, ?)`,
+		app.ID, app.WorkspaceID, app.Name, app.Secret, app.Role,
+	)
+	if err != nil {
+		if sqlstore.IsUniqueViolation(err) {
+			return errors.Wrap(storeModels.ErrAlreadyExists, op)
+		}
+		return errors.Wrap(err, op)
+	}
+
+	return nil
+}
+
+// SaveAPIKey stores API key hash of the app.
+func (store *Store) SaveAPIKey(ctx context.Context, appID, hash string) error {
+
+	const op = "store.apps.SaveAPIKey"
+
+	_, err := store.db.ExecContext(ctx,
+		`INSERT INTO api_keys (hash, app_id) VALUES (?, ?)`,
+		hash, appID,
+	)
+	if err != nil {
+		if sqlstore.IsUniqueViolation(err) {
+			return errors.Wrap(storeModels.ErrAlreadyExists, op)
+		}
+		return errors.Wrap(err, op)
+	}
+
+	return nil
+}
+
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+func scanApp(row scanner) (*storeModels.App, error) {
+
+	var app storeModels.App
+
+	if err := row.Scan(&app.ID, &app.WorkspaceID, &app.Name, &app.Secret, &app.Role); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, storeModels.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return &app, nil
+}
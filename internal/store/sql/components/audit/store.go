@@ -0,0 +1,188 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/pkg/errors"
+	"github.com/vishenosik/CherryWatch/internal/services/models"
+	storeModels "github.com/vishenosik/CherryWatch/internal/store/models"
+	sqlstore "github.com/vishenosik/CherryWatch/internal/store/sql"
+)
+
+type Store struct {
+	db *sqlstore.DB
+}
+
+func NewAuditStore(db *sqlstore.DB) *Store {
+	return &Store{
+		db: db,
+	}
+}
+
+const selectEntries = `
+SELECT id, workspace_id, user_id, app_id, app_name, action, resource, resource_id,
+	before, after, diff, created_at
+FROM audit_log
+`
+
+// inWorkspace limits entries to the workspace, empty workspace matches all.
+const inWorkspace = `(? = '' OR workspace_id = ?)`
+
+// SaveEntry appends an entry to the audit log.
+func (store *Store) SaveEntry(ctx context.Context, entry *models.AuditEntry) error {
+
+	const op = "store.audit.SaveEntry"
+
+	workspaceID, err := storeModels.WorkspaceScope(ctx)
+	if err != nil {
+		return errors.Wrap(err, op)
+	}
+
+	if workspaceID != "" && entry.WorkspaceID != workspaceID {
+		return errors.Wrap(storeModels.ErrNotFound, op)
+	}
+
+	diff, err := json.Marshal(entry.Diff)
+	if err != nil {
+		return errors.Wrap(err, op)
+	}
+
+	_, err = store.db.ExecContext(ctx, `
+		INSERT INTO audit_log (
+			id, workspace_id, user_id, app_id, app_name, action, resource, resource_id,
+			before, after, diff, created_at
+		)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		entry.ID,
+		entry.WorkspaceID,
+		entry.UserID,
+		entry.AppID,
+		entry.AppName,
+		string(entry.Action),
+		string(entry.Resource),
+		entry.ResourceID,
+		nullJSON(entry.Before),
+		nullJSON(entry.After),
+		diff,
+		entry.Time,
+	)
+	if err != nil {
+		return errors.Wrap(err, op)
+	}
+
+	return nil
+}
+
+// Entries returns entries matching the filter, newest first.
+func (store *Store) Entries(ctx context.Context, filter models.AuditFilter) (models.AuditEntries, error) {
+
+	const op = "store.audit.Entries"
+
+	workspaceID, err := storeModels.WorkspaceScope(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	query := selectEntries + `WHERE ` + inWorkspace + `
+		AND (? = '' OR resource = ?)
+		AND (? = '' OR resource_id = ?)
+		AND (? = '' OR action = ?)
+		AND (? = '' OR user_id = ? OR app_id = ?)`
+
+	args := []any{
+		workspaceID, workspaceID,
+		string(filter.Resource), string(filter.Resource),
+		filter.ResourceID, filter.ResourceID,
+		string(filter.Action), string(filter.Action),
+		filter.Actor, filter.Actor, filter.Actor,
+	}
+
+	// optional bounds are added only when set,
+	// postgres can't infer the type of a parameter only compared to NULL
+	if !filter.Since.IsZero() {
+		query += ` AND created_at >= ?`
+		args = append(args, filter.Since)
+	}
+	if !filter.Until.IsZero() {
+		query += ` AND created_at < ?`
+		args = append(args, filter.Until)
+	}
+
+	query += ` ORDER BY created_at DESC, id`
+
+	if filter.Limit > 0 {
+		query += ` LIMIT ?`
+		args = append(args, filter.Limit)
+	}
+
+	rows, err := store.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	defer rows.Close()
+
+	entries := make(models.AuditEntries, 0)
+	for rows.Next() {
+		entry, err := scanEntry(rows)
+		if err != nil {
+			return nil, errors.Wrap(err, op)
+		}
+		entries = append(entries, entry)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	return entries, nil
+}
+
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+func scanEntry(row scanner) (*models.AuditEntry, error) {
+	var (
+		entry               models.AuditEntry
+		action, resource    string
+		before, after, diff []byte
+	)
+	err := row.Scan(
+		&entry.ID,
+		&entry.WorkspaceID,
+		&entry.UserID,
+		&entry.AppID,
+		&entry.AppName,
+		&action,
+		&resource,
+		&entry.ResourceID,
+		&before,
+		&after,
+		&diff,
+		&entry.Time,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	entry.Action = models.AuditAction(action)
+	entry.Resource = models.AuditResource(resource)
+	entry.Before = before
+	entry.After = after
+
+	if len(diff) > 0 {
+		if err := json.Unmarshal(diff, &entry.Diff); err != nil {
+			return nil, errors.Wrap(err, "failed to decode audit diff")
+		}
+	}
+
+	return &entry, nil
+}
+
+func nullJSON(data json.RawMessage) []byte {
+	if len(data) == 0 {
+		return nil
+	}
+	return data
+}
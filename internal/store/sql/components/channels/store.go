@@ -0,0 +1,123 @@
+package channels
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/vishenosik/CherryWatch/internal/services/models"
+	storeModels "github.com/vishenosik/CherryWatch/internal/store/models"
+	sqlstore "github.com/vishenosik/CherryWatch/internal/store/sql"
+)
+
+type Store struct {
+	db *sqlstore.DB
+}
+
+func NewChannelsStore(db *sqlstore.DB) *Store {
+	return &Store{
+		db: db,
+	}
+}
+
+// inWorkspace limits channels to the workspace, empty workspace matches all.
+const inWorkspace = `(? = '' OR workspace_id = ?)`
+
+// SaveChannel inserts a channel.
+func (store *Store) SaveChannel(ctx context.Context, ch *models.Channel) error {
+
+	const op = "store.channels.SaveChannel"
+
+	_, err := store.db.ExecContext(ctx, `
+		INSERT INTO channels (id, workspace_id, name, kind, target, created_at)
+		VALUES (?, ?, ?, ?, ?, ?)`,
+		ch.ID,
+		ch.WorkspaceID,
+		ch.Name,
+		ch.Kind,
+		ch.Target,
+		ch.CreatedAt.UTC(),
+	)
+	if err != nil {
+		if sqlstore.IsUniqueViolation(err) {
+			return errors.Wrap(storeModels.ErrAlreadyExists, op)
+		}
+		return errors.Wrap(err, op)
+	}
+
+	return nil
+}
+
+// Channels returns channels of the context workspace ordered by name.
+func (store *Store) Channels(ctx context.Context) (models.Channels, error) {
+
+	const op = "store.channels.Channels"
+
+	workspaceID, err := storeModels.WorkspaceScope(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	rows, err := store.db.QueryContext(ctx, `
+		SELECT id, workspace_id, name, kind, target, created_at
+		FROM channels
+		WHERE `+inWorkspace+`
+		ORDER BY name`,
+		workspaceID, workspaceID,
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	defer rows.Close()
+
+	channels := make(models.Channels, 0)
+	for rows.Next() {
+		var ch models.Channel
+		err := rows.Scan(
+			&ch.ID,
+			&ch.WorkspaceID,
+			&ch.Name,
+			&ch.Kind,
+			&ch.Target,
+			&ch.CreatedAt,
+		)
+		if err != nil {
+			return nil, errors.Wrap(err, op)
+		}
+		channels = append(channels, &ch)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	return channels, nil
+}
+
+// DeleteChannel deletes a channel by id.
+func (store *Store) DeleteChannel(ctx context.Context, id string) error {
+
+	const op = "store.channels.DeleteChannel"
+
+	workspaceID, err := storeModels.WorkspaceScope(ctx)
+	if err != nil {
+		return errors.Wrap(err, op)
+	}
+
+	res, err := store.db.ExecContext(ctx,
+		`DELETE FROM channels WHERE id = ? AND `+inWorkspace,
+		id, workspaceID, workspaceID,
+	)
+	if err != nil {
+		return errors.Wrap(err, op)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, op)
+	}
+	if affected == 0 {
+		return errors.Wrap(storeModels.ErrNotFound, op)
+	}
+
+	return nil
+}
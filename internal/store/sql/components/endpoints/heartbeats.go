@@ -0,0 +1,142 @@
+package endpoints
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/vishenosik/CherryWatch/internal/services/models"
+	storeModels "github.com/vishenosik/CherryWatch/internal/store/models"
+)
+
+const selectHeartbeats = `
+SELECT e.id, e.workspace_id, e.name, e.url, e.type, e.protocol, h.token,
+	e.created_at, h.last_ping, h.last_kind, h.started_at
+FROM heartbeats h
+JOIN endpoints e ON e.id = h.endpoint_id
+`
+
+// HeartbeatByToken returns state of the heartbeat monitor owning the token.
+func (store *Store) HeartbeatByToken(ctx context.Context, token string) (*models.HeartbeatState, error) {
+
+	const op = "store.endpoints.HeartbeatByToken"
+
+	workspaceID, err := storeModels.WorkspaceScope(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	row := store.db.QueryRowContext(ctx,
+		selectHeartbeats+`WHERE h.token = ? AND `+inWorkspace,
+		token, workspaceID, workspaceID,
+	)
+
+	state, err := scanHeartbeat(row)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errors.Wrap(storeModels.ErrNotFound, op)
+		}
+		return nil, errors.Wrap(err, op)
+	}
+
+	return state, nil
+}
+
+// Heartbeats returns states of all heartbeat monitors.
+func (store *Store) Heartbeats(ctx context.Context) ([]*models.HeartbeatState, error) {
+
+	const op = "store.endpoints.Heartbeats"
+
+	workspaceID, err := storeModels.WorkspaceScope(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	rows, err := store.db.QueryContext(ctx,
+		selectHeartbeats+`WHERE `+inWorkspace,
+		workspaceID, workspaceID,
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	defer rows.Close()
+
+	states := make([]*models.HeartbeatState, 0)
+	for rows.Next() {
+		state, err := scanHeartbeat(rows)
+		if err != nil {
+			return nil, errors.Wrap(err, op)
+		}
+		states = append(states, state)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	return states, nil
+}
+
+// SavePing stores ping timestamps of the heartbeat monitor.
+func (store *Store) SavePing(ctx context.Context, state *models.HeartbeatState) error {
+
+	const op = "store.endpoints.SavePing"
+
+	workspaceID, err := storeModels.WorkspaceScope(ctx)
+	if err != nil {
+		return errors.Wrap(err, op)
+	}
+
+	_, err = store.db.ExecContext(ctx, `
+		UPDATE heartbeats
+		SET last_ping = ?, last_kind = ?, started_at = ?
+		WHERE endpoint_id IN (SELECT e.id FROM endpoints e WHERE e.id = ? AND `+inWorkspace+`)`,
+		nullTime(state.LastPing),
+		string(state.LastKind),
+		nullTime(state.StartedAt),
+		state.Endpoint.ID,
+		workspaceID, workspaceID,
+	)
+	if err != nil {
+		return errors.Wrap(err, op)
+	}
+
+	return nil
+}
+
+func scanHeartbeat(row scanner) (*models.HeartbeatState, error) {
+	var (
+		id, workspaceID, name, url, checkType string
+		proto                                 []byte
+		token                                 sql.NullString
+		createdAt                             time.Time
+		lastPing, startedAt                   sql.NullTime
+		lastKind                              string
+	)
+
+	err := row.Scan(
+		&id, &workspaceID, &name, &url, &checkType, &proto, &token,
+		&createdAt, &lastPing, &lastKind, &startedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	endpoint, err := decodeEndpoint(id, workspaceID, name, url, checkType, proto, token)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.HeartbeatState{
+		Endpoint:  endpoint,
+		CreatedAt: createdAt,
+		LastPing:  lastPing.Time,
+		LastKind:  models.PingKind(lastKind),
+		StartedAt: startedAt.Time,
+	}, nil
+}
+
+func nullTime(t time.Time) sql.NullTime {
+	return sql.NullTime{Time: t, Valid: !t.IsZero()}
+}
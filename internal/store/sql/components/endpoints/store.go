@@ -1 +1,347 @@
 package endpoints
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/vishenosik/CherryWatch/internal/services/models"
+	storeModels "github.com/vishenosik/CherryWatch/internal/store/models"
+	sqlstore "github.com/vishenosik/CherryWatch/internal/store/sql"
+)
+
+type Store struct {
+	db *sqlstore.DB
+}
+
+func NewEndpointsStore(db *sqlstore.DB) *Store {
+	return &Store{
+		db: db,
+	}
+}
+
+// protocol is a check definition serialized into endpoints.protocol column.
+type protocol struct {
+	SuccessCodes         []int               `json:"success_codes,omitempty"`
+	NotificationServices []string            `json:"notification_services,omitempty"`
+	Interval             time.Duration       `json:"interval"`
+	Labels               map[string]string   `json:"labels,omitempty"`
+	Severity             models.Severity     `json:"severity,omitempty"`
+	Transaction          *models.Transaction `json:"transaction,omitempty"`
+	Heartbeat            *heartbeatProtocol  `json:"heartbeat,omitempty"`
+	SQL                  *models.SQLCheck    `json:"sql,omitempty"`
+	Exec                 *models.ExecCheck   `json:"exec,omitempty"`
+	Probes               *models.Probes      `json:"probes,omitempty"`
+}
+
+type heartbeatProtocol struct {
+	Grace time.Duration `json:"grace"`
+}
+
+func encodeProtocol(endpoint *models.Endpoint) ([]byte, error) {
+	proto := protocol{
+		SuccessCodes:         endpoint.SuccessCodes,
+		NotificationServices: endpoint.NotificationServices,
+		Interval:             endpoint.Interval,
+		Labels:               endpoint.Labels,
+		Severity:             endpoint.Severity,
+		Transaction:          endpoint.Transaction,
+		SQL:                  endpoint.SQL,
+		Exec:                 endpoint.Exec,
+		Probes:               endpoint.Probes,
+	}
+	if endpoint.Heartbeat != nil {
+		proto.Heartbeat = &heartbeatProtocol{Grace: endpoint.Heartbeat.Grace}
+	}
+	return json.Marshal(proto)
+}
+
+func decodeEndpoint(
+	id, workspaceID, name, url, checkType string,
+	data []byte,
+	token sql.NullString,
+) (*models.Endpoint, error) {
+
+	var proto protocol
+	if err := json.Unmarshal(data, &proto); err != nil {
+		return nil, errors.Wrap(err, "failed to decode endpoint protocol")
+	}
+
+	endpoint := &models.Endpoint{
+		ID:                   id,
+		WorkspaceID:          workspaceID,
+		ServiceName:          name,
+		URL:                  url,
+		SuccessCodes:         proto.SuccessCodes,
+		NotificationServices: proto.NotificationServices,
+		Interval:             proto.Interval,
+		Labels:               proto.Labels,
+		Severity:             proto.Severity,
+		Type:                 models.CheckType(checkType),
+		Transaction:          proto.Transaction,
+		SQL:                  proto.SQL,
+		Exec:                 proto.Exec,
+		Probes:               proto.Probes,
+	}
+
+	if proto.Heartbeat != nil || token.Valid {
+		endpoint.Heartbeat = &models.Heartbeat{Token: token.String}
+		if proto.Heartbeat != nil {
+			endpoint.Heartbeat.Grace = proto.Heartbeat.Grace
+		}
+	}
+
+	return endpoint, nil
+}
+
+const selectEndpoints = `
+SELECT e.id, e.workspace_id, e.name, e.url, e.type, e.protocol, h.token
+FROM endpoints e
+LEFT JOIN heartbeats h ON h.endpoint_id = e.id
+`
+
+// inWorkspace limits endpoints to the workspace, empty workspace matches all.
+const inWorkspace = `(? = '' OR e.workspace_id = ?)`
+
+// SaveEndpoints inserts endpoints or updates existing ones in a single transaction.
+// Endpoints of other workspaces are never overwritten.
+func (store *Store) SaveEndpoints(ctx context.Context, endpoints models.Endpoints) error {
+	const op = "store.endpoints.SaveEndpoints"
+	return store.write(ctx, op, endpoints, upsertEndpoint)
+}
+
+// CreateEndpoints inserts new endpoints in a single transaction,
+// storeModels.ErrAlreadyExists is returned if any id is taken.
+func (store *Store) CreateEndpoints(ctx context.Context, endpoints models.Endpoints) error {
+	const op = "store.endpoints.CreateEndpoints"
+	return store.write(ctx, op, endpoints, insertEndpoint)
+}
+
+type writeFunc func(ctx context.Context, tx *sqlstore.Tx, endpoint *models.Endpoint, proto []byte) error
+
+func (store *Store) write(ctx context.Context, op string, endpoints models.Endpoints, write writeFunc) error {
+
+	workspaceID, err := storeModels.WorkspaceScope(ctx)
+	if err != nil {
+		return errors.Wrap(err, op)
+	}
+
+	for _, endpoint := range endpoints {
+		if workspaceID != "" && endpoint.WorkspaceID != workspaceID {
+			return errors.Wrap(storeModels.ErrNotFound, op)
+		}
+	}
+
+	tx, err := store.db.BeginTx(ctx, nil)
+	if err != nil {
+		return errors.Wrap(err, op)
+	}
+	defer tx.Rollback()
+
+	for _, endpoint := range endpoints {
+		if err := saveEndpoint(ctx, tx, endpoint, write); err != nil {
+			return errors.Wrap(err, op)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errors.Wrap(err, op)
+	}
+	return nil
+}
+
+func saveEndpoint(ctx context.Context, tx *sqlstore.Tx, endpoint *models.Endpoint, write writeFunc) error {
+
+	proto, err := encodeProtocol(endpoint)
+	if err != nil {
+		return err
+	}
+
+	if err := write(ctx, tx, endpoint, proto); err != nil {
+		return err
+	}
+
+	if endpoint.Heartbeat == nil {
+		_, err = tx.ExecContext(ctx, `DELETE FROM heartbeats WHERE endpoint_id = ?`, endpoint.ID)
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO heartbeats (endpoint_id, token)
+		VALUES (?, ?)
+		ON CONFLICT (endpoint_id) DO UPDATE SET token = excluded.token`,
+		endpoint.ID,
+		endpoint.Heartbeat.Token,
+	)
+	if sqlstore.IsUniqueViolation(err) {
+		return storeModels.ErrAlreadyExists
+	}
+	return err
+}
+
+func insertEndpoint(ctx context.Context, tx *sqlstore.Tx, endpoint *models.Endpoint, proto []byte) error {
+
+	_, err := tx.ExecContext(ctx, `
+		INSERT INTO endpoints (id, workspace_id, name, url, type, protocol)
+		VALUES (?, ?, ?, ?, ?, ?)`,
+		endpoint.ID,
+		endpoint.WorkspaceID,
+		endpoint.ServiceName,
+		endpoint.URL,
+		string(endpoint.CheckType()),
+		proto,
+	)
+	if sqlstore.IsUniqueViolation(err) {
+		return storeModels.ErrAlreadyExists
+	}
+	return err
+}
+
+func upsertEndpoint(ctx context.Context, tx *sqlstore.Tx, endpoint *models.Endpoint, proto []byte) error {
+
+	res, err := tx.ExecContext(ctx, `
+		INSERT INTO endpoints (id, workspace_id, name, url, type, protocol)
+		VALUES (?, ?, ?, ?, ?, ?)
+		ON CONFLICT (id) DO UPDATE SET
+			name = excluded.name,
+			url = excluded.url,
+			type = excluded.type,
+			protocol = excluded.protocol
+		WHERE endpoints.workspace_id = excluded.workspace_id`,
+		endpoint.ID,
+		endpoint.WorkspaceID,
+		endpoint.ServiceName,
+		endpoint.URL,
+		string(endpoint.CheckType()),
+		proto,
+	)
+	if err != nil {
+		return err
+	}
+
+	// id is taken in another workspace
+	if affected, err := res.RowsAffected(); err != nil {
+		return err
+	} else if affected == 0 {
+		return storeModels.ErrAlreadyExists
+	}
+
+	return nil
+}
+
+// Endpoint returns endpoint by id.
+func (store *Store) Endpoint(ctx context.Context, id string) (*models.Endpoint, error) {
+
+	const op = "store.endpoints.Endpoint"
+
+	workspaceID, err := storeModels.WorkspaceScope(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	row := store.db.QueryRowContext(ctx,
+		selectEndpoints+`WHERE e.id = ? AND `+inWorkspace,
+		id, workspaceID, workspaceID,
+	)
+
+	endpoint, err := scanEndpoint(row)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errors.Wrap(storeModels.ErrNotFound, op)
+		}
+		return nil, errors.Wrap(err, op)
+	}
+
+	return endpoint, nil
+}
+
+// Endpoints returns all endpoints of the workspace ordered by name.
+func (store *Store) Endpoints(ctx context.Context) (models.Endpoints, error) {
+
+	const op = "store.endpoints.Endpoints"
+
+	workspaceID, err := storeModels.WorkspaceScope(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	rows, err := store.db.QueryContext(ctx,
+		selectEndpoints+`WHERE `+inWorkspace+` ORDER BY e.name, e.id`,
+		workspaceID, workspaceID,
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	defer rows.Close()
+
+	endpoints := make(models.Endpoints, 0)
+	for rows.Next() {
+		endpoint, err := scanEndpoint(rows)
+		if err != nil {
+			return nil, errors.Wrap(err, op)
+		}
+		endpoints = append(endpoints, endpoint)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	return endpoints, nil
+}
+
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+func scanEndpoint(row scanner) (*models.Endpoint, error) {
+	var (
+		id, workspaceID, name, url, checkType string
+		proto                                 []byte
+		token                                 sql.NullString
+	)
+	if err := row.Scan(&id, &workspaceID, &name, &url, &checkType, &proto, &token); err != nil {
+		return nil, err
+	}
+	return decodeEndpoint(id, workspaceID, name, url, checkType, proto, token)
+}
+
+// DeleteEndpoint deletes endpoint by id.
+func (store *Store) DeleteEndpoint(ctx context.Context, id string) error {
+
+	const op = "store.endpoints.DeleteEndpoint"
+
+	workspaceID, err := storeModels.WorkspaceScope(ctx)
+	if err != nil {
+		return errors.Wrap(err, op)
+	}
+
+	tx, err := store.db.BeginTx(ctx, nil)
+	if err != nil {
+		return errors.Wrap(err, op)
+	}
+	defer tx.Rollback()
+
+	res, err := tx.ExecContext(ctx,
+		`DELETE FROM endpoints AS e WHERE e.id = ? AND `+inWorkspace,
+		id, workspaceID, workspaceID,
+	)
+	if err != nil {
+		return errors.Wrap(err, op)
+	}
+
+	if affected, err := res.RowsAffected(); err == nil && affected == 0 {
+		return errors.Wrap(storeModels.ErrNotFound, op)
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM heartbeats WHERE endpoint_id = ?`, id); err != nil {
+		return errors.Wrap(err, op)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errors.Wrap(err, op)
+	}
+	return nil
+}
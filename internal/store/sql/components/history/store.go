@@ -0,0 +1,112 @@
+package history
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/vishenosik/CherryWatch/internal/services/models"
+	storeModels "github.com/vishenosik/CherryWatch/internal/store/models"
+	sqlstore "github.com/vishenosik/CherryWatch/internal/store/sql"
+)
+
+// dayLayout is the day column format, it keeps days ordered as strings.
+const dayLayout = time.DateOnly
+
+type Store struct {
+	db *sqlstore.DB
+}
+
+func NewHistoryStore(db *sqlstore.DB) *Store {
+	return &Store{
+		db: db,
+	}
+}
+
+// RecordResult adds the check result to the daily totals of the endpoint.
+func (store *Store) RecordResult(ctx context.Context, endpoint *models.Endpoint, result *models.CheckResult) error {
+
+	const op = "store.history.RecordResult"
+
+	var failures int
+	if !result.Success {
+		failures = 1
+	}
+
+	_, err := store.db.ExecContext(ctx, `
+		INSERT INTO check_history (endpoint_id, workspace_id, day, checks, failures, latency_ms)
+		VALUES (?, ?, ?, 1, ?, ?)
+		ON CONFLICT (endpoint_id, day) DO UPDATE SET
+			checks = check_history.checks + 1,
+			failures = check_history.failures + excluded.failures,
+			latency_ms = check_history.latency_ms + excluded.latency_ms`,
+		endpoint.ID,
+		endpoint.WorkspaceID,
+		models.Day(result.CheckedAt).Format(dayLayout),
+		failures,
+		result.Latency.Milliseconds(),
+	)
+	if err != nil {
+		return errors.Wrap(err, op)
+	}
+
+	return nil
+}
+
+// DailyChecks returns daily totals of the endpoints since the day, oldest first.
+func (store *Store) DailyChecks(ctx context.Context, endpointIDs []string, since time.Time) ([]*models.DailyChecks, error) {
+
+	const op = "store.history.DailyChecks"
+
+	workspaceID, err := storeModels.WorkspaceScope(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	if len(endpointIDs) == 0 {
+		return []*models.DailyChecks{}, nil
+	}
+
+	args := make([]any, 0, len(endpointIDs)+3)
+	for _, id := range endpointIDs {
+		args = append(args, id)
+	}
+	args = append(args, models.Day(since).Format(dayLayout), workspaceID, workspaceID)
+
+	rows, err := store.db.QueryContext(ctx, `
+		SELECT endpoint_id, day, checks, failures, latency_ms
+		FROM check_history
+		WHERE endpoint_id IN (?`+strings.Repeat(", ?", len(endpointIDs)-1)+`)
+			AND day >= ? AND (? = '' OR workspace_id = ?)
+		ORDER BY day, endpoint_id`,
+		args...,
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	defer rows.Close()
+
+	history := make([]*models.DailyChecks, 0)
+	for rows.Next() {
+		var (
+			checks    models.DailyChecks
+			day       string
+			latencyMs int64
+		)
+		if err := rows.Scan(&checks.EndpointID, &day, &checks.Checks, &checks.Failures, &latencyMs); err != nil {
+			return nil, errors.Wrap(err, op)
+		}
+		if checks.Day, err = time.Parse(dayLayout, day); err != nil {
+			return nil, errors.Wrap(err, op)
+		}
+		checks.Latency = time.Duration(latencyMs) * time.Millisecond
+		history = append(history, &checks)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	return history, nil
+}
@@ -0,0 +1,200 @@
+package incidents
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/vishenosik/CherryWatch/internal/services/models"
+	storeModels "github.com/vishenosik/CherryWatch/internal/store/models"
+	sqlstore "github.com/vishenosik/CherryWatch/internal/store/sql"
+)
+
+type Store struct {
+	db *sqlstore.DB
+}
+
+func NewIncidentsStore(db *sqlstore.DB) *Store {
+	return &Store{
+		db: db,
+	}
+}
+
+const selectIncidents = `
+SELECT id, endpoint_id, workspace_id, cause, opened_at, resolved_at, acknowledged_at, acknowledged_by
+FROM incidents
+`
+
+// inWorkspace limits incidents to the workspace, empty workspace matches all.
+const inWorkspace = `(? = '' OR workspace_id = ?)`
+
+// Incident returns incident by id.
+func (store *Store) Incident(ctx context.Context, id string) (*models.Incident, error) {
+
+	const op = "store.incidents.Incident"
+
+	workspaceID, err := storeModels.WorkspaceScope(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	row := store.db.QueryRowContext(ctx,
+		selectIncidents+`WHERE id = ? AND `+inWorkspace,
+		id, workspaceID, workspaceID,
+	)
+
+	incident, err := scanIncident(row)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errors.Wrap(storeModels.ErrNotFound, op)
+		}
+		return nil, errors.Wrap(err, op)
+	}
+
+	return incident, nil
+}
+
+// ActiveIncident returns unresolved incident of the endpoint.
+func (store *Store) ActiveIncident(ctx context.Context, endpointID string) (*models.Incident, error) {
+
+	const op = "store.incidents.ActiveIncident"
+
+	workspaceID, err := storeModels.WorkspaceScope(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	row := store.db.QueryRowContext(ctx,
+		selectIncidents+`WHERE endpoint_id = ? AND resolved_at IS NULL AND `+inWorkspace+`
+		ORDER BY opened_at DESC LIMIT 1`,
+		endpointID, workspaceID, workspaceID,
+	)
+
+	incident, err := scanIncident(row)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errors.Wrap(storeModels.ErrNotFound, op)
+		}
+		return nil, errors.Wrap(err, op)
+	}
+
+	return incident, nil
+}
+
+// Incidents returns incidents matching the filter, newest first.
+func (store *Store) Incidents(ctx context.Context, filter models.IncidentsFilter) (models.Incidents, error) {
+
+	const op = "store.incidents.Incidents"
+
+	workspaceID, err := storeModels.WorkspaceScope(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	query := selectIncidents + `WHERE (? = '' OR endpoint_id = ?) AND (? = FALSE OR resolved_at IS NULL) AND ` +
+		inWorkspace + ` ORDER BY opened_at DESC`
+
+	rows, err := store.db.QueryContext(ctx, query,
+		filter.EndpointID, filter.EndpointID,
+		filter.ActiveOnly,
+		workspaceID, workspaceID,
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	defer rows.Close()
+
+	incidents := make(models.Incidents, 0)
+	for rows.Next() {
+		incident, err := scanIncident(rows)
+		if err != nil {
+			return nil, errors.Wrap(err, op)
+		}
+		incidents = append(incidents, incident)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	return incidents, nil
+}
+
+// SaveIncident inserts incident or updates the existing one.
+// Inserting a second active incident of an endpoint fails with ErrAlreadyExists.
+func (store *Store) SaveIncident(ctx context.Context, incident *models.Incident) error {
+
+	const op = "store.incidents.SaveIncident"
+
+	workspaceID, err := storeModels.WorkspaceScope(ctx)
+	if err != nil {
+		return errors.Wrap(err, op)
+	}
+
+	if workspaceID != "" && incident.WorkspaceID != workspaceID {
+		return errors.Wrap(storeModels.ErrNotFound, op)
+	}
+
+	_, err = store.db.ExecContext(ctx, `
+		INSERT INTO incidents (
+			id, endpoint_id, workspace_id, cause, opened_at, resolved_at, acknowledged_at, acknowledged_by
+		)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT (id) DO UPDATE SET
+			cause = excluded.cause,
+			resolved_at = excluded.resolved_at,
+			acknowledged_at = excluded.acknowledged_at,
+			acknowledged_by = excluded.acknowledged_by
+		WHERE incidents.workspace_id = excluded.workspace_id`,
+		incident.ID,
+		incident.EndpointID,
+		incident.WorkspaceID,
+		incident.Cause,
+		incident.OpenedAt,
+		nullTime(incident.ResolvedAt),
+		nullTime(incident.AcknowledgedAt),
+		incident.AcknowledgedBy,
+	)
+	if err != nil {
+		// an endpoint has a single active incident
+		if sqlstore.IsUniqueViolation(err) {
+			return errors.Wrap(storeModels.ErrAlreadyExists, op)
+		}
+		return errors.Wrap(err, op)
+	}
+
+	return nil
+}
+
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+func scanIncident(row scanner) (*models.Incident, error) {
+	var (
+		incident       models.Incident
+		resolvedAt     sql.NullTime
+		acknowledgedAt sql.NullTime
+	)
+	err := row.Scan(
+		&incident.ID,
+		&incident.EndpointID,
+		&incident.WorkspaceID,
+		&incident.Cause,
+		&incident.OpenedAt,
+		&resolvedAt,
+		&acknowledgedAt,
+		&incident.AcknowledgedBy,
+	)
+	if err != nil {
+		return nil, err
+	}
+	incident.ResolvedAt = resolvedAt.Time
+	incident.AcknowledgedAt = acknowledgedAt.Time
+	return &incident, nil
+}
+
+func nullTime(t time.Time) sql.NullTime {
+	return sql.NullTime{Time: t, Valid: !t.IsZero()}
+}
@@ -0,0 +1,165 @@
+package incidents
+
+import (
+	"context"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/vishenosik/CherryWatch/internal/services/models"
+	storeModels "github.com/vishenosik/CherryWatch/internal/store/models"
+	sqlstore "github.com/vishenosik/CherryWatch/internal/store/sql"
+)
+
+// SaveIncidentUpdate inserts an incident update, updates are never changed.
+func (store *Store) SaveIncidentUpdate(ctx context.Context, update *models.IncidentUpdate) error {
+
+	const op = "store.incidents.SaveIncidentUpdate"
+
+	_, err := store.db.ExecContext(ctx, `
+		INSERT INTO incident_updates (id, incident_id, workspace_id, state, body, author, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?)`,
+		update.ID,
+		update.IncidentID,
+		update.WorkspaceID,
+		update.State,
+		update.Body,
+		update.Author,
+		update.CreatedAt.UTC(),
+	)
+	if err != nil {
+		return errors.Wrap(err, op)
+	}
+
+	return nil
+}
+
+// IncidentUpdates returns updates of the incidents, oldest first.
+func (store *Store) IncidentUpdates(ctx context.Context, incidentIDs []string) ([]*models.IncidentUpdate, error) {
+
+	const op = "store.incidents.IncidentUpdates"
+
+	if len(incidentIDs) == 0 {
+		return []*models.IncidentUpdate{}, nil
+	}
+
+	workspaceID, err := storeModels.WorkspaceScope(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	args := make([]any, 0, len(incidentIDs)+2)
+	for _, id := range incidentIDs {
+		args = append(args, id)
+	}
+	args = append(args, workspaceID, workspaceID)
+
+	rows, err := store.db.QueryContext(ctx, `
+		SELECT id, incident_id, workspace_id, state, body, author, created_at
+		FROM incident_updates
+		WHERE incident_id IN (?`+strings.Repeat(", ?", len(incidentIDs)-1)+`) AND `+inWorkspace+`
+		ORDER BY created_at, id`,
+		args...,
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	defer rows.Close()
+
+	updates := make([]*models.IncidentUpdate, 0)
+	for rows.Next() {
+		var update models.IncidentUpdate
+		err := rows.Scan(
+			&update.ID,
+			&update.IncidentID,
+			&update.WorkspaceID,
+			&update.State,
+			&update.Body,
+			&update.Author,
+			&update.CreatedAt,
+		)
+		if err != nil {
+			return nil, errors.Wrap(err, op)
+		}
+		updates = append(updates, &update)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	return updates, nil
+}
+
+// SavePostmortem stores the postmortem as the next version of the incident postmortem,
+// the version number is set on the postmortem.
+func (store *Store) SavePostmortem(ctx context.Context, postmortem *models.Postmortem) error {
+
+	const op = "store.incidents.SavePostmortem"
+
+	row := store.db.QueryRowContext(ctx, `
+		INSERT INTO postmortems (incident_id, version, workspace_id, body, author, created_at)
+		VALUES (?, (SELECT COALESCE(MAX(version), 0) + 1 FROM postmortems WHERE incident_id = ?), ?, ?, ?, ?)
+		RETURNING version`,
+		postmortem.IncidentID,
+		postmortem.IncidentID,
+		postmortem.WorkspaceID,
+		postmortem.Body,
+		postmortem.Author,
+		postmortem.CreatedAt.UTC(),
+	)
+
+	if err := row.Scan(&postmortem.Version); err != nil {
+		if sqlstore.IsUniqueViolation(err) {
+			return errors.Wrap(storeModels.ErrAlreadyExists, op)
+		}
+		return errors.Wrap(err, op)
+	}
+
+	return nil
+}
+
+// Postmortems returns every version of the incident postmortem, newest first.
+func (store *Store) Postmortems(ctx context.Context, incidentID string) ([]*models.Postmortem, error) {
+
+	const op = "store.incidents.Postmortems"
+
+	workspaceID, err := storeModels.WorkspaceScope(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	rows, err := store.db.QueryContext(ctx, `
+		SELECT incident_id, version, workspace_id, body, author, created_at
+		FROM postmortems
+		WHERE incident_id = ? AND `+inWorkspace+`
+		ORDER BY version DESC`,
+		incidentID, workspaceID, workspaceID,
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	defer rows.Close()
+
+	postmortems := make([]*models.Postmortem, 0)
+	for rows.Next() {
+		var postmortem models.Postmortem
+		err := rows.Scan(
+			&postmortem.IncidentID,
+			&postmortem.Version,
+			&postmortem.WorkspaceID,
+			&postmortem.Body,
+			&postmortem.Author,
+			&postmortem.CreatedAt,
+		)
+		if err != nil {
+			return nil, errors.Wrap(err, op)
+		}
+		postmortems = append(postmortems, &postmortem)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	return postmortems, nil
+}
@@ -0,0 +1,144 @@
+package maintenances
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/pkg/errors"
+	"github.com/vishenosik/CherryWatch/internal/services/models"
+	storeModels "github.com/vishenosik/CherryWatch/internal/store/models"
+	sqlstore "github.com/vishenosik/CherryWatch/internal/store/sql"
+)
+
+type Store struct {
+	db *sqlstore.DB
+}
+
+func NewMaintenancesStore(db *sqlstore.DB) *Store {
+	return &Store{
+		db: db,
+	}
+}
+
+// inWorkspace limits maintenances to the workspace, empty workspace matches all.
+const inWorkspace = `(? = '' OR workspace_id = ?)`
+
+// SaveMaintenance inserts a maintenance.
+func (store *Store) SaveMaintenance(ctx context.Context, mnt *models.Maintenance) error {
+
+	const op = "store.maintenances.SaveMaintenance"
+
+	endpointIDs, err := json.Marshal(mnt.EndpointIDs)
+	if err != nil {
+		return errors.Wrap(err, op)
+	}
+
+	_, err = store.db.ExecContext(ctx, `
+		INSERT INTO maintenances (id, workspace_id, title, message, starts_at, ends_at, endpoint_ids)
+		VALUES (?, ?, ?, ?, ?, ?, ?)`,
+		mnt.ID,
+		mnt.WorkspaceID,
+		mnt.Title,
+		mnt.Message,
+		mnt.StartsAt.UTC(),
+		mnt.EndsAt.UTC(),
+		endpointIDs,
+	)
+	if err != nil {
+		return errors.Wrap(err, op)
+	}
+
+	return nil
+}
+
+// Maintenances returns maintenances matching the filter ordered by start.
+func (store *Store) Maintenances(ctx context.Context, filter models.MaintenancesFilter) (models.Maintenances, error) {
+
+	const op = "store.maintenances.Maintenances"
+
+	workspaceID, err := storeModels.WorkspaceScope(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	query := `
+		SELECT id, workspace_id, title, message, starts_at, ends_at, endpoint_ids
+		FROM maintenances
+		WHERE ` + inWorkspace
+
+	args := []any{workspaceID, workspaceID}
+
+	// the bound is added only when set,
+	// postgres can't infer the type of a parameter only compared to NULL
+	if !filter.EndsAfter.IsZero() {
+		query += ` AND ends_at > ?`
+		args = append(args, filter.EndsAfter.UTC())
+	}
+
+	rows, err := store.db.QueryContext(ctx, query+` ORDER BY starts_at`, args...)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	defer rows.Close()
+
+	maintenances := make(models.Maintenances, 0)
+	for rows.Next() {
+		var (
+			mnt         models.Maintenance
+			endpointIDs []byte
+		)
+		err := rows.Scan(
+			&mnt.ID,
+			&mnt.WorkspaceID,
+			&mnt.Title,
+			&mnt.Message,
+			&mnt.StartsAt,
+			&mnt.EndsAt,
+			&endpointIDs,
+		)
+		if err != nil {
+			return nil, errors.Wrap(err, op)
+		}
+		if len(endpointIDs) > 0 {
+			if err := json.Unmarshal(endpointIDs, &mnt.EndpointIDs); err != nil {
+				return nil, errors.Wrap(err, op)
+			}
+		}
+		maintenances = append(maintenances, &mnt)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	return maintenances, nil
+}
+
+// DeleteMaintenance deletes a maintenance by id.
+func (store *Store) DeleteMaintenance(ctx context.Context, id string) error {
+
+	const op = "store.maintenances.DeleteMaintenance"
+
+	workspaceID, err := storeModels.WorkspaceScope(ctx)
+	if err != nil {
+		return errors.Wrap(err, op)
+	}
+
+	res, err := store.db.ExecContext(ctx,
+		`DELETE FROM maintenances WHERE id = ? AND `+inWorkspace,
+		id, workspaceID, workspaceID,
+	)
+	if err != nil {
+		return errors.Wrap(err, op)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, op)
+	}
+	if affected == 0 {
+		return errors.Wrap(storeModels.ErrNotFound, op)
+	}
+
+	return nil
+}
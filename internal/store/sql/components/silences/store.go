@@ -0,0 +1,144 @@
+package silences
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/pkg/errors"
+	"github.com/vishenosik/CherryWatch/internal/services/models"
+	storeModels "github.com/vishenosik/CherryWatch/internal/store/models"
+	sqlstore "github.com/vishenosik/CherryWatch/internal/store/sql"
+)
+
+type Store struct {
+	db *sqlstore.DB
+}
+
+func NewSilencesStore(db *sqlstore.DB) *Store {
+	return &Store{
+		db: db,
+	}
+}
+
+// inWorkspace limits silences to the workspace, empty workspace matches all.
+const inWorkspace = `(? = '' OR workspace_id = ?)`
+
+// SaveSilence inserts a silence.
+func (store *Store) SaveSilence(ctx context.Context, sil *models.Silence) error {
+
+	const op = "store.silences.SaveSilence"
+
+	endpointIDs, err := json.Marshal(sil.EndpointIDs)
+	if err != nil {
+		return errors.Wrap(err, op)
+	}
+
+	_, err = store.db.ExecContext(ctx, `
+		INSERT INTO silences (id, workspace_id, comment, created_by, starts_at, ends_at, endpoint_ids)
+		VALUES (?, ?, ?, ?, ?, ?, ?)`,
+		sil.ID,
+		sil.WorkspaceID,
+		sil.Comment,
+		sil.CreatedBy,
+		sil.StartsAt.UTC(),
+		sil.EndsAt.UTC(),
+		endpointIDs,
+	)
+	if err != nil {
+		return errors.Wrap(err, op)
+	}
+
+	return nil
+}
+
+// Silences returns silences matching the filter ordered by start.
+func (store *Store) Silences(ctx context.Context, filter models.SilencesFilter) (models.Silences, error) {
+
+	const op = "store.silences.Silences"
+
+	workspaceID, err := storeModels.WorkspaceScope(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	query := `
+		SELECT id, workspace_id, comment, created_by, starts_at, ends_at, endpoint_ids
+		FROM silences
+		WHERE ` + inWorkspace
+
+	args := []any{workspaceID, workspaceID}
+
+	// the bound is added only when set,
+	// postgres can't infer the type of a parameter only compared to NULL
+	if !filter.EndsAfter.IsZero() {
+		query += ` AND ends_at > ?`
+		args = append(args, filter.EndsAfter.UTC())
+	}
+
+	rows, err := store.db.QueryContext(ctx, query+` ORDER BY starts_at`, args...)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	defer rows.Close()
+
+	silences := make(models.Silences, 0)
+	for rows.Next() {
+		var (
+			sil         models.Silence
+			endpointIDs []byte
+		)
+		err := rows.Scan(
+			&sil.ID,
+			&sil.WorkspaceID,
+			&sil.Comment,
+			&sil.CreatedBy,
+			&sil.StartsAt,
+			&sil.EndsAt,
+			&endpointIDs,
+		)
+		if err != nil {
+			return nil, errors.Wrap(err, op)
+		}
+		if len(endpointIDs) > 0 {
+			if err := json.Unmarshal(endpointIDs, &sil.EndpointIDs); err != nil {
+				return nil, errors.Wrap(err, op)
+			}
+		}
+		silences = append(silences, &sil)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	return silences, nil
+}
+
+// DeleteSilence deletes a silence by id.
+func (store *Store) DeleteSilence(ctx context.Context, id string) error {
+
+	const op = "store.silences.DeleteSilence"
+
+	workspaceID, err := storeModels.WorkspaceScope(ctx)
+	if err != nil {
+		return errors.Wrap(err, op)
+	}
+
+	res, err := store.db.ExecContext(ctx,
+		`DELETE FROM silences WHERE id = ? AND `+inWorkspace,
+		id, workspaceID, workspaceID,
+	)
+	if err != nil {
+		return errors.Wrap(err, op)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, op)
+	}
+	if affected == 0 {
+		return errors.Wrap(storeModels.ErrNotFound, op)
+	}
+
+	return nil
+}
@@ -0,0 +1,239 @@
+package statuspages
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+
+	"github.com/pkg/errors"
+	"github.com/vishenosik/CherryWatch/internal/services/models"
+	storeModels "github.com/vishenosik/CherryWatch/internal/store/models"
+	sqlstore "github.com/vishenosik/CherryWatch/internal/store/sql"
+)
+
+type Store struct {
+	db *sqlstore.DB
+}
+
+func NewStatusPagesStore(db *sqlstore.DB) *Store {
+	return &Store{
+		db: db,
+	}
+}
+
+const selectStatusPages = `
+SELECT id, workspace_id, slug, title, logo_url, endpoint_ids, selector, visibility, token, host
+FROM status_pages
+`
+
+// inWorkspace limits status pages to the workspace, empty workspace matches all.
+const inWorkspace = `(? = '' OR workspace_id = ?)`
+
+// StatusPage returns a status page by id.
+func (store *Store) StatusPage(ctx context.Context, id string) (*models.StatusPageConfig, error) {
+	return store.statusPage(ctx, "store.statuspages.StatusPage", "id", id)
+}
+
+// StatusPageBySlug returns a status page by slug.
+func (store *Store) StatusPageBySlug(ctx context.Context, slug string) (*models.StatusPageConfig, error) {
+	return store.statusPage(ctx, "store.statuspages.StatusPageBySlug", "slug", slug)
+}
+
+// StatusPageByHost returns a status page bound to the host.
+func (store *Store) StatusPageByHost(ctx context.Context, host string) (*models.StatusPageConfig, error) {
+	if host == "" {
+		return nil, errors.Wrap(storeModels.ErrNotFound, "store.statuspages.StatusPageByHost")
+	}
+	return store.statusPage(ctx, "store.statuspages.StatusPageByHost", "host", host)
+}
+
+// StatusPages returns status pages ordered by slug.
+func (store *Store) StatusPages(ctx context.Context) (models.StatusPageConfigs, error) {
+
+	const op = "store.statuspages.StatusPages"
+
+	workspaceID, err := storeModels.WorkspaceScope(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	rows, err := store.db.QueryContext(ctx,
+		selectStatusPages+`WHERE `+inWorkspace+` ORDER BY slug`,
+		workspaceID, workspaceID,
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	defer rows.Close()
+
+	pages := make(models.StatusPageConfigs, 0)
+	for rows.Next() {
+		page, err := scanStatusPage(rows)
+		if err != nil {
+			return nil, errors.Wrap(err, op)
+		}
+		pages = append(pages, page)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	return pages, nil
+}
+
+// SaveStatusPage inserts status page or updates the existing one.
+func (store *Store) SaveStatusPage(ctx context.Context, page *models.StatusPageConfig) error {
+
+	const op = "store.statuspages.SaveStatusPage"
+
+	workspaceID, err := storeModels.WorkspaceScope(ctx)
+	if err != nil {
+		return errors.Wrap(err, op)
+	}
+
+	if workspaceID != "" && page.WorkspaceID != workspaceID {
+		return errors.Wrap(storeModels.ErrNotFound, op)
+	}
+
+	endpointIDs, err := json.Marshal(page.EndpointIDs)
+	if err != nil {
+		return errors.Wrap(err, op)
+	}
+
+	selector, err := json.Marshal(page.Selector)
+	if err != nil {
+		return errors.Wrap(err, op)
+	}
+
+	_, err = store.db.ExecContext(ctx, `
+		INSERT INTO status_pages (
+			id, workspace_id, slug, title, logo_url, endpoint_ids, selector, visibility, token, host
+		)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT (id) DO UPDATE SET
+			slug = excluded.slug,
+			title = excluded.title,
+			logo_url = excluded.logo_url,
+			endpoint_ids = excluded.endpoint_ids,
+			selector = excluded.selector,
+			visibility = excluded.visibility,
+			token = excluded.token,
+			host = excluded.host
+		WHERE status_pages.workspace_id = excluded.workspace_id`,
+		page.ID,
+		page.WorkspaceID,
+		page.Slug,
+		page.Title,
+		page.LogoURL,
+		endpointIDs,
+		selector,
+		page.Visibility,
+		page.Token,
+		page.Host,
+	)
+	if err != nil {
+		if sqlstore.IsUniqueViolation(err) {
+			return errors.Wrap(storeModels.ErrAlreadyExists, op)
+		}
+		return errors.Wrap(err, op)
+	}
+
+	return nil
+}
+
+// DeleteStatusPage deletes a status page by id.
+func (store *Store) DeleteStatusPage(ctx context.Context, id string) error {
+
+	const op = "store.statuspages.DeleteStatusPage"
+
+	workspaceID, err := storeModels.WorkspaceScope(ctx)
+	if err != nil {
+		return errors.Wrap(err, op)
+	}
+
+	res, err := store.db.ExecContext(ctx,
+		`DELETE FROM status_pages WHERE id = ? AND `+inWorkspace,
+		id, workspaceID, workspaceID,
+	)
+	if err != nil {
+		return errors.Wrap(err, op)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, op)
+	}
+	if affected == 0 {
+		return errors.Wrap(storeModels.ErrNotFound, op)
+	}
+
+	return nil
+}
+
+// statusPage returns a status page by a unique column.
+func (store *Store) statusPage(ctx context.Context, op, column, value string) (*models.StatusPageConfig, error) {
+
+	workspaceID, err := storeModels.WorkspaceScope(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	row := store.db.QueryRowContext(ctx,
+		selectStatusPages+`WHERE `+column+` = ? AND `+inWorkspace,
+		value, workspaceID, workspaceID,
+	)
+
+	page, err := scanStatusPage(row)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errors.Wrap(storeModels.ErrNotFound, op)
+		}
+		return nil, errors.Wrap(err, op)
+	}
+
+	return page, nil
+}
+
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+func scanStatusPage(row scanner) (*models.StatusPageConfig, error) {
+
+	var (
+		page        models.StatusPageConfig
+		endpointIDs []byte
+		selector    []byte
+	)
+
+	err := row.Scan(
+		&page.ID,
+		&page.WorkspaceID,
+		&page.Slug,
+		&page.Title,
+		&page.LogoURL,
+		&endpointIDs,
+		&selector,
+		&page.Visibility,
+		&page.Token,
+		&page.Host,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(endpointIDs) > 0 {
+		if err := json.Unmarshal(endpointIDs, &page.EndpointIDs); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(selector) > 0 {
+		if err := json.Unmarshal(selector, &page.Selector); err != nil {
+			return nil, err
+		}
+	}
+
+	return &page, nil
+}
@@ -0,0 +1,196 @@
+package subscriptions
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/vishenosik/CherryWatch/internal/services/models"
+	storeModels "github.com/vishenosik/CherryWatch/internal/store/models"
+	sqlstore "github.com/vishenosik/CherryWatch/internal/store/sql"
+)
+
+type Store struct {
+	db *sqlstore.DB
+}
+
+func NewSubscriptionsStore(db *sqlstore.DB) *Store {
+	return &Store{
+		db: db,
+	}
+}
+
+const selectSubscriptions = `
+SELECT id, page_id, workspace_id, kind, target, components, token, confirmed_at, created_at
+FROM subscriptions
+`
+
+// SaveSubscription inserts a subscription.
+func (store *Store) SaveSubscription(ctx context.Context, sub *models.Subscription) error {
+
+	const op = "store.subscriptions.SaveSubscription"
+
+	components, err := json.Marshal(sub.Components)
+	if err != nil {
+		return errors.Wrap(err, op)
+	}
+
+	_, err = store.db.ExecContext(ctx, `
+		INSERT INTO subscriptions (
+			id, page_id, workspace_id, kind, target, components, token, confirmed_at, created_at
+		)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		sub.ID,
+		sub.PageID,
+		sub.WorkspaceID,
+		sub.Kind,
+		sub.Target,
+		components,
+		sub.Token,
+		nullTime(sub.ConfirmedAt),
+		sub.CreatedAt.UTC(),
+	)
+	if err != nil {
+		if sqlstore.IsUniqueViolation(err) {
+			return errors.Wrap(storeModels.ErrAlreadyExists, op)
+		}
+		return errors.Wrap(err, op)
+	}
+
+	return nil
+}
+
+// SubscriptionByToken returns a subscription by its link token.
+func (store *Store) SubscriptionByToken(ctx context.Context, token string) (*models.Subscription, error) {
+
+	const op = "store.subscriptions.SubscriptionByToken"
+
+	sub, err := scanSubscription(store.db.QueryRowContext(ctx, selectSubscriptions+`WHERE token = ?`, token))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errors.Wrap(storeModels.ErrNotFound, op)
+		}
+		return nil, errors.Wrap(err, op)
+	}
+
+	return sub, nil
+}
+
+// Subscriptions returns confirmed subscriptions of the page.
+func (store *Store) Subscriptions(ctx context.Context, pageID string) (models.Subscriptions, error) {
+
+	const op = "store.subscriptions.Subscriptions"
+
+	rows, err := store.db.QueryContext(ctx,
+		selectSubscriptions+`WHERE page_id = ? AND confirmed_at IS NOT NULL ORDER BY created_at`,
+		pageID,
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	defer rows.Close()
+
+	subs := make(models.Subscriptions, 0)
+	for rows.Next() {
+		sub, err := scanSubscription(rows)
+		if err != nil {
+			return nil, errors.Wrap(err, op)
+		}
+		subs = append(subs, sub)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	return subs, nil
+}
+
+// ConfirmSubscription marks the subscription confirmed, keeping the first confirmation time.
+func (store *Store) ConfirmSubscription(ctx context.Context, id string, at time.Time) error {
+
+	const op = "store.subscriptions.ConfirmSubscription"
+
+	res, err := store.db.ExecContext(ctx,
+		`UPDATE subscriptions SET confirmed_at = COALESCE(confirmed_at, ?) WHERE id = ?`,
+		at.UTC(), id,
+	)
+	if err != nil {
+		return errors.Wrap(err, op)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, op)
+	}
+	if affected == 0 {
+		return errors.Wrap(storeModels.ErrNotFound, op)
+	}
+
+	return nil
+}
+
+// DeleteSubscription deletes a subscription by id.
+func (store *Store) DeleteSubscription(ctx context.Context, id string) error {
+
+	const op = "store.subscriptions.DeleteSubscription"
+
+	res, err := store.db.ExecContext(ctx, `DELETE FROM subscriptions WHERE id = ?`, id)
+	if err != nil {
+		return errors.Wrap(err, op)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, op)
+	}
+	if affected == 0 {
+		return errors.Wrap(storeModels.ErrNotFound, op)
+	}
+
+	return nil
+}
+
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+func scanSubscription(row scanner) (*models.Subscription, error) {
+
+	var (
+		sub         models.Subscription
+		components  []byte
+		confirmedAt sql.NullTime
+	)
+
+	err := row.Scan(
+		&sub.ID,
+		&sub.PageID,
+		&sub.WorkspaceID,
+		&sub.Kind,
+		&sub.Target,
+		&components,
+		&sub.Token,
+		&confirmedAt,
+		&sub.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(components) > 0 {
+		if err := json.Unmarshal(components, &sub.Components); err != nil {
+			return nil, err
+		}
+	}
+
+	sub.ConfirmedAt = confirmedAt.Time
+
+	return &sub, nil
+}
+
+func nullTime(t time.Time) sql.NullTime {
+	return sql.NullTime{Time: t.UTC(), Valid: !t.IsZero()}
+}
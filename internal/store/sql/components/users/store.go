@@ -0,0 +1,195 @@
+package users
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/pkg/errors"
+	storeModels "github.com/vishenosik/CherryWatch/internal/store/models"
+	sqlstore "github.com/vishenosik/CherryWatch/internal/store/sql"
+)
+
+type Store struct {
+	db *sqlstore.DB
+}
+
+func NewUsersStore(db *sqlstore.DB) *Store {
+	return &Store{
+		db: db,
+	}
+}
+
+const selectUsers = `
+SELECT id, workspace_id, nickname, email, password_hash, role
+FROM users
+`
+
+// inWorkspace limits users to the workspace, empty workspace matches all.
+const inWorkspace = `(? = '' OR workspace_id = ?)`
+
+// User returns a user by id.
+func (store *Store) User(ctx context.Context, id string) (*storeModels.User, error) {
+
+	const op = "store.users.User"
+
+	workspaceID, err := storeModels.WorkspaceScope(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	user, err := scanUser(store.db.QueryRowContext(ctx,
+		selectUsers+`WHERE id = ? AND `+inWorkspace,
+		id, workspaceID, workspaceID,
+	))
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	return user, nil
+}
+
+// UserByEmail returns a user by email.
+func (store *Store) UserByEmail(ctx context.Context, email string) (*storeModels.User, error) {
+
+	const op = "store.users.UserByEmail"
+
+	workspaceID, err := storeModels.WorkspaceScope(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	user, err := scanUser(store.db.QueryRowContext(ctx,
+		selectUsers+`WHERE email = ? AND `+inWorkspace,
+		email, workspaceID, workspaceID,
+	))
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	return user, nil
+}
+
+// Users returns all users of the workspace ordered by nickname.
+func (store *Store) Users(ctx context.Context) ([]*storeModels.User, error) {
+
+	const op = "store.users.Users"
+
+	workspaceID, err := storeModels.WorkspaceScope(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	rows, err := store.db.QueryContext(ctx,
+		selectUsers+`WHERE `+inWorkspace+` ORDER BY nickname`,
+		workspaceID, workspaceID,
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	defer rows.Close()
+
+	users := make([]*storeModels.User, 0)
+	for rows.Next() {
+		user, err := scanUser(rows)
+		if err != nil {
+			return nil, errors.Wrap(err, op)
+		}
+		users = append(users, user)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	return users, nil
+}
+
+// SaveUser inserts a new user or replaces an existing one of the same workspace.
+func (store *Store) SaveUser(ctx context.Context, user *storeModels.User) error {
+
+	const op = "store.users.SaveUser"
+
+	workspaceID, err := storeModels.WorkspaceScope(ctx)
+	if err != nil {
+		return errors.Wrap(err, op)
+	}
+
+	if workspaceID != "" && user.WorkspaceID != workspaceID {
+		return errors.Wrap(storeModels.ErrNotFound, op)
+	}
+
+	res, err := store.db.ExecContext(ctx, `
+		INSERT INTO users (id, workspace_id, nickname, email, password_hash, role)
+		VALUES (?, ?, ?, ?, ?, ?)
+		ON CONFLICT (id) DO UPDATE SET
+			nickname = excluded.nickname,
+			email = excluded.email,
+			password_hash = excluded.password_hash,
+			role = excluded.role
+		WHERE users.workspace_id = excluded.workspace_id`,
+		user.ID, user.WorkspaceID, user.Nickname, user.Email, user.PasswordHash, user.Role,
+	)
+	if err != nil {
+		if sqlstore.IsUniqueViolation(err) {
+			return errors.Wrap(storeModels.ErrAlreadyExists, op)
+		}
+		return errors.Wrap(err, op)
+	}
+
+	// id is taken in another workspace
+	if affected, err := res.RowsAffected(); err != nil {
+		return errors.Wrap(err, op)
+	} else if affected == 0 {
+		return errors.Wrap(storeModels.ErrAlreadyExists, op)
+	}
+
+	return nil
+}
+
+// DeleteUser deletes a user by id.
+func (store *Store) DeleteUser(ctx context.Context, id string) error {
+
+	const op = "store.users.DeleteUser"
+
+	workspaceID, err := storeModels.WorkspaceScope(ctx)
+	if err != nil {
+		return errors.Wrap(err, op)
+	}
+
+	res, err := store.db.ExecContext(ctx,
+		`DELETE FROM users WHERE id = ? AND `+inWorkspace,
+		id, workspaceID, workspaceID,
+	)
+	if err != nil {
+		return errors.Wrap(err, op)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, op)
+	}
+
+	if affected == 0 {
+		return errors.Wrap(storeModels.ErrNotFound, op)
+	}
+
+	return nil
+}
+
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+func scanUser(row scanner) (*storeModels.User, error) {
+
+	var user storeModels.User
+
+	if err := row.Scan(&user.ID, &user.WorkspaceID, &user.Nickname, &user.Email, &user.PasswordHash, &user.Role); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, storeModels.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return &user, nil
+}
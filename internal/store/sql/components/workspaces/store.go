@@ -0,0 +1,121 @@
+package workspaces
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/pkg/errors"
+	"github.com/vishenosik/CherryWatch/internal/services/models"
+	storeModels "github.com/vishenosik/CherryWatch/internal/store/models"
+	sqlstore "github.com/vishenosik/CherryWatch/internal/store/sql"
+)
+
+type Store struct {
+	db *sqlstore.DB
+}
+
+func NewWorkspacesStore(db *sqlstore.DB) *Store {
+	return &Store{
+		db: db,
+	}
+}
+
+// inWorkspace limits workspaces to the scope one, empty workspace matches all.
+const inWorkspace = `(? = '' OR id = ?)`
+
+// Workspace returns a workspace by id.
+func (store *Store) Workspace(ctx context.Context, id string) (*models.Workspace, error) {
+
+	const op = "store.workspaces.Workspace"
+
+	workspaceID, err := storeModels.WorkspaceScope(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	var ws models.Workspace
+
+	err = store.db.QueryRowContext(ctx,
+		`SELECT id, name FROM workspaces WHERE id = ? AND `+inWorkspace,
+		id, workspaceID, workspaceID,
+	).Scan(&ws.ID, &ws.Name)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errors.Wrap(storeModels.ErrNotFound, op)
+		}
+		return nil, errors.Wrap(err, op)
+	}
+
+	return &ws, nil
+}
+
+// Workspaces returns workspaces visible in the scope ordered by name.
+func (store *Store) Workspaces(ctx context.Context) (models.Workspaces, error) {
+
+	const op = "store.workspaces.Workspaces"
+
+	workspaceID, err := storeModels.WorkspaceScope(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	rows, err := store.db.QueryContext(ctx,
+		`SELECT id, name FROM workspaces WHERE `+inWorkspace+` ORDER BY name`,
+		workspaceID, workspaceID,
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	defer rows.Close()
+
+	workspaces := make(models.Workspaces, 0)
+	for rows.Next() {
+		var ws models.Workspace
+		if err := rows.Scan(&ws.ID, &ws.Name); err != nil {
+			return nil, errors.Wrap(err, op)
+		}
+		workspaces = append(workspaces, &ws)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	return workspaces, nil
+}
+
+// SaveWorkspace creates a workspace.
+func (store *Store) SaveWorkspace(ctx context.Context, ws *models.Workspace) error {
+
+	const op = "store.workspaces.SaveWorkspace"
+
+	_, err := store.db.ExecContext(ctx,
+		`INSERT INTO workspaces (id, name) VALUES (?, ?)`,
+		ws.ID, ws.Name,
+	)
+	if err != nil {
+		if sqlstore.IsUniqueViolation(err) {
+			return errors.Wrap(storeModels.ErrAlreadyExists, op)
+		}
+		return errors.Wrap(err, op)
+	}
+
+	return nil
+}
+
+// DeleteWorkspace deletes a workspace by id.
+func (store *Store) DeleteWorkspace(ctx context.Context, id string) error {
+
+	const op = "store.workspaces.DeleteWorkspace"
+
+	res, err := store.db.ExecContext(ctx, `DELETE FROM workspaces WHERE id = ?`, id)
+	if err != nil {
+		return errors.Wrap(err, op)
+	}
+
+	if affected, err := res.RowsAffected(); err == nil && affected == 0 {
+		return errors.Wrap(storeModels.ErrNotFound, op)
+	}
+
+	return nil
+}
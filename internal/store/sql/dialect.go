@@ -0,0 +1,135 @@
+package sqlstore
+
+import (
+	"context"
+	"database/sql"
+	"strconv"
+	"strings"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/mattn/go-sqlite3"
+	"github.com/pkg/errors"
+)
+
+type Dialect string
+
+const (
+	SQLite   Dialect = "sqlite"
+	Postgres Dialect = "postgres"
+)
+
+// pgUniqueViolation is the postgres SQLSTATE of unique constraint violations.
+const pgUniqueViolation = "23505"
+
+// DB runs queries written with "?" placeholders against the database of any supported dialect.
+// Components use the context methods only, they are the ones rewriting placeholders.
+type DB struct {
+	*sql.DB
+	dialect Dialect
+}
+
+func NewDB(db *sql.DB, dialect Dialect) *DB {
+	return &DB{
+		DB:      db,
+		dialect: dialect,
+	}
+}
+
+func (db *DB) Dialect() Dialect {
+	return db.dialect
+}
+
+func (db *DB) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	return db.DB.ExecContext(ctx, db.dialect.Rebind(query), args...)
+}
+
+func (db *DB) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	return db.DB.QueryContext(ctx, db.dialect.Rebind(query), args...)
+}
+
+func (db *DB) QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row {
+	return db.DB.QueryRowContext(ctx, db.dialect.Rebind(query), args...)
+}
+
+func (db *DB) BeginTx(ctx context.Context, opts *sql.TxOptions) (*Tx, error) {
+	tx, err := db.DB.BeginTx(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	return &Tx{Tx: tx, dialect: db.dialect}, nil
+}
+
+// Tx is a transaction rewriting placeholders like DB does.
+type Tx struct {
+	*sql.Tx
+	dialect Dialect
+}
+
+func (tx *Tx) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	return tx.Tx.ExecContext(ctx, tx.dialect.Rebind(query), args...)
+}
+
+func (tx *Tx) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	return tx.Tx.QueryContext(ctx, tx.dialect.Rebind(query), args...)
+}
+
+func (tx *Tx) QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row {
+	return tx.Tx.QueryRowContext(ctx, tx.dialect.Rebind(query), args...)
+}
+
+// Rebind replaces "?" placeholders with the numbered "$N" ones for postgres.
+// Question marks inside quoted literals and identifiers are kept.
+func (dialect Dialect) Rebind(query string) string {
+
+	if dialect != Postgres || !strings.Contains(query, "?") {
+		return query
+	}
+
+	var (
+		builder strings.Builder
+		quote   rune
+		n       int
+	)
+
+	builder.Grow(len(query) + 8)
+
+	for _, char := range query {
+		switch {
+		case quote != 0:
+			if char == quote {
+				quote = 0
+			}
+		case char == '\'' || char == '"':
+			quote = char
+		case char == '?':
+			n++
+			builder.WriteByte('$')
+			builder.WriteString(strconv.Itoa(n))
+			continue
+		}
+		builder.WriteRune(char)
+	}
+
+	return builder.String()
+}
+
+// IsUniqueViolation tells whether the error is caused by a unique or primary key constraint.
+func IsUniqueViolation(err error) bool {
+
+	if err == nil {
+		return false
+	}
+
+	var sqliteErr sqlite3.Error
+	if errors.As(err, &sqliteErr) {
+		return sqliteErr.ExtendedCode == sqlite3.ErrConstraintUnique ||
+			sqliteErr.ExtendedCode == sqlite3.ErrConstraintPrimaryKey
+	}
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		return pgErr.Code == pgUniqueViolation
+	}
+
+	return false
+}
@@ -0,0 +1,91 @@
+package sqlstore
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Rebind(t *testing.T) {
+
+	tests := []struct {
+		name    string
+		dialect Dialect
+		query   string
+		want    string
+	}{
+		{
+			name:    "sqlite keeps placeholders",
+			dialect: SQLite,
+			query:   `SELECT id FROM endpoints WHERE id = ? AND (? = '' OR workspace_id = ?)`,
+			want:    `SELECT id FROM endpoints WHERE id = ? AND (? = '' OR workspace_id = ?)`,
+		},
+		{
+			name:    "postgres numbers placeholders",
+			dialect: Postgres,
+			query:   `SELECT id FROM endpoints WHERE id = ? AND (? = '' OR workspace_id = ?)`,
+			want:    `SELECT id FROM endpoints WHERE id = $1 AND ($2 = '' OR workspace_id = $3)`,
+		},
+		{
+			name:    "quoted question marks",
+			dialect: Postgres,
+			query:   `SELECT "what?" FROM t WHERE a = 'why?' AND b = ?`,
+			want:    `SELECT "what?" FROM t WHERE a = 'why?' AND b = $1`,
+		},
+		{
+			name:    "escaped quotes",
+			dialect: Postgres,
+			query:   `SELECT ? WHERE a = 'it''s ?' AND b = ?`,
+			want:    `SELECT $1 WHERE a = 'it''s ?' AND b = $2`,
+		},
+		{
+			name:    "no placeholders",
+			dialect: Postgres,
+			query:   `SELECT 1`,
+			want:    `SELECT 1`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.dialect.Rebind(tt.query))
+		})
+	}
+}
+
+func Test_IsUniqueViolation(t *testing.T) {
+
+	ctx := context.Background()
+
+	sqliteDB, err := sql.Open("sqlite3", ":memory:")
+	require.NoError(t, err)
+	defer sqliteDB.Close()
+
+	db := NewDB(sqliteDB, SQLite)
+
+	_, err = db.ExecContext(ctx, `CREATE TABLE t (id TEXT PRIMARY KEY, name TEXT UNIQUE, value TEXT NOT NULL)`)
+	require.NoError(t, err)
+
+	_, err = db.ExecContext(ctx, `INSERT INTO t (id, name, value) VALUES (?, ?, ?)`, "1", "a", "x")
+	require.NoError(t, err)
+
+	_, err = db.ExecContext(ctx, `INSERT INTO t (id, name, value) VALUES (?, ?, ?)`, "2", "a", "x")
+	assert.True(t, IsUniqueViolation(errors.Wrap(err, "op")), "unique")
+
+	_, err = db.ExecContext(ctx, `INSERT INTO t (id, name, value) VALUES (?, ?, ?)`, "1", "b", "x")
+	assert.True(t, IsUniqueViolation(err), "primary key")
+
+	_, err = db.ExecContext(ctx, `INSERT INTO t (id, name, value) VALUES (?, ?, ?)`, "3", "c", nil)
+	assert.False(t, IsUniqueViolation(err), "not null")
+
+	assert.True(t, IsUniqueViolation(errors.Wrap(&pgconn.PgError{Code: "23505"}, "op")))
+	assert.False(t, IsUniqueViolation(&pgconn.PgError{Code: "23502"}))
+	assert.False(t, IsUniqueViolation(errors.New("UNIQUE constraint failed")))
+	assert.False(t, IsUniqueViolation(nil))
+}
@@ -0,0 +1,227 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/mattn/go-sqlite3"
+	"github.com/pkg/errors"
+	"github.com/pressly/goose/v3"
+	embed "github.com/vishenosik/CherryWatch"
+	storeModels "github.com/vishenosik/CherryWatch/internal/store/models"
+)
+
+// backupRetry is the pause before another backup step when a database is locked.
+const backupRetry = 50 * time.Millisecond
+
+// Backup writes a consistent snapshot of the database to dst with the online backup API.
+//
+// The snapshot is read through a connection of its own: in WAL mode the reader
+// doesn't block the writer of the store, so checks keep being recorded meanwhile.
+// The file is written next to dst and renamed once complete.
+func (Store *Store) Backup(ctx context.Context, dst string) error {
+
+	const op = "Store.sqlite.Backup"
+
+	if inMemory(Store.path) {
+		return errors.Wrap(storeModels.ErrNotSupported, op)
+	}
+
+	src, err := sql.Open("sqlite3", dsn(Store.path))
+	if err != nil {
+		return errors.Wrap(err, op)
+	}
+	defer src.Close()
+
+	tmp, err := tempFile(dst)
+	if err != nil {
+		return errors.Wrap(err, op)
+	}
+	defer os.Remove(tmp)
+
+	target, err := sql.Open("sqlite3", tmp)
+	if err != nil {
+		return errors.Wrap(err, op)
+	}
+
+	if err := copyDatabase(ctx, target, src); err != nil {
+		target.Close()
+		return errors.Wrap(err, op)
+	}
+
+	if err := target.Close(); err != nil {
+		return errors.Wrap(err, op)
+	}
+
+	if err := os.Rename(tmp, dst); err != nil {
+		return errors.Wrap(err, op)
+	}
+
+	return nil
+}
+
+// Restore replaces the database with the backup at src.
+//
+// The backup is copied aside and checked first: it has to pass the integrity
+// check and carry a goose schema version known to this build. Newer backups
+// can't be downgraded, older ones are migrated on the next start. The copy is
+// swapped in with the backup API, the server should be stopped meanwhile:
+// changes it makes are lost.
+func (Store *Store) Restore(ctx context.Context, src string) error {
+
+	const op = "Store.sqlite.Restore"
+
+	if inMemory(Store.path) {
+		return errors.Wrap(storeModels.ErrNotSupported, op)
+	}
+
+	if _, err := os.Stat(src); err != nil {
+		return errors.Wrap(err, op)
+	}
+
+	backup, err := sql.Open("sqlite3", "file:"+src+"?mode=ro")
+	if err != nil {
+		return errors.Wrap(err, op)
+	}
+	defer backup.Close()
+
+	tmp, err := tempFile(filePath(Store.path))
+	if err != nil {
+		return errors.Wrap(err, op)
+	}
+	defer os.Remove(tmp)
+
+	staged, err := sql.Open("sqlite3", tmp)
+	if err != nil {
+		return errors.Wrap(err, op)
+	}
+	defer staged.Close()
+
+	if err := copyDatabase(ctx, staged, backup); err != nil {
+		return errors.Wrapf(storeModels.ErrInvalidBackup, "%s: %v", op, err)
+	}
+
+	if err := Store.validate(ctx, staged); err != nil {
+		return errors.Wrapf(storeModels.ErrInvalidBackup, "%s: %v", op, err)
+	}
+
+	if err := copyDatabase(ctx, Store.db, staged); err != nil {
+		return errors.Wrap(err, op)
+	}
+
+	return nil
+}
+
+// validate checks the integrity and the schema version of the database.
+func (Store *Store) validate(ctx context.Context, db *sql.DB) error {
+
+	var integrity string
+	if err := db.QueryRowContext(ctx, "PRAGMA integrity_check").Scan(&integrity); err != nil {
+		return err
+	}
+	if integrity != "ok" {
+		return errors.Errorf("integrity check failed: %s", integrity)
+	}
+
+	migrations, err := fs.Sub(embed.Migrations, Store.MigrationsPath())
+	if err != nil {
+		return err
+	}
+
+	provider, err := goose.NewProvider(goose.DialectSQLite3, db, migrations)
+	if err != nil {
+		return err
+	}
+
+	version, err := provider.GetDBVersion(ctx)
+	if err != nil {
+		return errors.Wrap(err, "schema version")
+	}
+
+	var latest int64
+	for _, source := range provider.ListSources() {
+		latest = max(latest, source.Version)
+	}
+
+	if version < 1 || version > latest {
+		return errors.Errorf("schema version %d is unknown, latest is %d", version, latest)
+	}
+
+	return nil
+}
+
+// copyDatabase copies the main database of src over the one of dst.
+// The pages are copied in one step, the copy is a snapshot of a single read transaction.
+func copyDatabase(ctx context.Context, dst, src *sql.DB) error {
+
+	dstConn, err := dst.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer dstConn.Close()
+
+	srcConn, err := src.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer srcConn.Close()
+
+	return dstConn.Raw(func(dstDriver any) error {
+		return srcConn.Raw(func(srcDriver any) error {
+
+			dstSqlite, ok := dstDriver.(*sqlite3.SQLiteConn)
+			if !ok {
+				return errors.Errorf("unexpected driver connection %T", dstDriver)
+			}
+
+			srcSqlite, ok := srcDriver.(*sqlite3.SQLiteConn)
+			if !ok {
+				return errors.Errorf("unexpected driver connection %T", srcDriver)
+			}
+
+			backup, err := dstSqlite.Backup("main", srcSqlite, "main")
+			if err != nil {
+				return err
+			}
+
+			for {
+				// busy and locked databases are reported as not done, the step is retried
+				done, err := backup.Step(-1)
+				if err != nil {
+					backup.Close()
+					return err
+				}
+				if done {
+					return backup.Finish()
+				}
+
+				select {
+				case <-ctx.Done():
+					backup.Close()
+					return ctx.Err()
+				case <-time.After(backupRetry):
+				}
+			}
+		})
+	})
+}
+
+// tempFile creates an empty file next to the path, empty files are valid sqlite databases.
+func tempFile(path string) (string, error) {
+
+	file, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+"-*")
+	if err != nil {
+		return "", err
+	}
+
+	if err := file.Close(); err != nil {
+		os.Remove(file.Name())
+		return "", err
+	}
+
+	return file.Name(), nil
+}
@@ -0,0 +1,168 @@
+package sqlite
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/pressly/goose/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	embed "github.com/vishenosik/CherryWatch"
+	storeModels "github.com/vishenosik/CherryWatch/internal/store/models"
+)
+
+// migratedStore opens a store in the test directory with every migration applied.
+func migratedStore(t *testing.T, name string) *Store {
+
+	store, err := NewSqliteStore(filepath.Join(t.TempDir(), name))
+	require.NoError(t, err)
+	t.Cleanup(func() { store.Stop() })
+
+	goose.SetBaseFS(embed.Migrations)
+	goose.SetLogger(goose.NopLogger())
+	require.NoError(t, goose.SetDialect(store.Dialect()))
+	require.NoError(t, goose.Up(store.DB(), store.MigrationsPath()))
+
+	_, err = store.DB().Exec(`CREATE TABLE results (id INTEGER PRIMARY KEY AUTOINCREMENT)`)
+	require.NoError(t, err)
+
+	return store
+}
+
+func count(t *testing.T, store *Store) int {
+	var count int
+	require.NoError(t, store.DB().QueryRow(`SELECT COUNT(*) FROM results`).Scan(&count))
+	return count
+}
+
+func Test_Backup(t *testing.T) {
+
+	ctx := context.Background()
+	store := migratedStore(t, "CherryWatch.db")
+
+	// the store keeps being written during the backup
+	var (
+		wg   sync.WaitGroup
+		stop = make(chan struct{})
+	)
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for {
+			select {
+			case <-stop:
+				return
+			default:
+			}
+			_, err := store.DB().ExecContext(ctx, `INSERT INTO results DEFAULT VALUES`)
+			assert.NoError(t, err)
+		}
+	}()
+
+	dst := filepath.Join(t.TempDir(), "backup.db")
+	err := store.Backup(ctx, dst)
+	close(stop)
+	wg.Wait()
+	require.NoError(t, err)
+
+	// only the backup is left in the directory
+	entries, err := os.ReadDir(filepath.Dir(dst))
+	require.NoError(t, err)
+	assert.Len(t, entries, 1)
+
+	backup, err := NewSqliteStore(dst)
+	require.NoError(t, err)
+	defer backup.Stop()
+
+	assert.NoError(t, store.validate(ctx, backup.DB()))
+	assert.LessOrEqual(t, count(t, backup), count(t, store))
+}
+
+func Test_Backup_memory(t *testing.T) {
+
+	store, err := NewSqliteStore(":memory:")
+	require.NoError(t, err)
+	defer store.Stop()
+
+	err = store.Backup(context.Background(), filepath.Join(t.TempDir(), "backup.db"))
+	assert.ErrorIs(t, err, storeModels.ErrNotSupported)
+}
+
+func Test_Restore(t *testing.T) {
+
+	ctx := context.Background()
+
+	store := migratedStore(t, "CherryWatch.db")
+	_, err := store.DB().ExecContext(ctx, `INSERT INTO results DEFAULT VALUES`)
+	require.NoError(t, err)
+
+	dst := filepath.Join(t.TempDir(), "backup.db")
+	require.NoError(t, store.Backup(ctx, dst))
+
+	// changes made after the backup are rolled back by the restore
+	_, err = store.DB().ExecContext(ctx, `INSERT INTO results DEFAULT VALUES`)
+	require.NoError(t, err)
+	require.Equal(t, 2, count(t, store))
+
+	require.NoError(t, store.Restore(ctx, dst))
+	assert.Equal(t, 1, count(t, store))
+	assert.NoError(t, store.validate(ctx, store.DB()))
+}
+
+func Test_Restore_invalid(t *testing.T) {
+
+	ctx := context.Background()
+
+	tests := []struct {
+		name   string
+		backup func(t *testing.T) string
+	}{
+		{
+			name: "not a database",
+			backup: func(t *testing.T) string {
+				path := filepath.Join(t.TempDir(), "backup.db")
+				require.NoError(t, os.WriteFile(path, []byte("not a database, but long enough to have a header of one"), 0o600))
+				return path
+			},
+		},
+		{
+			name: "no schema version",
+			backup: func(t *testing.T) string {
+				store, err := NewSqliteStore(filepath.Join(t.TempDir(), "backup.db"))
+				require.NoError(t, err)
+				defer store.Stop()
+				_, err = store.DB().Exec(`CREATE TABLE results (id INTEGER)`)
+				require.NoError(t, err)
+				return store.path
+			},
+		},
+		{
+			name: "newer schema version",
+			backup: func(t *testing.T) string {
+				store := migratedStore(t, "backup.db")
+				_, err := store.DB().Exec(`INSERT INTO goose_db_version (version_id, is_applied) VALUES (99999, TRUE)`)
+				require.NoError(t, err)
+				return store.path
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			store := migratedStore(t, "CherryWatch.db")
+			_, err := store.DB().ExecContext(ctx, `INSERT INTO results DEFAULT VALUES`)
+			require.NoError(t, err)
+
+			err = store.Restore(ctx, tt.backup(t))
+			assert.ErrorIs(t, err, storeModels.ErrInvalidBackup)
+
+			// the database is left untouched
+			assert.Equal(t, 1, count(t, store))
+		})
+	}
+}
@@ -1,8 +1,15 @@
 package sqlite
 
 import (
+	"context"
 	"database/sql"
+	"net/url"
+	"os"
 	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
 
 	_ "github.com/mattn/go-sqlite3"
 	"github.com/pkg/errors"
@@ -12,8 +19,16 @@ const (
 	dialect string = "sqlite"
 )
 
+const (
+	// busyTimeout is how long a connection waits for a lock held by another process
+	busyTimeout = 5 * time.Second
+	// pingTimeout bounds the startup check of the database file
+	pingTimeout = 5 * time.Second
+)
+
 type Store struct {
-	db *sql.DB
+	db   *sql.DB
+	path string
 }
 
 func MustInitSqlite(StorePath string) *Store {
@@ -24,20 +39,80 @@ func MustInitSqlite(StorePath string) *Store {
 	return Store
 }
 
+// NewSqliteStore opens the database file creating its directory if needed.
+//
+// The database runs in WAL mode, so readers of other processes (backups, CLI)
+// don't block writers, with foreign keys enforced. SQLite allows one writer at
+// a time: the pool keeps a single connection so concurrent writers of the
+// process queue up instead of failing with SQLITE_BUSY.
 func NewSqliteStore(StorePath string) (*Store, error) {
 
 	const op = "Store.sqlite.New"
 
-	db, err := sql.Open("sqlite3", StorePath)
+	if dir := filepath.Dir(filePath(StorePath)); !inMemory(StorePath) && dir != "." {
+		if err := os.MkdirAll(dir, 0o750); err != nil {
+			return nil, errors.Wrap(err, op)
+		}
+	}
+
+	db, err := sql.Open("sqlite3", dsn(StorePath))
 	if err != nil {
 		return nil, errors.Wrap(err, op)
 	}
 
+	db.SetMaxOpenConns(1)
+	db.SetMaxIdleConns(1)
+	db.SetConnMaxLifetime(0)
+	db.SetConnMaxIdleTime(0)
+
+	ctx, cancel := context.WithTimeout(context.Background(), pingTimeout)
+	defer cancel()
+
+	if err := db.PingContext(ctx); err != nil {
+		db.Close()
+		return nil, errors.Wrap(err, op)
+	}
+
 	return &Store{
-		db: db,
+		db:   db,
+		path: StorePath,
 	}, nil
 }
 
+// dsn adds connection pragmas to the path.
+func dsn(path string) string {
+
+	params := url.Values{
+		"_busy_timeout": {strconv.FormatInt(busyTimeout.Milliseconds(), 10)},
+		"_foreign_keys": {"on"},
+		// writing transactions take the lock at once instead of failing on upgrade
+		"_txlock": {"immediate"},
+	}
+
+	if !inMemory(path) {
+		params.Set("_journal_mode", "WAL")
+		// WAL keeps the database consistent on power loss with NORMAL
+		params.Set("_synchronous", "NORMAL")
+	}
+
+	separator := "?"
+	if strings.Contains(path, "?") {
+		separator = "&"
+	}
+
+	return path + separator + params.Encode()
+}
+
+// filePath strips the URI scheme and parameters of the path.
+func filePath(path string) string {
+	path, _, _ = strings.Cut(strings.TrimPrefix(path, "file:"), "?")
+	return path
+}
+
+func inMemory(path string) bool {
+	return path == ":memory:" || strings.Contains(path, "mode=memory")
+}
+
 func (Store *Store) DB() *sql.DB {
 	return Store.db
 }
@@ -0,0 +1,105 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_NewSqliteStore(t *testing.T) {
+
+	ctx := context.Background()
+
+	// the directory doesn't exist yet
+	store, err := NewSqliteStore(filepath.Join(t.TempDir(), "storage", "CherryWatch.db"))
+	require.NoError(t, err)
+	defer store.Stop()
+
+	pragma := func(name string) string {
+		var value string
+		require.NoError(t, store.DB().QueryRowContext(ctx, "PRAGMA "+name).Scan(&value))
+		return value
+	}
+
+	assert.Equal(t, "wal", pragma("journal_mode"))
+	assert.Equal(t, "5000", pragma("busy_timeout"))
+	assert.Equal(t, "1", pragma("foreign_keys"))
+	assert.Equal(t, "1", pragma("synchronous"))
+}
+
+func Test_NewSqliteStore_memory(t *testing.T) {
+
+	store, err := NewSqliteStore(":memory:")
+	require.NoError(t, err)
+	defer store.Stop()
+
+	// the single connection keeps one in-memory database
+	_, err = store.DB().Exec(`CREATE TABLE t (id INTEGER)`)
+	require.NoError(t, err)
+	_, err = store.DB().Exec(`INSERT INTO t (id) VALUES (1)`)
+	require.NoError(t, err)
+}
+
+func Test_NewSqliteStore_concurrentWrites(t *testing.T) {
+
+	ctx := context.Background()
+
+	store, err := NewSqliteStore(filepath.Join(t.TempDir(), "CherryWatch.db"))
+	require.NoError(t, err)
+	defer store.Stop()
+
+	_, err = store.DB().ExecContext(ctx, `CREATE TABLE results (id TEXT PRIMARY KEY)`)
+	require.NoError(t, err)
+
+	const (
+		writers = 8
+		writes  = 50
+	)
+
+	var wg sync.WaitGroup
+	errs := make(chan error, writers*writes)
+
+	for w := range writers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range writes {
+				tx, err := store.DB().BeginTx(ctx, nil)
+				if err != nil {
+					errs <- err
+					return
+				}
+				if _, err := tx.ExecContext(ctx, `INSERT INTO results (id) VALUES (?)`, fmt.Sprintf("%d-%d", w, i)); err != nil {
+					tx.Rollback()
+					errs <- err
+					return
+				}
+				if err := tx.Commit(); err != nil {
+					errs <- err
+					return
+				}
+			}
+		}()
+	}
+
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		assert.NoError(t, err)
+	}
+
+	var count int
+	require.NoError(t, store.DB().QueryRowContext(ctx, `SELECT COUNT(*) FROM results`).Scan(&count))
+	assert.Equal(t, writers*writes, count)
+}
+
+func Test_filePath(t *testing.T) {
+	assert.Equal(t, "./storage/CherryWatch.db", filePath("./storage/CherryWatch.db"))
+	assert.Equal(t, "./storage/CherryWatch.db", filePath("file:./storage/CherryWatch.db?cache=shared"))
+}
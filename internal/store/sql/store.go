@@ -1,15 +1,28 @@
 package sqlstore
 
 import (
+	"context"
 	"database/sql"
+
+	"github.com/pkg/errors"
+	storeModels "github.com/vishenosik/CherryWatch/internal/store/models"
 )
 
 type Store struct {
 	provider StoreProvider
+	db       *DB
 }
 
 type StoreProvider interface {
 	DB() *sql.DB
+	Dialect() string
+	MigrationsPath() string
+}
+
+// BackupProvider is implemented by providers copying the live database to and from files.
+type BackupProvider interface {
+	Backup(ctx context.Context, dst string) error
+	Restore(ctx context.Context, src string) error
 }
 
 func NewStore(
@@ -17,9 +30,40 @@ func NewStore(
 ) *Store {
 	return &Store{
 		provider: provider,
+		db:       NewDB(provider.DB(), Dialect(provider.Dialect())),
 	}
 }
 
+func (store *Store) DB() *DB {
+	return store.db
+}
+
 func (store *Store) Stop() error {
 	return store.provider.DB().Close()
 }
+
+// Backup writes a snapshot of the database to dst if the provider supports it.
+func (store *Store) Backup(ctx context.Context, dst string) error {
+
+	const op = "Store.sql.Backup"
+
+	provider, ok := store.provider.(BackupProvider)
+	if !ok {
+		return errors.Wrap(storeModels.ErrNotSupported, op)
+	}
+
+	return provider.Backup(ctx, dst)
+}
+
+// Restore replaces the database with the backup at src if the provider supports it.
+func (store *Store) Restore(ctx context.Context, src string) error {
+
+	const op = "Store.sql.Restore"
+
+	provider, ok := store.provider.(BackupProvider)
+	if !ok {
+		return errors.Wrap(storeModels.ErrNotSupported, op)
+	}
+
+	return provider.Restore(ctx, src)
+}
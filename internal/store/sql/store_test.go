@@ -0,0 +1,298 @@
+package sqlstore_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/pressly/goose/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	embed "github.com/vishenosik/CherryWatch"
+	"github.com/vishenosik/CherryWatch/internal/services/models"
+	storeModels "github.com/vishenosik/CherryWatch/internal/store/models"
+	sqlstore "github.com/vishenosik/CherryWatch/internal/store/sql"
+	"github.com/vishenosik/CherryWatch/internal/store/sql/components/audit"
+	"github.com/vishenosik/CherryWatch/internal/store/sql/components/channels"
+	"github.com/vishenosik/CherryWatch/internal/store/sql/components/endpoints"
+	"github.com/vishenosik/CherryWatch/internal/store/sql/components/history"
+	"github.com/vishenosik/CherryWatch/internal/store/sql/components/incidents"
+	"github.com/vishenosik/CherryWatch/internal/store/sql/components/maintenances"
+	"github.com/vishenosik/CherryWatch/internal/store/sql/components/silences"
+	"github.com/vishenosik/CherryWatch/internal/store/sql/components/statuspages"
+	"github.com/vishenosik/CherryWatch/internal/store/sql/components/subscriptions"
+	"github.com/vishenosik/CherryWatch/internal/store/sql/components/workspaces"
+	"github.com/vishenosik/CherryWatch/internal/store/sql/providers/postgres"
+	"github.com/vishenosik/CherryWatch/internal/store/sql/providers/sqlite"
+)
+
+// postgresDSN names the variable with the DSN of an empty database postgres tests run against,
+// they are skipped if it is not set.
+const postgresDSN = "CHERRYWATCH_TEST_POSTGRES_DSN"
+
+type provider struct {
+	name string
+	open func(t *testing.T) sqlstore.StoreProvider
+}
+
+func providers() []provider {
+	return []provider{
+		{
+			name: "sqlite",
+			open: func(t *testing.T) sqlstore.StoreProvider {
+				store, err := sqlite.NewSqliteStore(filepath.Join(t.TempDir(), "CherryWatch.db"))
+				require.NoError(t, err)
+				t.Cleanup(func() { store.Stop() })
+				return store
+			},
+		},
+		{
+			name: "postgres",
+			open: func(t *testing.T) sqlstore.StoreProvider {
+				dsn := os.Getenv(postgresDSN)
+				if dsn == "" {
+					t.Skip(postgresDSN + " is not set")
+				}
+				store, err := postgres.NewPostgresStore(dsn)
+				require.NoError(t, err)
+				t.Cleanup(func() { store.Stop() })
+				return store
+			},
+		},
+	}
+}
+
+// migrate applies every migration and reverts them once the test is done.
+func migrate(t *testing.T, provider sqlstore.StoreProvider) {
+
+	goose.SetBaseFS(embed.Migrations)
+	goose.SetLogger(goose.NopLogger())
+	require.NoError(t, goose.SetDialect(provider.Dialect()))
+
+	require.NoError(t, goose.Up(provider.DB(), provider.MigrationsPath()))
+
+	t.Cleanup(func() {
+		assert.NoError(t, goose.DownTo(provider.DB(), provider.MigrationsPath(), 0))
+	})
+}
+
+func Test_Migrations(t *testing.T) {
+
+	for _, provider := range providers() {
+		t.Run(provider.name, func(t *testing.T) {
+
+			store := provider.open(t)
+
+			goose.SetBaseFS(embed.Migrations)
+			goose.SetLogger(goose.NopLogger())
+			require.NoError(t, goose.SetDialect(store.Dialect()))
+
+			migrations, err := goose.CollectMigrations(store.MigrationsPath(), 0, goose.MaxVersion)
+			require.NoError(t, err)
+			require.NotEmpty(t, migrations)
+
+			version := func() int64 {
+				version, err := goose.GetDBVersion(store.DB())
+				require.NoError(t, err)
+				return version
+			}
+
+			// every migration applies on top of the previous ones and reverts cleanly
+			for _, migration := range migrations {
+				require.NoError(t, goose.UpByOne(store.DB(), store.MigrationsPath()), migration.Source)
+				require.Equal(t, migration.Version, version())
+			}
+
+			for i := len(migrations) - 1; i >= 0; i-- {
+				require.NoError(t, goose.Down(store.DB(), store.MigrationsPath()), migrations[i].Source)
+			}
+			require.EqualValues(t, 0, version())
+
+			// the chain is applied again after a full revert
+			require.NoError(t, goose.Up(store.DB(), store.MigrationsPath()))
+			require.Equal(t, migrations[len(migrations)-1].Version, version())
+			require.NoError(t, goose.DownTo(store.DB(), store.MigrationsPath(), 0))
+		})
+	}
+}
+
+func Test_MigrationVersions(t *testing.T) {
+
+	goose.SetBaseFS(embed.Migrations)
+
+	versions := func(dir string) []int64 {
+		migrations, err := goose.CollectMigrations(dir, 0, goose.MaxVersion)
+		require.NoError(t, err)
+		result := make([]int64, 0, len(migrations))
+		for _, migration := range migrations {
+			result = append(result, migration.Version)
+		}
+		return result
+	}
+
+	// every schema change is made for both dialects
+	assert.Equal(t, versions("migrations/sqlite"), versions("migrations/postgres"))
+}
+
+func Test_Dialects(t *testing.T) {
+
+	for _, provider := range providers() {
+		t.Run(provider.name, func(t *testing.T) {
+
+			store := provider.open(t)
+			migrate(t, store)
+
+			db := sqlstore.NewStore(store).DB()
+			ctx := models.WithAllWorkspaces(context.Background())
+			now := time.Now().UTC().Truncate(time.Second)
+
+			t.Run("unique violation", func(t *testing.T) {
+				store := workspaces.NewWorkspacesStore(db)
+				require.NoError(t, store.SaveWorkspace(ctx, &models.Workspace{ID: "team-a", Name: "team-a"}))
+				err := store.SaveWorkspace(ctx, &models.Workspace{ID: "team-b", Name: "team-a"})
+				assert.ErrorIs(t, err, storeModels.ErrAlreadyExists)
+
+				require.NoError(t, store.DeleteWorkspace(ctx, "team-a"))
+				assert.ErrorIs(t, store.DeleteWorkspace(ctx, "team-a"), storeModels.ErrNotFound)
+				require.NoError(t, store.SaveWorkspace(ctx, &models.Workspace{ID: "team-b", Name: "team-a"}))
+			})
+
+			t.Run("endpoints create & upsert", func(t *testing.T) {
+				store := endpoints.NewEndpointsStore(db)
+				endpoint := func(url string) *models.Endpoint {
+					return &models.Endpoint{ID: "web", WorkspaceID: models.DefaultWorkspace, ServiceName: "web", URL: url, Interval: time.Minute}
+				}
+
+				require.NoError(t, store.CreateEndpoints(ctx, models.Endpoints{endpoint("https://a.example.com")}))
+
+				// creating never overwrites
+				err := store.CreateEndpoints(ctx, models.Endpoints{endpoint("https://b.example.com")})
+				assert.ErrorIs(t, err, storeModels.ErrAlreadyExists)
+
+				stored, err := store.Endpoint(ctx, "web")
+				require.NoError(t, err)
+				assert.Equal(t, "https://a.example.com", stored.URL)
+
+				require.NoError(t, store.SaveEndpoints(ctx, models.Endpoints{endpoint("https://c.example.com")}))
+				stored, err = store.Endpoint(ctx, "web")
+				require.NoError(t, err)
+				assert.Equal(t, "https://c.example.com", stored.URL)
+			})
+
+			t.Run("foreign keys", func(t *testing.T) {
+				pages := statuspages.NewStatusPagesStore(db)
+				subs := subscriptions.NewSubscriptionsStore(db)
+
+				require.NoError(t, pages.SaveStatusPage(ctx, &models.StatusPageConfig{ID: "page", WorkspaceID: models.DefaultWorkspace, Slug: "page", Title: "Status"}))
+				require.NoError(t, subs.SaveSubscription(ctx, &models.Subscription{
+					ID: "1", PageID: "page", WorkspaceID: models.DefaultWorkspace, Kind: models.ChannelEmail,
+					Target: "ops@example.com", Token: "token", CreatedAt: now,
+				}))
+
+				// subscriptions of deleted pages are deleted along
+				require.NoError(t, pages.DeleteStatusPage(ctx, "page"))
+				_, err := subs.SubscriptionByToken(ctx, "token")
+				assert.ErrorIs(t, err, storeModels.ErrNotFound)
+			})
+
+			t.Run("history upsert", func(t *testing.T) {
+				store := history.NewHistoryStore(db)
+				endpoint := &models.Endpoint{ID: "api", WorkspaceID: models.DefaultWorkspace}
+				require.NoError(t, store.RecordResult(ctx, endpoint, &models.CheckResult{Success: true, CheckedAt: now}))
+				require.NoError(t, store.RecordResult(ctx, endpoint, &models.CheckResult{Success: false, CheckedAt: now}))
+
+				days, err := store.DailyChecks(ctx, []string{"api"}, now.AddDate(0, 0, -1))
+				require.NoError(t, err)
+				require.Len(t, days, 1)
+				assert.EqualValues(t, 2, days[0].Checks)
+				assert.EqualValues(t, 1, days[0].Failures)
+			})
+
+			t.Run("incidents", func(t *testing.T) {
+				store := incidents.NewIncidentsStore(db)
+				require.NoError(t, store.SaveIncident(ctx, &models.Incident{ID: "1", EndpointID: "api", WorkspaceID: models.DefaultWorkspace, OpenedAt: now, ResolvedAt: now}))
+				require.NoError(t, store.SaveIncident(ctx, &models.Incident{ID: "2", EndpointID: "api", WorkspaceID: models.DefaultWorkspace, OpenedAt: now}))
+
+				// an endpoint has a single active incident
+				err := store.SaveIncident(ctx, &models.Incident{ID: "3", EndpointID: "api", WorkspaceID: models.DefaultWorkspace, OpenedAt: now})
+				assert.ErrorIs(t, err, storeModels.ErrAlreadyExists)
+
+				active, err := store.Incidents(ctx, models.IncidentsFilter{ActiveOnly: true})
+				require.NoError(t, err)
+				require.Len(t, active, 1)
+				assert.Equal(t, "2", active[0].ID)
+
+				for version := 1; version <= 2; version++ {
+					postmortem := &models.Postmortem{IncidentID: "1", WorkspaceID: models.DefaultWorkspace, Body: "text", CreatedAt: now}
+					require.NoError(t, store.SavePostmortem(ctx, postmortem))
+					assert.Equal(t, version, postmortem.Version)
+				}
+			})
+
+			t.Run("channels & silences of workspaces", func(t *testing.T) {
+				teamA := models.WithWorkspace(context.Background(), "team-a")
+				teamB := models.WithWorkspace(context.Background(), "team-b")
+
+				channelsStore := channels.NewChannelsStore(db)
+				channel := func(id, workspaceID string) *models.Channel {
+					return &models.Channel{ID: id, WorkspaceID: workspaceID, Name: "ops", Kind: models.ChannelWebhook, Target: "https://hooks.example.com", CreatedAt: now}
+				}
+				require.NoError(t, channelsStore.SaveChannel(ctx, channel("1", "team-a")))
+				require.NoError(t, channelsStore.SaveChannel(ctx, channel("2", "team-b")))
+				assert.ErrorIs(t, channelsStore.SaveChannel(ctx, channel("3", "team-a")), storeModels.ErrAlreadyExists)
+
+				found, err := channelsStore.Channels(teamA)
+				require.NoError(t, err)
+				require.Len(t, found, 1)
+				assert.Equal(t, "1", found[0].ID)
+				assert.ErrorIs(t, channelsStore.DeleteChannel(teamB, "1"), storeModels.ErrNotFound)
+
+				silencesStore := silences.NewSilencesStore(db)
+				require.NoError(t, silencesStore.SaveSilence(ctx, &models.Silence{
+					ID: "1", WorkspaceID: "team-a", CreatedBy: "user:1", StartsAt: now, EndsAt: now.Add(time.Hour), EndpointIDs: []string{"api"},
+				}))
+
+				muted, err := silencesStore.Silences(teamA, models.SilencesFilter{EndsAfter: now})
+				require.NoError(t, err)
+				require.Len(t, muted, 1)
+				assert.Equal(t, []string{"api"}, muted[0].EndpointIDs)
+
+				muted, err = silencesStore.Silences(teamB, models.SilencesFilter{})
+				require.NoError(t, err)
+				assert.Empty(t, muted)
+				assert.ErrorIs(t, silencesStore.DeleteSilence(teamB, "1"), storeModels.ErrNotFound)
+			})
+
+			t.Run("optional filters", func(t *testing.T) {
+				auditStore := audit.NewAuditStore(db)
+				require.NoError(t, auditStore.SaveEntry(ctx, &models.AuditEntry{
+					ID: "1", WorkspaceID: models.DefaultWorkspace, Action: models.AuditCreate,
+					Resource: models.AuditEndpoint, ResourceID: "api", Time: now,
+				}))
+
+				entries, err := auditStore.Entries(ctx, models.AuditFilter{})
+				require.NoError(t, err)
+				assert.Len(t, entries, 1)
+
+				entries, err = auditStore.Entries(ctx, models.AuditFilter{Since: now.Add(time.Hour), Limit: 10})
+				require.NoError(t, err)
+				assert.Empty(t, entries)
+
+				maintenancesStore := maintenances.NewMaintenancesStore(db)
+				require.NoError(t, maintenancesStore.SaveMaintenance(ctx, &models.Maintenance{
+					ID: "1", WorkspaceID: models.DefaultWorkspace, Title: "upgrade", StartsAt: now, EndsAt: now.Add(time.Hour),
+				}))
+
+				found, err := maintenancesStore.Maintenances(ctx, models.MaintenancesFilter{EndsAfter: now.Add(2 * time.Hour)})
+				require.NoError(t, err)
+				assert.Empty(t, found)
+
+				found, err = maintenancesStore.Maintenances(ctx, models.MaintenancesFilter{})
+				require.NoError(t, err)
+				assert.Len(t, found, 1)
+			})
+		})
+	}
+}
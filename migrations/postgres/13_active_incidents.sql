@@ -0,0 +1,15 @@
+-- +goose Up
+-- duplicates left by concurrent checks are closed as they were opened, the latest one stays active
+UPDATE incidents SET resolved_at = opened_at
+WHERE resolved_at IS NULL
+  AND EXISTS (
+    SELECT 1 FROM incidents newer
+    WHERE newer.endpoint_id = incidents.endpoint_id
+      AND newer.resolved_at IS NULL
+      AND (newer.opened_at > incidents.opened_at OR (newer.opened_at = incidents.opened_at AND newer.id > incidents.id))
+  );
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_incidents_active ON incidents (endpoint_id) WHERE resolved_at IS NULL;
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_incidents_active;
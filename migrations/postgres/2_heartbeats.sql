@@ -0,0 +1,41 @@
+-- +goose Up
+ALTER TABLE endpoints DROP CONSTRAINT IF EXISTS endpoints_url_key;
+ALTER TABLE endpoints DROP CONSTRAINT IF EXISTS endpoints_id_key;
+ALTER TABLE endpoints ADD PRIMARY KEY (id);
+ALTER TABLE endpoints ALTER COLUMN url SET DEFAULT '';
+ALTER TABLE endpoints ADD COLUMN type TEXT NOT NULL DEFAULT 'http';
+ALTER TABLE endpoints ADD COLUMN created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP;
+DROP INDEX IF EXISTS idx_endpoint_name;
+CREATE INDEX IF NOT EXISTS idx_endpoints_name ON endpoints (name);
+
+CREATE TABLE IF NOT EXISTS heartbeats
+(
+    endpoint_id TEXT NOT NULL PRIMARY KEY REFERENCES endpoints (id) ON DELETE CASCADE,
+    token       TEXT NOT NULL UNIQUE,
+    last_ping   TIMESTAMPTZ,
+    last_kind   TEXT NOT NULL DEFAULT '',
+    started_at  TIMESTAMPTZ
+);
+
+CREATE TABLE IF NOT EXISTS incidents
+(
+    id          TEXT        NOT NULL PRIMARY KEY,
+    endpoint_id TEXT        NOT NULL,
+    cause       TEXT        NOT NULL,
+    opened_at   TIMESTAMPTZ NOT NULL,
+    resolved_at TIMESTAMPTZ
+);
+CREATE INDEX IF NOT EXISTS idx_incidents_endpoint ON incidents (endpoint_id, resolved_at);
+
+-- +goose Down
+DROP TABLE IF EXISTS incidents;
+DROP TABLE IF EXISTS heartbeats;
+DROP INDEX IF EXISTS idx_endpoints_name;
+CREATE INDEX IF NOT EXISTS idx_endpoint_name ON endpoints (name);
+ALTER TABLE endpoints DROP COLUMN created_at;
+ALTER TABLE endpoints DROP COLUMN type;
+ALTER TABLE endpoints ALTER COLUMN url DROP DEFAULT;
+ALTER TABLE endpoints DROP CONSTRAINT endpoints_pkey;
+ALTER TABLE endpoints ADD CONSTRAINT endpoints_id_key UNIQUE (id);
+DELETE FROM endpoints a USING endpoints b WHERE a.url = b.url AND a.id > b.id;
+ALTER TABLE endpoints ADD CONSTRAINT endpoints_url_key UNIQUE (url);
@@ -0,0 +1,20 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS apps
+(
+    id         TEXT        NOT NULL PRIMARY KEY,
+    name       TEXT        NOT NULL UNIQUE,
+    secret     TEXT        NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS api_keys
+(
+    hash       TEXT        NOT NULL PRIMARY KEY,
+    app_id     TEXT        NOT NULL REFERENCES apps (id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX IF NOT EXISTS idx_api_keys_app ON api_keys (app_id);
+
+-- +goose Down
+DROP TABLE IF EXISTS api_keys;
+DROP TABLE IF EXISTS apps;
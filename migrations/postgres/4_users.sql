@@ -0,0 +1,16 @@
+-- +goose Up
+ALTER TABLE apps ADD COLUMN role TEXT NOT NULL DEFAULT 'admin';
+
+CREATE TABLE IF NOT EXISTS users
+(
+    id            TEXT        NOT NULL PRIMARY KEY,
+    nickname      TEXT        NOT NULL UNIQUE,
+    email         TEXT        NOT NULL UNIQUE,
+    password_hash BYTEA       NOT NULL,
+    role          TEXT        NOT NULL DEFAULT 'viewer',
+    created_at    TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS users;
+ALTER TABLE apps DROP COLUMN role;
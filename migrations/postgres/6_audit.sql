@@ -0,0 +1,39 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS audit_log
+(
+    id           TEXT        NOT NULL PRIMARY KEY,
+    workspace_id TEXT        NOT NULL,
+    user_id      TEXT        NOT NULL DEFAULT '',
+    app_id       TEXT        NOT NULL DEFAULT '',
+    app_name     TEXT        NOT NULL DEFAULT '',
+    action       TEXT        NOT NULL,
+    resource     TEXT        NOT NULL,
+    resource_id  TEXT        NOT NULL,
+    before       BYTEA,
+    after        BYTEA,
+    diff         BYTEA,
+    created_at   TIMESTAMPTZ NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_audit_log_workspace ON audit_log (workspace_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_audit_log_resource ON audit_log (resource, resource_id);
+
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION audit_log_append_only() RETURNS trigger AS
+$$
+BEGIN
+    RAISE EXCEPTION 'audit log is append-only';
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+CREATE TRIGGER audit_log_no_update BEFORE UPDATE ON audit_log
+    FOR EACH ROW EXECUTE FUNCTION audit_log_append_only();
+
+CREATE TRIGGER audit_log_no_delete BEFORE DELETE ON audit_log
+    FOR EACH ROW EXECUTE FUNCTION audit_log_append_only();
+
+-- +goose Down
+DROP TRIGGER IF EXISTS audit_log_no_delete ON audit_log;
+DROP TRIGGER IF EXISTS audit_log_no_update ON audit_log;
+DROP FUNCTION IF EXISTS audit_log_append_only();
+DROP TABLE IF EXISTS audit_log;
@@ -0,0 +1,7 @@
+-- +goose Up
+ALTER TABLE incidents ADD COLUMN acknowledged_at TIMESTAMPTZ;
+ALTER TABLE incidents ADD COLUMN acknowledged_by TEXT NOT NULL DEFAULT '';
+
+-- +goose Down
+ALTER TABLE incidents DROP COLUMN acknowledged_by;
+ALTER TABLE incidents DROP COLUMN acknowledged_at;
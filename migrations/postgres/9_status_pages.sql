@@ -0,0 +1,21 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS status_pages
+(
+    id           TEXT NOT NULL PRIMARY KEY,
+    workspace_id TEXT NOT NULL,
+    slug         TEXT NOT NULL UNIQUE,
+    title        TEXT NOT NULL,
+    logo_url     TEXT NOT NULL DEFAULT '',
+    endpoint_ids BYTEA,
+    selector     BYTEA,
+    visibility   TEXT NOT NULL DEFAULT 'public',
+    token        TEXT NOT NULL DEFAULT '',
+    host         TEXT NOT NULL DEFAULT ''
+);
+CREATE INDEX IF NOT EXISTS idx_status_pages_workspace ON status_pages (workspace_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_status_pages_host ON status_pages (host) WHERE host != '';
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_status_pages_host;
+DROP INDEX IF EXISTS idx_status_pages_workspace;
+DROP TABLE IF EXISTS status_pages;
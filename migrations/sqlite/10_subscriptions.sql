@@ -0,0 +1,17 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS subscriptions
+(
+    id           TEXT      NOT NULL PRIMARY KEY,
+    page_id      TEXT      NOT NULL REFERENCES status_pages (id) ON DELETE CASCADE,
+    workspace_id TEXT      NOT NULL,
+    kind         TEXT      NOT NULL,
+    target       TEXT      NOT NULL,
+    components   BLOB,
+    token        TEXT      NOT NULL UNIQUE,
+    confirmed_at TIMESTAMP,
+    created_at   TIMESTAMP NOT NULL,
+    UNIQUE (page_id, kind, target)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS subscriptions;
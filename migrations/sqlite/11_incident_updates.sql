@@ -0,0 +1,28 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS incident_updates
+(
+    id           TEXT      NOT NULL PRIMARY KEY,
+    incident_id  TEXT      NOT NULL,
+    workspace_id TEXT      NOT NULL,
+    state        TEXT      NOT NULL,
+    body         TEXT      NOT NULL,
+    author       TEXT      NOT NULL DEFAULT '',
+    created_at   TIMESTAMP NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_incident_updates_incident ON incident_updates (incident_id, created_at);
+
+CREATE TABLE IF NOT EXISTS postmortems
+(
+    incident_id  TEXT      NOT NULL,
+    version      INTEGER   NOT NULL,
+    workspace_id TEXT      NOT NULL,
+    body         TEXT      NOT NULL,
+    author       TEXT      NOT NULL DEFAULT '',
+    created_at   TIMESTAMP NOT NULL,
+    PRIMARY KEY (incident_id, version)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS postmortems;
+DROP INDEX IF EXISTS idx_incident_updates_incident;
+DROP TABLE IF EXISTS incident_updates;
@@ -0,0 +1,28 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS channels
+(
+    id           TEXT NOT NULL PRIMARY KEY,
+    workspace_id TEXT NOT NULL,
+    name         TEXT NOT NULL,
+    kind         TEXT NOT NULL,
+    target       TEXT NOT NULL,
+    created_at   TIMESTAMP NOT NULL,
+    UNIQUE (workspace_id, name)
+);
+
+CREATE TABLE IF NOT EXISTS silences
+(
+    id           TEXT NOT NULL PRIMARY KEY,
+    workspace_id TEXT NOT NULL,
+    comment      TEXT NOT NULL DEFAULT '',
+    created_by   TEXT NOT NULL DEFAULT '',
+    starts_at    TIMESTAMP NOT NULL,
+    ends_at      TIMESTAMP NOT NULL,
+    endpoint_ids BLOB
+);
+CREATE INDEX IF NOT EXISTS idx_silences_workspace ON silences (workspace_id, ends_at);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_silences_workspace;
+DROP TABLE IF EXISTS silences;
+DROP TABLE IF EXISTS channels;
@@ -6,7 +6,7 @@ CREATE TABLE IF NOT EXISTS endpoints
     url     TEXT NOT NULL UNIQUE,
     protocol BLOB NOT NULL
 );
-CREATE INDEX IF NOT EXISTS idx_endpoint_name ON users (name);
+CREATE INDEX IF NOT EXISTS idx_endpoint_name ON endpoints (name);
 
 -- +goose Down
 DROP TABLE IF EXISTS endpoints;
\ No newline at end of file
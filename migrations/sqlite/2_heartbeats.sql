@@ -0,0 +1,49 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS endpoints_new
+(
+    id         TEXT NOT NULL PRIMARY KEY,
+    name       TEXT NOT NULL,
+    url        TEXT NOT NULL DEFAULT '',
+    type       TEXT NOT NULL DEFAULT 'http',
+    protocol   BLOB NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+INSERT INTO endpoints_new (id, name, url, protocol)
+SELECT id, name, url, protocol FROM endpoints;
+DROP TABLE endpoints;
+ALTER TABLE endpoints_new RENAME TO endpoints;
+CREATE INDEX IF NOT EXISTS idx_endpoints_name ON endpoints (name);
+
+CREATE TABLE IF NOT EXISTS heartbeats
+(
+    endpoint_id TEXT NOT NULL PRIMARY KEY REFERENCES endpoints (id) ON DELETE CASCADE,
+    token       TEXT NOT NULL UNIQUE,
+    last_ping   TIMESTAMP,
+    last_kind   TEXT NOT NULL DEFAULT '',
+    started_at  TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS incidents
+(
+    id          TEXT NOT NULL PRIMARY KEY,
+    endpoint_id TEXT NOT NULL,
+    cause       TEXT NOT NULL,
+    opened_at   TIMESTAMP NOT NULL,
+    resolved_at TIMESTAMP
+);
+CREATE INDEX IF NOT EXISTS idx_incidents_endpoint ON incidents (endpoint_id, resolved_at);
+
+-- +goose Down
+DROP TABLE IF EXISTS incidents;
+DROP TABLE IF EXISTS heartbeats;
+CREATE TABLE IF NOT EXISTS endpoints_old
+(
+    id        TEXT NOT NULL UNIQUE,
+    name  TEXT NOT NULL,
+    url     TEXT NOT NULL UNIQUE,
+    protocol BLOB NOT NULL
+);
+INSERT OR IGNORE INTO endpoints_old (id, name, url, protocol)
+SELECT id, name, url, protocol FROM endpoints;
+DROP TABLE endpoints;
+ALTER TABLE endpoints_old RENAME TO endpoints;
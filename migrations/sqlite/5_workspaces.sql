@@ -0,0 +1,26 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS workspaces
+(
+    id         TEXT NOT NULL PRIMARY KEY,
+    name       TEXT NOT NULL UNIQUE,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+INSERT INTO workspaces (id, name) VALUES ('default', 'default');
+
+ALTER TABLE endpoints ADD COLUMN workspace_id TEXT NOT NULL DEFAULT 'default';
+CREATE INDEX IF NOT EXISTS idx_endpoints_workspace ON endpoints (workspace_id);
+
+ALTER TABLE incidents ADD COLUMN workspace_id TEXT NOT NULL DEFAULT 'default';
+CREATE INDEX IF NOT EXISTS idx_incidents_workspace ON incidents (workspace_id);
+
+ALTER TABLE users ADD COLUMN workspace_id TEXT NOT NULL DEFAULT 'default';
+ALTER TABLE apps ADD COLUMN workspace_id TEXT NOT NULL DEFAULT 'default';
+
+-- +goose Down
+ALTER TABLE apps DROP COLUMN workspace_id;
+ALTER TABLE users DROP COLUMN workspace_id;
+DROP INDEX IF EXISTS idx_incidents_workspace;
+ALTER TABLE incidents DROP COLUMN workspace_id;
+DROP INDEX IF EXISTS idx_endpoints_workspace;
+ALTER TABLE endpoints DROP COLUMN workspace_id;
+DROP TABLE IF EXISTS workspaces;
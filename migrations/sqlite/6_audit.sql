@@ -0,0 +1,37 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS audit_log
+(
+    id           TEXT NOT NULL PRIMARY KEY,
+    workspace_id TEXT NOT NULL,
+    user_id      TEXT NOT NULL DEFAULT '',
+    app_id       TEXT NOT NULL DEFAULT '',
+    app_name     TEXT NOT NULL DEFAULT '',
+    action       TEXT NOT NULL,
+    resource     TEXT NOT NULL,
+    resource_id  TEXT NOT NULL,
+    before       BLOB,
+    after        BLOB,
+    diff         BLOB,
+    created_at   TIMESTAMP NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_audit_log_workspace ON audit_log (workspace_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_audit_log_resource ON audit_log (resource, resource_id);
+
+-- +goose StatementBegin
+CREATE TRIGGER IF NOT EXISTS audit_log_no_update BEFORE UPDATE ON audit_log
+BEGIN
+    SELECT RAISE(ABORT, 'audit log is append-only');
+END;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TRIGGER IF NOT EXISTS audit_log_no_delete BEFORE DELETE ON audit_log
+BEGIN
+    SELECT RAISE(ABORT, 'audit log is append-only');
+END;
+-- +goose StatementEnd
+
+-- +goose Down
+DROP TRIGGER IF EXISTS audit_log_no_delete;
+DROP TRIGGER IF EXISTS audit_log_no_update;
+DROP TABLE IF EXISTS audit_log;
@@ -0,0 +1,30 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS check_history
+(
+    endpoint_id  TEXT NOT NULL,
+    workspace_id TEXT NOT NULL,
+    day          TEXT NOT NULL,
+    checks       INTEGER NOT NULL DEFAULT 0,
+    failures     INTEGER NOT NULL DEFAULT 0,
+    latency_ms   INTEGER NOT NULL DEFAULT 0,
+    PRIMARY KEY (endpoint_id, day)
+);
+CREATE INDEX IF NOT EXISTS idx_check_history_workspace ON check_history (workspace_id, day);
+
+CREATE TABLE IF NOT EXISTS maintenances
+(
+    id           TEXT NOT NULL PRIMARY KEY,
+    workspace_id TEXT NOT NULL,
+    title        TEXT NOT NULL,
+    message      TEXT NOT NULL DEFAULT '',
+    starts_at    TIMESTAMP NOT NULL,
+    ends_at      TIMESTAMP NOT NULL,
+    endpoint_ids BLOB
+);
+CREATE INDEX IF NOT EXISTS idx_maintenances_workspace ON maintenances (workspace_id, ends_at);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_maintenances_workspace;
+DROP TABLE IF EXISTS maintenances;
+DROP INDEX IF EXISTS idx_check_history_workspace;
+DROP TABLE IF EXISTS check_history;
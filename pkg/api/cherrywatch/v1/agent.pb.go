@@ -0,0 +1,583 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v5.29.3
+// source: cherrywatch/v1/agent.proto
+
+package cherrywatchv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Agent struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Agent identifier (generated by the server)
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Name unique within the workspace
+	Name string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// Location the agent checks endpoints from
+	Location      string                 `protobuf:"bytes,3,opt,name=location,proto3" json:"location,omitempty"`
+	RegisteredAt  *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=registered_at,json=registeredAt,proto3" json:"registered_at,omitempty"`
+	LastSeenAt    *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=last_seen_at,json=lastSeenAt,proto3" json:"last_seen_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Agent) Reset() {
+	*x = Agent{}
+	mi := &file_cherrywatch_v1_agent_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Agent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Agent) ProtoMessage() {}
+
+func (x *Agent) ProtoReflect() protoreflect.Message {
+	mi := &file_cherrywatch_v1_agent_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Agent.ProtoReflect.Descriptor instead.
+func (*Agent) Descriptor() ([]byte, []int) {
+	return file_cherrywatch_v1_agent_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Agent) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Agent) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Agent) GetLocation() string {
+	if x != nil {
+		return x.Location
+	}
+	return ""
+}
+
+func (x *Agent) GetRegisteredAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.RegisteredAt
+	}
+	return nil
+}
+
+func (x *Agent) GetLastSeenAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastSeenAt
+	}
+	return nil
+}
+
+type RegisterAgentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Location      string                 `protobuf:"bytes,2,opt,name=location,proto3" json:"location,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RegisterAgentRequest) Reset() {
+	*x = RegisterAgentRequest{}
+	mi := &file_cherrywatch_v1_agent_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RegisterAgentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegisterAgentRequest) ProtoMessage() {}
+
+func (x *RegisterAgentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_cherrywatch_v1_agent_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegisterAgentRequest.ProtoReflect.Descriptor instead.
+func (*RegisterAgentRequest) Descriptor() ([]byte, []int) {
+	return file_cherrywatch_v1_agent_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *RegisterAgentRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *RegisterAgentRequest) GetLocation() string {
+	if x != nil {
+		return x.Location
+	}
+	return ""
+}
+
+type RegisterAgentResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Agent         *Agent                 `protobuf:"bytes,1,opt,name=agent,proto3" json:"agent,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RegisterAgentResponse) Reset() {
+	*x = RegisterAgentResponse{}
+	mi := &file_cherrywatch_v1_agent_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RegisterAgentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegisterAgentResponse) ProtoMessage() {}
+
+func (x *RegisterAgentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_cherrywatch_v1_agent_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegisterAgentResponse.ProtoReflect.Descriptor instead.
+func (*RegisterAgentResponse) Descriptor() ([]byte, []int) {
+	return file_cherrywatch_v1_agent_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *RegisterAgentResponse) GetAgent() *Agent {
+	if x != nil {
+		return x.Agent
+	}
+	return nil
+}
+
+type ListAssignmentsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AgentId       string                 `protobuf:"bytes,1,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAssignmentsRequest) Reset() {
+	*x = ListAssignmentsRequest{}
+	mi := &file_cherrywatch_v1_agent_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAssignmentsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAssignmentsRequest) ProtoMessage() {}
+
+func (x *ListAssignmentsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_cherrywatch_v1_agent_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAssignmentsRequest.ProtoReflect.Descriptor instead.
+func (*ListAssignmentsRequest) Descriptor() ([]byte, []int) {
+	return file_cherrywatch_v1_agent_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ListAssignmentsRequest) GetAgentId() string {
+	if x != nil {
+		return x.AgentId
+	}
+	return ""
+}
+
+type ListAssignmentsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Endpoints     []*Endpoint            `protobuf:"bytes,1,rep,name=endpoints,proto3" json:"endpoints,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAssignmentsResponse) Reset() {
+	*x = ListAssignmentsResponse{}
+	mi := &file_cherrywatch_v1_agent_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAssignmentsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAssignmentsResponse) ProtoMessage() {}
+
+func (x *ListAssignmentsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_cherrywatch_v1_agent_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAssignmentsResponse.ProtoReflect.Descriptor instead.
+func (*ListAssignmentsResponse) Descriptor() ([]byte, []int) {
+	return file_cherrywatch_v1_agent_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ListAssignmentsResponse) GetEndpoints() []*Endpoint {
+	if x != nil {
+		return x.Endpoints
+	}
+	return nil
+}
+
+type ReportResultsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AgentId       string                 `protobuf:"bytes,1,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
+	Result        *CheckResult           `protobuf:"bytes,2,opt,name=result,proto3" json:"result,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReportResultsRequest) Reset() {
+	*x = ReportResultsRequest{}
+	mi := &file_cherrywatch_v1_agent_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReportResultsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReportResultsRequest) ProtoMessage() {}
+
+func (x *ReportResultsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_cherrywatch_v1_agent_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReportResultsRequest.ProtoReflect.Descriptor instead.
+func (*ReportResultsRequest) Descriptor() ([]byte, []int) {
+	return file_cherrywatch_v1_agent_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ReportResultsRequest) GetAgentId() string {
+	if x != nil {
+		return x.AgentId
+	}
+	return ""
+}
+
+func (x *ReportResultsRequest) GetResult() *CheckResult {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+type ReportResultsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Number of results applied
+	Accepted uint64 `protobuf:"varint,1,opt,name=accepted,proto3" json:"accepted,omitempty"`
+	// Number of results skipped
+	Skipped       uint64 `protobuf:"varint,2,opt,name=skipped,proto3" json:"skipped,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReportResultsResponse) Reset() {
+	*x = ReportResultsResponse{}
+	mi := &file_cherrywatch_v1_agent_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReportResultsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReportResultsResponse) ProtoMessage() {}
+
+func (x *ReportResultsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_cherrywatch_v1_agent_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReportResultsResponse.ProtoReflect.Descriptor instead.
+func (*ReportResultsResponse) Descriptor() ([]byte, []int) {
+	return file_cherrywatch_v1_agent_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ReportResultsResponse) GetAccepted() uint64 {
+	if x != nil {
+		return x.Accepted
+	}
+	return 0
+}
+
+func (x *ReportResultsResponse) GetSkipped() uint64 {
+	if x != nil {
+		return x.Skipped
+	}
+	return 0
+}
+
+type ListAgentsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAgentsRequest) Reset() {
+	*x = ListAgentsRequest{}
+	mi := &file_cherrywatch_v1_agent_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAgentsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAgentsRequest) ProtoMessage() {}
+
+func (x *ListAgentsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_cherrywatch_v1_agent_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAgentsRequest.ProtoReflect.Descriptor instead.
+func (*ListAgentsRequest) Descriptor() ([]byte, []int) {
+	return file_cherrywatch_v1_agent_proto_rawDescGZIP(), []int{7}
+}
+
+type ListAgentsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Agents        []*Agent               `protobuf:"bytes,1,rep,name=agents,proto3" json:"agents,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAgentsResponse) Reset() {
+	*x = ListAgentsResponse{}
+	mi := &file_cherrywatch_v1_agent_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAgentsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAgentsResponse) ProtoMessage() {}
+
+func (x *ListAgentsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_cherrywatch_v1_agent_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAgentsResponse.ProtoReflect.Descriptor instead.
+func (*ListAgentsResponse) Descriptor() ([]byte, []int) {
+	return file_cherrywatch_v1_agent_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ListAgentsResponse) GetAgents() []*Agent {
+	if x != nil {
+		return x.Agents
+	}
+	return nil
+}
+
+var File_cherrywatch_v1_agent_proto protoreflect.FileDescriptor
+
+const file_cherrywatch_v1_agent_proto_rawDesc = "" +
+	"\n" +
+	"\x1acherrywatch/v1/agent.proto\x12\x0echerrywatch.v1\x1a\x1fgoogle/protobuf/timestamp.proto\x1a cherrywatch/v1/cherrywatch.proto\"\xc6\x01\n" +
+	"\x05Agent\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1a\n" +
+	"\blocation\x18\x03 \x01(\tR\blocation\x12?\n" +
+	"\rregistered_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\fregisteredAt\x12<\n" +
+	"\flast_seen_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"lastSeenAt\"F\n" +
+	"\x14RegisterAgentRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1a\n" +
+	"\blocation\x18\x02 \x01(\tR\blocation\"D\n" +
+	"\x15RegisterAgentResponse\x12+\n" +
+	"\x05agent\x18\x01 \x01(\v2\x15.cherrywatch.v1.AgentR\x05agent\"3\n" +
+	"\x16ListAssignmentsRequest\x12\x19\n" +
+	"\bagent_id\x18\x01 \x01(\tR\aagentId\"Q\n" +
+	"\x17ListAssignmentsResponse\x126\n" +
+	"\tendpoints\x18\x01 \x03(\v2\x18.cherrywatch.v1.EndpointR\tendpoints\"f\n" +
+	"\x14ReportResultsRequest\x12\x19\n" +
+	"\bagent_id\x18\x01 \x01(\tR\aagentId\x123\n" +
+	"\x06result\x18\x02 \x01(\v2\x1b.cherrywatch.v1.CheckResultR\x06result\"M\n" +
+	"\x15ReportResultsResponse\x12\x1a\n" +
+	"\baccepted\x18\x01 \x01(\x04R\baccepted\x12\x18\n" +
+	"\askipped\x18\x02 \x01(\x04R\askipped\"\x13\n" +
+	"\x11ListAgentsRequest\"C\n" +
+	"\x12ListAgentsResponse\x12-\n" +
+	"\x06agents\x18\x01 \x03(\v2\x15.cherrywatch.v1.AgentR\x06agents2\x85\x03\n" +
+	"\fAgentService\x12\\\n" +
+	"\rRegisterAgent\x12$.cherrywatch.v1.RegisterAgentRequest\x1a%.cherrywatch.v1.RegisterAgentResponse\x12b\n" +
+	"\x0fListAssignments\x12&.cherrywatch.v1.ListAssignmentsRequest\x1a'.cherrywatch.v1.ListAssignmentsResponse\x12^\n" +
+	"\rReportResults\x12$.cherrywatch.v1.ReportResultsRequest\x1a%.cherrywatch.v1.ReportResultsResponse(\x01\x12S\n" +
+	"\n" +
+	"ListAgents\x12!.cherrywatch.v1.ListAgentsRequest\x1a\".cherrywatch.v1.ListAgentsResponseBHZFgithub.com/vishenosik/CherryWatch/pkg/api/cherrywatch/v1;cherrywatchv1b\x06proto3"
+
+var (
+	file_cherrywatch_v1_agent_proto_rawDescOnce sync.Once
+	file_cherrywatch_v1_agent_proto_rawDescData []byte
+)
+
+func file_cherrywatch_v1_agent_proto_rawDescGZIP() []byte {
+	file_cherrywatch_v1_agent_proto_rawDescOnce.Do(func() {
+		file_cherrywatch_v1_agent_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_cherrywatch_v1_agent_proto_rawDesc), len(file_cherrywatch_v1_agent_proto_rawDesc)))
+	})
+	return file_cherrywatch_v1_agent_proto_rawDescData
+}
+
+var file_cherrywatch_v1_agent_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
+var file_cherrywatch_v1_agent_proto_goTypes = []any{
+	(*Agent)(nil),                   // 0: cherrywatch.v1.Agent
+	(*RegisterAgentRequest)(nil),    // 1: cherrywatch.v1.RegisterAgentRequest
+	(*RegisterAgentResponse)(nil),   // 2: cherrywatch.v1.RegisterAgentResponse
+	(*ListAssignmentsRequest)(nil),  // 3: cherrywatch.v1.ListAssignmentsRequest
+	(*ListAssignmentsResponse)(nil), // 4: cherrywatch.v1.ListAssignmentsResponse
+	(*ReportResultsRequest)(nil),    // 5: cherrywatch.v1.ReportResultsRequest
+	(*ReportResultsResponse)(nil),   // 6: cherrywatch.v1.ReportResultsResponse
+	(*ListAgentsRequest)(nil),       // 7: cherrywatch.v1.ListAgentsRequest
+	(*ListAgentsResponse)(nil),      // 8: cherrywatch.v1.ListAgentsResponse
+	(*timestamppb.Timestamp)(nil),   // 9: google.protobuf.Timestamp
+	(*Endpoint)(nil),                // 10: cherrywatch.v1.Endpoint
+	(*CheckResult)(nil),             // 11: cherrywatch.v1.CheckResult
+}
+var file_cherrywatch_v1_agent_proto_depIdxs = []int32{
+	9,  // 0: cherrywatch.v1.Agent.registered_at:type_name -> google.protobuf.Timestamp
+	9,  // 1: cherrywatch.v1.Agent.last_seen_at:type_name -> google.protobuf.Timestamp
+	0,  // 2: cherrywatch.v1.RegisterAgentResponse.agent:type_name -> cherrywatch.v1.Agent
+	10, // 3: cherrywatch.v1.ListAssignmentsResponse.endpoints:type_name -> cherrywatch.v1.Endpoint
+	11, // 4: cherrywatch.v1.ReportResultsRequest.result:type_name -> cherrywatch.v1.CheckResult
+	0,  // 5: cherrywatch.v1.ListAgentsResponse.agents:type_name -> cherrywatch.v1.Agent
+	1,  // 6: cherrywatch.v1.AgentService.RegisterAgent:input_type -> cherrywatch.v1.RegisterAgentRequest
+	3,  // 7: cherrywatch.v1.AgentService.ListAssignments:input_type -> cherrywatch.v1.ListAssignmentsRequest
+	5,  // 8: cherrywatch.v1.AgentService.ReportResults:input_type -> cherrywatch.v1.ReportResultsRequest
+	7,  // 9: cherrywatch.v1.AgentService.ListAgents:input_type -> cherrywatch.v1.ListAgentsRequest
+	2,  // 10: cherrywatch.v1.AgentService.RegisterAgent:output_type -> cherrywatch.v1.RegisterAgentResponse
+	4,  // 11: cherrywatch.v1.AgentService.ListAssignments:output_type -> cherrywatch.v1.ListAssignmentsResponse
+	6,  // 12: cherrywatch.v1.AgentService.ReportResults:output_type -> cherrywatch.v1.ReportResultsResponse
+	8,  // 13: cherrywatch.v1.AgentService.ListAgents:output_type -> cherrywatch.v1.ListAgentsResponse
+	10, // [10:14] is the sub-list for method output_type
+	6,  // [6:10] is the sub-list for method input_type
+	6,  // [6:6] is the sub-list for extension type_name
+	6,  // [6:6] is the sub-list for extension extendee
+	0,  // [0:6] is the sub-list for field type_name
+}
+
+func init() { file_cherrywatch_v1_agent_proto_init() }
+func file_cherrywatch_v1_agent_proto_init() {
+	if File_cherrywatch_v1_agent_proto != nil {
+		return
+	}
+	file_cherrywatch_v1_cherrywatch_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_cherrywatch_v1_agent_proto_rawDesc), len(file_cherrywatch_v1_agent_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   9,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_cherrywatch_v1_agent_proto_goTypes,
+		DependencyIndexes: file_cherrywatch_v1_agent_proto_depIdxs,
+		MessageInfos:      file_cherrywatch_v1_agent_proto_msgTypes,
+	}.Build()
+	File_cherrywatch_v1_agent_proto = out.File
+	file_cherrywatch_v1_agent_proto_goTypes = nil
+	file_cherrywatch_v1_agent_proto_depIdxs = nil
+}
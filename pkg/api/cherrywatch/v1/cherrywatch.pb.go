@@ -0,0 +1,1740 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v5.29.3
+// source: cherrywatch/v1/cherrywatch.proto
+
+package cherrywatchv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Endpoint struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Endpoint identifier (uuid4 only)
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Name of checked service (ascii symbols only)
+	ServiceName string `protobuf:"bytes,2,opt,name=service_name,json=serviceName,proto3" json:"service_name,omitempty"`
+	// URL string to trigger during checks
+	Url string `protobuf:"bytes,3,opt,name=url,proto3" json:"url,omitempty"`
+	// HTTP codes & code ranges which are considered successful ("200", "200-299")
+	SuccessCodes []string `protobuf:"bytes,4,rep,name=success_codes,json=successCodes,proto3" json:"success_codes,omitempty"`
+	// Services used to notify about check failure
+	NotificationServices []string `protobuf:"bytes,5,rep,name=notification_services,json=notificationServices,proto3" json:"notification_services,omitempty"`
+	// Time interval between checks
+	Interval *durationpb.Duration `protobuf:"bytes,6,opt,name=interval,proto3" json:"interval,omitempty"`
+	// Check type: http (default), transaction, heartbeat, sql or exec
+	Type string `protobuf:"bytes,7,opt,name=type,proto3" json:"type,omitempty"`
+	// Steps of a transaction check
+	Transaction *Transaction `protobuf:"bytes,8,opt,name=transaction,proto3" json:"transaction,omitempty"`
+	// Heartbeat monitor settings (interval is the expected ping period)
+	Heartbeat *Heartbeat `protobuf:"bytes,9,opt,name=heartbeat,proto3" json:"heartbeat,omitempty"`
+	// Database query check settings
+	Sql *SQLCheck `protobuf:"bytes,10,opt,name=sql,proto3" json:"sql,omitempty"`
+	// Local command check settings
+	Exec *ExecCheck `protobuf:"bytes,11,opt,name=exec,proto3" json:"exec,omitempty"`
+	// Arbitrary key-value labels
+	Labels map[string]string `protobuf:"bytes,12,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// One of: info, warning, critical (default)
+	Severity string `protobuf:"bytes,13,opt,name=severity,proto3" json:"severity,omitempty"`
+	// Agents checking the endpoint instead of the server
+	Probes        *Probes `protobuf:"bytes,14,opt,name=probes,proto3" json:"probes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Endpoint) Reset() {
+	*x = Endpoint{}
+	mi := &file_cherrywatch_v1_cherrywatch_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Endpoint) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Endpoint) ProtoMessage() {}
+
+func (x *Endpoint) ProtoReflect() protoreflect.Message {
+	mi := &file_cherrywatch_v1_cherrywatch_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Endpoint.ProtoReflect.Descriptor instead.
+func (*Endpoint) Descriptor() ([]byte, []int) {
+	return file_cherrywatch_v1_cherrywatch_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Endpoint) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Endpoint) GetServiceName() string {
+	if x != nil {
+		return x.ServiceName
+	}
+	return ""
+}
+
+func (x *Endpoint) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *Endpoint) GetSuccessCodes() []string {
+	if x != nil {
+		return x.SuccessCodes
+	}
+	return nil
+}
+
+func (x *Endpoint) GetNotificationServices() []string {
+	if x != nil {
+		return x.NotificationServices
+	}
+	return nil
+}
+
+func (x *Endpoint) GetInterval() *durationpb.Duration {
+	if x != nil {
+		return x.Interval
+	}
+	return nil
+}
+
+func (x *Endpoint) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *Endpoint) GetTransaction() *Transaction {
+	if x != nil {
+		return x.Transaction
+	}
+	return nil
+}
+
+func (x *Endpoint) GetHeartbeat() *Heartbeat {
+	if x != nil {
+		return x.Heartbeat
+	}
+	return nil
+}
+
+func (x *Endpoint) GetSql() *SQLCheck {
+	if x != nil {
+		return x.Sql
+	}
+	return nil
+}
+
+func (x *Endpoint) GetExec() *ExecCheck {
+	if x != nil {
+		return x.Exec
+	}
+	return nil
+}
+
+func (x *Endpoint) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *Endpoint) GetSeverity() string {
+	if x != nil {
+		return x.Severity
+	}
+	return ""
+}
+
+func (x *Endpoint) GetProbes() *Probes {
+	if x != nil {
+		return x.Probes
+	}
+	return nil
+}
+
+type Probes struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Locations of agents checking the endpoint
+	Locations []string `protobuf:"bytes,1,rep,name=locations,proto3" json:"locations,omitempty"`
+	// Number of failing locations making the endpoint fail, a majority if zero
+	Quorum        int32 `protobuf:"varint,2,opt,name=quorum,proto3" json:"quorum,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Probes) Reset() {
+	*x = Probes{}
+	mi := &file_cherrywatch_v1_cherrywatch_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Probes) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Probes) ProtoMessage() {}
+
+func (x *Probes) ProtoReflect() protoreflect.Message {
+	mi := &file_cherrywatch_v1_cherrywatch_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Probes.ProtoReflect.Descriptor instead.
+func (*Probes) Descriptor() ([]byte, []int) {
+	return file_cherrywatch_v1_cherrywatch_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Probes) GetLocations() []string {
+	if x != nil {
+		return x.Locations
+	}
+	return nil
+}
+
+func (x *Probes) GetQuorum() int32 {
+	if x != nil {
+		return x.Quorum
+	}
+	return 0
+}
+
+type Transaction struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Steps         []*TransactionStep     `protobuf:"bytes,1,rep,name=steps,proto3" json:"steps,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Transaction) Reset() {
+	*x = Transaction{}
+	mi := &file_cherrywatch_v1_cherrywatch_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Transaction) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Transaction) ProtoMessage() {}
+
+func (x *Transaction) ProtoReflect() protoreflect.Message {
+	mi := &file_cherrywatch_v1_cherrywatch_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Transaction.ProtoReflect.Descriptor instead.
+func (*Transaction) Descriptor() ([]byte, []int) {
+	return file_cherrywatch_v1_cherrywatch_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Transaction) GetSteps() []*TransactionStep {
+	if x != nil {
+		return x.Steps
+	}
+	return nil
+}
+
+type TransactionStep struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Method        string                 `protobuf:"bytes,2,opt,name=method,proto3" json:"method,omitempty"`
+	Url           string                 `protobuf:"bytes,3,opt,name=url,proto3" json:"url,omitempty"`
+	Headers       map[string]string      `protobuf:"bytes,4,rep,name=headers,proto3" json:"headers,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Body          string                 `protobuf:"bytes,5,opt,name=body,proto3" json:"body,omitempty"`
+	SuccessCodes  []string               `protobuf:"bytes,6,rep,name=success_codes,json=successCodes,proto3" json:"success_codes,omitempty"`
+	Extract       []*Extraction          `protobuf:"bytes,7,rep,name=extract,proto3" json:"extract,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TransactionStep) Reset() {
+	*x = TransactionStep{}
+	mi := &file_cherrywatch_v1_cherrywatch_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TransactionStep) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransactionStep) ProtoMessage() {}
+
+func (x *TransactionStep) ProtoReflect() protoreflect.Message {
+	mi := &file_cherrywatch_v1_cherrywatch_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransactionStep.ProtoReflect.Descriptor instead.
+func (*TransactionStep) Descriptor() ([]byte, []int) {
+	return file_cherrywatch_v1_cherrywatch_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *TransactionStep) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *TransactionStep) GetMethod() string {
+	if x != nil {
+		return x.Method
+	}
+	return ""
+}
+
+func (x *TransactionStep) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *TransactionStep) GetHeaders() map[string]string {
+	if x != nil {
+		return x.Headers
+	}
+	return nil
+}
+
+func (x *TransactionStep) GetBody() string {
+	if x != nil {
+		return x.Body
+	}
+	return ""
+}
+
+func (x *TransactionStep) GetSuccessCodes() []string {
+	if x != nil {
+		return x.SuccessCodes
+	}
+	return nil
+}
+
+func (x *TransactionStep) GetExtract() []*Extraction {
+	if x != nil {
+		return x.Extract
+	}
+	return nil
+}
+
+type Extraction struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Variable name available to the following steps
+	Var string `protobuf:"bytes,1,opt,name=var,proto3" json:"var,omitempty"`
+	// One of: json, header, regex
+	From string `protobuf:"bytes,2,opt,name=from,proto3" json:"from,omitempty"`
+	// JSONPath, header name or regular expression
+	Expr          string `protobuf:"bytes,3,opt,name=expr,proto3" json:"expr,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Extraction) Reset() {
+	*x = Extraction{}
+	mi := &file_cherrywatch_v1_cherrywatch_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Extraction) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Extraction) ProtoMessage() {}
+
+func (x *Extraction) ProtoReflect() protoreflect.Message {
+	mi := &file_cherrywatch_v1_cherrywatch_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Extraction.ProtoReflect.Descriptor instead.
+func (*Extraction) Descriptor() ([]byte, []int) {
+	return file_cherrywatch_v1_cherrywatch_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *Extraction) GetVar() string {
+	if x != nil {
+		return x.Var
+	}
+	return ""
+}
+
+func (x *Extraction) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *Extraction) GetExpr() string {
+	if x != nil {
+		return x.Expr
+	}
+	return ""
+}
+
+type Heartbeat struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Extra time to wait for a ping, also the time a started job has to finish
+	// (the interval if zero)
+	Grace *durationpb.Duration `protobuf:"bytes,1,opt,name=grace,proto3" json:"grace,omitempty"`
+	// Ping token (generated by the server)
+	Token string `protobuf:"bytes,2,opt,name=token,proto3" json:"token,omitempty"`
+	// Ping URL path
+	PingUrl       string `protobuf:"bytes,3,opt,name=ping_url,json=pingUrl,proto3" json:"ping_url,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Heartbeat) Reset() {
+	*x = Heartbeat{}
+	mi := &file_cherrywatch_v1_cherrywatch_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Heartbeat) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Heartbeat) ProtoMessage() {}
+
+func (x *Heartbeat) ProtoReflect() protoreflect.Message {
+	mi := &file_cherrywatch_v1_cherrywatch_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Heartbeat.ProtoReflect.Descriptor instead.
+func (*Heartbeat) Descriptor() ([]byte, []int) {
+	return file_cherrywatch_v1_cherrywatch_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *Heartbeat) GetGrace() *durationpb.Duration {
+	if x != nil {
+		return x.Grace
+	}
+	return nil
+}
+
+func (x *Heartbeat) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *Heartbeat) GetPingUrl() string {
+	if x != nil {
+		return x.PingUrl
+	}
+	return ""
+}
+
+type SQLCheck struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Driver string                 `protobuf:"bytes,1,opt,name=driver,proto3" json:"driver,omitempty"`
+	// Never returned except to agents, omit it on updates to keep the current one
+	Dsn     string               `protobuf:"bytes,2,opt,name=dsn,proto3" json:"dsn,omitempty"`
+	Query   string               `protobuf:"bytes,3,opt,name=query,proto3" json:"query,omitempty"`
+	Timeout *durationpb.Duration `protobuf:"bytes,4,opt,name=timeout,proto3" json:"timeout,omitempty"`
+	// "rows" (default) or "value"
+	Subject string `protobuf:"bytes,5,opt,name=subject,proto3" json:"subject,omitempty"`
+	// One of: ==, !=, <, <=, >, >=
+	Op            string `protobuf:"bytes,6,opt,name=op,proto3" json:"op,omitempty"`
+	Value         string `protobuf:"bytes,7,opt,name=value,proto3" json:"value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SQLCheck) Reset() {
+	*x = SQLCheck{}
+	mi := &file_cherrywatch_v1_cherrywatch_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SQLCheck) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SQLCheck) ProtoMessage() {}
+
+func (x *SQLCheck) ProtoReflect() protoreflect.Message {
+	mi := &file_cherrywatch_v1_cherrywatch_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SQLCheck.ProtoReflect.Descriptor instead.
+func (*SQLCheck) Descriptor() ([]byte, []int) {
+	return file_cherrywatch_v1_cherrywatch_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *SQLCheck) GetDriver() string {
+	if x != nil {
+		return x.Driver
+	}
+	return ""
+}
+
+func (x *SQLCheck) GetDsn() string {
+	if x != nil {
+		return x.Dsn
+	}
+	return ""
+}
+
+func (x *SQLCheck) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *SQLCheck) GetTimeout() *durationpb.Duration {
+	if x != nil {
+		return x.Timeout
+	}
+	return nil
+}
+
+func (x *SQLCheck) GetSubject() string {
+	if x != nil {
+		return x.Subject
+	}
+	return ""
+}
+
+func (x *SQLCheck) GetOp() string {
+	if x != nil {
+		return x.Op
+	}
+	return ""
+}
+
+func (x *SQLCheck) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+type ExecCheck struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Command       string                 `protobuf:"bytes,1,opt,name=command,proto3" json:"command,omitempty"`
+	Args          []string               `protobuf:"bytes,2,rep,name=args,proto3" json:"args,omitempty"`
+	Env           map[string]string      `protobuf:"bytes,3,rep,name=env,proto3" json:"env,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Timeout       *durationpb.Duration   `protobuf:"bytes,4,opt,name=timeout,proto3" json:"timeout,omitempty"`
+	SuccessCodes  []int32                `protobuf:"varint,5,rep,packed,name=success_codes,json=successCodes,proto3" json:"success_codes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExecCheck) Reset() {
+	*x = ExecCheck{}
+	mi := &file_cherrywatch_v1_cherrywatch_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExecCheck) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExecCheck) ProtoMessage() {}
+
+func (x *ExecCheck) ProtoReflect() protoreflect.Message {
+	mi := &file_cherrywatch_v1_cherrywatch_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExecCheck.ProtoReflect.Descriptor instead.
+func (*ExecCheck) Descriptor() ([]byte, []int) {
+	return file_cherrywatch_v1_cherrywatch_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ExecCheck) GetCommand() string {
+	if x != nil {
+		return x.Command
+	}
+	return ""
+}
+
+func (x *ExecCheck) GetArgs() []string {
+	if x != nil {
+		return x.Args
+	}
+	return nil
+}
+
+func (x *ExecCheck) GetEnv() map[string]string {
+	if x != nil {
+		return x.Env
+	}
+	return nil
+}
+
+func (x *ExecCheck) GetTimeout() *durationpb.Duration {
+	if x != nil {
+		return x.Timeout
+	}
+	return nil
+}
+
+func (x *ExecCheck) GetSuccessCodes() []int32 {
+	if x != nil {
+		return x.SuccessCodes
+	}
+	return nil
+}
+
+type Incident struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Id         string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	EndpointId string                 `protobuf:"bytes,2,opt,name=endpoint_id,json=endpointId,proto3" json:"endpoint_id,omitempty"`
+	Cause      string                 `protobuf:"bytes,3,opt,name=cause,proto3" json:"cause,omitempty"`
+	OpenedAt   *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=opened_at,json=openedAt,proto3" json:"opened_at,omitempty"`
+	// Not set while the incident is active
+	ResolvedAt    *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=resolved_at,json=resolvedAt,proto3" json:"resolved_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Incident) Reset() {
+	*x = Incident{}
+	mi := &file_cherrywatch_v1_cherrywatch_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Incident) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Incident) ProtoMessage() {}
+
+func (x *Incident) ProtoReflect() protoreflect.Message {
+	mi := &file_cherrywatch_v1_cherrywatch_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Incident.ProtoReflect.Descriptor instead.
+func (*Incident) Descriptor() ([]byte, []int) {
+	return file_cherrywatch_v1_cherrywatch_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *Incident) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Incident) GetEndpointId() string {
+	if x != nil {
+		return x.EndpointId
+	}
+	return ""
+}
+
+func (x *Incident) GetCause() string {
+	if x != nil {
+		return x.Cause
+	}
+	return ""
+}
+
+func (x *Incident) GetOpenedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.OpenedAt
+	}
+	return nil
+}
+
+func (x *Incident) GetResolvedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ResolvedAt
+	}
+	return nil
+}
+
+type CheckResult struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	EndpointId string                 `protobuf:"bytes,1,opt,name=endpoint_id,json=endpointId,proto3" json:"endpoint_id,omitempty"`
+	Type       string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	Success    bool                   `protobuf:"varint,3,opt,name=success,proto3" json:"success,omitempty"`
+	StatusCode int32                  `protobuf:"varint,4,opt,name=status_code,json=statusCode,proto3" json:"status_code,omitempty"`
+	ExitCode   int32                  `protobuf:"varint,5,opt,name=exit_code,json=exitCode,proto3" json:"exit_code,omitempty"`
+	Output     string                 `protobuf:"bytes,6,opt,name=output,proto3" json:"output,omitempty"`
+	Message    string                 `protobuf:"bytes,7,opt,name=message,proto3" json:"message,omitempty"`
+	FailedStep string                 `protobuf:"bytes,8,opt,name=failed_step,json=failedStep,proto3" json:"failed_step,omitempty"`
+	Latency    *durationpb.Duration   `protobuf:"bytes,9,opt,name=latency,proto3" json:"latency,omitempty"`
+	CheckedAt  *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=checked_at,json=checkedAt,proto3" json:"checked_at,omitempty"`
+	// Location of the agent reporting the result, empty for checks of the server
+	Location      string `protobuf:"bytes,11,opt,name=location,proto3" json:"location,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CheckResult) Reset() {
+	*x = CheckResult{}
+	mi := &file_cherrywatch_v1_cherrywatch_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckResult) ProtoMessage() {}
+
+func (x *CheckResult) ProtoReflect() protoreflect.Message {
+	mi := &file_cherrywatch_v1_cherrywatch_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckResult.ProtoReflect.Descriptor instead.
+func (*CheckResult) Descriptor() ([]byte, []int) {
+	return file_cherrywatch_v1_cherrywatch_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *CheckResult) GetEndpointId() string {
+	if x != nil {
+		return x.EndpointId
+	}
+	return ""
+}
+
+func (x *CheckResult) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *CheckResult) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *CheckResult) GetStatusCode() int32 {
+	if x != nil {
+		return x.StatusCode
+	}
+	return 0
+}
+
+func (x *CheckResult) GetExitCode() int32 {
+	if x != nil {
+		return x.ExitCode
+	}
+	return 0
+}
+
+func (x *CheckResult) GetOutput() string {
+	if x != nil {
+		return x.Output
+	}
+	return ""
+}
+
+func (x *CheckResult) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *CheckResult) GetFailedStep() string {
+	if x != nil {
+		return x.FailedStep
+	}
+	return ""
+}
+
+func (x *CheckResult) GetLatency() *durationpb.Duration {
+	if x != nil {
+		return x.Latency
+	}
+	return nil
+}
+
+func (x *CheckResult) GetCheckedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CheckedAt
+	}
+	return nil
+}
+
+func (x *CheckResult) GetLocation() string {
+	if x != nil {
+		return x.Location
+	}
+	return ""
+}
+
+type CreateEndpointRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Endpoint      *Endpoint              `protobuf:"bytes,1,opt,name=endpoint,proto3" json:"endpoint,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateEndpointRequest) Reset() {
+	*x = CreateEndpointRequest{}
+	mi := &file_cherrywatch_v1_cherrywatch_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateEndpointRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateEndpointRequest) ProtoMessage() {}
+
+func (x *CreateEndpointRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_cherrywatch_v1_cherrywatch_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateEndpointRequest.ProtoReflect.Descriptor instead.
+func (*CreateEndpointRequest) Descriptor() ([]byte, []int) {
+	return file_cherrywatch_v1_cherrywatch_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *CreateEndpointRequest) GetEndpoint() *Endpoint {
+	if x != nil {
+		return x.Endpoint
+	}
+	return nil
+}
+
+type GetEndpointRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetEndpointRequest) Reset() {
+	*x = GetEndpointRequest{}
+	mi := &file_cherrywatch_v1_cherrywatch_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetEndpointRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetEndpointRequest) ProtoMessage() {}
+
+func (x *GetEndpointRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_cherrywatch_v1_cherrywatch_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetEndpointRequest.ProtoReflect.Descriptor instead.
+func (*GetEndpointRequest) Descriptor() ([]byte, []int) {
+	return file_cherrywatch_v1_cherrywatch_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *GetEndpointRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type ListEndpointsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListEndpointsRequest) Reset() {
+	*x = ListEndpointsRequest{}
+	mi := &file_cherrywatch_v1_cherrywatch_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListEndpointsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListEndpointsRequest) ProtoMessage() {}
+
+func (x *ListEndpointsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_cherrywatch_v1_cherrywatch_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListEndpointsRequest.ProtoReflect.Descriptor instead.
+func (*ListEndpointsRequest) Descriptor() ([]byte, []int) {
+	return file_cherrywatch_v1_cherrywatch_proto_rawDescGZIP(), []int{12}
+}
+
+type ListEndpointsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Endpoints     []*Endpoint            `protobuf:"bytes,1,rep,name=endpoints,proto3" json:"endpoints,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListEndpointsResponse) Reset() {
+	*x = ListEndpointsResponse{}
+	mi := &file_cherrywatch_v1_cherrywatch_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListEndpointsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListEndpointsResponse) ProtoMessage() {}
+
+func (x *ListEndpointsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_cherrywatch_v1_cherrywatch_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListEndpointsResponse.ProtoReflect.Descriptor instead.
+func (*ListEndpointsResponse) Descriptor() ([]byte, []int) {
+	return file_cherrywatch_v1_cherrywatch_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ListEndpointsResponse) GetEndpoints() []*Endpoint {
+	if x != nil {
+		return x.Endpoints
+	}
+	return nil
+}
+
+type UpdateEndpointRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Endpoint      *Endpoint              `protobuf:"bytes,1,opt,name=endpoint,proto3" json:"endpoint,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateEndpointRequest) Reset() {
+	*x = UpdateEndpointRequest{}
+	mi := &file_cherrywatch_v1_cherrywatch_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateEndpointRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateEndpointRequest) ProtoMessage() {}
+
+func (x *UpdateEndpointRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_cherrywatch_v1_cherrywatch_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateEndpointRequest.ProtoReflect.Descriptor instead.
+func (*UpdateEndpointRequest) Descriptor() ([]byte, []int) {
+	return file_cherrywatch_v1_cherrywatch_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *UpdateEndpointRequest) GetEndpoint() *Endpoint {
+	if x != nil {
+		return x.Endpoint
+	}
+	return nil
+}
+
+type DeleteEndpointRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteEndpointRequest) Reset() {
+	*x = DeleteEndpointRequest{}
+	mi := &file_cherrywatch_v1_cherrywatch_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteEndpointRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteEndpointRequest) ProtoMessage() {}
+
+func (x *DeleteEndpointRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_cherrywatch_v1_cherrywatch_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteEndpointRequest.ProtoReflect.Descriptor instead.
+func (*DeleteEndpointRequest) Descriptor() ([]byte, []int) {
+	return file_cherrywatch_v1_cherrywatch_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *DeleteEndpointRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type DeleteEndpointResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteEndpointResponse) Reset() {
+	*x = DeleteEndpointResponse{}
+	mi := &file_cherrywatch_v1_cherrywatch_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteEndpointResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteEndpointResponse) ProtoMessage() {}
+
+func (x *DeleteEndpointResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_cherrywatch_v1_cherrywatch_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteEndpointResponse.ProtoReflect.Descriptor instead.
+func (*DeleteEndpointResponse) Descriptor() ([]byte, []int) {
+	return file_cherrywatch_v1_cherrywatch_proto_rawDescGZIP(), []int{16}
+}
+
+type ListIncidentsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Only incidents of the endpoint if set
+	EndpointId string `protobuf:"bytes,1,opt,name=endpoint_id,json=endpointId,proto3" json:"endpoint_id,omitempty"`
+	// Only unresolved incidents if set
+	ActiveOnly    bool `protobuf:"varint,2,opt,name=active_only,json=activeOnly,proto3" json:"active_only,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListIncidentsRequest) Reset() {
+	*x = ListIncidentsRequest{}
+	mi := &file_cherrywatch_v1_cherrywatch_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListIncidentsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListIncidentsRequest) ProtoMessage() {}
+
+func (x *ListIncidentsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_cherrywatch_v1_cherrywatch_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListIncidentsRequest.ProtoReflect.Descriptor instead.
+func (*ListIncidentsRequest) Descriptor() ([]byte, []int) {
+	return file_cherrywatch_v1_cherrywatch_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ListIncidentsRequest) GetEndpointId() string {
+	if x != nil {
+		return x.EndpointId
+	}
+	return ""
+}
+
+func (x *ListIncidentsRequest) GetActiveOnly() bool {
+	if x != nil {
+		return x.ActiveOnly
+	}
+	return false
+}
+
+type ListIncidentsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Incidents     []*Incident            `protobuf:"bytes,1,rep,name=incidents,proto3" json:"incidents,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListIncidentsResponse) Reset() {
+	*x = ListIncidentsResponse{}
+	mi := &file_cherrywatch_v1_cherrywatch_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListIncidentsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListIncidentsResponse) ProtoMessage() {}
+
+func (x *ListIncidentsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_cherrywatch_v1_cherrywatch_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListIncidentsResponse.ProtoReflect.Descriptor instead.
+func (*ListIncidentsResponse) Descriptor() ([]byte, []int) {
+	return file_cherrywatch_v1_cherrywatch_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ListIncidentsResponse) GetIncidents() []*Incident {
+	if x != nil {
+		return x.Incidents
+	}
+	return nil
+}
+
+type TriggerCheckRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TriggerCheckRequest) Reset() {
+	*x = TriggerCheckRequest{}
+	mi := &file_cherrywatch_v1_cherrywatch_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TriggerCheckRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TriggerCheckRequest) ProtoMessage() {}
+
+func (x *TriggerCheckRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_cherrywatch_v1_cherrywatch_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TriggerCheckRequest.ProtoReflect.Descriptor instead.
+func (*TriggerCheckRequest) Descriptor() ([]byte, []int) {
+	return file_cherrywatch_v1_cherrywatch_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *TriggerCheckRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type WatchEventsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Only events of the endpoints if set
+	EndpointIds []string `protobuf:"bytes,1,rep,name=endpoint_ids,json=endpointIds,proto3" json:"endpoint_ids,omitempty"`
+	// Only events of endpoints having all the labels if set
+	Labels map[string]string `protobuf:"bytes,2,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// Only events of endpoints with any of the severities if set
+	Severities    []string `protobuf:"bytes,3,rep,name=severities,proto3" json:"severities,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchEventsRequest) Reset() {
+	*x = WatchEventsRequest{}
+	mi := &file_cherrywatch_v1_cherrywatch_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchEventsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchEventsRequest) ProtoMessage() {}
+
+func (x *WatchEventsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_cherrywatch_v1_cherrywatch_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchEventsRequest.ProtoReflect.Descriptor instead.
+func (*WatchEventsRequest) Descriptor() ([]byte, []int) {
+	return file_cherrywatch_v1_cherrywatch_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *WatchEventsRequest) GetEndpointIds() []string {
+	if x != nil {
+		return x.EndpointIds
+	}
+	return nil
+}
+
+func (x *WatchEventsRequest) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *WatchEventsRequest) GetSeverities() []string {
+	if x != nil {
+		return x.Severities
+	}
+	return nil
+}
+
+type Event struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// One of: check_result, incident_opened, incident_resolved, lagged
+	Kind       string            `protobuf:"bytes,1,opt,name=kind,proto3" json:"kind,omitempty"`
+	EndpointId string            `protobuf:"bytes,2,opt,name=endpoint_id,json=endpointId,proto3" json:"endpoint_id,omitempty"`
+	Labels     map[string]string `protobuf:"bytes,3,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Severity   string            `protobuf:"bytes,4,opt,name=severity,proto3" json:"severity,omitempty"`
+	// Set for check_result events
+	Result *CheckResult `protobuf:"bytes,5,opt,name=result,proto3" json:"result,omitempty"`
+	// Set for incident events
+	Incident *Incident `protobuf:"bytes,6,opt,name=incident,proto3" json:"incident,omitempty"`
+	// Number of skipped events, set for lagged events
+	Dropped       uint64                 `protobuf:"varint,7,opt,name=dropped,proto3" json:"dropped,omitempty"`
+	Time          *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=time,proto3" json:"time,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Event) Reset() {
+	*x = Event{}
+	mi := &file_cherrywatch_v1_cherrywatch_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Event) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Event) ProtoMessage() {}
+
+func (x *Event) ProtoReflect() protoreflect.Message {
+	mi := &file_cherrywatch_v1_cherrywatch_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Event.ProtoReflect.Descriptor instead.
+func (*Event) Descriptor() ([]byte, []int) {
+	return file_cherrywatch_v1_cherrywatch_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *Event) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *Event) GetEndpointId() string {
+	if x != nil {
+		return x.EndpointId
+	}
+	return ""
+}
+
+func (x *Event) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *Event) GetSeverity() string {
+	if x != nil {
+		return x.Severity
+	}
+	return ""
+}
+
+func (x *Event) GetResult() *CheckResult {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+func (x *Event) GetIncident() *Incident {
+	if x != nil {
+		return x.Incident
+	}
+	return nil
+}
+
+func (x *Event) GetDropped() uint64 {
+	if x != nil {
+		return x.Dropped
+	}
+	return 0
+}
+
+func (x *Event) GetTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Time
+	}
+	return nil
+}
+
+var File_cherrywatch_v1_cherrywatch_proto protoreflect.FileDescriptor
+
+const file_cherrywatch_v1_cherrywatch_proto_rawDesc = "" +
+	"\n" +
+	" cherrywatch/v1/cherrywatch.proto\x12\x0echerrywatch.v1\x1a\x1egoogle/protobuf/duration.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\x8c\x05\n" +
+	"\bEndpoint\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12!\n" +
+	"\fservice_name\x18\x02 \x01(\tR\vserviceName\x12\x10\n" +
+	"\x03url\x18\x03 \x01(\tR\x03url\x12#\n" +
+	"\rsuccess_codes\x18\x04 \x03(\tR\fsuccessCodes\x123\n" +
+	"\x15notification_services\x18\x05 \x03(\tR\x14notificationServices\x125\n" +
+	"\binterval\x18\x06 \x01(\v2\x19.google.protobuf.DurationR\binterval\x12\x12\n" +
+	"\x04type\x18\a \x01(\tR\x04type\x12=\n" +
+	"\vtransaction\x18\b \x01(\v2\x1b.cherrywatch.v1.TransactionR\vtransaction\x127\n" +
+	"\theartbeat\x18\t \x01(\v2\x19.cherrywatch.v1.HeartbeatR\theartbeat\x12*\n" +
+	"\x03sql\x18\n" +
+	" \x01(\v2\x18.cherrywatch.v1.SQLCheckR\x03sql\x12-\n" +
+	"\x04exec\x18\v \x01(\v2\x19.cherrywatch.v1.ExecCheckR\x04exec\x12<\n" +
+	"\x06labels\x18\f \x03(\v2$.cherrywatch.v1.Endpoint.LabelsEntryR\x06labels\x12\x1a\n" +
+	"\bseverity\x18\r \x01(\tR\bseverity\x12.\n" +
+	"\x06probes\x18\x0e \x01(\v2\x16.cherrywatch.v1.ProbesR\x06probes\x1a9\n" +
+	"\vLabelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\">\n" +
+	"\x06Probes\x12\x1c\n" +
+	"\tlocations\x18\x01 \x03(\tR\tlocations\x12\x16\n" +
+	"\x06quorum\x18\x02 \x01(\x05R\x06quorum\"D\n" +
+	"\vTransaction\x125\n" +
+	"\x05steps\x18\x01 \x03(\v2\x1f.cherrywatch.v1.TransactionStepR\x05steps\"\xc2\x02\n" +
+	"\x0fTransactionStep\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x16\n" +
+	"\x06method\x18\x02 \x01(\tR\x06method\x12\x10\n" +
+	"\x03url\x18\x03 \x01(\tR\x03url\x12F\n" +
+	"\aheaders\x18\x04 \x03(\v2,.cherrywatch.v1.TransactionStep.HeadersEntryR\aheaders\x12\x12\n" +
+	"\x04body\x18\x05 \x01(\tR\x04body\x12#\n" +
+	"\rsuccess_codes\x18\x06 \x03(\tR\fsuccessCodes\x124\n" +
+	"\aextract\x18\a \x03(\v2\x1a.cherrywatch.v1.ExtractionR\aextract\x1a:\n" +
+	"\fHeadersEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"F\n" +
+	"\n" +
+	"Extraction\x12\x10\n" +
+	"\x03var\x18\x01 \x01(\tR\x03var\x12\x12\n" +
+	"\x04from\x18\x02 \x01(\tR\x04from\x12\x12\n" +
+	"\x04expr\x18\x03 \x01(\tR\x04expr\"m\n" +
+	"\tHeartbeat\x12/\n" +
+	"\x05grace\x18\x01 \x01(\v2\x19.google.protobuf.DurationR\x05grace\x12\x14\n" +
+	"\x05token\x18\x02 \x01(\tR\x05token\x12\x19\n" +
+	"\bping_url\x18\x03 \x01(\tR\apingUrl\"\xbf\x01\n" +
+	"\bSQLCheck\x12\x16\n" +
+	"\x06driver\x18\x01 \x01(\tR\x06driver\x12\x10\n" +
+	"\x03dsn\x18\x02 \x01(\tR\x03dsn\x12\x14\n" +
+	"\x05query\x18\x03 \x01(\tR\x05query\x123\n" +
+	"\atimeout\x18\x04 \x01(\v2\x19.google.protobuf.DurationR\atimeout\x12\x18\n" +
+	"\asubject\x18\x05 \x01(\tR\asubject\x12\x0e\n" +
+	"\x02op\x18\x06 \x01(\tR\x02op\x12\x14\n" +
+	"\x05value\x18\a \x01(\tR\x05value\"\x81\x02\n" +
+	"\tExecCheck\x12\x18\n" +
+	"\acommand\x18\x01 \x01(\tR\acommand\x12\x12\n" +
+	"\x04args\x18\x02 \x03(\tR\x04args\x124\n" +
+	"\x03env\x18\x03 \x03(\v2\".cherrywatch.v1.ExecCheck.EnvEntryR\x03env\x123\n" +
+	"\atimeout\x18\x04 \x01(\v2\x19.google.protobuf.DurationR\atimeout\x12#\n" +
+	"\rsuccess_codes\x18\x05 \x03(\x05R\fsuccessCodes\x1a6\n" +
+	"\bEnvEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xc7\x01\n" +
+	"\bIncident\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1f\n" +
+	"\vendpoint_id\x18\x02 \x01(\tR\n" +
+	"endpointId\x12\x14\n" +
+	"\x05cause\x18\x03 \x01(\tR\x05cause\x127\n" +
+	"\topened_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\bopenedAt\x12;\n" +
+	"\vresolved_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"resolvedAt\"\xf9\x02\n" +
+	"\vCheckResult\x12\x1f\n" +
+	"\vendpoint_id\x18\x01 \x01(\tR\n" +
+	"endpointId\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12\x18\n" +
+	"\asuccess\x18\x03 \x01(\bR\asuccess\x12\x1f\n" +
+	"\vstatus_code\x18\x04 \x01(\x05R\n" +
+	"statusCode\x12\x1b\n" +
+	"\texit_code\x18\x05 \x01(\x05R\bexitCode\x12\x16\n" +
+	"\x06output\x18\x06 \x01(\tR\x06output\x12\x18\n" +
+	"\amessage\x18\a \x01(\tR\amessage\x12\x1f\n" +
+	"\vfailed_step\x18\b \x01(\tR\n" +
+	"failedStep\x123\n" +
+	"\alatency\x18\t \x01(\v2\x19.google.protobuf.DurationR\alatency\x129\n" +
+	"\n" +
+	"checked_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\tcheckedAt\x12\x1a\n" +
+	"\blocation\x18\v \x01(\tR\blocation\"M\n" +
+	"\x15CreateEndpointRequest\x124\n" +
+	"\bendpoint\x18\x01 \x01(\v2\x18.cherrywatch.v1.EndpointR\bendpoint\"$\n" +
+	"\x12GetEndpointRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\x16\n" +
+	"\x14ListEndpointsRequest\"O\n" +
+	"\x15ListEndpointsResponse\x126\n" +
+	"\tendpoints\x18\x01 \x03(\v2\x18.cherrywatch.v1.EndpointR\tendpoints\"M\n" +
+	"\x15UpdateEndpointRequest\x124\n" +
+	"\bendpoint\x18\x01 \x01(\v2\x18.cherrywatch.v1.EndpointR\bendpoint\"'\n" +
+	"\x15DeleteEndpointRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\x18\n" +
+	"\x16DeleteEndpointResponse\"X\n" +
+	"\x14ListIncidentsRequest\x12\x1f\n" +
+	"\vendpoint_id\x18\x01 \x01(\tR\n" +
+	"endpointId\x12\x1f\n" +
+	"\vactive_only\x18\x02 \x01(\bR\n" +
+	"activeOnly\"O\n" +
+	"\x15ListIncidentsResponse\x126\n" +
+	"\tincidents\x18\x01 \x03(\v2\x18.cherrywatch.v1.IncidentR\tincidents\"%\n" +
+	"\x13TriggerCheckRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\xda\x01\n" +
+	"\x12WatchEventsRequest\x12!\n" +
+	"\fendpoint_ids\x18\x01 \x03(\tR\vendpointIds\x12F\n" +
+	"\x06labels\x18\x02 \x03(\v2..cherrywatch.v1.WatchEventsRequest.LabelsEntryR\x06labels\x12\x1e\n" +
+	"\n" +
+	"severities\x18\x03 \x03(\tR\n" +
+	"severities\x1a9\n" +
+	"\vLabelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x83\x03\n" +
+	"\x05Event\x12\x12\n" +
+	"\x04kind\x18\x01 \x01(\tR\x04kind\x12\x1f\n" +
+	"\vendpoint_id\x18\x02 \x01(\tR\n" +
+	"endpointId\x129\n" +
+	"\x06labels\x18\x03 \x03(\v2!.cherrywatch.v1.Event.LabelsEntryR\x06labels\x12\x1a\n" +
+	"\bseverity\x18\x04 \x01(\tR\bseverity\x123\n" +
+	"\x06result\x18\x05 \x01(\v2\x1b.cherrywatch.v1.CheckResultR\x06result\x124\n" +
+	"\bincident\x18\x06 \x01(\v2\x18.cherrywatch.v1.IncidentR\bincident\x12\x18\n" +
+	"\adropped\x18\a \x01(\x04R\adropped\x12.\n" +
+	"\x04time\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\x04time\x1a9\n" +
+	"\vLabelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x012\xc2\x05\n" +
+	"\x12CherryWatchService\x12Q\n" +
+	"\x0eCreateEndpoint\x12%.cherrywatch.v1.CreateEndpointRequest\x1a\x18.cherrywatch.v1.Endpoint\x12K\n" +
+	"\vGetEndpoint\x12\".cherrywatch.v1.GetEndpointRequest\x1a\x18.cherrywatch.v1.Endpoint\x12\\\n" +
+	"\rListEndpoints\x12$.cherrywatch.v1.ListEndpointsRequest\x1a%.cherrywatch.v1.ListEndpointsResponse\x12Q\n" +
+	"\x0eUpdateEndpoint\x12%.cherrywatch.v1.UpdateEndpointRequest\x1a\x18.cherrywatch.v1.Endpoint\x12_\n" +
+	"\x0eDeleteEndpoint\x12%.cherrywatch.v1.DeleteEndpointRequest\x1a&.cherrywatch.v1.DeleteEndpointResponse\x12\\\n" +
+	"\rListIncidents\x12$.cherrywatch.v1.ListIncidentsRequest\x1a%.cherrywatch.v1.ListIncidentsResponse\x12P\n" +
+	"\fTriggerCheck\x12#.cherrywatch.v1.TriggerCheckRequest\x1a\x1b.cherrywatch.v1.CheckResult\x12J\n" +
+	"\vWatchEvents\x12\".cherrywatch.v1.WatchEventsRequest\x1a\x15.cherrywatch.v1.Event0\x01BHZFgithub.com/vishenosik/CherryWatch/pkg/api/cherrywatch/v1;cherrywatchv1b\x06proto3"
+
+var (
+	file_cherrywatch_v1_cherrywatch_proto_rawDescOnce sync.Once
+	file_cherrywatch_v1_cherrywatch_proto_rawDescData []byte
+)
+
+func file_cherrywatch_v1_cherrywatch_proto_rawDescGZIP() []byte {
+	file_cherrywatch_v1_cherrywatch_proto_rawDescOnce.Do(func() {
+		file_cherrywatch_v1_cherrywatch_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_cherrywatch_v1_cherrywatch_proto_rawDesc), len(file_cherrywatch_v1_cherrywatch_proto_rawDesc)))
+	})
+	return file_cherrywatch_v1_cherrywatch_proto_rawDescData
+}
+
+var file_cherrywatch_v1_cherrywatch_proto_msgTypes = make([]protoimpl.MessageInfo, 27)
+var file_cherrywatch_v1_cherrywatch_proto_goTypes = []any{
+	(*Endpoint)(nil),               // 0: cherrywatch.v1.Endpoint
+	(*Probes)(nil),                 // 1: cherrywatch.v1.Probes
+	(*Transaction)(nil),            // 2: cherrywatch.v1.Transaction
+	(*TransactionStep)(nil),        // 3: cherrywatch.v1.TransactionStep
+	(*Extraction)(nil),             // 4: cherrywatch.v1.Extraction
+	(*Heartbeat)(nil),              // 5: cherrywatch.v1.Heartbeat
+	(*SQLCheck)(nil),               // 6: cherrywatch.v1.SQLCheck
+	(*ExecCheck)(nil),              // 7: cherrywatch.v1.ExecCheck
+	(*Incident)(nil),               // 8: cherrywatch.v1.Incident
+	(*CheckResult)(nil),            // 9: cherrywatch.v1.CheckResult
+	(*CreateEndpointRequest)(nil),  // 10: cherrywatch.v1.CreateEndpointRequest
+	(*GetEndpointRequest)(nil),     // 11: cherrywatch.v1.GetEndpointRequest
+	(*ListEndpointsRequest)(nil),   // 12: cherrywatch.v1.ListEndpointsRequest
+	(*ListEndpointsResponse)(nil),  // 13: cherrywatch.v1.ListEndpointsResponse
+	(*UpdateEndpointRequest)(nil),  // 14: cherrywatch.v1.UpdateEndpointRequest
+	(*DeleteEndpointRequest)(nil),  // 15: cherrywatch.v1.DeleteEndpointRequest
+	(*DeleteEndpointResponse)(nil), // 16: cherrywatch.v1.DeleteEndpointResponse
+	(*ListIncidentsRequest)(nil),   // 17: cherrywatch.v1.ListIncidentsRequest
+	(*ListIncidentsResponse)(nil),  // 18: cherrywatch.v1.ListIncidentsResponse
+	(*TriggerCheckRequest)(nil),    // 19: cherrywatch.v1.TriggerCheckRequest
+	(*WatchEventsRequest)(nil),     // 20: cherrywatch.v1.WatchEventsRequest
+	(*Event)(nil),                  // 21: cherrywatch.v1.Event
+	nil,                            // 22: cherrywatch.v1.Endpoint.LabelsEntry
+	nil,                            // 23: cherrywatch.v1.TransactionStep.HeadersEntry
+	nil,                            // 24: cherrywatch.v1.ExecCheck.EnvEntry
+	nil,                            // 25: cherrywatch.v1.WatchEventsRequest.LabelsEntry
+	nil,                            // 26: cherrywatch.v1.Event.LabelsEntry
+	(*durationpb.Duration)(nil),    // 27: google.protobuf.Duration
+	(*timestamppb.Timestamp)(nil),  // 28: google.protobuf.Timestamp
+}
+var file_cherrywatch_v1_cherrywatch_proto_depIdxs = []int32{
+	27, // 0: cherrywatch.v1.Endpoint.interval:type_name -> google.protobuf.Duration
+	2,  // 1: cherrywatch.v1.Endpoint.transaction:type_name -> cherrywatch.v1.Transaction
+	5,  // 2: cherrywatch.v1.Endpoint.heartbeat:type_name -> cherrywatch.v1.Heartbeat
+	6,  // 3: cherrywatch.v1.Endpoint.sql:type_name -> cherrywatch.v1.SQLCheck
+	7,  // 4: cherrywatch.v1.Endpoint.exec:type_name -> cherrywatch.v1.ExecCheck
+	22, // 5: cherrywatch.v1.Endpoint.labels:type_name -> cherrywatch.v1.Endpoint.LabelsEntry
+	1,  // 6: cherrywatch.v1.Endpoint.probes:type_name -> cherrywatch.v1.Probes
+	3,  // 7: cherrywatch.v1.Transaction.steps:type_name -> cherrywatch.v1.TransactionStep
+	23, // 8: cherrywatch.v1.TransactionStep.headers:type_name -> cherrywatch.v1.TransactionStep.HeadersEntry
+	4,  // 9: cherrywatch.v1.TransactionStep.extract:type_name -> cherrywatch.v1.Extraction
+	27, // 10: cherrywatch.v1.Heartbeat.grace:type_name -> google.protobuf.Duration
+	27, // 11: cherrywatch.v1.SQLCheck.timeout:type_name -> google.protobuf.Duration
+	24, // 12: cherrywatch.v1.ExecCheck.env:type_name -> cherrywatch.v1.ExecCheck.EnvEntry
+	27, // 13: cherrywatch.v1.ExecCheck.timeout:type_name -> google.protobuf.Duration
+	28, // 14: cherrywatch.v1.Incident.opened_at:type_name -> google.protobuf.Timestamp
+	28, // 15: cherrywatch.v1.Incident.resolved_at:type_name -> google.protobuf.Timestamp
+	27, // 16: cherrywatch.v1.CheckResult.latency:type_name -> google.protobuf.Duration
+	28, // 17: cherrywatch.v1.CheckResult.checked_at:type_name -> google.protobuf.Timestamp
+	0,  // 18: cherrywatch.v1.CreateEndpointRequest.endpoint:type_name -> cherrywatch.v1.Endpoint
+	0,  // 19: cherrywatch.v1.ListEndpointsResponse.endpoints:type_name -> cherrywatch.v1.Endpoint
+	0,  // 20: cherrywatch.v1.UpdateEndpointRequest.endpoint:type_name -> cherrywatch.v1.Endpoint
+	8,  // 21: cherrywatch.v1.ListIncidentsResponse.incidents:type_name -> cherrywatch.v1.Incident
+	25, // 22: cherrywatch.v1.WatchEventsRequest.labels:type_name -> cherrywatch.v1.WatchEventsRequest.LabelsEntry
+	26, // 23: cherrywatch.v1.Event.labels:type_name -> cherrywatch.v1.Event.LabelsEntry
+	9,  // 24: cherrywatch.v1.Event.result:type_name -> cherrywatch.v1.CheckResult
+	8,  // 25: cherrywatch.v1.Event.incident:type_name -> cherrywatch.v1.Incident
+	28, // 26: cherrywatch.v1.Event.time:type_name -> google.protobuf.Timestamp
+	10, // 27: cherrywatch.v1.CherryWatchService.CreateEndpoint:input_type -> cherrywatch.v1.CreateEndpointRequest
+	11, // 28: cherrywatch.v1.CherryWatchService.GetEndpoint:input_type -> cherrywatch.v1.GetEndpointRequest
+	12, // 29: cherrywatch.v1.CherryWatchService.ListEndpoints:input_type -> cherrywatch.v1.ListEndpointsRequest
+	14, // 30: cherrywatch.v1.CherryWatchService.UpdateEndpoint:input_type -> cherrywatch.v1.UpdateEndpointRequest
+	15, // 31: cherrywatch.v1.CherryWatchService.DeleteEndpoint:input_type -> cherrywatch.v1.DeleteEndpointRequest
+	17, // 32: cherrywatch.v1.CherryWatchService.ListIncidents:input_type -> cherrywatch.v1.ListIncidentsRequest
+	19, // 33: cherrywatch.v1.CherryWatchService.TriggerCheck:input_type -> cherrywatch.v1.TriggerCheckRequest
+	20, // 34: cherrywatch.v1.CherryWatchService.WatchEvents:input_type -> cherrywatch.v1.WatchEventsRequest
+	0,  // 35: cherrywatch.v1.CherryWatchService.CreateEndpoint:output_type -> cherrywatch.v1.Endpoint
+	0,  // 36: cherrywatch.v1.CherryWatchService.GetEndpoint:output_type -> cherrywatch.v1.Endpoint
+	13, // 37: cherrywatch.v1.CherryWatchService.ListEndpoints:output_type -> cherrywatch.v1.ListEndpointsResponse
+	0,  // 38: cherrywatch.v1.CherryWatchService.UpdateEndpoint:output_type -> cherrywatch.v1.Endpoint
+	16, // 39: cherrywatch.v1.CherryWatchService.DeleteEndpoint:output_type -> cherrywatch.v1.DeleteEndpointResponse
+	18, // 40: cherrywatch.v1.CherryWatchService.ListIncidents:output_type -> cherrywatch.v1.ListIncidentsResponse
+	9,  // 41: cherrywatch.v1.CherryWatchService.TriggerCheck:output_type -> cherrywatch.v1.CheckResult
+	21, // 42: cherrywatch.v1.CherryWatchService.WatchEvents:output_type -> cherrywatch.v1.Event
+	35, // [35:43] is the sub-list for method output_type
+	27, // [27:35] is the sub-list for method input_type
+	27, // [27:27] is the sub-list for extension type_name
+	27, // [27:27] is the sub-list for extension extendee
+	0,  // [0:27] is the sub-list for field type_name
+}
+
+func init() { file_cherrywatch_v1_cherrywatch_proto_init() }
+func file_cherrywatch_v1_cherrywatch_proto_init() {
+	if File_cherrywatch_v1_cherrywatch_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_cherrywatch_v1_cherrywatch_proto_rawDesc), len(file_cherrywatch_v1_cherrywatch_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   27,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_cherrywatch_v1_cherrywatch_proto_goTypes,
+		DependencyIndexes: file_cherrywatch_v1_cherrywatch_proto_depIdxs,
+		MessageInfos:      file_cherrywatch_v1_cherrywatch_proto_msgTypes,
+	}.Build()
+	File_cherrywatch_v1_cherrywatch_proto = out.File
+	file_cherrywatch_v1_cherrywatch_proto_goTypes = nil
+	file_cherrywatch_v1_cherrywatch_proto_depIdxs = nil
+}
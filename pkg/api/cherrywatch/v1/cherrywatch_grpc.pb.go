@@ -0,0 +1,415 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v5.29.3
+// source: cherrywatch/v1/cherrywatch.proto
+
+package cherrywatchv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	CherryWatchService_CreateEndpoint_FullMethodName = "/cherrywatch.v1.CherryWatchService/CreateEndpoint"
+	CherryWatchService_GetEndpoint_FullMethodName    = "/cherrywatch.v1.CherryWatchService/GetEndpoint"
+	CherryWatchService_ListEndpoints_FullMethodName  = "/cherrywatch.v1.CherryWatchService/ListEndpoints"
+	CherryWatchService_UpdateEndpoint_FullMethodName = "/cherrywatch.v1.CherryWatchService/UpdateEndpoint"
+	CherryWatchService_DeleteEndpoint_FullMethodName = "/cherrywatch.v1.CherryWatchService/DeleteEndpoint"
+	CherryWatchService_ListIncidents_FullMethodName  = "/cherrywatch.v1.CherryWatchService/ListIncidents"
+	CherryWatchService_TriggerCheck_FullMethodName   = "/cherrywatch.v1.CherryWatchService/TriggerCheck"
+	CherryWatchService_WatchEvents_FullMethodName    = "/cherrywatch.v1.CherryWatchService/WatchEvents"
+)
+
+// CherryWatchServiceClient is the client API for CherryWatchService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// CherryWatchService manages monitored endpoints.
+// It mirrors the REST endpoints API.
+type CherryWatchServiceClient interface {
+	// Creates an endpoint, id is generated if empty.
+	CreateEndpoint(ctx context.Context, in *CreateEndpointRequest, opts ...grpc.CallOption) (*Endpoint, error)
+	// Returns an endpoint by id.
+	GetEndpoint(ctx context.Context, in *GetEndpointRequest, opts ...grpc.CallOption) (*Endpoint, error)
+	// Returns all endpoints.
+	ListEndpoints(ctx context.Context, in *ListEndpointsRequest, opts ...grpc.CallOption) (*ListEndpointsResponse, error)
+	// Replaces an existing endpoint.
+	UpdateEndpoint(ctx context.Context, in *UpdateEndpointRequest, opts ...grpc.CallOption) (*Endpoint, error)
+	// Deletes an endpoint by id.
+	DeleteEndpoint(ctx context.Context, in *DeleteEndpointRequest, opts ...grpc.CallOption) (*DeleteEndpointResponse, error)
+	// Returns incidents, newest first.
+	ListIncidents(ctx context.Context, in *ListIncidentsRequest, opts ...grpc.CallOption) (*ListIncidentsResponse, error)
+	// Checks an endpoint immediately and returns the result.
+	TriggerCheck(ctx context.Context, in *TriggerCheckRequest, opts ...grpc.CallOption) (*CheckResult, error)
+	// Streams check results and incident transitions as they happen.
+	// A slow client receives a lagged event instead of the skipped ones.
+	WatchEvents(ctx context.Context, in *WatchEventsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Event], error)
+}
+
+type cherryWatchServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewCherryWatchServiceClient(cc grpc.ClientConnInterface) CherryWatchServiceClient {
+	return &cherryWatchServiceClient{cc}
+}
+
+func (c *cherryWatchServiceClient) CreateEndpoint(ctx context.Context, in *CreateEndpointRequest, opts ...grpc.CallOption) (*Endpoint, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Endpoint)
+	err := c.cc.Invoke(ctx, CherryWatchService_CreateEndpoint_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cherryWatchServiceClient) GetEndpoint(ctx context.Context, in *GetEndpointRequest, opts ...grpc.CallOption) (*Endpoint, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Endpoint)
+	err := c.cc.Invoke(ctx, CherryWatchService_GetEndpoint_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cherryWatchServiceClient) ListEndpoints(ctx context.Context, in *ListEndpointsRequest, opts ...grpc.CallOption) (*ListEndpointsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListEndpointsResponse)
+	err := c.cc.Invoke(ctx, CherryWatchService_ListEndpoints_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cherryWatchServiceClient) UpdateEndpoint(ctx context.Context, in *UpdateEndpointRequest, opts ...grpc.CallOption) (*Endpoint, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Endpoint)
+	err := c.cc.Invoke(ctx, CherryWatchService_UpdateEndpoint_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cherryWatchServiceClient) DeleteEndpoint(ctx context.Context, in *DeleteEndpointRequest, opts ...grpc.CallOption) (*DeleteEndpointResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteEndpointResponse)
+	err := c.cc.Invoke(ctx, CherryWatchService_DeleteEndpoint_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cherryWatchServiceClient) ListIncidents(ctx context.Context, in *ListIncidentsRequest, opts ...grpc.CallOption) (*ListIncidentsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListIncidentsResponse)
+	err := c.cc.Invoke(ctx, CherryWatchService_ListIncidents_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cherryWatchServiceClient) TriggerCheck(ctx context.Context, in *TriggerCheckRequest, opts ...grpc.CallOption) (*CheckResult, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CheckResult)
+	err := c.cc.Invoke(ctx, CherryWatchService_TriggerCheck_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cherryWatchServiceClient) WatchEvents(ctx context.Context, in *WatchEventsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Event], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &CherryWatchService_ServiceDesc.Streams[0], CherryWatchService_WatchEvents_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchEventsRequest, Event]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type CherryWatchService_WatchEventsClient = grpc.ServerStreamingClient[Event]
+
+// CherryWatchServiceServer is the server API for CherryWatchService service.
+// All implementations must embed UnimplementedCherryWatchServiceServer
+// for forward compatibility.
+//
+// CherryWatchService manages monitored endpoints.
+// It mirrors the REST endpoints API.
+type CherryWatchServiceServer interface {
+	// Creates an endpoint, id is generated if empty.
+	CreateEndpoint(context.Context, *CreateEndpointRequest) (*Endpoint, error)
+	// Returns an endpoint by id.
+	GetEndpoint(context.Context, *GetEndpointRequest) (*Endpoint, error)
+	// Returns all endpoints.
+	ListEndpoints(context.Context, *ListEndpointsRequest) (*ListEndpointsResponse, error)
+	// Replaces an existing endpoint.
+	UpdateEndpoint(context.Context, *UpdateEndpointRequest) (*Endpoint, error)
+	// Deletes an endpoint by id.
+	DeleteEndpoint(context.Context, *DeleteEndpointRequest) (*DeleteEndpointResponse, error)
+	// Returns incidents, newest first.
+	ListIncidents(context.Context, *ListIncidentsRequest) (*ListIncidentsResponse, error)
+	// Checks an endpoint immediately and returns the result.
+	TriggerCheck(context.Context, *TriggerCheckRequest) (*CheckResult, error)
+	// Streams check results and incident transitions as they happen.
+	// A slow client receives a lagged event instead of the skipped ones.
+	WatchEvents(*WatchEventsRequest, grpc.ServerStreamingServer[Event]) error
+	mustEmbedUnimplementedCherryWatchServiceServer()
+}
+
+// UnimplementedCherryWatchServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedCherryWatchServiceServer struct{}
+
+func (UnimplementedCherryWatchServiceServer) CreateEndpoint(context.Context, *CreateEndpointRequest) (*Endpoint, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateEndpoint not implemented")
+}
+func (UnimplementedCherryWatchServiceServer) GetEndpoint(context.Context, *GetEndpointRequest) (*Endpoint, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetEndpoint not implemented")
+}
+func (UnimplementedCherryWatchServiceServer) ListEndpoints(context.Context, *ListEndpointsRequest) (*ListEndpointsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListEndpoints not implemented")
+}
+func (UnimplementedCherryWatchServiceServer) UpdateEndpoint(context.Context, *UpdateEndpointRequest) (*Endpoint, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateEndpoint not implemented")
+}
+func (UnimplementedCherryWatchServiceServer) DeleteEndpoint(context.Context, *DeleteEndpointRequest) (*DeleteEndpointResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteEndpoint not implemented")
+}
+func (UnimplementedCherryWatchServiceServer) ListIncidents(context.Context, *ListIncidentsRequest) (*ListIncidentsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListIncidents not implemented")
+}
+func (UnimplementedCherryWatchServiceServer) TriggerCheck(context.Context, *TriggerCheckRequest) (*CheckResult, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TriggerCheck not implemented")
+}
+func (UnimplementedCherryWatchServiceServer) WatchEvents(*WatchEventsRequest, grpc.ServerStreamingServer[Event]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchEvents not implemented")
+}
+func (UnimplementedCherryWatchServiceServer) mustEmbedUnimplementedCherryWatchServiceServer() {}
+func (UnimplementedCherryWatchServiceServer) testEmbeddedByValue()                            {}
+
+// UnsafeCherryWatchServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to CherryWatchServiceServer will
+// result in compilation errors.
+type UnsafeCherryWatchServiceServer interface {
+	mustEmbedUnimplementedCherryWatchServiceServer()
+}
+
+func RegisterCherryWatchServiceServer(s grpc.ServiceRegistrar, srv CherryWatchServiceServer) {
+	// If the following call pancis, it indicates UnimplementedCherryWatchServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&CherryWatchService_ServiceDesc, srv)
+}
+
+func _CherryWatchService_CreateEndpoint_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateEndpointRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CherryWatchServiceServer).CreateEndpoint(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CherryWatchService_CreateEndpoint_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CherryWatchServiceServer).CreateEndpoint(ctx, req.(*CreateEndpointRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CherryWatchService_GetEndpoint_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetEndpointRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CherryWatchServiceServer).GetEndpoint(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CherryWatchService_GetEndpoint_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CherryWatchServiceServer).GetEndpoint(ctx, req.(*GetEndpointRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CherryWatchService_ListEndpoints_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListEndpointsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CherryWatchServiceServer).ListEndpoints(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CherryWatchService_ListEndpoints_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CherryWatchServiceServer).ListEndpoints(ctx, req.(*ListEndpointsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CherryWatchService_UpdateEndpoint_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateEndpointRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CherryWatchServiceServer).UpdateEndpoint(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CherryWatchService_UpdateEndpoint_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CherryWatchServiceServer).UpdateEndpoint(ctx, req.(*UpdateEndpointRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CherryWatchService_DeleteEndpoint_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteEndpointRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CherryWatchServiceServer).DeleteEndpoint(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CherryWatchService_DeleteEndpoint_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CherryWatchServiceServer).DeleteEndpoint(ctx, req.(*DeleteEndpointRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CherryWatchService_ListIncidents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListIncidentsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CherryWatchServiceServer).ListIncidents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CherryWatchService_ListIncidents_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CherryWatchServiceServer).ListIncidents(ctx, req.(*ListIncidentsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CherryWatchService_TriggerCheck_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TriggerCheckRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CherryWatchServiceServer).TriggerCheck(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CherryWatchService_TriggerCheck_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CherryWatchServiceServer).TriggerCheck(ctx, req.(*TriggerCheckRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CherryWatchService_WatchEvents_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchEventsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(CherryWatchServiceServer).WatchEvents(m, &grpc.GenericServerStream[WatchEventsRequest, Event]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type CherryWatchService_WatchEventsServer = grpc.ServerStreamingServer[Event]
+
+// CherryWatchService_ServiceDesc is the grpc.ServiceDesc for CherryWatchService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var CherryWatchService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "cherrywatch.v1.CherryWatchService",
+	HandlerType: (*CherryWatchServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateEndpoint",
+			Handler:    _CherryWatchService_CreateEndpoint_Handler,
+		},
+		{
+			MethodName: "GetEndpoint",
+			Handler:    _CherryWatchService_GetEndpoint_Handler,
+		},
+		{
+			MethodName: "ListEndpoints",
+			Handler:    _CherryWatchService_ListEndpoints_Handler,
+		},
+		{
+			MethodName: "UpdateEndpoint",
+			Handler:    _CherryWatchService_UpdateEndpoint_Handler,
+		},
+		{
+			MethodName: "DeleteEndpoint",
+			Handler:    _CherryWatchService_DeleteEndpoint_Handler,
+		},
+		{
+			MethodName: "ListIncidents",
+			Handler:    _CherryWatchService_ListIncidents_Handler,
+		},
+		{
+			MethodName: "TriggerCheck",
+			Handler:    _CherryWatchService_TriggerCheck_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchEvents",
+			Handler:       _CherryWatchService_WatchEvents_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "cherrywatch/v1/cherrywatch.proto",
+}